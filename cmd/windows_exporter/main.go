@@ -32,6 +32,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +41,9 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/httphandler"
 	"github.com/prometheus-community/windows_exporter/internal/log"
 	"github.com/prometheus-community/windows_exporter/internal/log/flag"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/replay"
 	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
 	"github.com/prometheus/common/version"
@@ -88,7 +92,15 @@ func run(ctx context.Context, args []string) int {
 		enabledCollectors = app.Flag(
 			"collectors.enabled",
 			"Comma-separated list of collectors to use. Use '[defaults]' as a placeholder for all the collectors enabled by default.").
-			Default(collector.DefaultCollectors).String()
+			Default(collector.Defaults()).String()
+		maxSeries = app.Flag(
+			"collectors.max-series",
+			"Maximum number of series a single collector may emit per scrape. Additional series are dropped and the collection is marked as failed. 0 means no limit.",
+		).Default("0").Int()
+		maxSeriesPerCollector = app.Flag(
+			"collectors.max-series-per-collector",
+			"Comma-separated list of per-collector series limits, overriding --collectors.max-series. Example: 'process=5000,service=1000'.",
+		).Default("").String()
 		timeoutMargin = app.Flag(
 			"scrape.timeout-margin",
 			"Seconds to subtract from the timeout allowed by the client. Tune to allow for overhead or high loads.",
@@ -97,6 +109,26 @@ func run(ctx context.Context, args []string) int {
 			"debug.enabled",
 			"If true, windows_exporter will expose debug endpoints under /debug/pprof.",
 		).Default("false").Bool()
+		recordFile = app.Flag(
+			"debug.record-file",
+			"If set, windows_exporter records the raw PDH counter values and MI instances of all scrapes and writes them to this file on shutdown. Recordings can be replayed in collector tests.",
+		).Default("").String()
+		recordSamples = app.Flag(
+			"debug.record-samples",
+			"Maximum number of samples recorded per PDH collector and MI query with --debug.record-file. Later samples are dropped.",
+		).Default("10").Int()
+		pdhSharedQuery = app.Flag(
+			"pdh.shared-query",
+			"If true, all PDH based collectors share a single PDH query, which is sampled once per scrape. This reduces the overhead per scrape and gives all collectors the same sample time.",
+		).Default("false").Bool()
+		pdhCalculateFormatted = app.Flag(
+			"pdh.calculate-formatted",
+			"If true, PDH based collectors read raw values and calculate the formatted values in the exporter, over at least 30 seconds. This makes rates and averages independent of the scrape interval and the number of scrapers.",
+		).Default("false").Bool()
+		miSlowQueryThreshold = app.Flag(
+			"mi.slow-query-threshold",
+			"Duration after which a WMI query is logged as slow, together with its WQL text. 0 disables the logging.",
+		).Default("5s").Duration()
 		processPriority = app.Flag(
 			"process.priority",
 			"Priority of the exporter process. Higher priorities may improve exporter responsiveness during periods of system load. Can be one of [\"realtime\", \"high\", \"abovenormal\", \"normal\", \"belownormal\", \"low\"]",
@@ -166,6 +198,49 @@ func run(ctx context.Context, args []string) int {
 		return 1
 	}
 
+	maxSeriesLimits, err := parseMaxSeriesPerCollector(*maxSeriesPerCollector)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "couldn't parse series limits",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	collectors.SetMaxSeries(*maxSeries, maxSeriesLimits)
+
+	var recorder *replay.Recorder
+
+	if *recordFile != "" {
+		recorder = replay.NewRecorder(*recordSamples)
+
+		pdh.SetRecorder(recorder)
+		mi.SetRecorder(recorder)
+
+		logger.LogAttrs(ctx, slog.LevelWarn, "recording PDH and MI data to "+*recordFile)
+	}
+
+	pdh.SetLogger(logger)
+	mi.SetLogger(logger)
+	mi.SetSlowQueryThreshold(*miSlowQueryThreshold)
+	pdh.SetCalculateFormatted(*pdhCalculateFormatted)
+
+	if *pdhSharedQuery {
+		var sharedQuery *pdh.SharedQuery
+
+		if sharedQuery, err = pdh.NewSharedQuery(); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "Failed to open shared PDH query",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		defer sharedQuery.Close()
+
+		pdh.SetSharedQuery(sharedQuery)
+	}
+
 	// Initialize collectors before loading
 	if err = collectors.Build(ctx, logger); err != nil {
 		for _, err := range utils.SplitError(err) {
@@ -188,6 +263,7 @@ func run(ctx context.Context, args []string) int {
 		DisableExporterMetrics: *disableExporterMetrics,
 		TimeoutMargin:          *timeoutMargin,
 	}))
+	mux.Handle("GET "+strings.TrimSuffix(*metricsPath, "/")+"/catalogue", httphandler.NewCatalogueHandler(collectors))
 
 	if *debugEnabled {
 		mux.HandleFunc("GET /debug/pprof/", pprof.Index)
@@ -253,6 +329,17 @@ func run(ctx context.Context, args []string) int {
 		logger.LogAttrs(ctx, slog.LevelInfo, "windows_exporter has shut down")
 	}
 
+	if recorder != nil {
+		if err = recorder.WriteFile(*recordFile); err != nil {
+			//nolint:contextcheck
+			logger.LogAttrs(ctx, slog.LevelError, "Failed to write recording",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+	}
+
 	return 0
 }
 
@@ -314,8 +401,37 @@ func setPriorityWindows(ctx context.Context, logger *slog.Logger, pid int, prior
 	return nil
 }
 
+// parseMaxSeriesPerCollector parses a comma-separated list of collector=limit pairs.
+func parseMaxSeriesPerCollector(value string) (map[string]int, error) {
+	limits := make(map[string]int)
+
+	if value == "" {
+		return limits, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		name, limit, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid series limit %q, expected collector=limit", pair)
+		}
+
+		if !slices.Contains(collector.Available(), name) {
+			return nil, fmt.Errorf("invalid series limit %q: unknown collector %s", pair, name)
+		}
+
+		maxSeries, err := strconv.Atoi(limit)
+		if err != nil || maxSeries < 0 {
+			return nil, fmt.Errorf("invalid series limit %q: limit must be a non-negative integer", pair)
+		}
+
+		limits[name] = maxSeries
+	}
+
+	return limits, nil
+}
+
 func expandEnabledCollectors(enabled string) []string {
-	expanded := strings.ReplaceAll(enabled, "[defaults]", collector.DefaultCollectors)
+	expanded := strings.ReplaceAll(enabled, "[defaults]", collector.Defaults())
 
 	return slices.Compact(strings.Split(expanded, ","))
 }
@@ -132,388 +132,463 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.addressBookOperationsTotal = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "DirectoryServices", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create DirectoryServices collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.addressBookOperationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "address_book_operations_total"),
 		"",
 		[]string{"operation"},
 		nil,
 	)
-	c.addressBookClientSessions = prometheus.NewDesc(
+	c.addressBookClientSessions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "address_book_client_sessions"),
 		"",
 		nil,
 		nil,
 	)
-	c.approximateHighestDistinguishedNameTag = prometheus.NewDesc(
+	c.approximateHighestDistinguishedNameTag = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "approximate_highest_distinguished_name_tag"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqEstimatedDelaySeconds = prometheus.NewDesc(
+	c.atqEstimatedDelaySeconds = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_estimated_delay_seconds"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqOutstandingRequests = prometheus.NewDesc(
+	c.atqOutstandingRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_outstanding_requests"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqAverageRequestLatency = prometheus.NewDesc(
+	c.atqAverageRequestLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_average_request_latency"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqCurrentThreads = prometheus.NewDesc(
+	c.atqCurrentThreads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_current_threads"),
 		"",
 		[]string{"service"},
 		nil,
 	)
-	c.searchesTotal = prometheus.NewDesc(
+	c.searchesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "searches_total"),
 		"",
 		[]string{"scope"},
 		nil,
 	)
-	c.databaseOperationsTotal = prometheus.NewDesc(
+	c.databaseOperationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "database_operations_total"),
 		"",
 		[]string{"operation"},
 		nil,
 	)
-	c.bindsTotal = prometheus.NewDesc(
+	c.bindsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "binds_total"),
 		"",
 		[]string{"bind_method"},
 		nil,
 	)
-	c.replicationHighestUsn = prometheus.NewDesc(
+	c.replicationHighestUsn = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_highest_usn"),
 		"",
 		[]string{"state"},
 		nil,
 	)
-	c.intraSiteReplicationDataBytesTotal = prometheus.NewDesc(
+	c.intraSiteReplicationDataBytesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_data_intrasite_bytes_total"),
 		"",
 		[]string{"direction"},
 		nil,
 	)
-	c.interSiteReplicationDataBytesTotal = prometheus.NewDesc(
+	c.interSiteReplicationDataBytesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_data_intersite_bytes_total"),
 		"",
 		[]string{"direction"},
 		nil,
 	)
-	c.replicationInboundSyncObjectsRemaining = prometheus.NewDesc(
+	c.replicationInboundSyncObjectsRemaining = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_sync_objects_remaining"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundLinkValueUpdatesRemaining = prometheus.NewDesc(
+	c.replicationInboundLinkValueUpdatesRemaining = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_link_value_updates_remaining"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundObjectsUpdatedTotal = prometheus.NewDesc(
+	c.replicationInboundObjectsUpdatedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_objects_updated_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundObjectsFilteredTotal = prometheus.NewDesc(
+	c.replicationInboundObjectsFilteredTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_objects_filtered_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundPropertiesUpdatedTotal = prometheus.NewDesc(
+	c.replicationInboundPropertiesUpdatedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_properties_updated_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundPropertiesFilteredTotal = prometheus.NewDesc(
+	c.replicationInboundPropertiesFilteredTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_properties_filtered_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationPendingOperations = prometheus.NewDesc(
+	c.replicationPendingOperations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_pending_operations"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationPendingSynchronizations = prometheus.NewDesc(
+	c.replicationPendingSynchronizations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_pending_synchronizations"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationSyncRequestsTotal = prometheus.NewDesc(
+	c.replicationSyncRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_sync_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationSyncRequestsSuccessTotal = prometheus.NewDesc(
+	c.replicationSyncRequestsSuccessTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_sync_requests_success_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationSyncRequestsSchemaMismatchFailureTotal = prometheus.NewDesc(
+	c.replicationSyncRequestsSchemaMismatchFailureTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_sync_requests_schema_mismatch_failure_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.nameTranslationsTotal = prometheus.NewDesc(
+	c.nameTranslationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "name_translations_total"),
 		"",
 		[]string{"target_name"},
 		nil,
 	)
-	c.changeMonitorsRegistered = prometheus.NewDesc(
+	c.changeMonitorsRegistered = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "change_monitors_registered"),
 		"",
 		nil,
 		nil,
 	)
-	c.changeMonitorUpdatesPending = prometheus.NewDesc(
+	c.changeMonitorUpdatesPending = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "change_monitor_updates_pending"),
 		"",
 		nil,
 		nil,
 	)
-	c.nameCacheHitsTotal = prometheus.NewDesc(
+	c.nameCacheHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "name_cache_hits_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.nameCacheLookupsTotal = prometheus.NewDesc(
+	c.nameCacheLookupsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "name_cache_lookups_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.directoryOperationsTotal = prometheus.NewDesc(
+	c.directoryOperationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "directory_operations_total"),
 		"",
 		[]string{"operation", "origin"},
 		nil,
 	)
-	c.directorySearchSubOperationsTotal = prometheus.NewDesc(
+	c.directorySearchSubOperationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "directory_search_suboperations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationEventsTotal = prometheus.NewDesc(
+	c.securityDescriptorPropagationEventsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_events_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationEventsQueued = prometheus.NewDesc(
+	c.securityDescriptorPropagationEventsQueued = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_events_queued"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationAccessWaitTotalSeconds = prometheus.NewDesc(
+	c.securityDescriptorPropagationAccessWaitTotalSeconds = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_access_wait_total_seconds"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationItemsQueuedTotal = prometheus.NewDesc(
+	c.securityDescriptorPropagationItemsQueuedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_items_queued_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.directoryServiceThreads = prometheus.NewDesc(
+	c.directoryServiceThreads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "directory_service_threads"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapClosedConnectionsTotal = prometheus.NewDesc(
+	c.ldapClosedConnectionsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_closed_connections_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapOpenedConnectionsTotal = prometheus.NewDesc(
+	c.ldapOpenedConnectionsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_opened_connections_total"),
 		"",
 		[]string{"type"},
 		nil,
 	)
-	c.ldapActiveThreads = prometheus.NewDesc(
+	c.ldapActiveThreads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_active_threads"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapLastBindTimeSeconds = prometheus.NewDesc(
+	c.ldapLastBindTimeSeconds = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_last_bind_time_seconds"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapSearchesTotal = prometheus.NewDesc(
+	c.ldapSearchesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_searches_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapUdpOperationsTotal = prometheus.NewDesc(
+	c.ldapUdpOperationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_udp_operations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapWritesTotal = prometheus.NewDesc(
+	c.ldapWritesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_writes_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapClientSessions = prometheus.NewDesc(
+	c.ldapClientSessions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_client_sessions"),
 		"This is the number of sessions opened by LDAP clients at the time the data is taken. This is helpful in determining LDAP client activity and if the DC is able to handle the load. Of course, spikes during normal periods of authentication — such as first thing in the morning — are not necessarily a problem, but long sustained periods of high values indicate an overworked DC.",
 		nil,
 		nil,
 	)
-	c.linkValuesCleanedTotal = prometheus.NewDesc(
+	c.linkValuesCleanedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "link_values_cleaned_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.phantomObjectsCleanedTotal = prometheus.NewDesc(
+	c.phantomObjectsCleanedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "phantom_objects_cleaned_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.phantomObjectsVisitedTotal = prometheus.NewDesc(
+	c.phantomObjectsVisitedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "phantom_objects_visited_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupMembershipEvaluationsTotal = prometheus.NewDesc(
+	c.samGroupMembershipEvaluationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_evaluations_total"),
 		"",
 		[]string{"group_type"},
 		nil,
 	)
-	c.samGroupMembershipGlobalCatalogEvaluationsTotal = prometheus.NewDesc(
+	c.samGroupMembershipGlobalCatalogEvaluationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_global_catalog_evaluations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupMembershipEvaluationsNonTransitiveTotal = prometheus.NewDesc(
+	c.samGroupMembershipEvaluationsNonTransitiveTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_evaluations_nontransitive_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupMembershipEvaluationsTransitiveTotal = prometheus.NewDesc(
+	c.samGroupMembershipEvaluationsTransitiveTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_evaluations_transitive_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupEvaluationLatency = prometheus.NewDesc(
+	c.samGroupEvaluationLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_evaluation_latency"),
 		"The mean latency of the last 100 group evaluations performed for authentication",
 		[]string{"evaluation_type"},
 		nil,
 	)
-	c.samComputerCreationRequestsTotal = prometheus.NewDesc(
+	c.samComputerCreationRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_computer_creation_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samComputerCreationSuccessfulRequestsTotal = prometheus.NewDesc(
+	c.samComputerCreationSuccessfulRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_computer_creation_successful_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samUserCreationRequestsTotal = prometheus.NewDesc(
+	c.samUserCreationRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_user_creation_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samUserCreationSuccessfulRequestsTotal = prometheus.NewDesc(
+	c.samUserCreationSuccessfulRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_user_creation_successful_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samQueryDisplayRequestsTotal = prometheus.NewDesc(
+	c.samQueryDisplayRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_query_display_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samEnumerationsTotal = prometheus.NewDesc(
+	c.samEnumerationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_enumerations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samMembershipChangesTotal = prometheus.NewDesc(
+	c.samMembershipChangesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_membership_changes_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samPasswordChangesTotal = prometheus.NewDesc(
+	c.samPasswordChangesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_password_changes_total"),
 		"",
 		nil,
 		nil,
 	)
 
-	c.tombstonesObjectsCollectedTotal = prometheus.NewDesc(
+	c.tombstonesObjectsCollectedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "tombstoned_objects_collected_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.tombstonesObjectsVisitedTotal = prometheus.NewDesc(
+	c.tombstonesObjectsVisitedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "tombstoned_objects_visited_total"),
 		"",
 		nil,
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "DirectoryServices", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create DirectoryServices collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.addressBookClientSessions,
+		c.approximateHighestDistinguishedNameTag,
+		c.atqAverageRequestLatency,
+		c.atqCurrentThreads,
+		c.atqEstimatedDelaySeconds,
+		c.atqOutstandingRequests,
+		c.changeMonitorUpdatesPending,
+		c.changeMonitorsRegistered,
+		c.directoryServiceThreads,
+		c.ldapActiveThreads,
+		c.ldapClientSessions,
+		c.ldapLastBindTimeSeconds,
+		c.replicationInboundLinkValueUpdatesRemaining,
+		c.replicationInboundSyncObjectsRemaining,
+		c.replicationPendingOperations,
+		c.replicationPendingSynchronizations,
+		c.samGroupEvaluationLatency,
+		c.securityDescriptorPropagationAccessWaitTotalSeconds,
+		c.securityDescriptorPropagationEventsQueued,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.addressBookOperationsTotal,
+		c.bindsTotal,
+		c.databaseOperationsTotal,
+		c.directoryOperationsTotal,
+		c.directorySearchSubOperationsTotal,
+		c.interSiteReplicationDataBytesTotal,
+		c.intraSiteReplicationDataBytesTotal,
+		c.ldapClosedConnectionsTotal,
+		c.ldapOpenedConnectionsTotal,
+		c.ldapSearchesTotal,
+		c.ldapUdpOperationsTotal,
+		c.ldapWritesTotal,
+		c.linkValuesCleanedTotal,
+		c.nameCacheHitsTotal,
+		c.nameCacheLookupsTotal,
+		c.nameTranslationsTotal,
+		c.phantomObjectsCleanedTotal,
+		c.phantomObjectsVisitedTotal,
+		c.replicationHighestUsn,
+		c.replicationInboundObjectsFilteredTotal,
+		c.replicationInboundObjectsUpdatedTotal,
+		c.replicationInboundPropertiesFilteredTotal,
+		c.replicationInboundPropertiesUpdatedTotal,
+		c.replicationSyncRequestsSchemaMismatchFailureTotal,
+		c.replicationSyncRequestsSuccessTotal,
+		c.replicationSyncRequestsTotal,
+		c.samComputerCreationRequestsTotal,
+		c.samComputerCreationSuccessfulRequestsTotal,
+		c.samEnumerationsTotal,
+		c.samGroupMembershipEvaluationsNonTransitiveTotal,
+		c.samGroupMembershipEvaluationsTotal,
+		c.samGroupMembershipEvaluationsTransitiveTotal,
+		c.samGroupMembershipGlobalCatalogEvaluationsTotal,
+		c.samMembershipChangesTotal,
+		c.samPasswordChangesTotal,
+		c.samQueryDisplayRequestsTotal,
+		c.samUserCreationRequestsTotal,
+		c.samUserCreationSuccessfulRequestsTotal,
+		c.searchesTotal,
+		c.securityDescriptorPropagationEventsTotal,
+		c.securityDescriptorPropagationItemsQueuedTotal,
+		c.tombstonesObjectsCollectedTotal,
+		c.tombstonesObjectsVisitedTotal,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -84,93 +84,119 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.requestsPerSecond = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Certification Authority", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create Certification Authority collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.requestsPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "requests_total"),
 		"Total certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.requestProcessingTime = prometheus.NewDesc(
+	c.requestProcessingTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "request_processing_time_seconds"),
 		"Last time elapsed for certificate requests",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.retrievalsPerSecond = prometheus.NewDesc(
+	c.retrievalsPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "retrievals_total"),
 		"Total certificate retrieval requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.retrievalProcessingTime = prometheus.NewDesc(
+	c.retrievalProcessingTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "retrievals_processing_time_seconds"),
 		"Last time elapsed for certificate retrieval request",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.failedRequestsPerSecond = prometheus.NewDesc(
+	c.failedRequestsPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "failed_requests_total"),
 		"Total failed certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.issuedRequestsPerSecond = prometheus.NewDesc(
+	c.issuedRequestsPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "issued_requests_total"),
 		"Total issued certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.pendingRequestsPerSecond = prometheus.NewDesc(
+	c.pendingRequestsPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pending_requests_total"),
 		"Total pending certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.requestCryptographicSigningTime = prometheus.NewDesc(
+	c.requestCryptographicSigningTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "request_cryptographic_signing_time_seconds"),
 		"Last time elapsed for signing operation request",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.requestPolicyModuleProcessingTime = prometheus.NewDesc(
+	c.requestPolicyModuleProcessingTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "request_policy_module_processing_time_seconds"),
 		"Last time elapsed for policy module processing request",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.challengeResponsesPerSecond = prometheus.NewDesc(
+	c.challengeResponsesPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "challenge_responses_total"),
 		"Total certificate challenge responses processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.challengeResponseProcessingTime = prometheus.NewDesc(
+	c.challengeResponseProcessingTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "challenge_response_processing_time_seconds"),
 		"Last time elapsed for challenge response",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.signedCertificateTimestampListsPerSecond = prometheus.NewDesc(
+	c.signedCertificateTimestampListsPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "signed_certificate_timestamp_lists_total"),
 		"Total Signed Certificate Timestamp Lists processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.signedCertificateTimestampListProcessingTime = prometheus.NewDesc(
+	c.signedCertificateTimestampListProcessingTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "signed_certificate_timestamp_list_processing_time_seconds"),
 		"Last time elapsed for Signed Certificate Timestamp List",
 		[]string{"cert_template"},
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Certification Authority", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create Certification Authority collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.challengeResponseProcessingTime,
+		c.requestCryptographicSigningTime,
+		c.requestPolicyModuleProcessingTime,
+		c.requestProcessingTime,
+		c.retrievalProcessingTime,
+		c.signedCertificateTimestampListProcessingTime,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.challengeResponsesPerSecond,
+		c.failedRequestsPerSecond,
+		c.issuedRequestsPerSecond,
+		c.pendingRequestsPerSecond,
+		c.requestsPerSecond,
+		c.retrievalsPerSecond,
+		c.signedCertificateTimestampListsPerSecond,
+	)
 }
 
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
@@ -114,273 +114,327 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.adLoginConnectionFailures = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "AD FS", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create AD FS collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.adLoginConnectionFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ad_login_connection_failures_total"),
 		"Total number of connection failures to an Active Directory domain controller",
 		nil,
 		nil,
 	)
-	c.certificateAuthentications = prometheus.NewDesc(
+	c.certificateAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "certificate_authentications_total"),
 		"Total number of User Certificate authentications",
 		nil,
 		nil,
 	)
-	c.deviceAuthentications = prometheus.NewDesc(
+	c.deviceAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "device_authentications_total"),
 		"Total number of Device authentications",
 		nil,
 		nil,
 	)
-	c.extranetAccountLockouts = prometheus.NewDesc(
+	c.extranetAccountLockouts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "extranet_account_lockouts_total"),
 		"Total number of Extranet Account Lockouts",
 		nil,
 		nil,
 	)
-	c.federatedAuthentications = prometheus.NewDesc(
+	c.federatedAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "federated_authentications_total"),
 		"Total number of authentications from a federated source",
 		nil,
 		nil,
 	)
-	c.passportAuthentications = prometheus.NewDesc(
+	c.passportAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "passport_authentications_total"),
 		"Total number of Microsoft Passport SSO authentications",
 		nil,
 		nil,
 	)
-	c.passiveRequests = prometheus.NewDesc(
+	c.passiveRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "passive_requests_total"),
 		"Total number of passive (browser-based) requests",
 		nil,
 		nil,
 	)
-	c.passwordChangeFailed = prometheus.NewDesc(
+	c.passwordChangeFailed = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "password_change_failed_total"),
 		"Total number of failed password changes",
 		nil,
 		nil,
 	)
-	c.passwordChangeSucceeded = prometheus.NewDesc(
+	c.passwordChangeSucceeded = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "password_change_succeeded_total"),
 		"Total number of successful password changes",
 		nil,
 		nil,
 	)
-	c.tokenRequests = prometheus.NewDesc(
+	c.tokenRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "token_requests_total"),
 		"Total number of token requests",
 		nil,
 		nil,
 	)
-	c.windowsIntegratedAuthentications = prometheus.NewDesc(
+	c.windowsIntegratedAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "windows_integrated_authentications_total"),
 		"Total number of Windows integrated authentications (Kerberos/NTLM)",
 		nil,
 		nil,
 	)
-	c.oAuthAuthZRequests = prometheus.NewDesc(
+	c.oAuthAuthZRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_authorization_requests_total"),
 		"Total number of incoming requests to the OAuth Authorization endpoint",
 		nil,
 		nil,
 	)
-	c.oAuthClientAuthentications = prometheus.NewDesc(
+	c.oAuthClientAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_authentication_success_total"),
 		"Total number of successful OAuth client Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientAuthenticationsFailures = prometheus.NewDesc(
+	c.oAuthClientAuthenticationsFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_authentication_failure_total"),
 		"Total number of failed OAuth client Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientCredentialsRequestFailures = prometheus.NewDesc(
+	c.oAuthClientCredentialsRequestFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_credentials_failure_total"),
 		"Total number of failed OAuth Client Credentials Requests",
 		nil,
 		nil,
 	)
-	c.oAuthClientCredentialsRequests = prometheus.NewDesc(
+	c.oAuthClientCredentialsRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_credentials_success_total"),
 		"Total number of successful RP tokens issued for OAuth Client Credentials Requests",
 		nil,
 		nil,
 	)
-	c.oAuthClientPrivateKeyJwtAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientPrivateKeyJwtAuthenticationFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_privkey_jwt_authentication_failure_total"),
 		"Total number of failed OAuth Client Private Key Jwt Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientPrivateKeyJwtAuthentications = prometheus.NewDesc(
+	c.oAuthClientPrivateKeyJwtAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_privkey_jwt_authentications_success_total"),
 		"Total number of successful OAuth Client Private Key Jwt Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretBasicAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientSecretBasicAuthenticationFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_basic_authentications_failure_total"),
 		"Total number of failed OAuth Client Secret Basic Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretBasicAuthentications = prometheus.NewDesc(
+	c.oAuthClientSecretBasicAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_basic_authentications_success_total"),
 		"Total number of successful OAuth Client Secret Basic Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretPostAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientSecretPostAuthenticationFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_post_authentications_failure_total"),
 		"Total number of failed OAuth Client Secret Post Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretPostAuthentications = prometheus.NewDesc(
+	c.oAuthClientSecretPostAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_post_authentications_success_total"),
 		"Total number of successful OAuth Client Secret Post Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientWindowsIntegratedAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientWindowsIntegratedAuthenticationFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_windows_authentications_failure_total"),
 		"Total number of failed OAuth Client Windows Integrated Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientWindowsIntegratedAuthentications = prometheus.NewDesc(
+	c.oAuthClientWindowsIntegratedAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_windows_authentications_success_total"),
 		"Total number of successful OAuth Client Windows Integrated Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthLogonCertificateRequestFailures = prometheus.NewDesc(
+	c.oAuthLogonCertificateRequestFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_logon_certificate_requests_failure_total"),
 		"Total number of failed OAuth Logon Certificate Requests",
 		nil,
 		nil,
 	)
-	c.oAuthLogonCertificateTokenRequests = prometheus.NewDesc(
+	c.oAuthLogonCertificateTokenRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_logon_certificate_token_requests_success_total"),
 		"Total number of successful RP tokens issued for OAuth Logon Certificate Requests",
 		nil,
 		nil,
 	)
-	c.oAuthPasswordGrantRequestFailures = prometheus.NewDesc(
+	c.oAuthPasswordGrantRequestFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_password_grant_requests_failure_total"),
 		"Total number of failed OAuth Password Grant Requests",
 		nil,
 		nil,
 	)
-	c.oAuthPasswordGrantRequests = prometheus.NewDesc(
+	c.oAuthPasswordGrantRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_password_grant_requests_success_total"),
 		"Total number of successful OAuth Password Grant Requests",
 		nil,
 		nil,
 	)
-	c.oAuthTokenRequests = prometheus.NewDesc(
+	c.oAuthTokenRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_token_requests_success_total"),
 		"Total number of successful RP tokens issued over OAuth protocol",
 		nil,
 		nil,
 	)
-	c.samlPTokenRequests = prometheus.NewDesc(
+	c.samlPTokenRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "samlp_token_requests_success_total"),
 		"Total number of successful RP tokens issued over SAML-P protocol",
 		nil,
 		nil,
 	)
-	c.ssoAuthenticationFailures = prometheus.NewDesc(
+	c.ssoAuthenticationFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sso_authentications_failure_total"),
 		"Total number of failed SSO authentications",
 		nil,
 		nil,
 	)
-	c.ssoAuthentications = prometheus.NewDesc(
+	c.ssoAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sso_authentications_success_total"),
 		"Total number of successful SSO authentications",
 		nil,
 		nil,
 	)
-	c.wsFedTokenRequests = prometheus.NewDesc(
+	c.wsFedTokenRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wsfed_token_requests_success_total"),
 		"Total number of successful RP tokens issued over WS-Fed protocol",
 		nil,
 		nil,
 	)
-	c.wsTrustTokenRequests = prometheus.NewDesc(
+	c.wsTrustTokenRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wstrust_token_requests_success_total"),
 		"Total number of successful RP tokens issued over WS-Trust protocol",
 		nil,
 		nil,
 	)
-	c.upAuthenticationFailures = prometheus.NewDesc(
+	c.upAuthenticationFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "userpassword_authentications_failure_total"),
 		"Total number of failed AD U/P authentications",
 		nil,
 		nil,
 	)
-	c.upAuthentications = prometheus.NewDesc(
+	c.upAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "userpassword_authentications_success_total"),
 		"Total number of successful AD U/P authentications",
 		nil,
 		nil,
 	)
-	c.externalAuthenticationFailures = prometheus.NewDesc(
+	c.externalAuthenticationFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "external_authentications_failure_total"),
 		"Total number of failed authentications from external MFA providers",
 		nil,
 		nil,
 	)
-	c.externalAuthentications = prometheus.NewDesc(
+	c.externalAuthentications = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "external_authentications_success_total"),
 		"Total number of successful authentications from external MFA providers",
 		nil,
 		nil,
 	)
-	c.artifactDBFailures = prometheus.NewDesc(
+	c.artifactDBFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_artifact_failure_total"),
 		"Total number of failures connecting to the artifact database",
 		nil,
 		nil,
 	)
-	c.avgArtifactDBQueryTime = prometheus.NewDesc(
+	c.avgArtifactDBQueryTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_artifact_query_time_seconds_total"),
 		"Accumulator of time taken for an artifact database query",
 		nil,
 		nil,
 	)
-	c.configDBFailures = prometheus.NewDesc(
+	c.configDBFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_config_failure_total"),
 		"Total number of failures connecting to the configuration database",
 		nil,
 		nil,
 	)
-	c.avgConfigDBQueryTime = prometheus.NewDesc(
+	c.avgConfigDBQueryTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_config_query_time_seconds_total"),
 		"Accumulator of time taken for a configuration database query",
 		nil,
 		nil,
 	)
-	c.federationMetadataRequests = prometheus.NewDesc(
+	c.federationMetadataRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "federation_metadata_requests_total"),
 		"Total number of Federation Metadata requests",
 		nil,
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "AD FS", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create AD FS collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.adLoginConnectionFailures,
+		c.artifactDBFailures,
+		c.avgArtifactDBQueryTime,
+		c.avgConfigDBQueryTime,
+		c.certificateAuthentications,
+		c.configDBFailures,
+		c.deviceAuthentications,
+		c.externalAuthenticationFailures,
+		c.externalAuthentications,
+		c.extranetAccountLockouts,
+		c.federatedAuthentications,
+		c.federationMetadataRequests,
+		c.oAuthAuthZRequests,
+		c.oAuthClientAuthentications,
+		c.oAuthClientAuthenticationsFailures,
+		c.oAuthClientCredentialsRequestFailures,
+		c.oAuthClientCredentialsRequests,
+		c.oAuthClientPrivateKeyJwtAuthenticationFailures,
+		c.oAuthClientPrivateKeyJwtAuthentications,
+		c.oAuthClientSecretBasicAuthenticationFailures,
+		c.oAuthClientSecretBasicAuthentications,
+		c.oAuthClientSecretPostAuthenticationFailures,
+		c.oAuthClientSecretPostAuthentications,
+		c.oAuthClientWindowsIntegratedAuthenticationFailures,
+		c.oAuthClientWindowsIntegratedAuthentications,
+		c.oAuthLogonCertificateRequestFailures,
+		c.oAuthLogonCertificateTokenRequests,
+		c.oAuthPasswordGrantRequestFailures,
+		c.oAuthPasswordGrantRequests,
+		c.oAuthTokenRequests,
+		c.passiveRequests,
+		c.passportAuthentications,
+		c.passwordChangeFailed,
+		c.passwordChangeSucceeded,
+		c.samlPTokenRequests,
+		c.ssoAuthenticationFailures,
+		c.ssoAuthentications,
+		c.tokenRequests,
+		c.upAuthenticationFailures,
+		c.upAuthentications,
+		c.windowsIntegratedAuthentications,
+		c.wsFedTokenRequests,
+		c.wsTrustTokenRequests,
+	)
 }
 
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
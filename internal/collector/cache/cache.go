@@ -100,189 +100,231 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.asyncCopyReadsTotal = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Cache", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create Cache collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.asyncCopyReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_copy_reads_total"),
 		"(AsyncCopyReadsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncDataMapsTotal = prometheus.NewDesc(
+	c.asyncDataMapsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_data_maps_total"),
 		"(AsyncDataMapsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncFastReadsTotal = prometheus.NewDesc(
+	c.asyncFastReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_fast_reads_total"),
 		"(AsyncFastReadsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncMDLReadsTotal = prometheus.NewDesc(
+	c.asyncMDLReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_mdl_reads_total"),
 		"(AsyncMDLReadsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncPinReadsTotal = prometheus.NewDesc(
+	c.asyncPinReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_pin_reads_total"),
 		"(AsyncPinReadsTotal)",
 		nil,
 		nil,
 	)
-	c.copyReadHitsTotal = prometheus.NewDesc(
+	c.copyReadHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "copy_read_hits_total"),
 		"(CopyReadHitsTotal)",
 		nil,
 		nil,
 	)
-	c.copyReadsTotal = prometheus.NewDesc(
+	c.copyReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "copy_reads_total"),
 		"(CopyReadsTotal)",
 		nil,
 		nil,
 	)
-	c.dataFlushesTotal = prometheus.NewDesc(
+	c.dataFlushesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_flushes_total"),
 		"(DataFlushesTotal)",
 		nil,
 		nil,
 	)
-	c.dataFlushPagesTotal = prometheus.NewDesc(
+	c.dataFlushPagesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_flush_pages_total"),
 		"(DataFlushPagesTotal)",
 		nil,
 		nil,
 	)
-	c.dataMapHitsPercent = prometheus.NewDesc(
+	c.dataMapHitsPercent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_map_hits_percent"),
 		"(DataMapHitsPercent)",
 		nil,
 		nil,
 	)
-	c.dataMapPinsTotal = prometheus.NewDesc(
+	c.dataMapPinsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_map_pins_total"),
 		"(DataMapPinsTotal)",
 		nil,
 		nil,
 	)
-	c.dataMapsTotal = prometheus.NewDesc(
+	c.dataMapsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_maps_total"),
 		"(DataMapsTotal)",
 		nil,
 		nil,
 	)
-	c.dirtyPages = prometheus.NewDesc(
+	c.dirtyPages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dirty_pages"),
 		"(DirtyPages)",
 		nil,
 		nil,
 	)
-	c.dirtyPageThreshold = prometheus.NewDesc(
+	c.dirtyPageThreshold = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dirty_page_threshold"),
 		"(DirtyPageThreshold)",
 		nil,
 		nil,
 	)
-	c.fastReadNotPossiblesTotal = prometheus.NewDesc(
+	c.fastReadNotPossiblesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "fast_read_not_possibles_total"),
 		"(FastReadNotPossiblesTotal)",
 		nil,
 		nil,
 	)
-	c.fastReadResourceMissesTotal = prometheus.NewDesc(
+	c.fastReadResourceMissesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "fast_read_resource_misses_total"),
 		"(FastReadResourceMissesTotal)",
 		nil,
 		nil,
 	)
-	c.fastReadsTotal = prometheus.NewDesc(
+	c.fastReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "fast_reads_total"),
 		"(FastReadsTotal)",
 		nil,
 		nil,
 	)
-	c.lazyWriteFlushesTotal = prometheus.NewDesc(
+	c.lazyWriteFlushesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "lazy_write_flushes_total"),
 		"(LazyWriteFlushesTotal)",
 		nil,
 		nil,
 	)
-	c.lazyWritePagesTotal = prometheus.NewDesc(
+	c.lazyWritePagesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "lazy_write_pages_total"),
 		"(LazyWritePagesTotal)",
 		nil,
 		nil,
 	)
-	c.mdlReadHitsTotal = prometheus.NewDesc(
+	c.mdlReadHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mdl_read_hits_total"),
 		"(MDLReadHitsTotal)",
 		nil,
 		nil,
 	)
-	c.mdlReadsTotal = prometheus.NewDesc(
+	c.mdlReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mdl_reads_total"),
 		"(MDLReadsTotal)",
 		nil,
 		nil,
 	)
-	c.pinReadHitsTotal = prometheus.NewDesc(
+	c.pinReadHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pin_read_hits_total"),
 		"(PinReadHitsTotal)",
 		nil,
 		nil,
 	)
-	c.pinReadsTotal = prometheus.NewDesc(
+	c.pinReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pin_reads_total"),
 		"(PinReadsTotal)",
 		nil,
 		nil,
 	)
-	c.readAheadsTotal = prometheus.NewDesc(
+	c.readAheadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "read_aheads_total"),
 		"(ReadAheadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncCopyReadsTotal = prometheus.NewDesc(
+	c.syncCopyReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_copy_reads_total"),
 		"(SyncCopyReadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncDataMapsTotal = prometheus.NewDesc(
+	c.syncDataMapsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_data_maps_total"),
 		"(SyncDataMapsTotal)",
 		nil,
 		nil,
 	)
-	c.syncFastReadsTotal = prometheus.NewDesc(
+	c.syncFastReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_fast_reads_total"),
 		"(SyncFastReadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncMDLReadsTotal = prometheus.NewDesc(
+	c.syncMDLReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_mdl_reads_total"),
 		"(SyncMDLReadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncPinReadsTotal = prometheus.NewDesc(
+	c.syncPinReadsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_pin_reads_total"),
 		"(SyncPinReadsTotal)",
 		nil,
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Cache", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create Cache collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.asyncCopyReadsTotal,
+		c.asyncDataMapsTotal,
+		c.asyncFastReadsTotal,
+		c.asyncMDLReadsTotal,
+		c.asyncPinReadsTotal,
+		c.copyReadsTotal,
+		c.dataFlushesTotal,
+		c.dataFlushPagesTotal,
+		c.dataMapPinsTotal,
+		c.dataMapsTotal,
+		c.fastReadNotPossiblesTotal,
+		c.fastReadResourceMissesTotal,
+		c.fastReadsTotal,
+		c.lazyWriteFlushesTotal,
+		c.lazyWritePagesTotal,
+		c.mdlReadHitsTotal,
+		c.mdlReadsTotal,
+		c.pinReadHitsTotal,
+		c.pinReadsTotal,
+		c.readAheadsTotal,
+		c.syncCopyReadsTotal,
+		c.syncDataMapsTotal,
+		c.syncFastReadsTotal,
+		c.syncMDLReadsTotal,
+		c.syncPinReadsTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.copyReadHitsTotal,
+		c.dataMapHitsPercent,
+		c.dirtyPages,
+		c.dirtyPageThreshold,
+	)
 }
 
 // Collect implements the Collector interface.
@@ -25,43 +25,29 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
-	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const Name = "cpu"
-
-type Config struct{}
+type Config struct {
+	CounterBackend perfdata.Backend `yaml:"counter-backend"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	CounterBackend: perfdata.BackendPDH,
+}
 
 type Collector struct {
 	config Config
 
-	perfDataCollector *pdh.Collector
+	perfDataCollector *perfdata.Collector
 	perfDataObject    []perfDataCounterValues
 
 	mu sync.Mutex
 
-	processorRTCValues   map[string]utils.Counter
-	processorMPerfValues map[string]utils.Counter
-
-	logicalProcessors          *prometheus.Desc
-	cStateSecondsTotal         *prometheus.Desc
-	timeTotal                  *prometheus.Desc
-	interruptsTotal            *prometheus.Desc
-	dpcsTotal                  *prometheus.Desc
-	clockInterruptsTotal       *prometheus.Desc
-	idleBreakEventsTotal       *prometheus.Desc
-	parkingStatus              *prometheus.Desc
-	processorFrequencyMHz      *prometheus.Desc
-	processorPerformance       *prometheus.Desc
-	processorMPerf             *prometheus.Desc
-	processorRTC               *prometheus.Desc
-	processorUtility           *prometheus.Desc
-	processorPrivilegedUtility *prometheus.Desc
+	metrics
 }
 
 func New(config *Config) *Collector {
@@ -69,6 +55,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CounterBackend == "" {
+		config.CounterBackend = ConfigDefaults.CounterBackend
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -76,8 +66,17 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	app.Flag(
+		"collector.cpu.counter-backend",
+		"API used to read performance counters. One of: pdh, registry.",
+	).Default(string(ConfigDefaults.CounterBackend)).EnumVar((*string)(&c.config.CounterBackend), perfdata.Backends...)
+
+	return c
 }
 
 func (c *Collector) GetName() string {
@@ -93,97 +92,14 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 	c.mu = sync.Mutex{}
 
-	c.logicalProcessors = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "logical_processor"),
-		"Total number of logical processors",
-		nil,
-		nil,
-	)
-	c.cStateSecondsTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "cstate_seconds_total"),
-		"Time spent in low-power idle state",
-		[]string{"core", "state"},
-		nil,
-	)
-	c.timeTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "time_total"),
-		"Time that processor spent in different modes (dpc, idle, interrupt, privileged, user)",
-		[]string{"core", "mode"},
-		nil,
-	)
-	c.interruptsTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "interrupts_total"),
-		"Total number of received and serviced hardware interrupts",
-		[]string{"core"},
-		nil,
-	)
-	c.dpcsTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "dpcs_total"),
-		"Total number of received and serviced deferred procedure calls (DPCs)",
-		[]string{"core"},
-		nil,
-	)
-	c.clockInterruptsTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "clock_interrupts_total"),
-		"Total number of received and serviced clock tick interrupts",
-		[]string{"core"},
-		nil,
-	)
-	c.idleBreakEventsTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "idle_break_events_total"),
-		"Total number of time processor was woken from idle",
-		[]string{"core"},
-		nil,
-	)
-	c.parkingStatus = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "parking_status"),
-		"Parking Status represents whether a processor is parked or not",
-		[]string{"core"},
-		nil,
-	)
-	c.processorFrequencyMHz = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "core_frequency_mhz"),
-		"Core frequency in megahertz",
-		[]string{"core"},
-		nil,
-	)
-	c.processorPerformance = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "processor_performance_total"),
-		"Processor Performance is the average performance of the processor while it is executing instructions, as a percentage of the nominal performance of the processor. On some processors, Processor Performance may exceed 100%",
-		[]string{"core"},
-		nil,
-	)
-	c.processorMPerf = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "processor_mperf_total"),
-		"Processor MPerf is the number of TSC ticks incremented while executing instructions",
-		[]string{"core"},
-		nil,
-	)
-	c.processorRTC = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "processor_rtc_total"),
-		"Processor RTC represents the number of RTC ticks made since the system booted. It should consistently be 64e6, and can be used to properly derive Processor Utility Rate",
-		[]string{"core"},
-		nil,
-	)
-	c.processorUtility = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "processor_utility_total"),
-		"Processor Utility represents is the amount of time the core spends executing instructions",
-		[]string{"core"},
-		nil,
-	)
-	c.processorPrivilegedUtility = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "processor_privileged_utility_total"),
-		"Processor Privileged Utility represents is the amount of time the core has spent executing instructions inside the kernel",
-		[]string{"core"},
-		nil,
-	)
+	c.BuildDescs()
 
 	c.processorRTCValues = map[string]utils.Counter{}
 	c.processorMPerfValues = map[string]utils.Counter{}
 
 	var err error
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
+	c.perfDataCollector, err = perfdata.NewCollector[perfDataCounterValues](c.config.CounterBackend, pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
 	if err != nil {
 		return fmt.Errorf("failed to create Processor Information collector: %w", err)
 	}
@@ -200,159 +116,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 		return fmt.Errorf("failed to collect Processor Information metrics: %w", err)
 	}
 
-	var coreCount float64
-
-	for _, coreData := range c.perfDataObject {
-		core := coreData.Name
-		coreCount++
-
-		var (
-			counterProcessorRTCValues   utils.Counter
-			counterProcessorMPerfValues utils.Counter
-			ok                          bool
-		)
-
-		if counterProcessorRTCValues, ok = c.processorRTCValues[core]; ok {
-			counterProcessorRTCValues.AddValue(uint32(coreData.ProcessorUtilityRateSecondValue))
-		} else {
-			counterProcessorRTCValues = utils.NewCounter(uint32(coreData.ProcessorUtilityRateSecondValue))
-		}
-
-		c.processorRTCValues[core] = counterProcessorRTCValues
-
-		if counterProcessorMPerfValues, ok = c.processorMPerfValues[core]; ok {
-			counterProcessorMPerfValues.AddValue(uint32(coreData.ProcessorPerformanceSecondValue))
-		} else {
-			counterProcessorMPerfValues = utils.NewCounter(uint32(coreData.ProcessorPerformanceSecondValue))
-		}
-
-		c.processorMPerfValues[core] = counterProcessorMPerfValues
-
-		ch <- prometheus.MustNewConstMetric(
-			c.cStateSecondsTotal,
-			prometheus.CounterValue,
-			coreData.C1TimeSeconds,
-			core, "c1",
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.cStateSecondsTotal,
-			prometheus.CounterValue,
-			coreData.C2TimeSeconds,
-			core, "c2",
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.cStateSecondsTotal,
-			prometheus.CounterValue,
-			coreData.C3TimeSeconds,
-			core, "c3",
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.timeTotal,
-			prometheus.CounterValue,
-			coreData.IdleTimeSeconds,
-			core, "idle",
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.timeTotal,
-			prometheus.CounterValue,
-			coreData.InterruptTimeSeconds,
-			core, "interrupt",
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.timeTotal,
-			prometheus.CounterValue,
-			coreData.DpcTimeSeconds,
-			core, "dpc",
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.timeTotal,
-			prometheus.CounterValue,
-			coreData.PrivilegedTimeSeconds,
-			core, "privileged",
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.timeTotal,
-			prometheus.CounterValue,
-			coreData.UserTimeSeconds,
-			core, "user",
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.interruptsTotal,
-			prometheus.CounterValue,
-			coreData.InterruptsTotal,
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.dpcsTotal,
-			prometheus.CounterValue,
-			coreData.DpcQueuedPerSecond,
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.clockInterruptsTotal,
-			prometheus.CounterValue,
-			coreData.ClockInterruptsTotal,
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.idleBreakEventsTotal,
-			prometheus.CounterValue,
-			coreData.IdleBreakEventsTotal,
-			core,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.parkingStatus,
-			prometheus.GaugeValue,
-			coreData.ParkingStatus,
-			core,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.processorFrequencyMHz,
-			prometheus.GaugeValue,
-			coreData.ProcessorFrequencyMHz,
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.processorPerformance,
-			prometheus.CounterValue,
-			coreData.ProcessorPerformance,
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.processorMPerf,
-			prometheus.CounterValue,
-			counterProcessorMPerfValues.Value(),
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.processorRTC,
-			prometheus.CounterValue,
-			counterProcessorRTCValues.Value(),
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.processorUtility,
-			prometheus.CounterValue,
-			coreData.ProcessorUtilityRate,
-			core,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.processorPrivilegedUtility,
-			prometheus.CounterValue,
-			coreData.PrivilegedUtilitySeconds,
-			core,
-		)
-	}
-
-	ch <- prometheus.MustNewConstMetric(
-		c.logicalProcessors,
-		prometheus.GaugeValue,
-		coreCount,
-	)
+	c.collectProcessors(ch, c.perfDataObject)
 
 	return nil
 }
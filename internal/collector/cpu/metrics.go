@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpu
+
+import (
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "cpu"
+
+// metrics holds the descriptors of the collector and converts the performance counter values into metrics.
+// It doesn't call Windows APIs, so the conversion can be tested against recordings on any platform.
+type metrics struct {
+	processorRTCValues   map[string]utils.Counter
+	processorMPerfValues map[string]utils.Counter
+
+	logicalProcessors          *prometheus.Desc
+	cStateSecondsTotal         *prometheus.Desc
+	timeTotal                  *prometheus.Desc
+	interruptsTotal            *prometheus.Desc
+	dpcsTotal                  *prometheus.Desc
+	clockInterruptsTotal       *prometheus.Desc
+	idleBreakEventsTotal       *prometheus.Desc
+	parkingStatus              *prometheus.Desc
+	processorFrequencyMHz      *prometheus.Desc
+	processorPerformance       *prometheus.Desc
+	processorMPerf             *prometheus.Desc
+	processorRTC               *prometheus.Desc
+	processorUtility           *prometheus.Desc
+	processorPrivilegedUtility *prometheus.Desc
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *metrics) BuildDescs() {
+	c.logicalProcessors = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "logical_processor"),
+		"Total number of logical processors",
+		nil,
+		nil,
+	)
+	c.cStateSecondsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cstate_seconds_total"),
+		"Time spent in low-power idle state",
+		[]string{"core", "state"},
+		nil,
+	)
+	c.timeTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "time_total"),
+		"Time that processor spent in different modes (dpc, idle, interrupt, privileged, user)",
+		[]string{"core", "mode"},
+		nil,
+	)
+	c.interruptsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "interrupts_total"),
+		"Total number of received and serviced hardware interrupts",
+		[]string{"core"},
+		nil,
+	)
+	c.dpcsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "dpcs_total"),
+		"Total number of received and serviced deferred procedure calls (DPCs)",
+		[]string{"core"},
+		nil,
+	)
+	c.clockInterruptsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "clock_interrupts_total"),
+		"Total number of received and serviced clock tick interrupts",
+		[]string{"core"},
+		nil,
+	)
+	c.idleBreakEventsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "idle_break_events_total"),
+		"Total number of time processor was woken from idle",
+		[]string{"core"},
+		nil,
+	)
+	c.parkingStatus = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "parking_status"),
+		"Parking Status represents whether a processor is parked or not",
+		[]string{"core"},
+		nil,
+	)
+	c.processorFrequencyMHz = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "core_frequency_mhz"),
+		"Core frequency in megahertz",
+		[]string{"core"},
+		nil,
+	)
+	c.processorPerformance = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "processor_performance_total"),
+		"Processor Performance is the average performance of the processor while it is executing instructions, as a percentage of the nominal performance of the processor. On some processors, Processor Performance may exceed 100%",
+		[]string{"core"},
+		nil,
+	)
+	c.processorMPerf = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "processor_mperf_total"),
+		"Processor MPerf is the number of TSC ticks incremented while executing instructions",
+		[]string{"core"},
+		nil,
+	)
+	c.processorRTC = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "processor_rtc_total"),
+		"Processor RTC represents the number of RTC ticks made since the system booted. It should consistently be 64e6, and can be used to properly derive Processor Utility Rate",
+		[]string{"core"},
+		nil,
+	)
+	c.processorUtility = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "processor_utility_total"),
+		"Processor Utility represents is the amount of time the core spends executing instructions",
+		[]string{"core"},
+		nil,
+	)
+	c.processorPrivilegedUtility = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "processor_privileged_utility_total"),
+		"Processor Privileged Utility represents is the amount of time the core has spent executing instructions inside the kernel",
+		[]string{"core"},
+		nil,
+	)
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *metrics) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.logicalProcessors,
+		c.parkingStatus,
+		c.processorFrequencyMHz,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.cStateSecondsTotal,
+		c.timeTotal,
+		c.interruptsTotal,
+		c.dpcsTotal,
+		c.clockInterruptsTotal,
+		c.idleBreakEventsTotal,
+		c.processorPerformance,
+		c.processorMPerf,
+		c.processorRTC,
+		c.processorUtility,
+		c.processorPrivilegedUtility,
+	)
+}
+
+// collectProcessors converts the values of the Processor Information counters into metrics.
+func (c *metrics) collectProcessors(ch chan<- prometheus.Metric, data []perfDataCounterValues) {
+	var coreCount float64
+
+	for _, coreData := range data {
+		core := coreData.Name
+		coreCount++
+
+		var (
+			counterProcessorRTCValues   utils.Counter
+			counterProcessorMPerfValues utils.Counter
+			ok                          bool
+		)
+
+		if counterProcessorRTCValues, ok = c.processorRTCValues[core]; ok {
+			counterProcessorRTCValues.AddValue(uint32(coreData.ProcessorUtilityRateSecondValue))
+		} else {
+			counterProcessorRTCValues = utils.NewCounter(uint32(coreData.ProcessorUtilityRateSecondValue))
+		}
+
+		c.processorRTCValues[core] = counterProcessorRTCValues
+
+		if counterProcessorMPerfValues, ok = c.processorMPerfValues[core]; ok {
+			counterProcessorMPerfValues.AddValue(uint32(coreData.ProcessorPerformanceSecondValue))
+		} else {
+			counterProcessorMPerfValues = utils.NewCounter(uint32(coreData.ProcessorPerformanceSecondValue))
+		}
+
+		c.processorMPerfValues[core] = counterProcessorMPerfValues
+
+		ch <- prometheus.MustNewConstMetric(
+			c.cStateSecondsTotal,
+			prometheus.CounterValue,
+			coreData.C1TimeSeconds,
+			core, "c1",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.cStateSecondsTotal,
+			prometheus.CounterValue,
+			coreData.C2TimeSeconds,
+			core, "c2",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.cStateSecondsTotal,
+			prometheus.CounterValue,
+			coreData.C3TimeSeconds,
+			core, "c3",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.timeTotal,
+			prometheus.CounterValue,
+			coreData.IdleTimeSeconds,
+			core, "idle",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.timeTotal,
+			prometheus.CounterValue,
+			coreData.InterruptTimeSeconds,
+			core, "interrupt",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.timeTotal,
+			prometheus.CounterValue,
+			coreData.DpcTimeSeconds,
+			core, "dpc",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.timeTotal,
+			prometheus.CounterValue,
+			coreData.PrivilegedTimeSeconds,
+			core, "privileged",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.timeTotal,
+			prometheus.CounterValue,
+			coreData.UserTimeSeconds,
+			core, "user",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.interruptsTotal,
+			prometheus.CounterValue,
+			coreData.InterruptsTotal,
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.dpcsTotal,
+			prometheus.CounterValue,
+			coreData.DpcQueuedPerSecond,
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.clockInterruptsTotal,
+			prometheus.CounterValue,
+			coreData.ClockInterruptsTotal,
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.idleBreakEventsTotal,
+			prometheus.CounterValue,
+			coreData.IdleBreakEventsTotal,
+			core,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.parkingStatus,
+			prometheus.GaugeValue,
+			coreData.ParkingStatus,
+			core,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.processorFrequencyMHz,
+			prometheus.GaugeValue,
+			coreData.ProcessorFrequencyMHz,
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.processorPerformance,
+			prometheus.CounterValue,
+			coreData.ProcessorPerformance,
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.processorMPerf,
+			prometheus.CounterValue,
+			counterProcessorMPerfValues.Value(),
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.processorRTC,
+			prometheus.CounterValue,
+			counterProcessorRTCValues.Value(),
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.processorUtility,
+			prometheus.CounterValue,
+			coreData.ProcessorUtilityRate,
+			core,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.processorPrivilegedUtility,
+			prometheus.CounterValue,
+			coreData.PrivilegedUtilitySeconds,
+			core,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.logicalProcessors,
+		prometheus.GaugeValue,
+		coreCount,
+	)
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpu
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/replay/replaytest"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+)
+
+func TestCollectProcessorsReplay(t *testing.T) {
+	t.Parallel()
+
+	c := &metrics{
+		processorRTCValues:   map[string]utils.Counter{},
+		processorMPerfValues: map[string]utils.Counter{},
+	}
+
+	c.BuildDescs()
+
+	replaytest.TestPDH(t, "testdata/recording.json", pdh.CounterTypeRaw, "Processor Information", 2, c.collectProcessors, `
+# HELP windows_cpu_logical_processor Total number of logical processors
+# TYPE windows_cpu_logical_processor gauge
+windows_cpu_logical_processor 2
+# HELP windows_cpu_processor_mperf_total Processor MPerf is the number of TSC ticks incremented while executing instructions
+# TYPE windows_cpu_processor_mperf_total counter
+windows_cpu_processor_mperf_total{core="0,0"} 400
+windows_cpu_processor_mperf_total{core="0,1"} 400
+# HELP windows_cpu_processor_rtc_total Processor RTC represents the number of RTC ticks made since the system booted. It should consistently be 64e6, and can be used to properly derive Processor Utility Rate
+# TYPE windows_cpu_processor_rtc_total counter
+windows_cpu_processor_rtc_total{core="0,0"} 392
+windows_cpu_processor_rtc_total{core="0,1"} 1000
+`,
+		"windows_cpu_logical_processor",
+		"windows_cpu_processor_mperf_total",
+		"windows_cpu_processor_rtc_total",
+	)
+}
@@ -23,6 +23,7 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
@@ -32,6 +33,9 @@ import (
 
 const Name = "cpu_info"
 
+// queryTTL is the time the result of the Win32_Processor query is shared, since it only changes with the hardware.
+const queryTTL = 5 * time.Minute
+
 type Config struct{}
 
 //nolint:gochecknoglobals
@@ -77,7 +81,31 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
-	c.cpuInfo = prometheus.NewDesc(
+	c.BuildDescs()
+
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	miQuery, err := mi.NewQuery("SELECT Architecture, DeviceId, Description, Family, L2CacheSize, L3CacheSize, Name, ThreadCount, NumberOfCores, NumberOfEnabledCore, NumberOfLogicalProcessors FROM Win32_Processor")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	var dst []miProcessor
+	if err := c.miSession.QueryWithTTL(&dst, mi.NamespaceRootCIMv2, c.miQuery, queryTTL); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.cpuInfo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, "", Name),
 		"Labelled CPU information as provided by Win32_Processor",
 		[]string{
@@ -89,7 +117,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuThreadCount = prometheus.NewDesc(
+	c.cpuThreadCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "thread"),
 		"Number of threads per CPU",
 		[]string{
@@ -97,7 +125,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuCoreCount = prometheus.NewDesc(
+	c.cpuCoreCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "core"),
 		"Number of cores per CPU",
 		[]string{
@@ -105,7 +133,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuEnabledCoreCount = prometheus.NewDesc(
+	c.cpuEnabledCoreCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "enabled_core"),
 		"Number of enabled cores per CPU",
 		[]string{
@@ -113,7 +141,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuLogicalProcessorsCount = prometheus.NewDesc(
+	c.cpuLogicalProcessorsCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "logical_processor"),
 		"Number of logical processors per CPU",
 		[]string{
@@ -121,7 +149,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuL2CacheSize = prometheus.NewDesc(
+	c.cpuL2CacheSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "l2_cache_size"),
 		"Size of L2 cache per CPU",
 		[]string{
@@ -129,7 +157,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuL3CacheSize = prometheus.NewDesc(
+	c.cpuL3CacheSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "l3_cache_size"),
 		"Size of L3 cache per CPU",
 		[]string{
@@ -137,25 +165,6 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-
-	if miSession == nil {
-		return errors.New("miSession is nil")
-	}
-
-	miQuery, err := mi.NewQuery("SELECT Architecture, DeviceId, Description, Family, L2CacheSize, L3CacheSize, Name, ThreadCount, NumberOfCores, NumberOfEnabledCore, NumberOfLogicalProcessors FROM Win32_Processor")
-	if err != nil {
-		return fmt.Errorf("failed to create WMI query: %w", err)
-	}
-
-	c.miQuery = miQuery
-	c.miSession = miSession
-
-	var dst []miProcessor
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery); err != nil {
-		return fmt.Errorf("WMI query failed: %w", err)
-	}
-
-	return nil
 }
 
 type miProcessor struct {
@@ -174,11 +183,24 @@ type miProcessor struct {
 	Total int
 }
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.cpuInfo,
+		c.cpuCoreCount,
+		c.cpuEnabledCoreCount,
+		c.cpuLogicalProcessorsCount,
+		c.cpuThreadCount,
+		c.cpuL2CacheSize,
+		c.cpuL3CacheSize,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 	var dst []miProcessor
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery); err != nil {
+	if err := c.miSession.QueryWithTTL(&dst, mi.NamespaceRootCIMv2, c.miQuery, queryTTL); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
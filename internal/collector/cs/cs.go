@@ -79,19 +79,26 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		"Physical memory has been moved to memory collector. " +
 		"Hostname has been moved to os collector.")
 
-	c.logicalProcessors = prometheus.NewDesc(
+	c.BuildDescs()
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.logicalProcessors = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "logical_processors"),
 		"Deprecated: Use windows_cpu_logical_processor instead",
 		nil,
 		nil,
 	)
-	c.physicalMemoryBytes = prometheus.NewDesc(
+	c.physicalMemoryBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "physical_memory_bytes"),
 		"Deprecated: Use windows_memory_physical_total_bytes instead",
 		nil,
 		nil,
 	)
-	c.hostname = prometheus.NewDesc(
+	c.hostname = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hostname"),
 		"Deprecated: Use windows_os_hostname instead",
 		[]string{
@@ -101,8 +108,15 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		},
 		nil,
 	)
+}
 
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.physicalMemoryBytes,
+		c.logicalProcessors,
+		c.hostname,
+	)
 }
 
 // Collect sends the metric values for each metric
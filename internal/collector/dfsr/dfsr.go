@@ -162,64 +162,94 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	logger.Info("dfsr collector is in an experimental state! Metrics for this collector have not been tested.")
 
+	c.BuildDescs()
+
+	var err error
+
+	if slices.Contains(c.config.CollectorsEnabled, "connection") {
+		c.perfDataCollectorConnection, err = pdh.NewCollector[perfDataCounterValuesConnection](pdh.CounterTypeRaw, "DFS Replication Connections", pdh.InstancesAll)
+		if err != nil {
+			return fmt.Errorf("failed to create DFS Replication Connections collector: %w", err)
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, "folder") {
+		c.perfDataCollectorFolder, err = pdh.NewCollector[perfDataCounterValuesFolder](pdh.CounterTypeRaw, "DFS Replicated Folders", pdh.InstancesAll)
+		if err != nil {
+			return fmt.Errorf("failed to create DFS Replicated Folders collector: %w", err)
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, "volume") {
+		c.perfDataCollectorVolume, err = pdh.NewCollector[perfDataCounterValuesVolume](pdh.CounterTypeRaw, "DFS Replication Service Volumes", pdh.InstancesAll)
+		if err != nil {
+			return fmt.Errorf("failed to create DFS Replication Service Volumes collector: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
 	// connection
-	c.connectionBandwidthSavingsUsingDFSReplicationTotal = prometheus.NewDesc(
+	c.connectionBandwidthSavingsUsingDFSReplicationTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_bandwidth_savings_using_dfs_replication_bytes_total"),
 		"Total bytes of bandwidth saved using DFS Replication for this connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionBytesReceivedTotal = prometheus.NewDesc(
+	c.connectionBytesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_bytes_received_total"),
 		"Total bytes received for connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionCompressedSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_compressed_size_of_files_received_bytes_total"),
 		"Total compressed size of files received on the connection, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_received_files_total"),
 		"Total number of files received for connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCBytesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCBytesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_received_bytes_total"),
 		"Total bytes received on the connection while replicating files using Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCCompressedSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_compressed_size_of_received_files_bytes_total"),
 		"Total uncompressed size of files received with Remote Differential Compression for connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCNumberOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCNumberOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_received_files_total"),
 		"Total number of files received using remote differential compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_size_of_received_files_bytes_total"),
 		"Total size of received Remote Differential Compression files, in bytes.",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_files_received_bytes_total"),
 		"Total size of files received, in bytes",
 		[]string{"name"},
@@ -227,189 +257,189 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 
 	// folder
-	c.folderBandwidthSavingsUsingDFSReplicationTotal = prometheus.NewDesc(
+	c.folderBandwidthSavingsUsingDFSReplicationTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_bandwidth_savings_using_dfs_replication_bytes_total"),
 		"Total bytes of bandwidth saved using DFS Replication for this folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderCompressedSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_compressed_size_of_received_files_bytes_total"),
 		"Total compressed size of files received on the folder, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictBytesCleanedUpTotal = prometheus.NewDesc(
+	c.folderConflictBytesCleanedUpTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_cleaned_up_bytes_total"),
 		"Total size of conflict loser files and folders deleted from the Conflict and Deleted folder, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictBytesGeneratedTotal = prometheus.NewDesc(
+	c.folderConflictBytesGeneratedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_generated_bytes_total"),
 		"Total size of conflict loser files and folders moved to the Conflict and Deleted folder, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictFilesCleanedUpTotal = prometheus.NewDesc(
+	c.folderConflictFilesCleanedUpTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_cleaned_up_files_total"),
 		"Number of conflict loser files deleted from the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictFilesGeneratedTotal = prometheus.NewDesc(
+	c.folderConflictFilesGeneratedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_generated_files_total"),
 		"Number of files and folders moved to the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictFolderCleanupsCompletedTotal = prometheus.NewDesc(
+	c.folderConflictFolderCleanupsCompletedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_folder_cleanups_total"),
 		"Number of deletions of conflict loser files and folders in the Conflict and Deleted",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictSpaceInUse = prometheus.NewDesc(
+	c.folderConflictSpaceInUse = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_space_in_use_bytes"),
 		"Total size of the conflict loser files and folders currently in the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedSpaceInUse = prometheus.NewDesc(
+	c.folderDeletedSpaceInUse = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_space_in_use_bytes"),
 		"Total size (in bytes) of the deleted files and folders currently in the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedBytesCleanedUpTotal = prometheus.NewDesc(
+	c.folderDeletedBytesCleanedUpTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_cleaned_up_bytes_total"),
 		"Total size (in bytes) of replicating deleted files and folders that were cleaned up from the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedBytesGeneratedTotal = prometheus.NewDesc(
+	c.folderDeletedBytesGeneratedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_generated_bytes_total"),
 		"Total size (in bytes) of replicated deleted files and folders that were moved to the Conflict and Deleted folder after they were deleted from a replicated folder on a sending member",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedFilesCleanedUpTotal = prometheus.NewDesc(
+	c.folderDeletedFilesCleanedUpTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_cleaned_up_files_total"),
 		"Number of files and folders that were cleaned up from the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedFilesGeneratedTotal = prometheus.NewDesc(
+	c.folderDeletedFilesGeneratedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_generated_files_total"),
 		"Number of deleted files and folders that were moved to the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderFileInstallsRetriedTotal = prometheus.NewDesc(
+	c.folderFileInstallsRetriedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_file_installs_retried_total"),
 		"Total number of file installs that are being retried due to sharing violations or other errors encountered when installing the files",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderFileInstallsSucceededTotal = prometheus.NewDesc(
+	c.folderFileInstallsSucceededTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_file_installs_succeeded_total"),
 		"Total number of files that were successfully received from sending members and installed locally on this server",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderFilesReceivedTotal = prometheus.NewDesc(
+	c.folderFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_received_files_total"),
 		"Total number of files received",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCBytesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCBytesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_received_bytes_total"),
 		"Total number of bytes received in replicating files using Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCCompressedSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_compressed_size_of_received_files_bytes_total"),
 		"Total compressed size (in bytes) of the files received with Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCNumberOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCNumberOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_received_files_total"),
 		"Total number of files received with Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_files_received_bytes_total"),
 		"Total uncompressed size (in bytes) of the files received with Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderSizeOfFilesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_files_received_bytes_total"),
 		"Total uncompressed size (in bytes) of the files received",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingSpaceInUse = prometheus.NewDesc(
+	c.folderStagingSpaceInUse = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_space_in_use_bytes"),
 		"Total size of files and folders currently in the staging folder.",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingBytesCleanedUpTotal = prometheus.NewDesc(
+	c.folderStagingBytesCleanedUpTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_cleaned_up_bytes_total"),
 		"Total size (in bytes) of the files and folders that have been cleaned up from the staging folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingBytesGeneratedTotal = prometheus.NewDesc(
+	c.folderStagingBytesGeneratedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_generated_bytes_total"),
 		"Total size (in bytes) of replicated files and folders in the staging folder created by the DFS Replication service since last restart",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingFilesCleanedUpTotal = prometheus.NewDesc(
+	c.folderStagingFilesCleanedUpTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_cleaned_up_files_total"),
 		"Total number of files and folders that have been cleaned up from the staging folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingFilesGeneratedTotal = prometheus.NewDesc(
+	c.folderStagingFilesGeneratedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_generated_files_total"),
 		"Total number of times replicated files and folders have been staged by the DFS Replication service",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderUpdatesDroppedTotal = prometheus.NewDesc(
+	c.folderUpdatesDroppedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_dropped_updates_total"),
 		"Total number of redundant file replication update records that have been ignored by the DFS Replication service because they did not change the replicated file or folder",
 		[]string{"name"},
@@ -417,65 +447,89 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 
 	// volume
-	c.volumeDatabaseCommitsTotal = prometheus.NewDesc(
+	c.volumeDatabaseCommitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_database_commits_total"),
 		"Total number of DFSR volume database commits",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeDatabaseLookupsTotal = prometheus.NewDesc(
+	c.volumeDatabaseLookupsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_database_lookups_total"),
 		"Total number of DFSR volume database lookups",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeUSNJournalUnreadPercentage = prometheus.NewDesc(
+	c.volumeUSNJournalUnreadPercentage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_usn_journal_unread_percentage"),
 		"Percentage of DFSR volume USN journal records that are unread",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeUSNJournalRecordsAcceptedTotal = prometheus.NewDesc(
+	c.volumeUSNJournalRecordsAcceptedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_usn_journal_accepted_records_total"),
 		"Total number of USN journal records accepted",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeUSNJournalRecordsReadTotal = prometheus.NewDesc(
+	c.volumeUSNJournalRecordsReadTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_usn_journal_read_records_total"),
 		"Total number of DFSR volume USN journal records read",
 		[]string{"name"},
 		nil,
 	)
+}
 
-	var err error
-
-	if slices.Contains(c.config.CollectorsEnabled, "connection") {
-		c.perfDataCollectorConnection, err = pdh.NewCollector[perfDataCounterValuesConnection](pdh.CounterTypeRaw, "DFS Replication Connections", pdh.InstancesAll)
-		if err != nil {
-			return fmt.Errorf("failed to create DFS Replication Connections collector: %w", err)
-		}
-	}
-
-	if slices.Contains(c.config.CollectorsEnabled, "folder") {
-		c.perfDataCollectorFolder, err = pdh.NewCollector[perfDataCounterValuesFolder](pdh.CounterTypeRaw, "DFS Replicated Folders", pdh.InstancesAll)
-		if err != nil {
-			return fmt.Errorf("failed to create DFS Replicated Folders collector: %w", err)
-		}
-	}
-
-	if slices.Contains(c.config.CollectorsEnabled, "volume") {
-		c.perfDataCollectorVolume, err = pdh.NewCollector[perfDataCounterValuesVolume](pdh.CounterTypeRaw, "DFS Replication Service Volumes", pdh.InstancesAll)
-		if err != nil {
-			return fmt.Errorf("failed to create DFS Replication Service Volumes collector: %w", err)
-		}
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.connectionBandwidthSavingsUsingDFSReplicationTotal,
+		c.connectionBytesReceivedTotal,
+		c.connectionCompressedSizeOfFilesReceivedTotal,
+		c.connectionFilesReceivedTotal,
+		c.connectionRDCBytesReceivedTotal,
+		c.connectionRDCCompressedSizeOfFilesReceivedTotal,
+		c.connectionRDCSizeOfFilesReceivedTotal,
+		c.connectionRDCNumberOfFilesReceivedTotal,
+		c.connectionSizeOfFilesReceivedTotal,
+		c.folderBandwidthSavingsUsingDFSReplicationTotal,
+		c.folderCompressedSizeOfFilesReceivedTotal,
+		c.folderConflictBytesCleanedUpTotal,
+		c.folderConflictBytesGeneratedTotal,
+		c.folderConflictFilesCleanedUpTotal,
+		c.folderConflictFilesGeneratedTotal,
+		c.folderConflictFolderCleanupsCompletedTotal,
+		c.folderDeletedBytesCleanedUpTotal,
+		c.folderDeletedBytesGeneratedTotal,
+		c.folderDeletedFilesCleanedUpTotal,
+		c.folderDeletedFilesGeneratedTotal,
+		c.folderFileInstallsRetriedTotal,
+		c.folderFileInstallsSucceededTotal,
+		c.folderFilesReceivedTotal,
+		c.folderRDCBytesReceivedTotal,
+		c.folderRDCCompressedSizeOfFilesReceivedTotal,
+		c.folderRDCNumberOfFilesReceivedTotal,
+		c.folderRDCSizeOfFilesReceivedTotal,
+		c.folderSizeOfFilesReceivedTotal,
+		c.folderStagingBytesCleanedUpTotal,
+		c.folderStagingBytesGeneratedTotal,
+		c.folderStagingFilesCleanedUpTotal,
+		c.folderStagingFilesGeneratedTotal,
+		c.folderUpdatesDroppedTotal,
+		c.volumeDatabaseLookupsTotal,
+		c.volumeDatabaseCommitsTotal,
+		c.volumeUSNJournalRecordsAcceptedTotal,
+		c.volumeUSNJournalRecordsReadTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.folderConflictSpaceInUse,
+		c.folderDeletedSpaceInUse,
+		c.folderStagingSpaceInUse,
+		c.volumeUSNJournalUnreadPercentage,
+	)
 }
 
 // Collect implements the Collector interface.
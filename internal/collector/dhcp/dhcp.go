@@ -151,236 +151,293 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 	var err error
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorScopeMetrics) {
-		c.scopeInfo = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_info"),
-			"DHCP Scope information",
-			[]string{"name", "superscope_name", "superscope_id", "scope"},
-			nil,
-		)
+		c.buildScopeDescs()
+	}
 
-		c.scopeState = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_state"),
-			"DHCP Scope state",
-			[]string{"scope", "state"},
-			nil,
-		)
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorServerMetrics) {
+		c.buildServerDescs()
 
-		c.scopeAddressesFreeTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free"),
-			"DHCP Scope free addresses",
-			[]string{"scope"},
-			nil,
-		)
+		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "DHCP Server", nil)
+		if err != nil {
+			return fmt.Errorf("failed to create DHCP Server collector: %w", err)
+		}
+	}
 
-		c.scopeAddressesFreeOnPartnerServerTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free_on_partner_server"),
-			"DHCP Scope free addresses on partner server",
-			[]string{"scope"},
-			nil,
-		)
+	return nil
+}
 
-		c.scopeAddressesFreeOnThisServerTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free_on_this_server"),
-			"DHCP Scope free addresses on this server",
-			[]string{"scope"},
-			nil,
-		)
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildScopeDescs()
+	c.buildServerDescs()
+}
 
-		c.scopeAddressesInUseTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use"),
-			"DHCP Scope addresses in use",
-			[]string{"scope"},
-			nil,
-		)
+func (c *Collector) buildScopeDescs() {
+	c.scopeInfo = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_info"),
+		"DHCP Scope information",
+		[]string{"name", "superscope_name", "superscope_id", "scope"},
+		nil,
+	)
 
-		c.scopeAddressesInUseOnPartnerServerTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use_on_partner_server"),
-			"DHCP Scope addresses in use on partner server",
-			[]string{"scope"},
-			nil,
-		)
+	c.scopeState = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_state"),
+		"DHCP Scope state",
+		[]string{"scope", "state"},
+		nil,
+	)
 
-		c.scopeAddressesInUseOnThisServerTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use_on_this_server"),
-			"DHCP Scope addresses in use on this server",
-			[]string{"scope"},
-			nil,
-		)
+	c.scopeAddressesFreeTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free"),
+		"DHCP Scope free addresses",
+		[]string{"scope"},
+		nil,
+	)
 
-		c.scopePendingOffersTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_pending_offers"),
-			"DHCP Scope pending offers",
-			[]string{"scope"},
-			nil,
-		)
+	c.scopeAddressesFreeOnPartnerServerTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free_on_partner_server"),
+		"DHCP Scope free addresses on partner server",
+		[]string{"scope"},
+		nil,
+	)
 
-		c.scopeReservedAddressTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "scope_reserved_address"),
-			"DHCP Scope reserved addresses",
-			[]string{"scope"},
-			nil,
-		)
-	}
+	c.scopeAddressesFreeOnThisServerTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free_on_this_server"),
+		"DHCP Scope free addresses on this server",
+		[]string{"scope"},
+		nil,
+	)
 
-	if slices.Contains(c.config.CollectorsEnabled, subCollectorServerMetrics) {
-		c.packetsReceivedTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "packets_received_total"),
-			"Total number of packets received by the DHCP server (PacketsReceivedTotal)",
-			nil,
-			nil,
-		)
-		c.duplicatesDroppedTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "duplicates_dropped_total"),
-			"Total number of duplicate packets received by the DHCP server (DuplicatesDroppedTotal)",
-			nil,
-			nil,
-		)
-		c.packetsExpiredTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "packets_expired_total"),
-			"Total number of packets expired in the DHCP server message queue (PacketsExpiredTotal)",
-			nil,
-			nil,
-		)
-		c.activeQueueLength = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "active_queue_length"),
-			"Number of packets in the processing queue of the DHCP server (ActiveQueueLength)",
-			nil,
-			nil,
-		)
-		c.conflictCheckQueueLength = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "conflict_check_queue_length"),
-			"Number of packets in the DHCP server queue waiting on conflict detection (ping). (ConflictCheckQueueLength)",
-			nil,
-			nil,
-		)
-		c.discoversTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "discovers_total"),
-			"Total DHCP Discovers received by the DHCP server (DiscoversTotal)",
-			nil,
-			nil,
-		)
-		c.offersTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "offers_total"),
-			"Total DHCP Offers sent by the DHCP server (OffersTotal)",
-			nil,
-			nil,
-		)
-		c.requestsTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "requests_total"),
-			"Total DHCP Requests received by the DHCP server (RequestsTotal)",
-			nil,
-			nil,
-		)
-		c.informsTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "informs_total"),
-			"Total DHCP Informs received by the DHCP server (InformsTotal)",
-			nil,
-			nil,
-		)
-		c.acksTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "acks_total"),
-			"Total DHCP Acks sent by the DHCP server (AcksTotal)",
-			nil,
-			nil,
-		)
-		c.nACKsTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "nacks_total"),
-			"Total DHCP Nacks sent by the DHCP server (NacksTotal)",
-			nil,
-			nil,
-		)
-		c.declinesTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "declines_total"),
-			"Total DHCP Declines received by the DHCP server (DeclinesTotal)",
-			nil,
-			nil,
-		)
-		c.releasesTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "releases_total"),
-			"Total DHCP Releases received by the DHCP server (ReleasesTotal)",
-			nil,
-			nil,
-		)
-		c.offerQueueLength = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "offer_queue_length"),
-			"Number of packets in the offer queue of the DHCP server (OfferQueueLength)",
-			nil,
-			nil,
-		)
-		c.deniedDueToMatch = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "denied_due_to_match_total"),
-			"Total number of DHCP requests denied, based on matches from the Deny list (DeniedDueToMatch)",
-			nil,
-			nil,
-		)
-		c.deniedDueToNonMatch = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "denied_due_to_nonmatch_total"),
-			"Total number of DHCP requests denied, based on non-matches from the Allow list (DeniedDueToNonMatch)",
-			nil,
-			nil,
-		)
-		c.failoverBndUpdSentTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_sent_total"),
-			"Number of DHCP fail over Binding Update messages sent (FailoverBndupdSentTotal)",
-			nil,
-			nil,
-		)
-		c.failoverBndUpdReceivedTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_received_total"),
-			"Number of DHCP fail over Binding Update messages received (FailoverBndupdReceivedTotal)",
-			nil,
-			nil,
-		)
-		c.failoverBndAckSentTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_bndack_sent_total"),
-			"Number of DHCP fail over Binding Ack messages sent (FailoverBndackSentTotal)",
-			nil,
-			nil,
-		)
-		c.failoverBndAckReceivedTotal = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_bndack_received_total"),
-			"Number of DHCP fail over Binding Ack messages received (FailoverBndackReceivedTotal)",
-			nil,
-			nil,
-		)
-		c.failoverBndUpdPendingOutboundQueue = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_pending_in_outbound_queue"),
-			"Number of pending outbound DHCP fail over Binding Update messages (FailoverBndupdPendingOutboundQueue)",
-			nil,
-			nil,
-		)
-		c.failoverTransitionsCommunicationInterruptedState = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_communicationinterrupted_state_total"),
-			"Total number of transitions into COMMUNICATION INTERRUPTED state (FailoverTransitionsCommunicationinterruptedState)",
-			nil,
-			nil,
-		)
-		c.failoverTransitionsPartnerDownState = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_partnerdown_state_total"),
-			"Total number of transitions into PARTNER DOWN state (FailoverTransitionsPartnerdownState)",
-			nil,
-			nil,
-		)
-		c.failoverTransitionsRecoverState = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_recover_total"),
-			"Total number of transitions into RECOVER state (FailoverTransitionsRecoverState)",
-			nil,
-			nil,
-		)
-		c.failoverBndUpdDropped = prometheus.NewDesc(
-			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_dropped_total"),
-			"Total number of DHCP fail over Binding Updates dropped (FailoverBndupdDropped)",
-			nil,
-			nil,
-		)
+	c.scopeAddressesInUseTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use"),
+		"DHCP Scope addresses in use",
+		[]string{"scope"},
+		nil,
+	)
 
-		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "DHCP Server", nil)
-		if err != nil {
-			return fmt.Errorf("failed to create DHCP Server collector: %w", err)
-		}
-	}
+	c.scopeAddressesInUseOnPartnerServerTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use_on_partner_server"),
+		"DHCP Scope addresses in use on partner server",
+		[]string{"scope"},
+		nil,
+	)
 
-	return nil
+	c.scopeAddressesInUseOnThisServerTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use_on_this_server"),
+		"DHCP Scope addresses in use on this server",
+		[]string{"scope"},
+		nil,
+	)
+
+	c.scopePendingOffersTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_pending_offers"),
+		"DHCP Scope pending offers",
+		[]string{"scope"},
+		nil,
+	)
+
+	c.scopeReservedAddressTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scope_reserved_address"),
+		"DHCP Scope reserved addresses",
+		[]string{"scope"},
+		nil,
+	)
+}
+
+func (c *Collector) buildServerDescs() {
+	c.packetsReceivedTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "packets_received_total"),
+		"Total number of packets received by the DHCP server (PacketsReceivedTotal)",
+		nil,
+		nil,
+	)
+	c.duplicatesDroppedTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "duplicates_dropped_total"),
+		"Total number of duplicate packets received by the DHCP server (DuplicatesDroppedTotal)",
+		nil,
+		nil,
+	)
+	c.packetsExpiredTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "packets_expired_total"),
+		"Total number of packets expired in the DHCP server message queue (PacketsExpiredTotal)",
+		nil,
+		nil,
+	)
+	c.activeQueueLength = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "active_queue_length"),
+		"Number of packets in the processing queue of the DHCP server (ActiveQueueLength)",
+		nil,
+		nil,
+	)
+	c.conflictCheckQueueLength = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "conflict_check_queue_length"),
+		"Number of packets in the DHCP server queue waiting on conflict detection (ping). (ConflictCheckQueueLength)",
+		nil,
+		nil,
+	)
+	c.discoversTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "discovers_total"),
+		"Total DHCP Discovers received by the DHCP server (DiscoversTotal)",
+		nil,
+		nil,
+	)
+	c.offersTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "offers_total"),
+		"Total DHCP Offers sent by the DHCP server (OffersTotal)",
+		nil,
+		nil,
+	)
+	c.requestsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "requests_total"),
+		"Total DHCP Requests received by the DHCP server (RequestsTotal)",
+		nil,
+		nil,
+	)
+	c.informsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "informs_total"),
+		"Total DHCP Informs received by the DHCP server (InformsTotal)",
+		nil,
+		nil,
+	)
+	c.acksTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "acks_total"),
+		"Total DHCP Acks sent by the DHCP server (AcksTotal)",
+		nil,
+		nil,
+	)
+	c.nACKsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "nacks_total"),
+		"Total DHCP Nacks sent by the DHCP server (NacksTotal)",
+		nil,
+		nil,
+	)
+	c.declinesTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "declines_total"),
+		"Total DHCP Declines received by the DHCP server (DeclinesTotal)",
+		nil,
+		nil,
+	)
+	c.releasesTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "releases_total"),
+		"Total DHCP Releases received by the DHCP server (ReleasesTotal)",
+		nil,
+		nil,
+	)
+	c.offerQueueLength = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "offer_queue_length"),
+		"Number of packets in the offer queue of the DHCP server (OfferQueueLength)",
+		nil,
+		nil,
+	)
+	c.deniedDueToMatch = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "denied_due_to_match_total"),
+		"Total number of DHCP requests denied, based on matches from the Deny list (DeniedDueToMatch)",
+		nil,
+		nil,
+	)
+	c.deniedDueToNonMatch = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "denied_due_to_nonmatch_total"),
+		"Total number of DHCP requests denied, based on non-matches from the Allow list (DeniedDueToNonMatch)",
+		nil,
+		nil,
+	)
+	c.failoverBndUpdSentTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_sent_total"),
+		"Number of DHCP fail over Binding Update messages sent (FailoverBndupdSentTotal)",
+		nil,
+		nil,
+	)
+	c.failoverBndUpdReceivedTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_received_total"),
+		"Number of DHCP fail over Binding Update messages received (FailoverBndupdReceivedTotal)",
+		nil,
+		nil,
+	)
+	c.failoverBndAckSentTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_bndack_sent_total"),
+		"Number of DHCP fail over Binding Ack messages sent (FailoverBndackSentTotal)",
+		nil,
+		nil,
+	)
+	c.failoverBndAckReceivedTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_bndack_received_total"),
+		"Number of DHCP fail over Binding Ack messages received (FailoverBndackReceivedTotal)",
+		nil,
+		nil,
+	)
+	c.failoverBndUpdPendingOutboundQueue = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_pending_in_outbound_queue"),
+		"Number of pending outbound DHCP fail over Binding Update messages (FailoverBndupdPendingOutboundQueue)",
+		nil,
+		nil,
+	)
+	c.failoverTransitionsCommunicationInterruptedState = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_communicationinterrupted_state_total"),
+		"Total number of transitions into COMMUNICATION INTERRUPTED state (FailoverTransitionsCommunicationinterruptedState)",
+		nil,
+		nil,
+	)
+	c.failoverTransitionsPartnerDownState = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_partnerdown_state_total"),
+		"Total number of transitions into PARTNER DOWN state (FailoverTransitionsPartnerdownState)",
+		nil,
+		nil,
+	)
+	c.failoverTransitionsRecoverState = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_recover_total"),
+		"Total number of transitions into RECOVER state (FailoverTransitionsRecoverState)",
+		nil,
+		nil,
+	)
+	c.failoverBndUpdDropped = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_dropped_total"),
+		"Total number of DHCP fail over Binding Updates dropped (FailoverBndupdDropped)",
+		nil,
+		nil,
+	)
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.acksTotal,
+		c.declinesTotal,
+		c.deniedDueToMatch,
+		c.deniedDueToNonMatch,
+		c.discoversTotal,
+		c.duplicatesDroppedTotal,
+		c.failoverBndAckReceivedTotal,
+		c.failoverBndAckSentTotal,
+		c.failoverBndUpdDropped,
+		c.failoverBndUpdReceivedTotal,
+		c.failoverBndUpdSentTotal,
+		c.failoverTransitionsCommunicationInterruptedState,
+		c.failoverTransitionsPartnerDownState,
+		c.failoverTransitionsRecoverState,
+		c.informsTotal,
+		c.nACKsTotal,
+		c.offersTotal,
+		c.packetsExpiredTotal,
+		c.packetsReceivedTotal,
+		c.releasesTotal,
+		c.requestsTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.activeQueueLength,
+		c.conflictCheckQueueLength,
+		c.failoverBndUpdPendingOutboundQueue,
+		c.offerQueueLength,
+		c.scopeInfo,
+		c.scopeState,
+		c.scopeAddressesFreeTotal,
+		c.scopeAddressesFreeOnPartnerServerTotal,
+		c.scopeAddressesFreeOnThisServerTotal,
+		c.scopeAddressesInUseTotal,
+		c.scopeAddressesInUseOnPartnerServerTotal,
+		c.scopeAddressesInUseOnThisServerTotal,
+		c.scopePendingOffersTotal,
+		c.scopeReservedAddressTotal,
+	)
 }
 
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
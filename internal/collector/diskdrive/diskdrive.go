@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
@@ -31,6 +32,10 @@ import (
 
 const Name = "diskdrive"
 
+// queryTTL is the time the result of the Win32_DiskDrive query is shared. Disks are rarely added or removed
+// and their status is updated by the storage driver in the same order of magnitude.
+const queryTTL = time.Minute
+
 type Config struct{}
 
 //nolint:gochecknoglobals
@@ -74,7 +79,31 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
-	c.diskInfo = prometheus.NewDesc(
+	c.BuildDescs()
+
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	miQuery, err := mi.NewQuery("SELECT DeviceID, Model, Caption, Name, Partitions, Size, Status, Availability FROM WIN32_DiskDrive")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	var dst []diskDrive
+	if err := c.miSession.QueryWithTTL(&dst, mi.NamespaceRootCIMv2, c.miQuery, queryTTL); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.diskInfo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"General drive information",
 		[]string{
@@ -85,49 +114,30 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.status = prometheus.NewDesc(
+	c.status = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "status"),
 		"Status of the drive",
 		[]string{"name", "status"},
 		nil,
 	)
-	c.size = prometheus.NewDesc(
+	c.size = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "size"),
 		"Size of the disk drive. It is calculated by multiplying the total number of cylinders, tracks in each cylinder, sectors in each track, and bytes in each sector.",
 		[]string{"name"},
 		nil,
 	)
-	c.partitions = prometheus.NewDesc(
+	c.partitions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "partitions"),
 		"Number of partitions",
 		[]string{"name"},
 		nil,
 	)
-	c.availability = prometheus.NewDesc(
+	c.availability = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availability"),
 		"Availability Status",
 		[]string{"name", "availability"},
 		nil,
 	)
-
-	if miSession == nil {
-		return errors.New("miSession is nil")
-	}
-
-	miQuery, err := mi.NewQuery("SELECT DeviceID, Model, Caption, Name, Partitions, Size, Status, Availability FROM WIN32_DiskDrive")
-	if err != nil {
-		return fmt.Errorf("failed to create WMI query: %w", err)
-	}
-
-	c.miQuery = miQuery
-	c.miSession = miSession
-
-	var dst []diskDrive
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery); err != nil {
-		return fmt.Errorf("WMI query failed: %w", err)
-	}
-
-	return nil
 }
 
 type diskDrive struct {
@@ -183,10 +193,21 @@ var (
 	}
 )
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.availability,
+		c.diskInfo,
+		c.partitions,
+		c.size,
+		c.status,
+	)
+}
+
 // Collect sends the metric values for each metric to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 	var dst []diskDrive
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery); err != nil {
+	if err := c.miSession.QueryWithTTL(&dst, mi.NamespaceRootCIMv2, c.miQuery, queryTTL); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
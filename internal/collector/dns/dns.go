@@ -156,155 +156,164 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 	return nil
 }
 
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildMetricsCollectorDescs()
+}
+
 func (c *Collector) buildMetricsCollector() error {
-	c.zoneTransferRequestsReceived = prometheus.NewDesc(
+	c.buildMetricsCollectorDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "DNS", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create DNS collector: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) buildMetricsCollectorDescs() {
+	c.zoneTransferRequestsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_requests_received_total"),
 		"Number of zone transfer requests (AXFR/IXFR) received by the master DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferRequestsSent = prometheus.NewDesc(
+	c.zoneTransferRequestsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_requests_sent_total"),
 		"Number of zone transfer requests (AXFR/IXFR) sent by the secondary DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferResponsesReceived = prometheus.NewDesc(
+	c.zoneTransferResponsesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_response_received_total"),
 		"Number of zone transfer responses (AXFR/IXFR) received by the secondary DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferSuccessReceived = prometheus.NewDesc(
+	c.zoneTransferSuccessReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_success_received_total"),
 		"Number of successful zone transfers (AXFR/IXFR) received by the secondary DNS server",
 		[]string{"qtype", "protocol"},
 		nil,
 	)
-	c.zoneTransferSuccessSent = prometheus.NewDesc(
+	c.zoneTransferSuccessSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_success_sent_total"),
 		"Number of successful zone transfers (AXFR/IXFR) of the master DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferFailures = prometheus.NewDesc(
+	c.zoneTransferFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_failures_total"),
 		"Number of failed zone transfers of the master DNS server",
 		nil,
 		nil,
 	)
-	c.memoryUsedBytes = prometheus.NewDesc(
+	c.memoryUsedBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memory_used_bytes"),
 		"Current memory used by DNS server",
 		[]string{"area"},
 		nil,
 	)
-	c.dynamicUpdatesQueued = prometheus.NewDesc(
+	c.dynamicUpdatesQueued = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_updates_queued"),
 		"Number of dynamic updates queued by the DNS server",
 		nil,
 		nil,
 	)
-	c.dynamicUpdatesReceived = prometheus.NewDesc(
+	c.dynamicUpdatesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_updates_received_total"),
 		"Number of secure update requests received by the DNS server",
 		[]string{"operation"},
 		nil,
 	)
-	c.dynamicUpdatesFailures = prometheus.NewDesc(
+	c.dynamicUpdatesFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_updates_failures_total"),
 		"Number of dynamic updates which timed out or were rejected by the DNS server",
 		[]string{"reason"},
 		nil,
 	)
-	c.notifyReceived = prometheus.NewDesc(
+	c.notifyReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "notify_received_total"),
 		"Number of notifies received by the secondary DNS server",
 		nil,
 		nil,
 	)
-	c.notifySent = prometheus.NewDesc(
+	c.notifySent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "notify_sent_total"),
 		"Number of notifies sent by the master DNS server",
 		nil,
 		nil,
 	)
-	c.secureUpdateFailures = prometheus.NewDesc(
+	c.secureUpdateFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "secure_update_failures_total"),
 		"Number of secure updates that failed on the DNS server",
 		nil,
 		nil,
 	)
-	c.secureUpdateReceived = prometheus.NewDesc(
+	c.secureUpdateReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "secure_update_received_total"),
 		"Number of secure update requests received by the DNS server",
 		nil,
 		nil,
 	)
-	c.queries = prometheus.NewDesc(
+	c.queries = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "queries_total"),
 		"Number of queries received by DNS server",
 		[]string{"protocol"},
 		nil,
 	)
-	c.responses = prometheus.NewDesc(
+	c.responses = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "responses_total"),
 		"Number of responses sent by DNS server",
 		[]string{"protocol"},
 		nil,
 	)
-	c.recursiveQueries = prometheus.NewDesc(
+	c.recursiveQueries = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "recursive_queries_total"),
 		"Number of recursive queries received by DNS server",
 		nil,
 		nil,
 	)
-	c.recursiveQueryFailures = prometheus.NewDesc(
+	c.recursiveQueryFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "recursive_query_failures_total"),
 		"Number of recursive query failures",
 		nil,
 		nil,
 	)
-	c.recursiveQuerySendTimeouts = prometheus.NewDesc(
+	c.recursiveQuerySendTimeouts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "recursive_query_send_timeouts_total"),
 		"Number of recursive query sending timeouts",
 		nil,
 		nil,
 	)
-	c.winsQueries = prometheus.NewDesc(
+	c.winsQueries = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wins_queries_total"),
 		"Number of WINS lookup requests received by the server",
 		[]string{"direction"},
 		nil,
 	)
-	c.winsResponses = prometheus.NewDesc(
+	c.winsResponses = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wins_responses_total"),
 		"Number of WINS lookup responses sent by the server",
 		[]string{"direction"},
 		nil,
 	)
-	c.unmatchedResponsesReceived = prometheus.NewDesc(
+	c.unmatchedResponsesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "unmatched_responses_total"),
 		"Number of response packets received by the DNS server that do not match any outstanding remote query",
 		nil,
 		nil,
 	)
 
-	c.dnsWMIStats = prometheus.NewDesc(
+	c.dnsWMIStats = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wmi_stats_total"),
 		"DNS WMI statistics from MicrosoftDNS_Statistic",
 		[]string{"name", "collection_name", "dns_server"},
 		nil,
 	)
-
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "DNS", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create DNS collector: %w", err)
-	}
-
-	return nil
 }
 
 func (c *Collector) buildErrorStatsCollector(miSession *mi.Session) error {
@@ -323,6 +332,37 @@ func (c *Collector) buildErrorStatsCollector(miSession *mi.Session) error {
 	return nil
 }
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.dynamicUpdatesFailures,
+		c.dynamicUpdatesReceived,
+		c.notifyReceived,
+		c.notifySent,
+		c.queries,
+		c.recursiveQueries,
+		c.recursiveQueryFailures,
+		c.recursiveQuerySendTimeouts,
+		c.responses,
+		c.secureUpdateFailures,
+		c.secureUpdateReceived,
+		c.unmatchedResponsesReceived,
+		c.winsQueries,
+		c.winsResponses,
+		c.zoneTransferFailures,
+		c.zoneTransferRequestsReceived,
+		c.zoneTransferRequestsSent,
+		c.zoneTransferResponsesReceived,
+		c.zoneTransferSuccessReceived,
+		c.zoneTransferSuccessSent,
+		c.dnsWMIStats,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.dynamicUpdatesQueued,
+		c.memoryUsedBytes,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
@@ -27,6 +27,7 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -248,6 +249,77 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 	return errors.Join(errs...)
 }
 
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildActiveSyncDescs()
+	c.buildADAccessProcessesDescs()
+	c.buildAutoDiscoverDescs()
+	c.buildAvailabilityServiceDescs()
+	c.buildHTTPProxyDescs()
+	c.buildMapiHTTPEmsMDBDescs()
+	c.buildOWADescs()
+	c.buildRpcClientAccessDescs()
+	c.buildTransportQueuesDescs()
+	c.buildWorkloadManagementWorkloadsDescs()
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.activeSyncRequestsPerSec,
+		c.syncCommandsPerSec,
+		c.ldapReadTime,
+		c.ldapSearchTime,
+		c.ldapTimeoutErrorsPerSec,
+		c.ldapWriteTime,
+		c.longRunningLDAPOperationsPerMin,
+		c.autoDiscoverRequestsPerSec,
+		c.availabilityRequestsSec,
+		c.proxyRequestsPerSec,
+		c.owaRequestsPerSec,
+		c.rpcOperationsPerSec,
+		c.messagesQueuedForDeliveryTotal,
+		c.messagesSubmittedTotal,
+		c.messagesDelayedTotal,
+		c.messagesCompletedDeliveryTotal,
+		c.itemsCompletedDeliveryTotal,
+		c.itemsQueuedForDeliveryExpiredTotal,
+		c.itemsQueuedForDeliveryTotal,
+		c.itemsResubmittedTotal,
+		c.completedTasks,
+		c.queuedTasks,
+		c.yieldedTasks,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.pingCommandsPending,
+		c.mailboxServerLocatorAverageLatency,
+		c.averageAuthenticationLatency,
+		c.outstandingProxyRequests,
+		c.averageCASProcessingLatency,
+		c.mailboxServerProxyFailureRate,
+		c.activeUserCountMapiHTTPEmsMDB,
+		c.currentUniqueUsers,
+		c.activeUserCount,
+		c.connectionCount,
+		c.rpcAveragedLatency,
+		c.rpcRequests,
+		c.userCount,
+		c.activeMailboxDeliveryQueueLength,
+		c.externalActiveRemoteDeliveryQueueLength,
+		c.externalLargestDeliveryQueueLength,
+		c.internalActiveRemoteDeliveryQueueLength,
+		c.internalLargestDeliveryQueueLength,
+		c.poisonQueueLength,
+		c.retryMailboxDeliveryQueueLength,
+		c.unreachableQueueLength,
+		c.aggregateShadowQueueLength,
+		c.submissionQueueLength,
+		c.delayQueueLength,
+		c.activeTasks,
+		c.isActive,
+	)
+}
+
 // Collect collects exchange metrics and sends them to prometheus.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 	errCh := make(chan error, len(c.collectorFns))
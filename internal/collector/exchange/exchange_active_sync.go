@@ -48,26 +48,30 @@ func (c *Collector) buildActiveSync() error {
 		return fmt.Errorf("failed to create MSExchange ActiveSync collector: %w", err)
 	}
 
-	c.pingCommandsPending = prometheus.NewDesc(
+	c.buildActiveSyncDescs()
+
+	return nil
+}
+
+func (c *Collector) buildActiveSyncDescs() {
+	c.pingCommandsPending = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "activesync_ping_cmds_pending"),
 		"Number of ping commands currently pending in the queue",
 		nil,
 		nil,
 	)
-	c.syncCommandsPerSec = prometheus.NewDesc(
+	c.syncCommandsPerSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "activesync_sync_cmds_total"),
 		"Number of sync commands processed per second. Clients use this command to synchronize items within a folder",
 		nil,
 		nil,
 	)
-	c.activeSyncRequestsPerSec = prometheus.NewDesc(
+	c.activeSyncRequestsPerSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "activesync_requests_total"),
 		"Num HTTP requests received from the client via ASP.NET per sec. Shows Current user load",
 		nil,
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectActiveSync(ch chan<- prometheus.Metric) error {
@@ -55,38 +55,42 @@ func (c *Collector) buildADAccessProcesses() error {
 		return fmt.Errorf("failed to create MSExchange ADAccess Processes collector: %w", err)
 	}
 
-	c.ldapReadTime = prometheus.NewDesc(
+	c.buildADAccessProcessesDescs()
+
+	return nil
+}
+
+func (c *Collector) buildADAccessProcessesDescs() {
+	c.ldapReadTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_read_time_sec"),
 		"Time (sec) to send an LDAP read request and receive a response",
 		[]string{"name"},
 		nil,
 	)
-	c.ldapSearchTime = prometheus.NewDesc(
+	c.ldapSearchTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_search_time_sec"),
 		"Time (sec) to send an LDAP search request and receive a response",
 		[]string{"name"},
 		nil,
 	)
-	c.ldapWriteTime = prometheus.NewDesc(
+	c.ldapWriteTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_write_time_sec"),
 		"Time (sec) to send an LDAP Add/Modify/Delete request and receive a response",
 		[]string{"name"},
 		nil,
 	)
-	c.ldapTimeoutErrorsPerSec = prometheus.NewDesc(
+	c.ldapTimeoutErrorsPerSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_timeout_errors_total"),
 		"Total number of LDAP timeout errors",
 		[]string{"name"},
 		nil,
 	)
-	c.longRunningLDAPOperationsPerMin = prometheus.NewDesc(
+	c.longRunningLDAPOperationsPerMin = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_long_running_ops_per_sec"),
 		"Long Running LDAP operations per second",
 		[]string{"name"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectADAccessProcesses(ch chan<- prometheus.Metric) error {
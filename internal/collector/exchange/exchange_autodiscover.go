@@ -44,14 +44,18 @@ func (c *Collector) buildAutoDiscover() error {
 		return fmt.Errorf("failed to create MSExchange Autodiscover collector: %w", err)
 	}
 
-	c.autoDiscoverRequestsPerSec = prometheus.NewDesc(
+	c.buildAutoDiscoverDescs()
+
+	return nil
+}
+
+func (c *Collector) buildAutoDiscoverDescs() {
+	c.autoDiscoverRequestsPerSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "autodiscover_requests_total"),
 		"Number of autodiscover service requests processed each second",
 		nil,
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectAutoDiscover(ch chan<- prometheus.Metric) error {
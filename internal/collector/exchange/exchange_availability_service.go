@@ -44,14 +44,18 @@ func (c *Collector) buildAvailabilityService() error {
 		return fmt.Errorf("failed to create MSExchange Availability Service collector: %w", err)
 	}
 
-	c.availabilityRequestsSec = prometheus.NewDesc(
+	c.buildAvailabilityServiceDescs()
+
+	return nil
+}
+
+func (c *Collector) buildAvailabilityServiceDescs() {
+	c.availabilityRequestsSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availability_service_requests_per_sec"),
 		"Number of requests serviced per second",
 		nil,
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectAvailabilityService(ch chan<- prometheus.Metric) error {
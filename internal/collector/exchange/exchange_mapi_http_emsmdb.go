@@ -44,14 +44,18 @@ func (c *Collector) buildMapiHTTPEmsMDB() error {
 		return fmt.Errorf("failed to create MSExchange MapiHttp Emsmdb: %w", err)
 	}
 
-	c.activeUserCountMapiHTTPEmsMDB = prometheus.NewDesc(
+	c.buildMapiHTTPEmsMDBDescs()
+
+	return nil
+}
+
+func (c *Collector) buildMapiHTTPEmsMDBDescs() {
+	c.activeUserCountMapiHTTPEmsMDB = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mapihttp_emsmdb_active_user_count"),
 		"Number of unique outlook users that have shown some kind of activity in the last 2 minutes",
 		nil,
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectMapiHTTPEmsMDB(ch chan<- prometheus.Metric) error {
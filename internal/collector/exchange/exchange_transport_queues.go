@@ -82,122 +82,126 @@ func (c *Collector) buildTransportQueues() error {
 		return fmt.Errorf("failed to create MSExchangeTransport Queues collector: %w", err)
 	}
 
-	c.externalActiveRemoteDeliveryQueueLength = prometheus.NewDesc(
+	c.buildTransportQueuesDescs()
+
+	return nil
+}
+
+func (c *Collector) buildTransportQueuesDescs() {
+	c.externalActiveRemoteDeliveryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_external_active_remote_delivery"),
 		"External Active Remote Delivery Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.internalActiveRemoteDeliveryQueueLength = prometheus.NewDesc(
+	c.internalActiveRemoteDeliveryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_internal_active_remote_delivery"),
 		"Internal Active Remote Delivery Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.activeMailboxDeliveryQueueLength = prometheus.NewDesc(
+	c.activeMailboxDeliveryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_active_mailbox_delivery"),
 		"Active Mailbox Delivery Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.retryMailboxDeliveryQueueLength = prometheus.NewDesc(
+	c.retryMailboxDeliveryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_retry_mailbox_delivery"),
 		"Retry Mailbox Delivery Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.unreachableQueueLength = prometheus.NewDesc(
+	c.unreachableQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_unreachable"),
 		"Unreachable Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.externalLargestDeliveryQueueLength = prometheus.NewDesc(
+	c.externalLargestDeliveryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_external_largest_delivery"),
 		"External Largest Delivery Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.internalLargestDeliveryQueueLength = prometheus.NewDesc(
+	c.internalLargestDeliveryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_internal_largest_delivery"),
 		"Internal Largest Delivery Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.poisonQueueLength = prometheus.NewDesc(
+	c.poisonQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_poison"),
 		"Poison Queue length",
 		[]string{"name"},
 		nil,
 	)
-	c.messagesQueuedForDeliveryTotal = prometheus.NewDesc(
+	c.messagesQueuedForDeliveryTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_messages_queued_for_delivery_total"),
 		"Messages Queued For Delivery Total",
 		[]string{"name"},
 		nil,
 	)
-	c.messagesSubmittedTotal = prometheus.NewDesc(
+	c.messagesSubmittedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_messages_submitted_total"),
 		"Messages Submitted Total",
 		[]string{"name"},
 		nil,
 	)
-	c.messagesDelayedTotal = prometheus.NewDesc(
+	c.messagesDelayedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_messages_delayed_total"),
 		"Messages Delayed Total",
 		[]string{"name"},
 		nil,
 	)
-	c.messagesCompletedDeliveryTotal = prometheus.NewDesc(
+	c.messagesCompletedDeliveryTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_messages_completed_delivery_total"),
 		"Messages Completed Delivery Total",
 		[]string{"name"},
 		nil,
 	)
-	c.aggregateShadowQueueLength = prometheus.NewDesc(
+	c.aggregateShadowQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_aggregate_shadow_queue_length"),
 		"The current number of messages in shadow queues.",
 		[]string{"name"},
 		nil,
 	)
-	c.submissionQueueLength = prometheus.NewDesc(
+	c.submissionQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_submission_queue_length"),
 		"Submission Queue Length",
 		[]string{"name"},
 		nil,
 	)
-	c.delayQueueLength = prometheus.NewDesc(
+	c.delayQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_delay_queue_length"),
 		"Delay Queue Length",
 		[]string{"name"},
 		nil,
 	)
-	c.itemsCompletedDeliveryTotal = prometheus.NewDesc(
+	c.itemsCompletedDeliveryTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_items_completed_delivery_total"),
 		"Items Completed Delivery Total",
 		[]string{"name"},
 		nil,
 	)
-	c.itemsQueuedForDeliveryExpiredTotal = prometheus.NewDesc(
+	c.itemsQueuedForDeliveryExpiredTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_items_queued_for_delivery_expired_total"),
 		"Items Queued For Delivery Expired Total",
 		[]string{"name"},
 		nil,
 	)
-	c.itemsQueuedForDeliveryTotal = prometheus.NewDesc(
+	c.itemsQueuedForDeliveryTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_items_queued_for_delivery_total"),
 		"Items Queued For Delivery Total",
 		[]string{"name"},
 		nil,
 	)
-	c.itemsResubmittedTotal = prometheus.NewDesc(
+	c.itemsResubmittedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transport_queues_items_resubmitted_total"),
 		"Items Resubmitted Total",
 		[]string{"name"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectTransportQueues(ch chan<- prometheus.Metric) error {
@@ -103,12 +103,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.logger.Info("filetime collector is in an experimental state! It may subject to change.")
 
-	c.fileMTime = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "mtime_timestamp_seconds"),
-		"File modification time",
-		[]string{"file"},
-		nil,
-	)
+	c.BuildDescs()
 
 	for _, filePattern := range c.config.FilePatterns {
 		basePath, pattern := doublestar.SplitPattern(filePattern)
@@ -122,6 +117,23 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	return nil
 }
 
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.fileMTime = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mtime_timestamp_seconds"),
+		"File modification time",
+		[]string{"file"},
+		nil,
+	)
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.fileMTime,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
@@ -90,67 +90,72 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 	c.miQuery = miQuery
 	c.miSession = miSession
 
-	c.quotasCount = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var dst []msftFSRMQuota
+	if err := c.miSession.Query(&dst, mi.NamespaceRootWindowsFSRM, c.miQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.quotasCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "count"),
 		"Number of Quotas",
 		nil,
 		nil,
 	)
-	c.peakUsage = prometheus.NewDesc(
+	c.peakUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "peak_usage_bytes"),
 		"The highest amount of disk space usage charged to this quota. (PeakUsage)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.size = prometheus.NewDesc(
+	c.size = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "size_bytes"),
 		"The size of the quota. (Size)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.usage = prometheus.NewDesc(
+	c.usage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "usage_bytes"),
 		"The current amount of disk space usage charged to this quota. (Usage)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.description = prometheus.NewDesc(
+	c.description = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "description"),
 		"Description of the quota (Description)",
 		[]string{"path", "template", "description"},
 		nil,
 	)
-	c.disabled = prometheus.NewDesc(
+	c.disabled = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "disabled"),
 		"If 1, the quota is disabled. The default value is 0. (Disabled)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.softLimit = prometheus.NewDesc(
+	c.softLimit = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "softlimit"),
 		"If 1, the quota is a soft limit. If 0, the quota is a hard limit. The default value is 0. Optional (SoftLimit)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.template = prometheus.NewDesc(
+	c.template = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "template"),
 		"Quota template name. (Template)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.matchesTemplate = prometheus.NewDesc(
+	c.matchesTemplate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "matchestemplate"),
 		"If 1, the property values of this quota match those values of the template from which it was derived. (MatchesTemplate)",
 		[]string{"path", "template"},
 		nil,
 	)
-
-	var dst []msftFSRMQuota
-	if err := c.miSession.Query(&dst, mi.NamespaceRootWindowsFSRM, c.miQuery); err != nil {
-		return fmt.Errorf("WMI query failed: %w", err)
-	}
-
-	return nil
 }
 
 // MSFT_FSRMQuota docs:
@@ -167,6 +172,20 @@ type msftFSRMQuota struct {
 	SoftLimit       bool   `mi:"SoftLimit"`
 }
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.quotasCount,
+		c.peakUsage,
+		c.size,
+		c.usage,
+		c.description,
+		c.disabled,
+		c.matchesTemplate,
+		c.softLimit,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
@@ -109,105 +109,129 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 	var err error
 
-	c.gpuEngineRunningTime = prometheus.NewDesc(
+	c.BuildDescs()
+
+	errs := make([]error, 0)
+
+	c.gpuEnginePerfDataCollector, err = pdh.NewCollector[gpuEnginePerfDataCounterValues](pdh.CounterTypeRaw, "GPU Engine", pdh.InstancesAll)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create GPU Engine perf data collector: %w", err))
+	}
+
+	c.gpuAdapterMemoryPerfDataCollector, err = pdh.NewCollector[gpuAdapterMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Adapter Memory", pdh.InstancesAll)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create GPU Adapter Memory perf data collector: %w", err))
+	}
+
+	c.gpuLocalAdapterMemoryPerfDataCollector, err = pdh.NewCollector[gpuLocalAdapterMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Local Adapter Memory", pdh.InstancesAll)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create GPU Local Adapter Memory perf data collector: %w", err))
+	}
+
+	c.gpuNonLocalAdapterMemoryPerfDataCollector, err = pdh.NewCollector[gpuNonLocalAdapterMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Non Local Adapter Memory", pdh.InstancesAll)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create GPU Non Local Adapter Memory perf data collector: %w", err))
+	}
+
+	c.gpuProcessMemoryPerfDataCollector, err = pdh.NewCollector[gpuProcessMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Process Memory", pdh.InstancesAll)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create GPU Process Memory perf data collector: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.gpuEngineRunningTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "engine_time_seconds"),
 		"Total running time of the GPU in seconds.",
 		[]string{"process_id", "phys", "eng", "engtype"},
 		nil,
 	)
 
-	c.gpuAdapterMemoryDedicatedUsage = prometheus.NewDesc(
+	c.gpuAdapterMemoryDedicatedUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "adapter_memory_dedicated_bytes"),
 		"Dedicated GPU memory usage in bytes.",
 		[]string{"phys"},
 		nil,
 	)
-	c.gpuAdapterMemorySharedUsage = prometheus.NewDesc(
+	c.gpuAdapterMemorySharedUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "adapter_memory_shared_bytes"),
 		"Shared GPU memory usage in bytes.",
 		[]string{"phys"},
 		nil,
 	)
-	c.gpuAdapterMemoryTotalCommitted = prometheus.NewDesc(
+	c.gpuAdapterMemoryTotalCommitted = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "adapter_memory_committed_bytes"),
 		"Total committed GPU memory in bytes.",
 		[]string{"phys"},
 		nil,
 	)
 
-	c.gpuLocalAdapterMemoryUsage = prometheus.NewDesc(
+	c.gpuLocalAdapterMemoryUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "local_adapter_memory_bytes"),
 		"Local adapter memory usage in bytes.",
 		[]string{"phys"},
 		nil,
 	)
 
-	c.gpuNonLocalAdapterMemoryUsage = prometheus.NewDesc(
+	c.gpuNonLocalAdapterMemoryUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "non_local_adapter_memory_bytes"),
 		"Non-local adapter memory usage in bytes.",
 		[]string{"phys"},
 		nil,
 	)
 
-	c.gpuProcessMemoryDedicatedUsage = prometheus.NewDesc(
+	c.gpuProcessMemoryDedicatedUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_dedicated_bytes"),
 		"Dedicated process memory usage in bytes.",
 		[]string{"process_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemoryLocalUsage = prometheus.NewDesc(
+	c.gpuProcessMemoryLocalUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_local_bytes"),
 		"Local process memory usage in bytes.",
 		[]string{"process_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemoryNonLocalUsage = prometheus.NewDesc(
+	c.gpuProcessMemoryNonLocalUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_non_local_bytes"),
 		"Non-local process memory usage in bytes.",
 		[]string{"process_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemorySharedUsage = prometheus.NewDesc(
+	c.gpuProcessMemorySharedUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_shared_bytes"),
 		"Shared process memory usage in bytes.",
 		[]string{"process_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemoryTotalCommitted = prometheus.NewDesc(
+	c.gpuProcessMemoryTotalCommitted = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_committed_bytes"),
 		"Total committed process memory in bytes.",
 		[]string{"process_id", "phys"},
 		nil,
 	)
+}
 
-	errs := make([]error, 0)
-
-	c.gpuEnginePerfDataCollector, err = pdh.NewCollector[gpuEnginePerfDataCounterValues](pdh.CounterTypeRaw, "GPU Engine", pdh.InstancesAll)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create GPU Engine perf data collector: %w", err))
-	}
-
-	c.gpuAdapterMemoryPerfDataCollector, err = pdh.NewCollector[gpuAdapterMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Adapter Memory", pdh.InstancesAll)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create GPU Adapter Memory perf data collector: %w", err))
-	}
-
-	c.gpuLocalAdapterMemoryPerfDataCollector, err = pdh.NewCollector[gpuLocalAdapterMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Local Adapter Memory", pdh.InstancesAll)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create GPU Local Adapter Memory perf data collector: %w", err))
-	}
-
-	c.gpuNonLocalAdapterMemoryPerfDataCollector, err = pdh.NewCollector[gpuNonLocalAdapterMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Non Local Adapter Memory", pdh.InstancesAll)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create GPU Non Local Adapter Memory perf data collector: %w", err))
-	}
-
-	c.gpuProcessMemoryPerfDataCollector, err = pdh.NewCollector[gpuProcessMemoryPerfDataCounterValues](pdh.CounterTypeRaw, "GPU Process Memory", pdh.InstancesAll)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create GPU Process Memory perf data collector: %w", err))
-	}
-
-	return errors.Join(errs...)
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.gpuEngineRunningTime,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.gpuAdapterMemoryDedicatedUsage,
+		c.gpuAdapterMemorySharedUsage,
+		c.gpuAdapterMemoryTotalCommitted,
+		c.gpuLocalAdapterMemoryUsage,
+		c.gpuNonLocalAdapterMemoryUsage,
+		c.gpuProcessMemoryDedicatedUsage,
+		c.gpuProcessMemoryLocalUsage,
+		c.gpuProcessMemoryNonLocalUsage,
+		c.gpuProcessMemorySharedUsage,
+		c.gpuProcessMemoryTotalCommitted,
+	)
 }
 
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
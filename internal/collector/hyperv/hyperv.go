@@ -28,6 +28,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -277,6 +278,189 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	return errors.Join(errs...)
 }
 
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildDataStoreDescs()
+	c.buildDynamicMemoryBalancerDescs()
+	c.buildDynamicMemoryVMDescs()
+	c.buildHypervisorLogicalProcessorDescs()
+	c.buildHypervisorRootPartitionDescs()
+	c.buildHypervisorRootVirtualProcessorDescs()
+	c.buildHypervisorVirtualProcessorDescs()
+	c.buildLegacyNetworkAdapterDescs()
+	c.buildVirtualMachineHealthSummaryDescs()
+	c.buildVirtualMachineVidPartitionDescs()
+	c.buildVirtualNetworkAdapterDescs()
+	c.buildVirtualNetworkAdapterDropReasonsDescs()
+	c.buildVirtualSMBDescs()
+	c.buildVirtualStorageDeviceDescs()
+	c.buildVirtualSwitchDescs()
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.dataStoreFragmentationRatio,
+		c.dataStoreSectorSize,
+		c.dataStoreDataAlignment,
+		c.dataStoreCurrentReplayLogSize,
+		c.dataStoreAvailableEntries,
+		c.dataStoreEmptyEntries,
+		c.dataStoreFreeBytes,
+		c.dataStoreDataEnd,
+		c.dataStoreFileObjects,
+		c.dataStoreObjectTables,
+		c.dataStoreKeyTables,
+		c.dataStoreFileDataSize,
+		c.dataStoreTableDataSize,
+		c.dataStoreNamesSize,
+		c.dataStoreNumberOfKeys,
+		c.dataStoreReconnectLatencyMicro,
+		c.dataStoreWriteToFileByteLatency,
+		c.dataStoreReadFromFileByteLatency,
+		c.dataStoreWriteToStorageByteLatency,
+		c.dataStoreReadFromStorageByteLatency,
+		c.dataStoreCommitByteLatency,
+		c.dataStoreCacheUpdateOperationLatency,
+		c.dataStoreCommitOperationLatency,
+		c.dataStoreCompactOperationLatency,
+		c.dataStoreLoadFileOperationLatency,
+		c.dataStoreRemoveOperationLatency,
+		c.dataStoreQuerySizeOperationLatency,
+		c.dataStoreSetOperationLatencyMicro,
+		c.vmDynamicMemoryBalancerAvailableMemoryForBalancing,
+		c.vmDynamicMemoryBalancerSystemCurrentPressure,
+		c.vmDynamicMemoryBalancerAvailableMemory,
+		c.vmDynamicMemoryBalancerAveragePressure,
+		c.vmMemoryCurrentPressure,
+		c.vmMemoryGuestVisiblePhysicalMemory,
+		c.vmMemoryMaximumPressure,
+		c.vmMemoryMinimumPressure,
+		c.vmMemoryPhysicalMemory,
+		c.vmMemoryGuestAvailableMemory,
+		c.hypervisorRootPartitionAddressSpaces,
+		c.hypervisorRootPartitionAttachedDevices,
+		c.hypervisorRootPartitionDepositedPages,
+		c.hypervisorRootPartitionDeviceDMAErrors,
+		c.hypervisorRootPartitionDeviceInterruptErrors,
+		c.hypervisorRootPartitionDeviceInterruptThrottleEvents,
+		c.hypervisorRootPartitionGPAPages,
+		c.hypervisorRootPartitionIOTLBFlushCost,
+		c.hypervisorRootPartitionRecommendedVirtualTLBSize,
+		c.hypervisorRootPartitionSkippedTimerTicks,
+		c.hypervisorRootPartition1GDevicePages,
+		c.hypervisorRootPartition1GGPAPages,
+		c.hypervisorRootPartition2MDevicePages,
+		c.hypervisorRootPartition2MGPAPages,
+		c.hypervisorRootPartition4KDevicePages,
+		c.hypervisorRootPartition4KGPAPages,
+		c.hypervisorRootPartitionVirtualTLBPages,
+		c.legacyNetworkAdapterBytesDropped,
+		c.health,
+		c.physicalPagesAllocated,
+		c.preferredNUMANodeIndex,
+		c.remotePhysicalPages,
+		c.virtualSMBDirectMappedSections,
+		c.virtualSMBDirectMappedPages,
+		c.virtualSMBCurrentPendingRequests,
+		c.virtualSMBCurrentOpenFileCount,
+		c.virtualSMBTreeConnectCount,
+		c.virtualStorageDeviceQueueLength,
+		c.virtualStorageDeviceLatency,
+		c.virtualStorageDeviceThroughput,
+		c.virtualStorageDeviceNormalizedThroughput,
+		c.virtualStorageDeviceLowerQueueLength,
+		c.virtualStorageDeviceLowerLatency,
+		c.virtualStorageDeviceIOQuotaReplenishmentRate,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.dataStoreDisconnectCount,
+		c.dataStoreWriteToFileByteCount,
+		c.dataStoreWriteToFileCount,
+		c.dataStoreReadFromFileByteCount,
+		c.dataStoreReadFromFileCount,
+		c.dataStoreWriteToStorageByteCount,
+		c.dataStoreWriteToStorageCount,
+		c.dataStoreReadFromStorageByteCount,
+		c.dataStoreReadFromStorageCount,
+		c.dataStoreCommitByteCount,
+		c.dataStoreCommitCount,
+		c.dataStoreCacheUpdateOperationCount,
+		c.dataStoreCommitOperationCount,
+		c.dataStoreCompactOperationCount,
+		c.dataStoreLoadFileOperationCount,
+		c.dataStoreRemoveOperationCount,
+		c.dataStoreQuerySizeOperationCount,
+		c.dataStoreSetOperationCount,
+		c.vmMemoryAddedMemory,
+		c.vmMemoryMemoryAddOperations,
+		c.vmMemoryMemoryRemoveOperations,
+		c.vmMemoryRemovedMemory,
+		c.hypervisorLogicalProcessorTimeTotal,
+		c.hypervisorLogicalProcessorTotalRunTimeTotal,
+		c.hypervisorLogicalProcessorContextSwitches,
+		c.hypervisorRootPartitionGPASpaceModifications,
+		c.hypervisorRootPartitionIOTLBFlushes,
+		c.hypervisorRootPartitionVirtualTLBFlushEntries,
+		c.hypervisorRootVirtualProcessorTimeTotal,
+		c.hypervisorRootVirtualProcessorTotalRunTimeTotal,
+		c.hypervisorRootVirtualProcessorCPUWaitTimePerDispatch,
+		c.hypervisorVirtualProcessorTimeTotal,
+		c.hypervisorVirtualProcessorTotalRunTimeTotal,
+		c.hypervisorVirtualProcessorContextSwitches,
+		c.legacyNetworkAdapterBytesReceived,
+		c.legacyNetworkAdapterBytesSent,
+		c.legacyNetworkAdapterFramesDropped,
+		c.legacyNetworkAdapterFramesReceived,
+		c.legacyNetworkAdapterFramesSent,
+		c.virtualNetworkAdapterBytesReceived,
+		c.virtualNetworkAdapterBytesSent,
+		c.virtualNetworkAdapterDroppedPacketsIncoming,
+		c.virtualNetworkAdapterDroppedPacketsOutgoing,
+		c.virtualNetworkAdapterPacketsReceived,
+		c.virtualNetworkAdapterPacketsSent,
+		c.virtualNetworkAdapterDropReasons,
+		c.virtualSMBWriteBytesRDMA,
+		c.virtualSMBWriteBytes,
+		c.virtualSMBReadBytesRDMA,
+		c.virtualSMBReadBytes,
+		c.virtualSMBFlushRequests,
+		c.virtualSMBWriteRequestsRDMA,
+		c.virtualSMBWriteRequests,
+		c.virtualSMBReadRequestsRDMA,
+		c.virtualSMBReadRequests,
+		c.virtualSMBRequests,
+		c.virtualSMBSentBytes,
+		c.virtualSMBReceivedBytes,
+		c.virtualStorageDeviceErrorCount,
+		c.virtualStorageDeviceReadBytes,
+		c.virtualStorageDeviceReadOperations,
+		c.virtualStorageDeviceWriteBytes,
+		c.virtualStorageDeviceWriteOperations,
+		c.virtualSwitchBroadcastPacketsReceived,
+		c.virtualSwitchBroadcastPacketsSent,
+		c.virtualSwitchBytes,
+		c.virtualSwitchBytesReceived,
+		c.virtualSwitchBytesSent,
+		c.virtualSwitchDirectedPacketsReceived,
+		c.virtualSwitchDirectedPacketsSent,
+		c.virtualSwitchDroppedPacketsIncoming,
+		c.virtualSwitchDroppedPacketsOutgoing,
+		c.virtualSwitchExtensionsDroppedPacketsIncoming,
+		c.virtualSwitchExtensionsDroppedPacketsOutgoing,
+		c.virtualSwitchLearnedMacAddresses,
+		c.virtualSwitchMulticastPacketsReceived,
+		c.virtualSwitchMulticastPacketsSent,
+		c.virtualSwitchNumberOfSendChannelMoves,
+		c.virtualSwitchNumberOfVMQMoves,
+		c.virtualSwitchPacketsFlooded,
+		c.virtualSwitchPackets,
+		c.virtualSwitchPacketsReceived,
+		c.virtualSwitchPacketsSent,
+		c.virtualSwitchPurgedMacAddresses,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
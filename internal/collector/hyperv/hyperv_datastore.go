@@ -137,284 +137,288 @@ func (c *Collector) buildDataStore() error {
 		return fmt.Errorf("failed to create Hyper-V DataStore collector: %w", err)
 	}
 
-	c.dataStoreFragmentationRatio = prometheus.NewDesc(
+	c.buildDataStoreDescs()
+
+	return nil
+}
+
+func (c *Collector) buildDataStoreDescs() {
+	c.dataStoreFragmentationRatio = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_fragmentation_ratio"),
 		"Represents the fragmentation ratio of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreSectorSize = prometheus.NewDesc(
+	c.dataStoreSectorSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_sector_size_bytes"),
 		"Represents the sector size of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreDataAlignment = prometheus.NewDesc(
+	c.dataStoreDataAlignment = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_data_alignment_bytes"),
 		"Represents the data alignment of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCurrentReplayLogSize = prometheus.NewDesc(
+	c.dataStoreCurrentReplayLogSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_current_replay_log_size_bytes"),
 		"Represents the current replay log size of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreAvailableEntries = prometheus.NewDesc(
+	c.dataStoreAvailableEntries = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_available_entries"),
 		"Represents the number of available entries inside object tables.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreEmptyEntries = prometheus.NewDesc(
+	c.dataStoreEmptyEntries = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_empty_entries"),
 		"Represents the number of empty entries inside object tables.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreFreeBytes = prometheus.NewDesc(
+	c.dataStoreFreeBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_free_bytes"),
 		"Represents the number of free bytes inside key tables.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreDataEnd = prometheus.NewDesc(
+	c.dataStoreDataEnd = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_data_end_bytes"),
 		"Represents the data end of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreFileObjects = prometheus.NewDesc(
+	c.dataStoreFileObjects = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_file_objects"),
 		"Represents the number of file objects in the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreObjectTables = prometheus.NewDesc(
+	c.dataStoreObjectTables = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_object_tables"),
 		"Represents the number of object tables in the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreKeyTables = prometheus.NewDesc(
+	c.dataStoreKeyTables = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_key_tables"),
 		"Represents the number of key tables in the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreFileDataSize = prometheus.NewDesc(
+	c.dataStoreFileDataSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_file_data_size_bytes"),
 		"Represents the file data size in bytes of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreTableDataSize = prometheus.NewDesc(
+	c.dataStoreTableDataSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_table_data_size_bytes"),
 		"Represents the table data size in bytes of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreNamesSize = prometheus.NewDesc(
+	c.dataStoreNamesSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_names_size_bytes"),
 		"Represents the names size in bytes of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreNumberOfKeys = prometheus.NewDesc(
+	c.dataStoreNumberOfKeys = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_number_of_keys"),
 		"Represents the number of keys in the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreReconnectLatencyMicro = prometheus.NewDesc(
+	c.dataStoreReconnectLatencyMicro = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_reconnect_latency_microseconds"),
 		"Represents the reconnect latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreDisconnectCount = prometheus.NewDesc(
+	c.dataStoreDisconnectCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_disconnect_count"),
 		"Represents the disconnect count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreWriteToFileByteLatency = prometheus.NewDesc(
+	c.dataStoreWriteToFileByteLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_file_byte_latency_microseconds"),
 		"Represents the write to file byte latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreWriteToFileByteCount = prometheus.NewDesc(
+	c.dataStoreWriteToFileByteCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_file_byte_count"),
 		"Represents the write to file byte count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreWriteToFileCount = prometheus.NewDesc(
+	c.dataStoreWriteToFileCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_file_count"),
 		"Represents the write to file count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreReadFromFileByteLatency = prometheus.NewDesc(
+	c.dataStoreReadFromFileByteLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_file_byte_latency_microseconds"),
 		"Represents the read from file byte latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreReadFromFileByteCount = prometheus.NewDesc(
+	c.dataStoreReadFromFileByteCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_file_byte_count"),
 		"Represents the read from file byte count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreReadFromFileCount = prometheus.NewDesc(
+	c.dataStoreReadFromFileCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_file_count"),
 		"Represents the read from file count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreWriteToStorageByteLatency = prometheus.NewDesc(
+	c.dataStoreWriteToStorageByteLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_storage_byte_latency_microseconds"),
 		"Represents the write to storage byte latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreWriteToStorageByteCount = prometheus.NewDesc(
+	c.dataStoreWriteToStorageByteCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_storage_byte_count"),
 		"Represents the write to storage byte count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreWriteToStorageCount = prometheus.NewDesc(
+	c.dataStoreWriteToStorageCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_storage_count"),
 		"Represents the write to storage count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreReadFromStorageByteLatency = prometheus.NewDesc(
+	c.dataStoreReadFromStorageByteLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_storage_byte_latency_microseconds"),
 		"Represents the read from storage byte latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreReadFromStorageByteCount = prometheus.NewDesc(
+	c.dataStoreReadFromStorageByteCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_storage_byte_count"),
 		"Represents the read from storage byte count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreReadFromStorageCount = prometheus.NewDesc(
+	c.dataStoreReadFromStorageCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_storage_count"),
 		"Represents the read from storage count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCommitByteLatency = prometheus.NewDesc(
+	c.dataStoreCommitByteLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_byte_latency_microseconds"),
 		"Represents the commit byte latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCommitByteCount = prometheus.NewDesc(
+	c.dataStoreCommitByteCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_byte_count"),
 		"Represents the commit byte count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCommitCount = prometheus.NewDesc(
+	c.dataStoreCommitCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_count"),
 		"Represents the commit count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCacheUpdateOperationLatency = prometheus.NewDesc(
+	c.dataStoreCacheUpdateOperationLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_cache_update_operation_latency_microseconds"),
 		"Represents the cache update operation latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCacheUpdateOperationCount = prometheus.NewDesc(
+	c.dataStoreCacheUpdateOperationCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_cache_update_operation_count"),
 		"Represents the cache update operation count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCommitOperationLatency = prometheus.NewDesc(
+	c.dataStoreCommitOperationLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_operation_latency_microseconds"),
 		"Represents the commit operation latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCommitOperationCount = prometheus.NewDesc(
+	c.dataStoreCommitOperationCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_operation_count"),
 		"Represents the commit operation count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCompactOperationLatency = prometheus.NewDesc(
+	c.dataStoreCompactOperationLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_compact_operation_latency_microseconds"),
 		"Represents the compact operation latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreCompactOperationCount = prometheus.NewDesc(
+	c.dataStoreCompactOperationCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_compact_operation_count"),
 		"Represents the compact operation count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreLoadFileOperationLatency = prometheus.NewDesc(
+	c.dataStoreLoadFileOperationLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_load_file_operation_latency_microseconds"),
 		"Represents the load file operation latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreLoadFileOperationCount = prometheus.NewDesc(
+	c.dataStoreLoadFileOperationCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_load_file_operation_count"),
 		"Represents the load file operation count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreRemoveOperationLatency = prometheus.NewDesc(
+	c.dataStoreRemoveOperationLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_remove_operation_latency_microseconds"),
 		"Represents the remove operation latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreRemoveOperationCount = prometheus.NewDesc(
+	c.dataStoreRemoveOperationCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_remove_operation_count"),
 		"Represents the remove operation count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreQuerySizeOperationLatency = prometheus.NewDesc(
+	c.dataStoreQuerySizeOperationLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_query_size_operation_latency_microseconds"),
 		"Represents the query size operation latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreQuerySizeOperationCount = prometheus.NewDesc(
+	c.dataStoreQuerySizeOperationCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_query_size_operation_count"),
 		"Represents the query size operation count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreSetOperationLatencyMicro = prometheus.NewDesc(
+	c.dataStoreSetOperationLatencyMicro = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_set_operation_latency_microseconds"),
 		"Represents the set operation latency in microseconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-	c.dataStoreSetOperationCount = prometheus.NewDesc(
+	c.dataStoreSetOperationCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_set_operation_count"),
 		"Represents the set operation count of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
@@ -58,28 +58,32 @@ func (c *Collector) buildHypervisorRootVirtualProcessor() error {
 		return fmt.Errorf("failed to create Hyper-V Hypervisor Root Virtual Processor collector: %w", err)
 	}
 
-	c.hypervisorRootVirtualProcessorTimeTotal = prometheus.NewDesc(
+	c.buildHypervisorRootVirtualProcessorDescs()
+
+	return nil
+}
+
+func (c *Collector) buildHypervisorRootVirtualProcessorDescs() {
+	c.hypervisorRootVirtualProcessorTimeTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_root_virtual_processor_time_total"),
 		"Time that processor spent in different modes (hypervisor, guest_run, guest_idle, remote)",
 		[]string{"core", "state"},
 		nil,
 	)
 
-	c.hypervisorRootVirtualProcessorTotalRunTimeTotal = prometheus.NewDesc(
+	c.hypervisorRootVirtualProcessorTotalRunTimeTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_root_virtual_processor_total_run_time_total"),
 		"Time that processor spent",
 		[]string{"core"},
 		nil,
 	)
 
-	c.hypervisorRootVirtualProcessorCPUWaitTimePerDispatch = prometheus.NewDesc(
+	c.hypervisorRootVirtualProcessorCPUWaitTimePerDispatch = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_root_virtual_cpu_wait_time_per_dispatch_total"),
 		"The average time (in nanoseconds) spent waiting for a virtual processor to be dispatched onto a logical processor.",
 		[]string{"core"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectHypervisorRootVirtualProcessor(ch chan<- prometheus.Metric) error {
@@ -57,26 +57,30 @@ func (c *Collector) buildHypervisorVirtualProcessor() error {
 		return fmt.Errorf("failed to create Hyper-V Hypervisor Virtual Processor collector: %w", err)
 	}
 
-	c.hypervisorVirtualProcessorTimeTotal = prometheus.NewDesc(
+	c.buildHypervisorVirtualProcessorDescs()
+
+	return nil
+}
+
+func (c *Collector) buildHypervisorVirtualProcessorDescs() {
+	c.hypervisorVirtualProcessorTimeTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_time_total"),
 		"Time that processor spent in different modes (hypervisor, guest_run, guest_idle, remote)",
 		[]string{"vm", "core", "state"},
 		nil,
 	)
-	c.hypervisorVirtualProcessorTotalRunTimeTotal = prometheus.NewDesc(
+	c.hypervisorVirtualProcessorTotalRunTimeTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_total_run_time_total"),
 		"Time that processor spent",
 		[]string{"vm", "core"},
 		nil,
 	)
-	c.hypervisorVirtualProcessorContextSwitches = prometheus.NewDesc(
+	c.hypervisorVirtualProcessorContextSwitches = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_cpu_wait_time_per_dispatch_total"),
 		"The average time (in nanoseconds) spent waiting for a virtual processor to be dispatched onto a logical processor.",
 		[]string{"vm", "core"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectHypervisorVirtualProcessor(ch chan<- prometheus.Metric) error {
@@ -57,44 +57,48 @@ func (c *Collector) buildLegacyNetworkAdapter() error {
 		return fmt.Errorf("failed to create Hyper-V Legacy Network Adapter collector: %w", err)
 	}
 
-	c.legacyNetworkAdapterBytesDropped = prometheus.NewDesc(
+	c.buildLegacyNetworkAdapterDescs()
+
+	return nil
+}
+
+func (c *Collector) buildLegacyNetworkAdapterDescs() {
+	c.legacyNetworkAdapterBytesDropped = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "legacy_network_adapter_bytes_dropped_total"),
 		"Bytes Dropped is the number of bytes dropped on the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.legacyNetworkAdapterBytesReceived = prometheus.NewDesc(
+	c.legacyNetworkAdapterBytesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "legacy_network_adapter_bytes_received_total"),
 		"Bytes received is the number of bytes received on the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.legacyNetworkAdapterBytesSent = prometheus.NewDesc(
+	c.legacyNetworkAdapterBytesSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "legacy_network_adapter_bytes_sent_total"),
 		"Bytes sent is the number of bytes sent over the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.legacyNetworkAdapterFramesDropped = prometheus.NewDesc(
+	c.legacyNetworkAdapterFramesDropped = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "legacy_network_adapter_frames_dropped_total"),
 		"Frames Dropped is the number of frames dropped on the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.legacyNetworkAdapterFramesReceived = prometheus.NewDesc(
+	c.legacyNetworkAdapterFramesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "legacy_network_adapter_frames_received_total"),
 		"Frames received is the number of frames received on the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.legacyNetworkAdapterFramesSent = prometheus.NewDesc(
+	c.legacyNetworkAdapterFramesSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "legacy_network_adapter_frames_sent_total"),
 		"Frames sent is the number of frames sent over the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectLegacyNetworkAdapter(ch chan<- prometheus.Metric) error {
@@ -49,14 +49,18 @@ func (c *Collector) buildVirtualMachineHealthSummary() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Machine Health Summary collector: %w", err)
 	}
 
-	c.health = prometheus.NewDesc(
+	c.buildVirtualMachineHealthSummaryDescs()
+
+	return nil
+}
+
+func (c *Collector) buildVirtualMachineHealthSummaryDescs() {
+	c.health = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_machine_health_total_count"),
 		"Represents the number of virtual machines with critical health",
 		[]string{"state"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectVirtualMachineHealthSummary(ch chan<- prometheus.Metric) error {
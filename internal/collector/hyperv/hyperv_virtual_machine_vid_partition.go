@@ -51,26 +51,30 @@ func (c *Collector) buildVirtualMachineVidPartition() error {
 		return fmt.Errorf("failed to create Hyper-V VM Vid Partition collector: %w", err)
 	}
 
-	c.physicalPagesAllocated = prometheus.NewDesc(
+	c.buildVirtualMachineVidPartitionDescs()
+
+	return nil
+}
+
+func (c *Collector) buildVirtualMachineVidPartitionDescs() {
+	c.physicalPagesAllocated = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vid_physical_pages_allocated"),
 		"The number of physical pages allocated",
 		[]string{"vm"},
 		nil,
 	)
-	c.preferredNUMANodeIndex = prometheus.NewDesc(
+	c.preferredNUMANodeIndex = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vid_preferred_numa_node_index"),
 		"The preferred NUMA node index associated with this partition",
 		[]string{"vm"},
 		nil,
 	)
-	c.remotePhysicalPages = prometheus.NewDesc(
+	c.remotePhysicalPages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vid_remote_physical_pages"),
 		"The number of physical pages not allocated from the preferred NUMA node",
 		[]string{"vm"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectVirtualMachineVidPartition(ch chan<- prometheus.Metric) error {
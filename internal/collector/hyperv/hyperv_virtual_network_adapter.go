@@ -57,44 +57,48 @@ func (c *Collector) buildVirtualNetworkAdapter() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Network Adapter collector: %w", err)
 	}
 
-	c.virtualNetworkAdapterBytesReceived = prometheus.NewDesc(
+	c.buildVirtualNetworkAdapterDescs()
+
+	return nil
+}
+
+func (c *Collector) buildVirtualNetworkAdapterDescs() {
+	c.virtualNetworkAdapterBytesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_received_bytes_total"),
 		"Represents the total number of bytes received per second by the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.virtualNetworkAdapterBytesSent = prometheus.NewDesc(
+	c.virtualNetworkAdapterBytesSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_sent_bytes_total"),
 		"Represents the total number of bytes sent per second by the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.virtualNetworkAdapterDroppedPacketsIncoming = prometheus.NewDesc(
+	c.virtualNetworkAdapterDroppedPacketsIncoming = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_incoming_dropped_packets_total"),
 		"Represents the total number of dropped packets per second in the incoming direction of the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.virtualNetworkAdapterDroppedPacketsOutgoing = prometheus.NewDesc(
+	c.virtualNetworkAdapterDroppedPacketsOutgoing = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_outgoing_dropped_packets_total"),
 		"Represents the total number of dropped packets per second in the outgoing direction of the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.virtualNetworkAdapterPacketsReceived = prometheus.NewDesc(
+	c.virtualNetworkAdapterPacketsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_received_packets_total"),
 		"Represents the total number of packets received per second by the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-	c.virtualNetworkAdapterPacketsSent = prometheus.NewDesc(
+	c.virtualNetworkAdapterPacketsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_sent_packets_total"),
 		"Represents the total number of packets sent per second by the network adapter",
 		[]string{"adapter"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectVirtualNetworkAdapter(ch chan<- prometheus.Metric) error {
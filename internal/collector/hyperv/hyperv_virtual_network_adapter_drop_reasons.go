@@ -172,14 +172,18 @@ func (c *Collector) buildVirtualNetworkAdapterDropReasons() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Network Adapter Drop Reasons collector: %w", err)
 	}
 
-	c.virtualNetworkAdapterDropReasons = prometheus.NewDesc(
+	c.buildVirtualNetworkAdapterDropReasonsDescs()
+
+	return nil
+}
+
+func (c *Collector) buildVirtualNetworkAdapterDropReasonsDescs() {
+	c.virtualNetworkAdapterDropReasons = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_drop_reasons"),
 		"Hyper-V Virtual Network Adapter Drop Reasons",
 		[]string{"adapter", "reason", "direction"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectVirtualNetworkAdapterDropReasons(ch chan<- prometheus.Metric) error {
@@ -69,80 +69,84 @@ func (c *Collector) buildVirtualStorageDevice() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Storage Device collector: %w", err)
 	}
 
-	c.virtualStorageDeviceErrorCount = prometheus.NewDesc(
+	c.buildVirtualStorageDeviceDescs()
+
+	return nil
+}
+
+func (c *Collector) buildVirtualStorageDeviceDescs() {
+	c.virtualStorageDeviceErrorCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_error_count_total"),
 		"Represents the total number of errors that have occurred on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceQueueLength = prometheus.NewDesc(
+	c.virtualStorageDeviceQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_queue_length"),
 		"Represents the average queue length on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceReadBytes = prometheus.NewDesc(
+	c.virtualStorageDeviceReadBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_bytes_read"),
 		"Represents the total number of bytes that have been read on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceReadOperations = prometheus.NewDesc(
+	c.virtualStorageDeviceReadOperations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_operations_read_total"),
 		"Represents the total number of read operations that have occurred on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceWriteBytes = prometheus.NewDesc(
+	c.virtualStorageDeviceWriteBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_bytes_written"),
 		"Represents the total number of bytes that have been written on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceWriteOperations = prometheus.NewDesc(
+	c.virtualStorageDeviceWriteOperations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_operations_written_total"),
 		"Represents the total number of write operations that have occurred on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceLatency = prometheus.NewDesc(
+	c.virtualStorageDeviceLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_latency_seconds"),
 		"Represents the average IO transfer latency for this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceThroughput = prometheus.NewDesc(
+	c.virtualStorageDeviceThroughput = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_throughput"),
 		"Represents the average number of 8KB IO transfers completed by this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceNormalizedThroughput = prometheus.NewDesc(
+	c.virtualStorageDeviceNormalizedThroughput = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_normalized_throughput"),
 		"Represents the average number of IO transfers completed by this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceLowerQueueLength = prometheus.NewDesc(
+	c.virtualStorageDeviceLowerQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_lower_queue_length"),
 		"Represents the average queue length on the underlying storage subsystem for this device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceLowerLatency = prometheus.NewDesc(
+	c.virtualStorageDeviceLowerLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_lower_latency_seconds"),
 		"Represents the average IO transfer latency on the underlying storage subsystem for this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceIOQuotaReplenishmentRate = prometheus.NewDesc(
+	c.virtualStorageDeviceIOQuotaReplenishmentRate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "io_quota_replenishment_rate"),
 		"Represents the IO quota replenishment rate for this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectVirtualStorageDevice(ch chan<- prometheus.Metric) error {
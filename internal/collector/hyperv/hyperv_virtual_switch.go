@@ -87,134 +87,138 @@ func (c *Collector) buildVirtualSwitch() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Switch collector: %w", err)
 	}
 
-	c.virtualSwitchBroadcastPacketsReceived = prometheus.NewDesc(
+	c.buildVirtualSwitchDescs()
+
+	return nil
+}
+
+func (c *Collector) buildVirtualSwitchDescs() {
+	c.virtualSwitchBroadcastPacketsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_broadcast_packets_received_total"),
 		"Represents the total number of broadcast packets received per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchBroadcastPacketsSent = prometheus.NewDesc(
+	c.virtualSwitchBroadcastPacketsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_broadcast_packets_sent_total"),
 		"Represents the total number of broadcast packets sent per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchBytes = prometheus.NewDesc(
+	c.virtualSwitchBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_bytes_total"),
 		"Represents the total number of bytes per second traversing the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchBytesReceived = prometheus.NewDesc(
+	c.virtualSwitchBytesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_bytes_received_total"),
 		"Represents the total number of bytes received per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchBytesSent = prometheus.NewDesc(
+	c.virtualSwitchBytesSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_bytes_sent_total"),
 		"Represents the total number of bytes sent per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchDirectedPacketsReceived = prometheus.NewDesc(
+	c.virtualSwitchDirectedPacketsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_directed_packets_received_total"),
 		"Represents the total number of directed packets received per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchDirectedPacketsSent = prometheus.NewDesc(
+	c.virtualSwitchDirectedPacketsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_directed_packets_send_total"),
 		"Represents the total number of directed packets sent per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchDroppedPacketsIncoming = prometheus.NewDesc(
+	c.virtualSwitchDroppedPacketsIncoming = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_dropped_packets_incoming_total"),
 		"Represents the total number of packet dropped per second by the virtual switch in the incoming direction",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchDroppedPacketsOutgoing = prometheus.NewDesc(
+	c.virtualSwitchDroppedPacketsOutgoing = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_dropped_packets_outcoming_total"),
 		"Represents the total number of packet dropped per second by the virtual switch in the outgoing direction",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchExtensionsDroppedPacketsIncoming = prometheus.NewDesc(
+	c.virtualSwitchExtensionsDroppedPacketsIncoming = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_extensions_dropped_packets_incoming_total"),
 		"Represents the total number of packet dropped per second by the virtual switch extensions in the incoming direction",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchExtensionsDroppedPacketsOutgoing = prometheus.NewDesc(
+	c.virtualSwitchExtensionsDroppedPacketsOutgoing = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_extensions_dropped_packets_outcoming_total"),
 		"Represents the total number of packet dropped per second by the virtual switch extensions in the outgoing direction",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchLearnedMacAddresses = prometheus.NewDesc(
+	c.virtualSwitchLearnedMacAddresses = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_learned_mac_addresses_total"),
 		"Represents the total number of learned MAC addresses of the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchMulticastPacketsReceived = prometheus.NewDesc(
+	c.virtualSwitchMulticastPacketsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_multicast_packets_received_total"),
 		"Represents the total number of multicast packets received per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchMulticastPacketsSent = prometheus.NewDesc(
+	c.virtualSwitchMulticastPacketsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_multicast_packets_sent_total"),
 		"Represents the total number of multicast packets sent per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchNumberOfSendChannelMoves = prometheus.NewDesc(
+	c.virtualSwitchNumberOfSendChannelMoves = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_number_of_send_channel_moves_total"),
 		"Represents the total number of send channel moves per second on this virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchNumberOfVMQMoves = prometheus.NewDesc(
+	c.virtualSwitchNumberOfVMQMoves = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_number_of_vmq_moves_total"),
 		"Represents the total number of VMQ moves per second on this virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchPacketsFlooded = prometheus.NewDesc(
+	c.virtualSwitchPacketsFlooded = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_packets_flooded_total"),
 		"Represents the total number of packets flooded by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchPackets = prometheus.NewDesc(
+	c.virtualSwitchPackets = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_packets_total"),
 		"Represents the total number of packets per second traversing the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchPacketsReceived = prometheus.NewDesc(
+	c.virtualSwitchPacketsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_packets_received_total"),
 		"Represents the total number of packets received per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchPacketsSent = prometheus.NewDesc(
+	c.virtualSwitchPacketsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_packets_sent_total"),
 		"Represents the total number of packets send per second by the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-	c.virtualSwitchPurgedMacAddresses = prometheus.NewDesc(
+	c.virtualSwitchPurgedMacAddresses = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "vswitch_purged_mac_addresses_total"),
 		"Represents the total number of purged MAC addresses of the virtual switch",
 		[]string{"vswitch"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectVirtualSwitch(ch chan<- prometheus.Metric) error {
@@ -162,12 +162,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.iisVersion = c.getIISVersion(logger)
 
-	c.info = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "info"),
-		"ISS information",
-		[]string{},
-		prometheus.Labels{"version": fmt.Sprintf("%d.%d", c.iisVersion.major, c.iisVersion.minor)},
-	)
+	c.buildInfoDesc()
 
 	errs := make([]error, 0)
 
@@ -190,6 +185,24 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	return errors.Join(errs...)
 }
 
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildInfoDesc()
+	c.buildAppPoolWASDescs()
+	c.buildW3SVCW3WPDescs()
+	c.buildWebServiceDescs()
+	c.buildWebServiceCacheDescs()
+}
+
+func (c *Collector) buildInfoDesc() {
+	c.info = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "info"),
+		"ISS information",
+		[]string{},
+		prometheus.Labels{"version": fmt.Sprintf("%d.%d", c.iisVersion.major, c.iisVersion.minor)},
+	)
+}
+
 type simpleVersion struct {
 	major uint64
 	minor uint64
@@ -240,6 +253,115 @@ func (c *Collector) getIISVersion(logger *slog.Logger) simpleVersion {
 	}
 }
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.info,
+		c.currentApplicationPoolState,
+		c.currentApplicationPoolUptime,
+		c.currentWorkerProcesses,
+		c.maximumWorkerProcesses,
+		c.recentWorkerProcessFailures,
+		c.timeSinceLastWorkerProcessFailure,
+		c.w3SVCW3WPThreads,
+		c.w3SVCW3WPActiveFlushedEntries,
+		c.w3SVCW3WPCurrentFileCacheMemoryUsage,
+		c.w3SVCW3WPFilesCached,
+		c.w3SVCW3WPURIsCached,
+		c.w3SVCW3WPMetadataCached,
+		c.webServiceCurrentAnonymousUsers,
+		c.webServiceCurrentBlockedAsyncIORequests,
+		c.webServiceCurrentCGIRequests,
+		c.webServiceCurrentConnections,
+		c.webServiceCurrentISAPIExtensionRequests,
+		c.webServiceCurrentNonAnonymousUsers,
+		c.webServiceServiceUptime,
+		c.serviceCacheActiveFlushedEntries,
+		c.serviceCacheCurrentFileCacheMemoryUsage,
+		c.serviceCacheFilesCached,
+		c.serviceCacheURIsCached,
+		c.serviceCacheMetadataCached,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.totalApplicationPoolRecycles,
+		c.totalApplicationPoolUptime,
+		c.totalWorkerProcessesCreated,
+		c.totalWorkerProcessFailures,
+		c.totalWorkerProcessPingFailures,
+		c.totalWorkerProcessShutdownFailures,
+		c.totalWorkerProcessStartupFailures,
+		c.w3SVCW3WPMaximumThreads,
+		c.w3SVCW3WPRequestsTotal,
+		c.w3SVCW3WPRequestsActive,
+		c.w3SVCW3WPMaximumFileCacheMemoryUsage,
+		c.w3SVCW3WPFileCacheFlushesTotal,
+		c.w3SVCW3WPFileCacheQueriesTotal,
+		c.w3SVCW3WPFileCacheHitsTotal,
+		c.w3SVCW3WPFilesCachedTotal,
+		c.w3SVCW3WPFilesFlushedTotal,
+		c.w3SVCW3WPURICacheFlushesTotal,
+		c.w3SVCW3WPURICacheQueriesTotal,
+		c.w3SVCW3WPURICacheHitsTotal,
+		c.w3SVCW3WPURIsCachedTotal,
+		c.w3SVCW3WPURIsFlushedTotal,
+		c.w3SVCW3WPMetadataCacheFlushes,
+		c.w3SVCW3WPMetadataCacheQueriesTotal,
+		c.w3SVCW3WPMetadataCacheHitsTotal,
+		c.w3SVCW3WPMetadataCachedTotal,
+		c.w3SVCW3WPMetadataFlushedTotal,
+		c.w3SVCW3WPOutputCacheActiveFlushedItems,
+		c.w3SVCW3WPOutputCacheItems,
+		c.w3SVCW3WPOutputCacheMemoryUsage,
+		c.w3SVCW3WPOutputCacheQueriesTotal,
+		c.w3SVCW3WPOutputCacheHitsTotal,
+		c.w3SVCW3WPOutputCacheFlushedItemsTotal,
+		c.w3SVCW3WPOutputCacheFlushesTotal,
+		c.w3SVCW3WPRequestErrorsTotal,
+		c.w3SVCW3WPWebSocketRequestsActive,
+		c.w3SVCW3WPWebSocketConnectionAttempts,
+		c.w3SVCW3WPWebSocketConnectionsAccepted,
+		c.w3SVCW3WPWebSocketConnectionsRejected,
+		c.webServiceTotalBytesReceived,
+		c.webServiceTotalBytesSent,
+		c.webServiceTotalAnonymousUsers,
+		c.webServiceTotalBlockedAsyncIORequests,
+		c.webServiceTotalCGIRequests,
+		c.webServiceTotalConnectionAttemptsAllInstances,
+		c.webServiceTotalRequests,
+		c.webServiceTotalFilesReceived,
+		c.webServiceTotalFilesSent,
+		c.webServiceTotalISAPIExtensionRequests,
+		c.webServiceTotalLockedErrors,
+		c.webServiceTotalLogonAttempts,
+		c.webServiceTotalNonAnonymousUsers,
+		c.webServiceTotalNotFoundErrors,
+		c.webServiceTotalRejectedAsyncIORequests,
+		c.serviceCacheMaximumFileCacheMemoryUsage,
+		c.serviceCacheFileCacheFlushesTotal,
+		c.serviceCacheFileCacheQueriesTotal,
+		c.serviceCacheFileCacheHitsTotal,
+		c.serviceCacheFilesCachedTotal,
+		c.serviceCacheFilesFlushedTotal,
+		c.serviceCacheURICacheFlushesTotal,
+		c.serviceCacheURICacheQueriesTotal,
+		c.serviceCacheURICacheHitsTotal,
+		c.serviceCacheURIsCachedTotal,
+		c.serviceCacheURIsFlushedTotal,
+		c.serviceCacheMetadataCacheFlushes,
+		c.serviceCacheMetadataCacheQueriesTotal,
+		c.serviceCacheMetadataCacheHitsTotal,
+		c.serviceCacheMetadataCachedTotal,
+		c.serviceCacheMetadataFlushedTotal,
+		c.serviceCacheOutputCacheActiveFlushedItems,
+		c.serviceCacheOutputCacheItems,
+		c.serviceCacheOutputCacheMemoryUsage,
+		c.serviceCacheOutputCacheQueriesTotal,
+		c.serviceCacheOutputCacheHitsTotal,
+		c.serviceCacheOutputCacheFlushedItemsTotal,
+		c.serviceCacheOutputCacheFlushesTotal,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
@@ -165,231 +165,235 @@ func (c *Collector) buildW3SVCW3WP() error {
 	}
 
 	// W3SVC_W3WP
-	c.w3SVCW3WPThreads = prometheus.NewDesc(
+	c.buildW3SVCW3WPDescs()
+
+	return nil
+}
+
+func (c *Collector) buildW3SVCW3WPDescs() {
+	c.w3SVCW3WPThreads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_threads"),
 		"Number of threads actively processing requests in the worker process",
 		[]string{"app", "pid", "state"},
 		nil,
 	)
-	c.w3SVCW3WPMaximumThreads = prometheus.NewDesc(
+	c.w3SVCW3WPMaximumThreads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_max_threads"),
 		"Maximum number of threads to which the thread pool can grow as needed",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPRequestsTotal = prometheus.NewDesc(
+	c.w3SVCW3WPRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_requests_total"),
 		"Total number of HTTP requests served by the worker process",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPRequestsActive = prometheus.NewDesc(
+	c.w3SVCW3WPRequestsActive = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_current_requests"),
 		"Current number of requests being processed by the worker process",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPActiveFlushedEntries = prometheus.NewDesc(
+	c.w3SVCW3WPActiveFlushedEntries = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_cache_active_flushed_entries"),
 		"Number of file handles cached in user-mode that will be closed when all current transfers complete.",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPCurrentFileCacheMemoryUsage = prometheus.NewDesc(
+	c.w3SVCW3WPCurrentFileCacheMemoryUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_memory_bytes"),
 		"Current number of bytes used by user-mode file cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPMaximumFileCacheMemoryUsage = prometheus.NewDesc(
+	c.w3SVCW3WPMaximumFileCacheMemoryUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_max_memory_bytes"),
 		"Maximum number of bytes used by user-mode file cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPFileCacheFlushesTotal = prometheus.NewDesc(
+	c.w3SVCW3WPFileCacheFlushesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_flushes_total"),
 		"Total number of files removed from the user-mode cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPFileCacheQueriesTotal = prometheus.NewDesc(
+	c.w3SVCW3WPFileCacheQueriesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_queries_total"),
 		"Total file cache queries (hits + misses)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPFileCacheHitsTotal = prometheus.NewDesc(
+	c.w3SVCW3WPFileCacheHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_hits_total"),
 		"Total number of successful lookups in the user-mode file cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPFilesCached = prometheus.NewDesc(
+	c.w3SVCW3WPFilesCached = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_items"),
 		"Current number of files whose contents are present in user-mode cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPFilesCachedTotal = prometheus.NewDesc(
+	c.w3SVCW3WPFilesCachedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_items_total"),
 		"Total number of files whose contents were ever added to the user-mode cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPFilesFlushedTotal = prometheus.NewDesc(
+	c.w3SVCW3WPFilesFlushedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_file_cache_items_flushed_total"),
 		"Total number of file handles that have been removed from the user-mode cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPURICacheFlushesTotal = prometheus.NewDesc(
+	c.w3SVCW3WPURICacheFlushesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_uri_cache_flushes_total"),
 		"Total number of URI cache flushes (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPURICacheQueriesTotal = prometheus.NewDesc(
+	c.w3SVCW3WPURICacheQueriesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_uri_cache_queries_total"),
 		"Total number of uri cache queries (hits + misses)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPURICacheHitsTotal = prometheus.NewDesc(
+	c.w3SVCW3WPURICacheHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_uri_cache_hits_total"),
 		"Total number of successful lookups in the user-mode URI cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPURIsCached = prometheus.NewDesc(
+	c.w3SVCW3WPURIsCached = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_uri_cache_items"),
 		"Number of URI information blocks currently in the user-mode cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPURIsCachedTotal = prometheus.NewDesc(
+	c.w3SVCW3WPURIsCachedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_uri_cache_items_total"),
 		"Total number of URI information blocks added to the user-mode cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPURIsFlushedTotal = prometheus.NewDesc(
+	c.w3SVCW3WPURIsFlushedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_uri_cache_items_flushed_total"),
 		"The number of URI information blocks that have been removed from the user-mode cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPMetadataCached = prometheus.NewDesc(
+	c.w3SVCW3WPMetadataCached = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_metadata_cache_items"),
 		"Number of metadata information blocks currently present in user-mode cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPMetadataCacheFlushes = prometheus.NewDesc(
+	c.w3SVCW3WPMetadataCacheFlushes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_metadata_cache_flushes_total"),
 		"Total number of user-mode metadata cache flushes (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPMetadataCacheQueriesTotal = prometheus.NewDesc(
+	c.w3SVCW3WPMetadataCacheQueriesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_metadata_cache_queries_total"),
 		"Total metadata cache queries (hits + misses)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPMetadataCacheHitsTotal = prometheus.NewDesc(
+	c.w3SVCW3WPMetadataCacheHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_metadata_cache_hits_total"),
 		"Total number of successful lookups in the user-mode metadata cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPMetadataCachedTotal = prometheus.NewDesc(
+	c.w3SVCW3WPMetadataCachedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_metadata_cache_items_cached_total"),
 		"Total number of metadata information blocks added to the user-mode cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPMetadataFlushedTotal = prometheus.NewDesc(
+	c.w3SVCW3WPMetadataFlushedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_metadata_cache_items_flushed_total"),
 		"Total number of metadata information blocks removed from the user-mode cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPOutputCacheActiveFlushedItems = prometheus.NewDesc(
+	c.w3SVCW3WPOutputCacheActiveFlushedItems = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_output_cache_active_flushed_items"),
 		"",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPOutputCacheItems = prometheus.NewDesc(
+	c.w3SVCW3WPOutputCacheItems = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_output_cache_items"),
 		"Number of items current present in output cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPOutputCacheMemoryUsage = prometheus.NewDesc(
+	c.w3SVCW3WPOutputCacheMemoryUsage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_output_cache_memory_bytes"),
 		"Current number of bytes used by output cache",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPOutputCacheQueriesTotal = prometheus.NewDesc(
+	c.w3SVCW3WPOutputCacheQueriesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_output_queries_total"),
 		"Total number of output cache queries (hits + misses)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPOutputCacheHitsTotal = prometheus.NewDesc(
+	c.w3SVCW3WPOutputCacheHitsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_output_cache_hits_total"),
 		"Total number of successful lookups in output cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPOutputCacheFlushedItemsTotal = prometheus.NewDesc(
+	c.w3SVCW3WPOutputCacheFlushedItemsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_output_cache_items_flushed_total"),
 		"Total number of items flushed from output cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPOutputCacheFlushesTotal = prometheus.NewDesc(
+	c.w3SVCW3WPOutputCacheFlushesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_output_cache_flushes_total"),
 		"Total number of flushes of output cache (since service startup)",
 		[]string{"app", "pid"},
 		nil,
 	)
 	// W3SVC_W3WP_IIS8
-	c.w3SVCW3WPRequestErrorsTotal = prometheus.NewDesc(
+	c.w3SVCW3WPRequestErrorsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_request_errors_total"),
 		"Total number of requests that returned an error",
 		[]string{"app", "pid", "status_code"},
 		nil,
 	)
-	c.w3SVCW3WPWebSocketRequestsActive = prometheus.NewDesc(
+	c.w3SVCW3WPWebSocketRequestsActive = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_current_websocket_requests"),
 		"",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPWebSocketConnectionAttempts = prometheus.NewDesc(
+	c.w3SVCW3WPWebSocketConnectionAttempts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_websocket_connection_attempts_total"),
 		"",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPWebSocketConnectionsAccepted = prometheus.NewDesc(
+	c.w3SVCW3WPWebSocketConnectionsAccepted = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_websocket_connection_accepted_total"),
 		"",
 		[]string{"app", "pid"},
 		nil,
 	)
-	c.w3SVCW3WPWebSocketConnectionsRejected = prometheus.NewDesc(
+	c.w3SVCW3WPWebSocketConnectionsRejected = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "worker_websocket_connection_rejected_total"),
 		"",
 		[]string{"app", "pid"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectW3SVCW3WP(ch chan<- prometheus.Metric) error {
@@ -107,140 +107,144 @@ func (c *Collector) buildWebService() error {
 		return fmt.Errorf("failed to create Web Service collector: %w", err)
 	}
 
-	c.webServiceCurrentAnonymousUsers = prometheus.NewDesc(
+	c.buildWebServiceDescs()
+
+	return nil
+}
+
+func (c *Collector) buildWebServiceDescs() {
+	c.webServiceCurrentAnonymousUsers = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_anonymous_users"),
 		"Number of users who currently have an anonymous connection using the Web service (WebService.CurrentAnonymousUsers)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceCurrentBlockedAsyncIORequests = prometheus.NewDesc(
+	c.webServiceCurrentBlockedAsyncIORequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_blocked_async_io_requests"),
 		"Current requests temporarily blocked due to bandwidth throttling settings (WebService.CurrentBlockedAsyncIORequests)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceCurrentCGIRequests = prometheus.NewDesc(
+	c.webServiceCurrentCGIRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_cgi_requests"),
 		"Current number of CGI requests being simultaneously processed by the Web service (WebService.CurrentCGIRequests)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceCurrentConnections = prometheus.NewDesc(
+	c.webServiceCurrentConnections = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_connections"),
 		"Current number of connections established with the Web service (WebService.CurrentConnections)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceCurrentISAPIExtensionRequests = prometheus.NewDesc(
+	c.webServiceCurrentISAPIExtensionRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_isapi_extension_requests"),
 		"Current number of ISAPI requests being simultaneously processed by the Web service (WebService.CurrentISAPIExtensionRequests)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceCurrentNonAnonymousUsers = prometheus.NewDesc(
+	c.webServiceCurrentNonAnonymousUsers = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_non_anonymous_users"),
 		"Number of users who currently have a non-anonymous connection using the Web service (WebService.CurrentNonAnonymousUsers)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceServiceUptime = prometheus.NewDesc(
+	c.webServiceServiceUptime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "service_uptime"),
 		"Number of seconds the WebService is up (WebService.ServiceUptime)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalBytesReceived = prometheus.NewDesc(
+	c.webServiceTotalBytesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "received_bytes_total"),
 		"Number of data bytes that have been received by the Web service (WebService.TotalBytesReceived)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalBytesSent = prometheus.NewDesc(
+	c.webServiceTotalBytesSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sent_bytes_total"),
 		"Number of data bytes that have been sent by the Web service (WebService.TotalBytesSent)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalAnonymousUsers = prometheus.NewDesc(
+	c.webServiceTotalAnonymousUsers = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "anonymous_users_total"),
 		"Total number of users who established an anonymous connection with the Web service (WebService.TotalAnonymousUsers)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalBlockedAsyncIORequests = prometheus.NewDesc(
+	c.webServiceTotalBlockedAsyncIORequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "blocked_async_io_requests_total"),
 		"Total requests temporarily blocked due to bandwidth throttling settings (WebService.TotalBlockedAsyncIORequests)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalCGIRequests = prometheus.NewDesc(
+	c.webServiceTotalCGIRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cgi_requests_total"),
 		"Total CGI requests is the total number of CGI requests (WebService.TotalCGIRequests)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalConnectionAttemptsAllInstances = prometheus.NewDesc(
+	c.webServiceTotalConnectionAttemptsAllInstances = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_attempts_all_instances_total"),
 		"Number of connections that have been attempted using the Web service (WebService.TotalConnectionAttemptsAllInstances)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalRequests = prometheus.NewDesc(
+	c.webServiceTotalRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "requests_total"),
 		"Number of HTTP requests (WebService.TotalRequests)",
 		[]string{"site", "method"},
 		nil,
 	)
-	c.webServiceTotalFilesReceived = prometheus.NewDesc(
+	c.webServiceTotalFilesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "files_received_total"),
 		"Number of files received by the Web service (WebService.TotalFilesReceived)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalFilesSent = prometheus.NewDesc(
+	c.webServiceTotalFilesSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "files_sent_total"),
 		"Number of files sent by the Web service (WebService.TotalFilesSent)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalISAPIExtensionRequests = prometheus.NewDesc(
+	c.webServiceTotalISAPIExtensionRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ipapi_extension_requests_total"),
 		"ISAPI Extension Requests received (WebService.TotalISAPIExtensionRequests)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalLockedErrors = prometheus.NewDesc(
+	c.webServiceTotalLockedErrors = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locked_errors_total"),
 		"Number of requests that couldn't be satisfied by the server because the requested resource was locked (WebService.TotalLockedErrors)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalLogonAttempts = prometheus.NewDesc(
+	c.webServiceTotalLogonAttempts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "logon_attempts_total"),
 		"Number of logons attempts to the Web Service (WebService.TotalLogonAttempts)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalNonAnonymousUsers = prometheus.NewDesc(
+	c.webServiceTotalNonAnonymousUsers = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "non_anonymous_users_total"),
 		"Number of users who established a non-anonymous connection with the Web service (WebService.TotalNonAnonymousUsers)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalNotFoundErrors = prometheus.NewDesc(
+	c.webServiceTotalNotFoundErrors = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "not_found_errors_total"),
 		"Number of requests that couldn't be satisfied by the server because the requested document could not be found (WebService.TotalNotFoundErrors)",
 		[]string{"site"},
 		nil,
 	)
-	c.webServiceTotalRejectedAsyncIORequests = prometheus.NewDesc(
+	c.webServiceTotalRejectedAsyncIORequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rejected_async_io_requests_total"),
 		"Requests rejected due to bandwidth throttling settings (WebService.TotalRejectedAsyncIORequests)",
 		[]string{"site"},
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) collectWebService(ch chan<- prometheus.Metric) error {
@@ -75,14 +75,26 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.licenseStatus = prometheus.NewDesc(
+	c.BuildDescs()
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.licenseStatus = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "status"),
 		"Status of windows license",
 		[]string{"state"},
 		nil,
 	)
+}
 
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.licenseStatus,
+	)
 }
 
 // Collect sends the metric values for each metric
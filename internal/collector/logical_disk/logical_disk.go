@@ -30,22 +30,23 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows"
 )
 
-const Name = "logical_disk"
-
 type Config struct {
-	VolumeInclude *regexp.Regexp `yaml:"volume-include"`
-	VolumeExclude *regexp.Regexp `yaml:"volume-exclude"`
+	VolumeInclude  *regexp.Regexp   `yaml:"volume-include"`
+	VolumeExclude  *regexp.Regexp   `yaml:"volume-exclude"`
+	CounterBackend perfdata.Backend `yaml:"counter-backend"`
 }
 
 //nolint:gochecknoglobals
 var ConfigDefaults = Config{
-	VolumeInclude: types.RegExpAny,
-	VolumeExclude: types.RegExpEmpty,
+	VolumeInclude:  types.RegExpAny,
+	VolumeExclude:  types.RegExpEmpty,
+	CounterBackend: perfdata.BackendPDH,
 }
 
 // A Collector is a Prometheus Collector for perflib logicalDisk metrics.
@@ -53,36 +54,10 @@ type Collector struct {
 	config Config
 	logger *slog.Logger
 
-	perfDataCollector *pdh.Collector
+	perfDataCollector *perfdata.Collector
 	perfDataObject    []perfDataCounterValues
 
-	avgReadQueue     *prometheus.Desc
-	avgWriteQueue    *prometheus.Desc
-	freeSpace        *prometheus.Desc
-	idleTime         *prometheus.Desc
-	information      *prometheus.Desc
-	readBytesTotal   *prometheus.Desc
-	readLatency      *prometheus.Desc
-	readOnly         *prometheus.Desc
-	readsTotal       *prometheus.Desc
-	readTime         *prometheus.Desc
-	readWriteLatency *prometheus.Desc
-	requestsQueued   *prometheus.Desc
-	splitIOs         *prometheus.Desc
-	totalSpace       *prometheus.Desc
-	writeBytesTotal  *prometheus.Desc
-	writeLatency     *prometheus.Desc
-	writesTotal      *prometheus.Desc
-	writeTime        *prometheus.Desc
-}
-
-type volumeInfo struct {
-	diskIDs      string
-	filesystem   string
-	serialNumber string
-	label        string
-	volumeType   string
-	readonly     float64
+	metrics
 }
 
 func New(config *Config) *Collector {
@@ -98,6 +73,10 @@ func New(config *Config) *Collector {
 		config.VolumeInclude = ConfigDefaults.VolumeInclude
 	}
 
+	if config.CounterBackend == "" {
+		config.CounterBackend = ConfigDefaults.CounterBackend
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -122,6 +101,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Regexp of volumes to include. Volume name must both match include and not match exclude to be included.",
 	).Default(".+").StringVar(&volumeInclude)
 
+	app.Flag(
+		"collector.logical_disk.counter-backend",
+		"API used to read performance counters. One of: pdh, registry.",
+	).Default(string(ConfigDefaults.CounterBackend)).EnumVar((*string)(&c.config.CounterBackend), perfdata.Backends...)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		var err error
 
@@ -152,133 +136,11 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
-	c.information = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "info"),
-		"A metric with a constant '1' value labeled with logical disk information",
-		[]string{"disk", "type", "volume", "volume_name", "filesystem", "serial_number"},
-		nil,
-	)
-	c.readOnly = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "readonly"),
-		"Whether the logical disk is read-only",
-		[]string{"volume"},
-		nil,
-	)
-	c.requestsQueued = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "requests_queued"),
-		"The number of requests queued to the disk (LogicalDisk.CurrentDiskQueueLength)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.avgReadQueue = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "avg_read_requests_queued"),
-		"Average number of read requests that were queued for the selected disk during the sample interval (LogicalDisk.AvgDiskReadQueueLength)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.avgWriteQueue = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "avg_write_requests_queued"),
-		"Average number of write requests that were queued for the selected disk during the sample interval (LogicalDisk.AvgDiskWriteQueueLength)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.readBytesTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "read_bytes_total"),
-		"The number of bytes transferred from the disk during read operations (LogicalDisk.DiskReadBytesPerSec)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.readsTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "reads_total"),
-		"The number of read operations on the disk (LogicalDisk.DiskReadsPerSec)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.writeBytesTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "write_bytes_total"),
-		"The number of bytes transferred to the disk during write operations (LogicalDisk.DiskWriteBytesPerSec)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.writesTotal = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "writes_total"),
-		"The number of write operations on the disk (LogicalDisk.DiskWritesPerSec)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.readTime = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "read_seconds_total"),
-		"Seconds that the disk was busy servicing read requests (LogicalDisk.PercentDiskReadTime)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.writeTime = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "write_seconds_total"),
-		"Seconds that the disk was busy servicing write requests (LogicalDisk.PercentDiskWriteTime)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.freeSpace = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "free_bytes"),
-		"Free space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.totalSpace = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "size_bytes"),
-		"Total space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace_Base)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.idleTime = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "idle_seconds_total"),
-		"Seconds that the disk was idle (LogicalDisk.PercentIdleTime)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.splitIOs = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "split_ios_total"),
-		"The number of I/Os to the disk were split into multiple I/Os (LogicalDisk.SplitIOPerSec)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.readLatency = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "read_latency_seconds_total"),
-		"Shows the average time, in seconds, of a read operation from the disk (LogicalDisk.AvgDiskSecPerRead)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.writeLatency = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "write_latency_seconds_total"),
-		"Shows the average time, in seconds, of a write operation to the disk (LogicalDisk.AvgDiskSecPerWrite)",
-		[]string{"volume"},
-		nil,
-	)
-
-	c.readWriteLatency = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "read_write_latency_seconds_total"),
-		"Shows the time, in seconds, of the average disk transfer (LogicalDisk.AvgDiskSecPerTransfer)",
-		[]string{"volume"},
-		nil,
-	)
+	c.BuildDescs()
 
 	var err error
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "LogicalDisk", pdh.InstancesAll)
+	c.perfDataCollector, err = perfdata.NewCollector[perfDataCounterValues](c.config.CounterBackend, pdh.CounterTypeRaw, "LogicalDisk", pdh.InstancesAll)
 	if err != nil {
 		return fmt.Errorf("failed to create LogicalDisk collector: %w", err)
 	}
@@ -286,8 +148,6 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	return nil
 }
 
-// Collect sends the metric values for each metric
-// to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 	var info volumeInfo
 
@@ -313,129 +173,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 			)
 		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.information,
-			prometheus.GaugeValue,
-			1,
-			info.diskIDs,
-			info.volumeType,
-			data.Name,
-			info.label,
-			info.filesystem,
-			info.serialNumber,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.requestsQueued,
-			prometheus.GaugeValue,
-			data.CurrentDiskQueueLength,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.avgReadQueue,
-			prometheus.GaugeValue,
-			data.AvgDiskReadQueueLength*pdh.TicksToSecondScaleFactor,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.avgWriteQueue,
-			prometheus.GaugeValue,
-			data.AvgDiskWriteQueueLength*pdh.TicksToSecondScaleFactor,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.readBytesTotal,
-			prometheus.CounterValue,
-			data.DiskReadBytesPerSec,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.readsTotal,
-			prometheus.CounterValue,
-			data.DiskReadsPerSec,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.writeBytesTotal,
-			prometheus.CounterValue,
-			data.DiskWriteBytesPerSec,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.writesTotal,
-			prometheus.CounterValue,
-			data.DiskWritesPerSec,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.readTime,
-			prometheus.CounterValue,
-			data.PercentDiskReadTime,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.writeTime,
-			prometheus.CounterValue,
-			data.PercentDiskWriteTime,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.freeSpace,
-			prometheus.GaugeValue,
-			data.FreeSpace*1024*1024,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.totalSpace,
-			prometheus.GaugeValue,
-			data.PercentFreeSpace*1024*1024,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.idleTime,
-			prometheus.CounterValue,
-			data.PercentIdleTime,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.splitIOs,
-			prometheus.CounterValue,
-			data.SplitIOPerSec,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.readLatency,
-			prometheus.CounterValue,
-			data.AvgDiskSecPerRead*pdh.TicksToSecondScaleFactor,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.writeLatency,
-			prometheus.CounterValue,
-			data.AvgDiskSecPerWrite*pdh.TicksToSecondScaleFactor,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.readWriteLatency,
-			prometheus.CounterValue,
-			data.AvgDiskSecPerTransfer*pdh.TicksToSecondScaleFactor,
-			data.Name,
-		)
+		c.collectVolume(ch, data, info)
 	}
 
 	return nil
@@ -22,6 +22,7 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/collector/logical_disk"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
 )
@@ -40,3 +41,9 @@ func TestCollector(t *testing.T) {
 		VolumeInclude: types.RegExpAny,
 	})
 }
+
+func TestCollectorRegistryBackend(t *testing.T) {
+	testutils.TestCollector(t, logical_disk.New, &logical_disk.Config{
+		CounterBackend: perfdata.BackendRegistry,
+	})
+}
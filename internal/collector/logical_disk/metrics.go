@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logical_disk
+
+import (
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "logical_disk"
+
+// metrics holds the descriptors of the collector and converts the performance counter values into metrics.
+// It doesn't call Windows APIs, so the conversion can be tested against recordings on any platform.
+type metrics struct {
+	avgReadQueue     *prometheus.Desc
+	avgWriteQueue    *prometheus.Desc
+	freeSpace        *prometheus.Desc
+	idleTime         *prometheus.Desc
+	information      *prometheus.Desc
+	readBytesTotal   *prometheus.Desc
+	readLatency      *prometheus.Desc
+	readOnly         *prometheus.Desc
+	readsTotal       *prometheus.Desc
+	readTime         *prometheus.Desc
+	readWriteLatency *prometheus.Desc
+	requestsQueued   *prometheus.Desc
+	splitIOs         *prometheus.Desc
+	totalSpace       *prometheus.Desc
+	writeBytesTotal  *prometheus.Desc
+	writeLatency     *prometheus.Desc
+	writesTotal      *prometheus.Desc
+	writeTime        *prometheus.Desc
+}
+
+type volumeInfo struct {
+	diskIDs      string
+	filesystem   string
+	serialNumber string
+	label        string
+	volumeType   string
+	readonly     float64
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *metrics) BuildDescs() {
+	c.information = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "info"),
+		"A metric with a constant '1' value labeled with logical disk information",
+		[]string{"disk", "type", "volume", "volume_name", "filesystem", "serial_number"},
+		nil,
+	)
+	c.readOnly = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "readonly"),
+		"Whether the logical disk is read-only",
+		[]string{"volume"},
+		nil,
+	)
+	c.requestsQueued = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "requests_queued"),
+		"The number of requests queued to the disk (LogicalDisk.CurrentDiskQueueLength)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.avgReadQueue = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "avg_read_requests_queued"),
+		"Average number of read requests that were queued for the selected disk during the sample interval (LogicalDisk.AvgDiskReadQueueLength)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.avgWriteQueue = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "avg_write_requests_queued"),
+		"Average number of write requests that were queued for the selected disk during the sample interval (LogicalDisk.AvgDiskWriteQueueLength)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.readBytesTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "read_bytes_total"),
+		"The number of bytes transferred from the disk during read operations (LogicalDisk.DiskReadBytesPerSec)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.readsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "reads_total"),
+		"The number of read operations on the disk (LogicalDisk.DiskReadsPerSec)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.writeBytesTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "write_bytes_total"),
+		"The number of bytes transferred to the disk during write operations (LogicalDisk.DiskWriteBytesPerSec)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.writesTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "writes_total"),
+		"The number of write operations on the disk (LogicalDisk.DiskWritesPerSec)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.readTime = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "read_seconds_total"),
+		"Seconds that the disk was busy servicing read requests (LogicalDisk.PercentDiskReadTime)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.writeTime = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "write_seconds_total"),
+		"Seconds that the disk was busy servicing write requests (LogicalDisk.PercentDiskWriteTime)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.freeSpace = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "free_bytes"),
+		"Free space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.totalSpace = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "size_bytes"),
+		"Total space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace_Base)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.idleTime = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "idle_seconds_total"),
+		"Seconds that the disk was idle (LogicalDisk.PercentIdleTime)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.splitIOs = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "split_ios_total"),
+		"The number of I/Os to the disk were split into multiple I/Os (LogicalDisk.SplitIOPerSec)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.readLatency = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "read_latency_seconds_total"),
+		"Shows the average time, in seconds, of a read operation from the disk (LogicalDisk.AvgDiskSecPerRead)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.writeLatency = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "write_latency_seconds_total"),
+		"Shows the average time, in seconds, of a write operation to the disk (LogicalDisk.AvgDiskSecPerWrite)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.readWriteLatency = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "read_write_latency_seconds_total"),
+		"Shows the time, in seconds, of the average disk transfer (LogicalDisk.AvgDiskSecPerTransfer)",
+		[]string{"volume"},
+		nil,
+	)
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *metrics) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.avgReadQueue,
+		c.avgWriteQueue,
+		c.freeSpace,
+		c.information,
+		c.requestsQueued,
+		c.totalSpace,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.idleTime,
+		c.readBytesTotal,
+		c.readLatency,
+		c.readsTotal,
+		c.readTime,
+		c.readWriteLatency,
+		c.splitIOs,
+		c.writeBytesTotal,
+		c.writeLatency,
+		c.writesTotal,
+		c.writeTime,
+	)
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+// collectVolume converts the values of the LogicalDisk counters of a volume into metrics.
+func (c *metrics) collectVolume(ch chan<- prometheus.Metric, data perfDataCounterValues, info volumeInfo) {
+	ch <- prometheus.MustNewConstMetric(
+		c.information,
+		prometheus.GaugeValue,
+		1,
+		info.diskIDs,
+		info.volumeType,
+		data.Name,
+		info.label,
+		info.filesystem,
+		info.serialNumber,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.requestsQueued,
+		prometheus.GaugeValue,
+		data.CurrentDiskQueueLength,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.avgReadQueue,
+		prometheus.GaugeValue,
+		data.AvgDiskReadQueueLength*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.avgWriteQueue,
+		prometheus.GaugeValue,
+		data.AvgDiskWriteQueueLength*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readBytesTotal,
+		prometheus.CounterValue,
+		data.DiskReadBytesPerSec,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readsTotal,
+		prometheus.CounterValue,
+		data.DiskReadsPerSec,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writeBytesTotal,
+		prometheus.CounterValue,
+		data.DiskWriteBytesPerSec,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writesTotal,
+		prometheus.CounterValue,
+		data.DiskWritesPerSec,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readTime,
+		prometheus.CounterValue,
+		data.PercentDiskReadTime,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writeTime,
+		prometheus.CounterValue,
+		data.PercentDiskWriteTime,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.freeSpace,
+		prometheus.GaugeValue,
+		data.FreeSpace*1024*1024,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.totalSpace,
+		prometheus.GaugeValue,
+		data.PercentFreeSpace*1024*1024,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.idleTime,
+		prometheus.CounterValue,
+		data.PercentIdleTime,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.splitIOs,
+		prometheus.CounterValue,
+		data.SplitIOPerSec,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readLatency,
+		prometheus.CounterValue,
+		data.AvgDiskSecPerRead*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writeLatency,
+		prometheus.CounterValue,
+		data.AvgDiskSecPerWrite*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readWriteLatency,
+		prometheus.CounterValue,
+		data.AvgDiskSecPerTransfer*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logical_disk
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/replay/replaytest"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCollectVolumeReplay(t *testing.T) {
+	t.Parallel()
+
+	c := &metrics{}
+	c.BuildDescs()
+
+	collect := func(ch chan<- prometheus.Metric, data []perfDataCounterValues) {
+		for _, volume := range data {
+			c.collectVolume(ch, volume, volumeInfo{})
+		}
+	}
+
+	replaytest.TestPDH(t, "testdata/recording.json", pdh.CounterTypeRaw, "LogicalDisk", 1, collect, `
+# HELP windows_logical_disk_avg_read_requests_queued Average number of read requests that were queued for the selected disk during the sample interval (LogicalDisk.AvgDiskReadQueueLength)
+# TYPE windows_logical_disk_avg_read_requests_queued gauge
+windows_logical_disk_avg_read_requests_queued{volume="C:"} 2.5
+windows_logical_disk_avg_read_requests_queued{volume="HarddiskVolume1"} 2.5
+# HELP windows_logical_disk_free_bytes Free space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace)
+# TYPE windows_logical_disk_free_bytes gauge
+windows_logical_disk_free_bytes{volume="C:"} 1.073741824e+10
+windows_logical_disk_free_bytes{volume="HarddiskVolume1"} 1.048576e+08
+# HELP windows_logical_disk_size_bytes Total space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace_Base)
+# TYPE windows_logical_disk_size_bytes gauge
+windows_logical_disk_size_bytes{volume="C:"} 5.36870912e+10
+windows_logical_disk_size_bytes{volume="HarddiskVolume1"} 5.24288e+08
+`,
+		"windows_logical_disk_avg_read_requests_queued",
+		"windows_logical_disk_free_bytes",
+		"windows_logical_disk_size_bytes",
+	)
+}
@@ -74,14 +74,26 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		slog.String("collector", Name),
 	)
 
-	c.sessionInfo = prometheus.NewDesc(
+	c.BuildDescs()
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.sessionInfo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "session_logon_timestamp_seconds"),
 		"Deprecated. Use windows_terminal_services_session_info instead.",
 		[]string{"id", "username", "domain", "type"},
 		nil,
 	)
+}
 
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.sessionInfo,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/prometheus-community/windows_exporter/internal/collector/memory"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
 )
 
@@ -31,3 +32,9 @@ func BenchmarkCollector(b *testing.B) {
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, memory.New, nil)
 }
+
+func TestCollectorRegistryBackend(t *testing.T) {
+	testutils.TestCollector(t, memory.New, &memory.Config{
+		CounterBackend: perfdata.BackendRegistry,
+	})
+}
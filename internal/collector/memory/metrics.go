@@ -0,0 +1,530 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "memory"
+
+// metrics holds the descriptors of the collector and converts the performance counter values into metrics.
+// It doesn't call Windows APIs, so the conversion can be tested against recordings on any platform.
+type metrics struct {
+	// Performance metrics
+	availableBytes                  *prometheus.Desc
+	cacheBytes                      *prometheus.Desc
+	cacheBytesPeak                  *prometheus.Desc
+	cacheFaultsTotal                *prometheus.Desc
+	commitLimit                     *prometheus.Desc
+	committedBytes                  *prometheus.Desc
+	demandZeroFaultsTotal           *prometheus.Desc
+	freeAndZeroPageListBytes        *prometheus.Desc
+	freeSystemPageTableEntries      *prometheus.Desc
+	modifiedPageListBytes           *prometheus.Desc
+	pageFaultsTotal                 *prometheus.Desc
+	swapPageReadsTotal              *prometheus.Desc
+	swapPagesReadTotal              *prometheus.Desc
+	swapPagesWrittenTotal           *prometheus.Desc
+	swapPageOperationsTotal         *prometheus.Desc
+	swapPageWritesTotal             *prometheus.Desc
+	poolNonPagedAllocationsTotal    *prometheus.Desc
+	poolNonPagedBytes               *prometheus.Desc
+	poolPagedAllocationsTotal       *prometheus.Desc
+	poolPagedBytes                  *prometheus.Desc
+	poolPagedResidentBytes          *prometheus.Desc
+	standbyCacheCoreBytes           *prometheus.Desc
+	standbyCacheNormalPriorityBytes *prometheus.Desc
+	standbyCacheReserveBytes        *prometheus.Desc
+	systemCacheResidentBytes        *prometheus.Desc
+	systemCodeResidentBytes         *prometheus.Desc
+	systemCodeTotalBytes            *prometheus.Desc
+	systemDriverResidentBytes       *prometheus.Desc
+	systemDriverTotalBytes          *prometheus.Desc
+	transitionFaultsTotal           *prometheus.Desc
+	transitionPagesRepurposedTotal  *prometheus.Desc
+	writeCopiesTotal                *prometheus.Desc
+
+	// Global memory status
+	processMemoryLimitBytes  *prometheus.Desc
+	physicalMemoryTotalBytes *prometheus.Desc
+	physicalMemoryFreeBytes  *prometheus.Desc
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *metrics) BuildDescs() {
+	c.availableBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "available_bytes"),
+		"The amount of physical memory immediately available for allocation to a process or for system use. It is equal to the sum of memory assigned to"+
+			" the standby (cached), free and zero page lists (AvailableBytes)",
+		nil,
+		nil,
+	)
+	c.cacheBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cache_bytes"),
+		"(CacheBytes)",
+		nil,
+		nil,
+	)
+	c.cacheBytesPeak = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cache_bytes_peak"),
+		"(CacheBytesPeak)",
+		nil,
+		nil,
+	)
+	c.cacheFaultsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cache_faults_total"),
+		"Number of faults which occur when a page sought in the file system cache is not found there and must be retrieved from elsewhere in memory (soft fault) "+
+			"or from disk (hard fault) (Cache Faults/sec)",
+		nil,
+		nil,
+	)
+	c.commitLimit = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "commit_limit"),
+		"(CommitLimit)",
+		nil,
+		nil,
+	)
+	c.committedBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "committed_bytes"),
+		"(CommittedBytes)",
+		nil,
+		nil,
+	)
+	c.demandZeroFaultsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "demand_zero_faults_total"),
+		"The number of zeroed pages required to satisfy faults. Zeroed pages, pages emptied of previously stored data and filled with zeros, are a security"+
+			" feature of Windows that prevent processes from seeing data stored by earlier processes that used the memory space (Demand Zero Faults/sec)",
+		nil,
+		nil,
+	)
+	c.freeAndZeroPageListBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "free_and_zero_page_list_bytes"),
+		"The amount of physical memory, in bytes, that is assigned to the free and zero page lists. This memory does not contain cached data. It is immediately"+
+			" available for allocation to a process or for system use (FreeAndZeroPageListBytes)",
+		nil,
+		nil,
+	)
+	c.freeSystemPageTableEntries = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "free_system_page_table_entries"),
+		"(FreeSystemPageTableEntries)",
+		nil,
+		nil,
+	)
+	c.modifiedPageListBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "modified_page_list_bytes"),
+		"The amount of physical memory, in bytes, that is assigned to the modified page list. This memory contains cached data and code that is not actively in "+
+			"use by processes, the system and the system cache (ModifiedPageListBytes)",
+		nil,
+		nil,
+	)
+	c.pageFaultsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "page_faults_total"),
+		"Overall rate at which faulted pages are handled by the processor (Page Faults/sec)",
+		nil,
+		nil,
+	)
+	c.swapPageReadsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "swap_page_reads_total"),
+		"Number of disk page reads (a single read operation reading several pages is still only counted once) (PageReadsPerSec)",
+		nil,
+		nil,
+	)
+	c.swapPagesReadTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "swap_pages_read_total"),
+		"Number of pages read across all page reads (ie counting all pages read even if they are read in a single operation) (PagesInputPerSec)",
+		nil,
+		nil,
+	)
+	c.swapPagesWrittenTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "swap_pages_written_total"),
+		"Number of pages written across all page writes (ie counting all pages written even if they are written in a single operation) (PagesOutputPerSec)",
+		nil,
+		nil,
+	)
+	c.swapPageOperationsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "swap_page_operations_total"),
+		"Total number of swap page read and writes (PagesPerSec)",
+		nil,
+		nil,
+	)
+	c.swapPageWritesTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "swap_page_writes_total"),
+		"Number of disk page writes (a single write operation writing several pages is still only counted once) (PageWritesPerSec)",
+		nil,
+		nil,
+	)
+	c.poolNonPagedAllocationsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pool_nonpaged_allocs_total"),
+		"The number of calls to allocate space in the nonpaged pool. The nonpaged pool is an area of system memory area for objects that cannot be written"+
+			" to disk, and must remain in physical memory as long as they are allocated (PoolNonpagedAllocs)",
+		nil,
+		nil,
+	)
+	c.poolNonPagedBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pool_nonpaged_bytes"),
+		"Number of bytes in the non-paged pool, an area of the system virtual memory that is used for objects that cannot be written to disk, but must "+
+			"remain in physical memory as long as they are allocated (PoolNonpagedBytes)",
+		nil,
+		nil,
+	)
+	c.poolPagedAllocationsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pool_paged_allocs_total"),
+		"Number of calls to allocate space in the paged pool, regardless of the amount of space allocated in each call (PoolPagedAllocs)",
+		nil,
+		nil,
+	)
+	c.poolPagedBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pool_paged_bytes"),
+		"(PoolPagedBytes)",
+		nil,
+		nil,
+	)
+	c.poolPagedResidentBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pool_paged_resident_bytes"),
+		"The size, in bytes, of the portion of the paged pool that is currently resident and active in physical memory. The paged pool is an area of the "+
+			"system virtual memory that is used for objects that can be written to disk when they are not being used (PoolPagedResidentBytes)",
+		nil,
+		nil,
+	)
+	c.standbyCacheCoreBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "standby_cache_core_bytes"),
+		"The amount of physical memory, in bytes, that is assigned to the core standby cache page lists. This memory contains cached data and code that is "+
+			"not actively in use by processes, the system and the system cache (StandbyCacheCoreBytes)",
+		nil,
+		nil,
+	)
+	c.standbyCacheNormalPriorityBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "standby_cache_normal_priority_bytes"),
+		"The amount of physical memory, in bytes, that is assigned to the normal priority standby cache page lists. This memory contains cached data and "+
+			"code that is not actively in use by processes, the system and the system cache (StandbyCacheNormalPriorityBytes)",
+		nil,
+		nil,
+	)
+	c.standbyCacheReserveBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "standby_cache_reserve_bytes"),
+		"The amount of physical memory, in bytes, that is assigned to the reserve standby cache page lists. This memory contains cached data and code "+
+			"that is not actively in use by processes, the system and the system cache (StandbyCacheReserveBytes)",
+		nil,
+		nil,
+	)
+	c.systemCacheResidentBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "system_cache_resident_bytes"),
+		"The size, in bytes, of the portion of the system file cache which is currently resident and active in physical memory (SystemCacheResidentBytes)",
+		nil,
+		nil,
+	)
+	c.systemCodeResidentBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "system_code_resident_bytes"),
+		"The size, in bytes, of the pageable operating system code that is currently resident and active in physical memory (SystemCodeResidentBytes)",
+		nil,
+		nil,
+	)
+	c.systemCodeTotalBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "system_code_total_bytes"),
+		"The size, in bytes, of the pageable operating system code currently mapped into the system virtual address space (SystemCodeTotalBytes)",
+		nil,
+		nil,
+	)
+	c.systemDriverResidentBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "system_driver_resident_bytes"),
+		"The size, in bytes, of the pageable physical memory being used by device drivers. It is the working set (physical memory area) of the drivers (SystemDriverResidentBytes)",
+		nil,
+		nil,
+	)
+	c.systemDriverTotalBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "system_driver_total_bytes"),
+		"The size, in bytes, of the pageable virtual memory currently being used by device drivers. Pageable memory can be written to disk when it is not being used (SystemDriverTotalBytes)",
+		nil,
+		nil,
+	)
+	c.transitionFaultsTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "transition_faults_total"),
+		"Number of faults rate at which page faults are resolved by recovering pages that were being used by another process sharing the page, or were on the "+
+			"modified page list or the standby list, or were being written to disk at the time of the page fault (TransitionFaultsPerSec)",
+		nil,
+		nil,
+	)
+	c.transitionPagesRepurposedTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "transition_pages_repurposed_total"),
+		"Transition Pages RePurposed is the rate at which the number of transition cache pages were reused for a different purpose (TransitionPagesRePurposedPerSec)",
+		nil,
+		nil,
+	)
+	c.writeCopiesTotal = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "write_copies_total"),
+		"The number of page faults caused by attempting to write that were satisfied by copying the page from elsewhere in physical memory (WriteCopiesPerSec)",
+		nil,
+		nil,
+	)
+	c.processMemoryLimitBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "process_memory_limit_bytes"),
+		"The size of the user-mode portion of the virtual address space of the calling process, in bytes. This value depends on the type of process, the type of processor, and the configuration of the operating system.",
+		nil,
+		nil,
+	)
+	c.physicalMemoryTotalBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "physical_total_bytes"),
+		"The amount of actual physical memory, in bytes.",
+		nil,
+		nil,
+	)
+	c.physicalMemoryFreeBytes = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "physical_free_bytes"),
+		"The amount of physical memory currently available, in bytes. This is the amount of physical memory that can be immediately reused without having to write its contents to disk first. It is the sum of the size of the standby, free, and zero lists.",
+		nil,
+		nil,
+	)
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *metrics) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.availableBytes,
+		c.cacheBytes,
+		c.cacheBytesPeak,
+		c.commitLimit,
+		c.committedBytes,
+		c.freeAndZeroPageListBytes,
+		c.freeSystemPageTableEntries,
+		c.modifiedPageListBytes,
+		c.poolNonPagedAllocationsTotal,
+		c.poolNonPagedBytes,
+		c.poolPagedBytes,
+		c.poolPagedResidentBytes,
+		c.standbyCacheCoreBytes,
+		c.standbyCacheNormalPriorityBytes,
+		c.standbyCacheReserveBytes,
+		c.systemCacheResidentBytes,
+		c.systemCodeResidentBytes,
+		c.systemCodeTotalBytes,
+		c.systemDriverResidentBytes,
+		c.systemDriverTotalBytes,
+		c.processMemoryLimitBytes,
+		c.physicalMemoryTotalBytes,
+		c.physicalMemoryFreeBytes,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.cacheFaultsTotal,
+		c.demandZeroFaultsTotal,
+		c.pageFaultsTotal,
+		c.swapPageReadsTotal,
+		c.swapPagesReadTotal,
+		c.swapPagesWrittenTotal,
+		c.swapPageOperationsTotal,
+		c.swapPageWritesTotal,
+		c.poolPagedAllocationsTotal,
+		c.transitionFaultsTotal,
+		c.transitionPagesRepurposedTotal,
+		c.writeCopiesTotal,
+	)
+}
+
+// collectMemory converts the values of the Memory counters into metrics. data must hold a single instance.
+func (c *metrics) collectMemory(ch chan<- prometheus.Metric, data []perfDataCounterValues) {
+	ch <- prometheus.MustNewConstMetric(
+		c.availableBytes,
+		prometheus.GaugeValue,
+		data[0].AvailableBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cacheBytes,
+		prometheus.GaugeValue,
+		data[0].CacheBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cacheBytesPeak,
+		prometheus.GaugeValue,
+		data[0].CacheBytesPeak,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cacheFaultsTotal,
+		prometheus.CounterValue,
+		data[0].CacheFaultsPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.commitLimit,
+		prometheus.GaugeValue,
+		data[0].CommitLimit,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.committedBytes,
+		prometheus.GaugeValue,
+		data[0].CommittedBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.demandZeroFaultsTotal,
+		prometheus.CounterValue,
+		data[0].DemandZeroFaultsPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.freeAndZeroPageListBytes,
+		prometheus.GaugeValue,
+		data[0].FreeAndZeroPageListBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.freeSystemPageTableEntries,
+		prometheus.GaugeValue,
+		data[0].FreeSystemPageTableEntries,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.modifiedPageListBytes,
+		prometheus.GaugeValue,
+		data[0].ModifiedPageListBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.pageFaultsTotal,
+		prometheus.CounterValue,
+		data[0].PageFaultsPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.swapPageReadsTotal,
+		prometheus.CounterValue,
+		data[0].PageReadsPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.swapPagesReadTotal,
+		prometheus.CounterValue,
+		data[0].PagesInputPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.swapPagesWrittenTotal,
+		prometheus.CounterValue,
+		data[0].PagesOutputPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.swapPageOperationsTotal,
+		prometheus.CounterValue,
+		data[0].PagesPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.swapPageWritesTotal,
+		prometheus.CounterValue,
+		data[0].PageWritesPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.poolNonPagedAllocationsTotal,
+		prometheus.GaugeValue,
+		data[0].PoolNonpagedAllocs,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.poolNonPagedBytes,
+		prometheus.GaugeValue,
+		data[0].PoolNonpagedBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.poolPagedAllocationsTotal,
+		prometheus.CounterValue,
+		data[0].PoolPagedAllocs,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.poolPagedBytes,
+		prometheus.GaugeValue,
+		data[0].PoolPagedBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.poolPagedResidentBytes,
+		prometheus.GaugeValue,
+		data[0].PoolPagedResidentBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.standbyCacheCoreBytes,
+		prometheus.GaugeValue,
+		data[0].StandbyCacheCoreBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.standbyCacheNormalPriorityBytes,
+		prometheus.GaugeValue,
+		data[0].StandbyCacheNormalPriorityBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.standbyCacheReserveBytes,
+		prometheus.GaugeValue,
+		data[0].StandbyCacheReserveBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.systemCacheResidentBytes,
+		prometheus.GaugeValue,
+		data[0].SystemCacheResidentBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.systemCodeResidentBytes,
+		prometheus.GaugeValue,
+		data[0].SystemCodeResidentBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.systemCodeTotalBytes,
+		prometheus.GaugeValue,
+		data[0].SystemCodeTotalBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.systemDriverResidentBytes,
+		prometheus.GaugeValue,
+		data[0].SystemDriverResidentBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.systemDriverTotalBytes,
+		prometheus.GaugeValue,
+		data[0].SystemDriverTotalBytes,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.transitionFaultsTotal,
+		prometheus.CounterValue,
+		data[0].TransitionFaultsPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.transitionPagesRepurposedTotal,
+		prometheus.CounterValue,
+		data[0].TransitionPagesRePurposedPerSec,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writeCopiesTotal,
+		prometheus.CounterValue,
+		data[0].WriteCopiesPerSec,
+	)
+}
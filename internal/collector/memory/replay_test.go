@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/replay/replaytest"
+)
+
+func TestCollectMemoryReplay(t *testing.T) {
+	t.Parallel()
+
+	c := &metrics{}
+	c.BuildDescs()
+
+	replaytest.TestPDH(t, "testdata/recording.json", pdh.CounterTypeRaw, "Memory", 1, c.collectMemory, `
+# HELP windows_memory_available_bytes The amount of physical memory immediately available for allocation to a process or for system use. It is equal to the sum of memory assigned to the standby (cached), free and zero page lists (AvailableBytes)
+# TYPE windows_memory_available_bytes gauge
+windows_memory_available_bytes 4.294967296e+09
+# HELP windows_memory_cache_bytes (CacheBytes)
+# TYPE windows_memory_cache_bytes gauge
+windows_memory_cache_bytes 1.23456789e+08
+# HELP windows_memory_swap_page_operations_total Total number of swap page read and writes (PagesPerSec)
+# TYPE windows_memory_swap_page_operations_total counter
+windows_memory_swap_page_operations_total 42
+`,
+		"windows_memory_available_bytes",
+		"windows_memory_cache_bytes",
+		"windows_memory_swap_page_operations_total",
+	)
+}
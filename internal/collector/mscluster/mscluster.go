@@ -27,6 +27,7 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -159,6 +160,148 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 	return errors.Join(errs...)
 }
 
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildClusterDescs()
+	c.buildNetworkDescs()
+	c.buildNodeDescs()
+	c.buildResourceDescs()
+	c.buildResourceGroupDescs()
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.clusterAddEvictDelay,
+		c.clusterAdminAccessPoint,
+		c.clusterAutoAssignNodeSite,
+		c.clusterAutoBalancerLevel,
+		c.clusterAutoBalancerMode,
+		c.clusterBackupInProgress,
+		c.clusterBlockCacheSize,
+		c.clusterClusSvcHangTimeout,
+		c.clusterClusSvcRegroupOpeningTimeout,
+		c.clusterClusSvcRegroupPruningTimeout,
+		c.clusterClusSvcRegroupStageTimeout,
+		c.clusterClusSvcRegroupTickInMilliseconds,
+		c.clusterClusterEnforcedAntiAffinity,
+		c.clusterClusterFunctionalLevel,
+		c.clusterClusterGroupWaitDelay,
+		c.clusterClusterLogLevel,
+		c.clusterClusterLogSize,
+		c.clusterClusterUpgradeVersion,
+		c.clusterCrossSiteDelay,
+		c.clusterCrossSiteThreshold,
+		c.clusterCrossSubnetDelay,
+		c.clusterCrossSubnetThreshold,
+		c.clusterCsvBalancer,
+		c.clusterDatabaseReadWriteMode,
+		c.clusterDefaultNetworkRole,
+		c.clusterDetectedCloudPlatform,
+		c.clusterDetectManagedEvents,
+		c.clusterDetectManagedEventsThreshold,
+		c.clusterDisableGroupPreferredOwnerRandomization,
+		c.clusterDrainOnShutdown,
+		c.clusterDynamicQuorumEnabled,
+		c.clusterEnableSharedVolumes,
+		c.clusterFixQuorum,
+		c.clusterGracePeriodEnabled,
+		c.clusterGracePeriodTimeout,
+		c.clusterGroupDependencyTimeout,
+		c.clusterHangRecoveryAction,
+		c.clusterIgnorePersistentStateOnStartup,
+		c.clusterLogResourceControls,
+		c.clusterLowerQuorumPriorityNodeId,
+		c.clusterMaxNumberOfNodes,
+		c.clusterMessageBufferLength,
+		c.clusterMinimumNeverPreemptPriority,
+		c.clusterMinimumPreemptorPriority,
+		c.clusterNetftIPSecEnabled,
+		c.clusterPlacementOptions,
+		c.clusterPlumbAllCrossSubnetRoutes,
+		c.clusterPreventQuorum,
+		c.clusterQuarantineDuration,
+		c.clusterQuarantineThreshold,
+		c.clusterQuorumArbitrationTimeMax,
+		c.clusterQuorumArbitrationTimeMin,
+		c.clusterQuorumLogFileSize,
+		c.clusterQuorumTypeValue,
+		c.clusterRequestReplyTimeout,
+		c.clusterResiliencyDefaultPeriod,
+		c.clusterResiliencyLevel,
+		c.clusterResourceDllDeadlockPeriod,
+		c.clusterRootMemoryReserved,
+		c.clusterRouteHistoryLength,
+		c.clusterS2DBusTypes,
+		c.clusterS2DCacheDesiredState,
+		c.clusterS2DCacheFlashReservePercent,
+		c.clusterS2DCachePageSizeKBytes,
+		c.clusterS2DEnabled,
+		c.clusterS2DIOLatencyThreshold,
+		c.clusterS2DOptimizations,
+		c.clusterSameSubnetDelay,
+		c.clusterSameSubnetThreshold,
+		c.clusterSecurityLevel,
+		c.clusterSecurityLevelForStorage,
+		c.clusterSharedVolumeVssWriterOperationTimeout,
+		c.clusterShutdownTimeoutInMinutes,
+		c.clusterUseClientAccessNetworksForSharedVolumes,
+		c.clusterWitnessDatabaseWriteTimeout,
+		c.clusterWitnessDynamicWeight,
+		c.clusterWitnessRestartInterval,
+		c.networkCharacteristics,
+		c.networkFlags,
+		c.networkMetric,
+		c.networkRole,
+		c.networkState,
+		c.nodeBuildNumber,
+		c.nodeCharacteristics,
+		c.nodeDetectedCloudPlatform,
+		c.nodeDynamicWeight,
+		c.nodeFlags,
+		c.nodeMajorVersion,
+		c.nodeMinorVersion,
+		c.nodeNeedsPreventQuorum,
+		c.nodeNodeDrainStatus,
+		c.nodeNodeHighestVersion,
+		c.nodeNodeLowestVersion,
+		c.nodeNodeWeight,
+		c.nodeState,
+		c.nodeStatusInformation,
+		c.resourceCharacteristics,
+		c.resourceDeadlockTimeout,
+		c.resourceEmbeddedFailureAction,
+		c.resourceFlags,
+		c.resourceIsAlivePollInterval,
+		c.resourceLooksAlivePollInterval,
+		c.resourceMonitorProcessId,
+		c.resourceOwnerNode,
+		c.resourcePendingTimeout,
+		c.resourceResourceClass,
+		c.resourceRestartAction,
+		c.resourceRestartDelay,
+		c.resourceRestartPeriod,
+		c.resourceRestartThreshold,
+		c.resourceRetryPeriodOnFailure,
+		c.resourceState,
+		c.resourceSubClass,
+		c.resourceGroupAutoFailbackType,
+		c.resourceGroupCharacteristics,
+		c.resourceGroupColdStartSetting,
+		c.resourceGroupDefaultOwner,
+		c.resourceGroupFailbackWindowEnd,
+		c.resourceGroupFailbackWindowStart,
+		c.resourceGroupFailOverPeriod,
+		c.resourceGroupFailOverThreshold,
+		c.resourceGroupFlags,
+		c.resourceGroupGroupType,
+		c.resourceGroupOwnerNode,
+		c.resourceGroupPriority,
+		c.resourceGroupResiliencyPeriod,
+		c.resourceGroupState,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
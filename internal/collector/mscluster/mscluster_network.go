@@ -57,44 +57,48 @@ func (c *Collector) buildNetwork() error {
 
 	c.networkMIQuery = networkMIQuery
 
-	c.networkCharacteristics = prometheus.NewDesc(
+	c.buildNetworkDescs()
+
+	var dst []msClusterNetwork
+
+	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.networkMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) buildNetworkDescs() {
+	c.networkCharacteristics = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "characteristics"),
 		"Provides the characteristics of the network.",
 		[]string{"name"},
 		nil,
 	)
-	c.networkFlags = prometheus.NewDesc(
+	c.networkFlags = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "flags"),
 		"Provides access to the flags set for the node. ",
 		[]string{"name"},
 		nil,
 	)
-	c.networkMetric = prometheus.NewDesc(
+	c.networkMetric = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "metric"),
 		"The metric of a cluster network (networks with lower values are used first). If this value is set, then the AutoMetric property is set to false.",
 		[]string{"name"},
 		nil,
 	)
-	c.networkRole = prometheus.NewDesc(
+	c.networkRole = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "role"),
 		"Provides access to the network's Role property. The Role property describes the role of the network in the cluster. 0: None; 1: Cluster; 2: Client; 3: Both ",
 		[]string{"name"},
 		nil,
 	)
-	c.networkState = prometheus.NewDesc(
+	c.networkState = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "state"),
 		"Provides the current state of the network. 1-1: Unknown; 0: Unavailable; 1: Down; 2: Partitioned; 3: Up",
 		[]string{"name"},
 		nil,
 	)
-
-	var dst []msClusterNetwork
-
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.networkMIQuery); err != nil {
-		return fmt.Errorf("WMI query failed: %w", err)
-	}
-
-	return nil
 }
 
 // Collect sends the metric values for each metric
@@ -83,98 +83,102 @@ func (c *Collector) buildNode() error {
 
 	c.nodeMIQuery = nodeMIQuery
 
-	c.nodeBuildNumber = prometheus.NewDesc(
+	c.buildNodeDescs()
+
+	var dst []msClusterNode
+
+	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.nodeMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) buildNodeDescs() {
+	c.nodeBuildNumber = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "build_number"),
 		"Provides access to the node's BuildNumber property.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeCharacteristics = prometheus.NewDesc(
+	c.nodeCharacteristics = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "characteristics"),
 		"Provides access to the characteristics set for the node.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeDetectedCloudPlatform = prometheus.NewDesc(
+	c.nodeDetectedCloudPlatform = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "detected_cloud_platform"),
 		"(DetectedCloudPlatform)",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeDynamicWeight = prometheus.NewDesc(
+	c.nodeDynamicWeight = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "dynamic_weight"),
 		"The dynamic vote weight of the node adjusted by dynamic quorum feature.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeFlags = prometheus.NewDesc(
+	c.nodeFlags = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "flags"),
 		"Provides access to the flags set for the node.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeMajorVersion = prometheus.NewDesc(
+	c.nodeMajorVersion = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "major_version"),
 		"Provides access to the node's MajorVersion property, which specifies the major portion of the Windows version installed.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeMinorVersion = prometheus.NewDesc(
+	c.nodeMinorVersion = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "minor_version"),
 		"Provides access to the node's MinorVersion property, which specifies the minor portion of the Windows version installed.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNeedsPreventQuorum = prometheus.NewDesc(
+	c.nodeNeedsPreventQuorum = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "needs_prevent_quorum"),
 		"Whether the cluster service on that node should be started with prevent quorum flag.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeDrainStatus = prometheus.NewDesc(
+	c.nodeNodeDrainStatus = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_drain_status"),
 		"The current node drain status of a node. 0: Not Initiated; 1: In Progress; 2: Completed; 3: Failed",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeHighestVersion = prometheus.NewDesc(
+	c.nodeNodeHighestVersion = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_highest_version"),
 		"Provides access to the node's NodeHighestVersion property, which specifies the highest possible version of the cluster service with which the node can join or communicate.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeLowestVersion = prometheus.NewDesc(
+	c.nodeNodeLowestVersion = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_lowest_version"),
 		"Provides access to the node's NodeLowestVersion property, which specifies the lowest possible version of the cluster service with which the node can join or communicate.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeWeight = prometheus.NewDesc(
+	c.nodeNodeWeight = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_weight"),
 		"The vote weight of the node.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeState = prometheus.NewDesc(
+	c.nodeState = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "state"),
 		"Returns the current state of a node. -1: Unknown; 0: Up; 1: Down; 2: Paused; 3: Joining",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeStatusInformation = prometheus.NewDesc(
+	c.nodeStatusInformation = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "status_information"),
 		"The isolation or quarantine status of the node.",
 		[]string{"name"},
 		nil,
 	)
-
-	var dst []msClusterNode
-
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.nodeMIQuery); err != nil {
-		return fmt.Errorf("WMI query failed: %w", err)
-	}
-
-	return nil
 }
 
 // Collect sends the metric values for each metric
@@ -256,20 +256,288 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		c.closeFns = append(c.closeFns, subCollectors[name].close)
 	}
 
-	c.mssqlScrapeDurationDesc = prometheus.NewDesc(
+	c.buildChildCollectorDescs()
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildChildCollectorDescs()
+	c.buildAccessMethodsDescs()
+	c.buildAvailabilityReplicaDescs()
+	c.buildBufferManagerDescs()
+	c.buildDatabasesDescs()
+	c.buildDatabaseReplicaDescs()
+	c.buildGeneralStatisticsDescs()
+	c.buildLocksDescs()
+	c.buildMemoryManagerDescs()
+	c.buildSQLErrorsDescs()
+	c.buildSQLStatsDescs()
+	c.buildTransactionsDescs()
+	c.buildWaitStatsDescs()
+}
+
+func (c *Collector) buildChildCollectorDescs() {
+	c.mssqlScrapeDurationDesc = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "collector_duration_seconds"),
 		"windows_exporter: Duration of an mssql child collection.",
 		[]string{"collector", "mssql_instance"},
 		nil,
 	)
-	c.mssqlScrapeSuccessDesc = prometheus.NewDesc(
+	c.mssqlScrapeSuccessDesc = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "collector_success"),
 		"windows_exporter: Whether a mssql child collector was successful.",
 		[]string{"collector", "mssql_instance"},
 		nil,
 	)
+}
 
-	return errors.Join(errs...)
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.mssqlScrapeDurationDesc,
+		c.mssqlScrapeSuccessDesc,
+		c.accessMethodsDeferreddroppedAUs,
+		c.accessMethodsDeferredDroppedrowsets,
+		c.bufManBuffercachehits,
+		c.bufManBuffercachelookups,
+		c.bufManDatabasepages,
+		c.bufManExtensionallocatedpages,
+		c.bufManExtensionfreepages,
+		c.bufManExtensioninuseaspercentage,
+		c.bufManExtensionoutstandingIOcounter,
+		c.bufManExtensionpageunreferencedtime,
+		c.bufManIntegralControllerSlope,
+		c.bufManPagelifeexpectancy,
+		c.bufManTargetpages,
+		c.databasesActiveParallelRedoThreads,
+		c.databasesActiveTransactions,
+		c.databasesCommitTableEntries,
+		c.databasesDataFilesSizeKB,
+		c.databasesLogCacheHits,
+		c.databasesLogCacheLookups,
+		c.databasesLogFilesSizeKB,
+		c.databasesLogFilesUsedSizeKB,
+		c.databasesLogFlushWaitTime,
+		c.databasesLogFlushWriteTimeMS,
+		c.databasesLogGrowths,
+		c.databasesLogPoolTotalActiveLogSize,
+		c.databasesLogPoolTotalSharedPoolSize,
+		c.databasesLogShrinks,
+		c.databasesLogTruncations,
+		c.databasesPercentLogUsed,
+		c.databasesReplPendingXacts,
+		c.databasesXTPControllerDLCLatencyPerFetch,
+		c.databasesXTPControllerDLCPeakLatency,
+		c.databasesXTPMemoryUsedKB,
+		c.dbReplicaDatabaseFlowControlDelay,
+		c.dbReplicaGroupCommitTime,
+		c.dbReplicaLogApplyPendingQueue,
+		c.dbReplicaLogApplyReadyQueue,
+		c.dbReplicaLogremainingforundo,
+		c.dbReplicaLogSendQueue,
+		c.dbReplicaRecoveryQueue,
+		c.dbReplicaRedoBytesRemaining,
+		c.dbReplicaTotalLogrequiringundo,
+		c.dbReplicaTransactionDelay,
+		c.genStatsActiveTempTables,
+		c.genStatsEventNotificationsDelayedDrop,
+		c.genStatsHTTPAuthenticatedRequests,
+		c.genStatsLogicalConnections,
+		c.genStatsMarsDeadlocks,
+		c.genStatsProcessesBlocked,
+		c.genStatsSOAPEmptyRequests,
+		c.genStatsSOAPMethodInvocations,
+		c.genStatsSOAPSessionInitiateRequests,
+		c.genStatsSOAPSessionTerminateRequests,
+		c.genStatsSOAPSQLRequests,
+		c.genStatsSOAPWSDLRequests,
+		c.genStatsSQLTraceIOProviderLockWaits,
+		c.genStatsTempDBRecoveryUnitID,
+		c.genStatsTempDBrowSetID,
+		c.genStatsTempTablesForDestruction,
+		c.genStatsTraceEventNotificationQueue,
+		c.genStatsTransactions,
+		c.genStatsUserConnections,
+		c.locksWaitTime,
+		c.locksCount,
+		c.locksLockWaitTimeMS,
+		c.memMgrConnectionMemoryKB,
+		c.memMgrDatabaseCacheMemoryKB,
+		c.memMgrExternalBenefitOfMemory,
+		c.memMgrFreeMemoryKB,
+		c.memMgrGrantedWorkspaceMemoryKB,
+		c.memMgrLockBlocks,
+		c.memMgrLockBlocksAllocated,
+		c.memMgrLockMemoryKB,
+		c.memMgrLockOwnerBlocks,
+		c.memMgrLockOwnerBlocksAllocated,
+		c.memMgrLogPoolMemoryKB,
+		c.memMgrMaximumWorkspaceMemoryKB,
+		c.memMgrMemoryGrantsOutstanding,
+		c.memMgrMemoryGrantsPending,
+		c.memMgrOptimizerMemoryKB,
+		c.memMgrReservedServerMemoryKB,
+		c.memMgrSQLCacheMemoryKB,
+		c.memMgrStolenServerMemoryKB,
+		c.memMgrTargetServerMemoryKB,
+		c.memMgrTotalServerMemoryKB,
+		c.transactionsTempDbFreeSpaceBytes,
+		c.transactionsLongestTransactionRunningSeconds,
+		c.transactionsActive,
+		c.transactionsVersionCleanupRateBytes,
+		c.transactionsVersionGenerationRateBytes,
+		c.transactionsVersionStoreSizeBytes,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.accessMethodsAUcleanupbatches,
+		c.accessMethodsAUcleanups,
+		c.accessMethodsByReferenceLobCreateCount,
+		c.accessMethodsByReferenceLobUseCount,
+		c.accessMethodsCountLobReadahead,
+		c.accessMethodsCountPullInRow,
+		c.accessMethodsCountPushOffRow,
+		c.accessMethodsDroppedrowsetcleanups,
+		c.accessMethodsDroppedrowsetsskipped,
+		c.accessMethodsExtentDeallocations,
+		c.accessMethodsExtentsAllocated,
+		c.accessMethodsFailedAUcleanupbatches,
+		c.accessMethodsFailedleafpagecookie,
+		c.accessMethodsFailedtreepagecookie,
+		c.accessMethodsForwardedRecords,
+		c.accessMethodsFreeSpacePageFetches,
+		c.accessMethodsFreeSpaceScans,
+		c.accessMethodsFullScans,
+		c.accessMethodsIndexSearches,
+		c.accessMethodsInSysXactwaits,
+		c.accessMethodsLobHandleCreateCount,
+		c.accessMethodsLobHandleDestroyCount,
+		c.accessMethodsLobSSProviderCreateCount,
+		c.accessMethodsLobSSProviderDestroyCount,
+		c.accessMethodsLobSSProviderTruncationCount,
+		c.accessMethodsMixedPageAllocations,
+		c.accessMethodsPageCompressionAttempts,
+		c.accessMethodsPageDeallocations,
+		c.accessMethodsPagesAllocated,
+		c.accessMethodsPagesCompressed,
+		c.accessMethodsPageSplits,
+		c.accessMethodsProbeScans,
+		c.accessMethodsRangeScans,
+		c.accessMethodsScanPointRevalidations,
+		c.accessMethodsSkippedGhostedRecords,
+		c.accessMethodsTableLockEscalations,
+		c.accessMethodsUsedleafpagecookie,
+		c.accessMethodsUsedtreepagecookie,
+		c.accessMethodsWorkfilesCreated,
+		c.accessMethodsWorktablesCreated,
+		c.accessMethodsWorktablesFromCacheHits,
+		c.accessMethodsWorktablesFromCacheLookups,
+		c.availReplicaBytesReceivedFromReplica,
+		c.availReplicaBytesSentToReplica,
+		c.availReplicaBytesSentToTransport,
+		c.availReplicaFlowControl,
+		c.availReplicaFlowControlTimeMS,
+		c.availReplicaReceivesFromReplica,
+		c.availReplicaResentMessages,
+		c.availReplicaSendsToReplica,
+		c.availReplicaSendsToTransport,
+		c.bufManBackgroundwriterpages,
+		c.bufManCheckpointpages,
+		c.bufManExtensionpageevictions,
+		c.bufManExtensionpagereads,
+		c.bufManExtensionpagewrites,
+		c.bufManFreeliststalls,
+		c.bufManLazywrites,
+		c.bufManPagelookups,
+		c.bufManPagereads,
+		c.bufManPagewrites,
+		c.bufManReadaheadpages,
+		c.bufManReadaheadtime,
+		c.databasesBackupPerRestoreThroughput,
+		c.databasesBulkCopyRows,
+		c.databasesBulkCopyThroughput,
+		c.databasesDBCCLogicalScanBytes,
+		c.databasesGroupCommitTime,
+		c.databasesLogBytesFlushed,
+		c.databasesLogCacheReads,
+		c.databasesLogFlushes,
+		c.databasesLogFlushWaits,
+		c.databasesLogPoolCacheMisses,
+		c.databasesLogPoolDiskReads,
+		c.databasesLogPoolHashDeletes,
+		c.databasesLogPoolHashInserts,
+		c.databasesLogPoolInvalidHashEntry,
+		c.databasesLogPoolLogScanPushes,
+		c.databasesLogPoolLogWriterPushes,
+		c.databasesLogPoolPushEmptyFreePool,
+		c.databasesLogPoolPushLowMemory,
+		c.databasesLogPoolPushNoFreeBuffer,
+		c.databasesLogPoolReqBehindTrunc,
+		c.databasesLogPoolRequestsOldVLF,
+		c.databasesLogPoolRequests,
+		c.databasesReplTransRate,
+		c.databasesShrinkDataMovementBytes,
+		c.databasesTrackedTransactions,
+		c.databasesTransactions,
+		c.databasesWriteTransactions,
+		c.databasesXTPControllerLogProcessed,
+		c.dbReplicaDatabaseFlowControls,
+		c.dbReplicaFileBytesReceived,
+		c.dbReplicaGroupCommits,
+		c.dbReplicaLogBytesCompressed,
+		c.dbReplicaLogBytesDecompressed,
+		c.dbReplicaLogBytesReceived,
+		c.dbReplicaLogCompressionCachehits,
+		c.dbReplicaLogCompressionCachemisses,
+		c.dbReplicaLogCompressions,
+		c.dbReplicaLogDecompressions,
+		c.dbReplicaMirroredWritetransactions,
+		c.dbReplicaRedoblocked,
+		c.dbReplicaRedoneBytes,
+		c.dbReplicaRedones,
+		c.genStatsConnectionReset,
+		c.genStatsLogins,
+		c.genStatsLogouts,
+		c.genStatsNonAtomicYieldRate,
+		c.genStatsTempTablesCreationRate,
+		c.locksLockRequests,
+		c.locksLockTimeouts,
+		c.locksLockTimeoutstimeout0,
+		c.locksLockWaits,
+		c.locksNumberOfDeadlocks,
+		c.sqlErrorsTotal,
+		c.sqlStatsAutoParamAttempts,
+		c.sqlStatsBatchRequests,
+		c.sqlStatsFailedAutoParams,
+		c.sqlStatsForcedParameterizations,
+		c.sqlStatsGuidedplanexecutions,
+		c.sqlStatsMisguidedplanexecutions,
+		c.sqlStatsSafeAutoParams,
+		c.sqlStatsSQLAttentionrate,
+		c.sqlStatsSQLCompilations,
+		c.sqlStatsSQLReCompilations,
+		c.sqlStatsUnsafeAutoParams,
+		c.transactionsNonSnapshotVersionActiveTotal,
+		c.transactionsSnapshotActiveTotal,
+		c.transactionsUpdateConflictsTotal,
+		c.transactionsUpdateSnapshotActiveTotal,
+		c.transactionsVersionStoreUnits,
+		c.transactionsVersionStoreCreationUnits,
+		c.transactionsVersionStoreTruncationUnits,
+		c.waitStatsLockWaits,
+		c.waitStatsMemoryGrantQueueWaits,
+		c.waitStatsThreadSafeMemoryObjectsWaits,
+		c.waitStatsLogWriteWaits,
+		c.waitStatsLogBufferWaits,
+		c.waitStatsNetworkIOWaits,
+		c.waitStatsPageIOLatchWaits,
+		c.waitStatsPageLatchWaits,
+		c.waitStatsNonPageLatchWaits,
+		c.waitStatsWaitForTheWorkerWaits,
+		c.waitStatsWorkspaceSynchronizationWaits,
+		c.waitStatsTransactionOwnershipWaits,
+	)
 }
 
 // Collect sends the metric values for each metric
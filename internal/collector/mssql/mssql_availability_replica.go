@@ -70,62 +70,66 @@ func (c *Collector) buildAvailabilityReplica() error {
 	}
 
 	// Win32_PerfRawData_{instance}_SQLServerAvailabilityReplica
-	c.availReplicaBytesReceivedFromReplica = prometheus.NewDesc(
+	c.buildAvailabilityReplicaDescs()
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) buildAvailabilityReplicaDescs() {
+	c.availReplicaBytesReceivedFromReplica = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_received_from_replica_bytes"),
 		"(AvailabilityReplica.BytesReceivedfromReplica)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaBytesSentToReplica = prometheus.NewDesc(
+	c.availReplicaBytesSentToReplica = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_sent_to_replica_bytes"),
 		"(AvailabilityReplica.BytesSenttoReplica)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaBytesSentToTransport = prometheus.NewDesc(
+	c.availReplicaBytesSentToTransport = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_sent_to_transport_bytes"),
 		"(AvailabilityReplica.BytesSenttoTransport)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaFlowControl = prometheus.NewDesc(
+	c.availReplicaFlowControl = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_initiated_flow_controls"),
 		"(AvailabilityReplica.FlowControl)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaFlowControlTimeMS = prometheus.NewDesc(
+	c.availReplicaFlowControlTimeMS = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_flow_control_wait_seconds"),
 		"(AvailabilityReplica.FlowControlTimems)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaReceivesFromReplica = prometheus.NewDesc(
+	c.availReplicaReceivesFromReplica = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_receives_from_replica"),
 		"(AvailabilityReplica.ReceivesfromReplica)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaResentMessages = prometheus.NewDesc(
+	c.availReplicaResentMessages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_resent_messages"),
 		"(AvailabilityReplica.ResentMessages)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaSendsToReplica = prometheus.NewDesc(
+	c.availReplicaSendsToReplica = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_sends_to_replica"),
 		"(AvailabilityReplica.SendstoReplica)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.availReplicaSendsToTransport = prometheus.NewDesc(
+	c.availReplicaSendsToTransport = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availreplica_sends_to_transport"),
 		"(AvailabilityReplica.SendstoTransport)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-
-	return errors.Join(errs...)
 }
 
 func (c *Collector) collectAvailabilityReplica(ch chan<- prometheus.Metric) error {
@@ -94,146 +94,150 @@ func (c *Collector) buildBufferManager() error {
 		}
 	}
 
-	c.bufManBackgroundwriterpages = prometheus.NewDesc(
+	c.buildBufferManagerDescs()
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) buildBufferManagerDescs() {
+	c.bufManBackgroundwriterpages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_background_writer_pages"),
 		"(BufferManager.Backgroundwriterpages)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManBuffercachehits = prometheus.NewDesc(
+	c.bufManBuffercachehits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_buffer_cache_hits"),
 		"(BufferManager.Buffercachehitratio)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManBuffercachelookups = prometheus.NewDesc(
+	c.bufManBuffercachelookups = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_buffer_cache_lookups"),
 		"(BufferManager.Buffercachehitratio_Base)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManCheckpointpages = prometheus.NewDesc(
+	c.bufManCheckpointpages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_checkpoint_pages"),
 		"(BufferManager.Checkpointpages)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManDatabasepages = prometheus.NewDesc(
+	c.bufManDatabasepages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_database_pages"),
 		"(BufferManager.Databasepages)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensionallocatedpages = prometheus.NewDesc(
+	c.bufManExtensionallocatedpages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_allocated_pages"),
 		"(BufferManager.Extensionallocatedpages)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensionfreepages = prometheus.NewDesc(
+	c.bufManExtensionfreepages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_free_pages"),
 		"(BufferManager.Extensionfreepages)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensioninuseaspercentage = prometheus.NewDesc(
+	c.bufManExtensioninuseaspercentage = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_in_use_as_percentage"),
 		"(BufferManager.Extensioninuseaspercentage)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensionoutstandingIOcounter = prometheus.NewDesc(
+	c.bufManExtensionoutstandingIOcounter = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_outstanding_io"),
 		"(BufferManager.ExtensionoutstandingIOcounter)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensionpageevictions = prometheus.NewDesc(
+	c.bufManExtensionpageevictions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_page_evictions"),
 		"(BufferManager.Extensionpageevictions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensionpagereads = prometheus.NewDesc(
+	c.bufManExtensionpagereads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_page_reads"),
 		"(BufferManager.Extensionpagereads)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensionpageunreferencedtime = prometheus.NewDesc(
+	c.bufManExtensionpageunreferencedtime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_page_unreferenced_seconds"),
 		"(BufferManager.Extensionpageunreferencedtime)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManExtensionpagewrites = prometheus.NewDesc(
+	c.bufManExtensionpagewrites = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_extension_page_writes"),
 		"(BufferManager.Extensionpagewrites)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManFreeliststalls = prometheus.NewDesc(
+	c.bufManFreeliststalls = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_free_list_stalls"),
 		"(BufferManager.Freeliststalls)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManIntegralControllerSlope = prometheus.NewDesc(
+	c.bufManIntegralControllerSlope = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_integral_controller_slope"),
 		"(BufferManager.IntegralControllerSlope)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManLazywrites = prometheus.NewDesc(
+	c.bufManLazywrites = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_lazywrites"),
 		"(BufferManager.Lazywrites)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManPagelifeexpectancy = prometheus.NewDesc(
+	c.bufManPagelifeexpectancy = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_page_life_expectancy_seconds"),
 		"(BufferManager.Pagelifeexpectancy)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManPagelookups = prometheus.NewDesc(
+	c.bufManPagelookups = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_page_lookups"),
 		"(BufferManager.Pagelookups)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManPagereads = prometheus.NewDesc(
+	c.bufManPagereads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_page_reads"),
 		"(BufferManager.Pagereads)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManPagewrites = prometheus.NewDesc(
+	c.bufManPagewrites = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_page_writes"),
 		"(BufferManager.Pagewrites)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManReadaheadpages = prometheus.NewDesc(
+	c.bufManReadaheadpages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_read_ahead_pages"),
 		"(BufferManager.Readaheadpages)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManReadaheadtime = prometheus.NewDesc(
+	c.bufManReadaheadtime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_read_ahead_issuing_seconds"),
 		"(BufferManager.Readaheadtime)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.bufManTargetpages = prometheus.NewDesc(
+	c.bufManTargetpages = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bufman_target_pages"),
 		"(BufferManager.Targetpages)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-
-	return errors.Join(errs...)
 }
 
 func (c *Collector) collectBufferManager(ch chan<- prometheus.Metric) error {
@@ -161,296 +161,300 @@ func (c *Collector) buildDatabases() error {
 		}
 	}
 
-	c.databasesActiveParallelRedoThreads = prometheus.NewDesc(
+	c.buildDatabasesDescs()
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) buildDatabasesDescs() {
+	c.databasesActiveParallelRedoThreads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_active_parallel_redo_threads"),
 		"(Databases.ActiveParallelredothreads)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesActiveTransactions = prometheus.NewDesc(
+	c.databasesActiveTransactions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_active_transactions"),
 		"(Databases.ActiveTransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesBackupPerRestoreThroughput = prometheus.NewDesc(
+	c.databasesBackupPerRestoreThroughput = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_backup_restore_operations"),
 		"(Databases.BackupPerRestoreThroughput)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesBulkCopyRows = prometheus.NewDesc(
+	c.databasesBulkCopyRows = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_bulk_copy_rows"),
 		"(Databases.BulkCopyRows)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesBulkCopyThroughput = prometheus.NewDesc(
+	c.databasesBulkCopyThroughput = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_bulk_copy_bytes"),
 		"(Databases.BulkCopyThroughput)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesCommitTableEntries = prometheus.NewDesc(
+	c.databasesCommitTableEntries = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_commit_table_entries"),
 		"(Databases.Committableentries)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesDataFilesSizeKB = prometheus.NewDesc(
+	c.databasesDataFilesSizeKB = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_data_files_size_bytes"),
 		"(Databases.DataFilesSizeKB)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesDBCCLogicalScanBytes = prometheus.NewDesc(
+	c.databasesDBCCLogicalScanBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_dbcc_logical_scan_bytes"),
 		"(Databases.DBCCLogicalScanBytes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesGroupCommitTime = prometheus.NewDesc(
+	c.databasesGroupCommitTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_group_commit_stall_seconds"),
 		"(Databases.GroupCommitTime)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogBytesFlushed = prometheus.NewDesc(
+	c.databasesLogBytesFlushed = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flushed_bytes"),
 		"(Databases.LogBytesFlushed)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogCacheHits = prometheus.NewDesc(
+	c.databasesLogCacheHits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_cache_hits"),
 		"(Databases.LogCacheHitRatio)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogCacheLookups = prometheus.NewDesc(
+	c.databasesLogCacheLookups = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_cache_lookups"),
 		"(Databases.LogCacheHitRatio_Base)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogCacheReads = prometheus.NewDesc(
+	c.databasesLogCacheReads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_cache_reads"),
 		"(Databases.LogCacheReads)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFilesSizeKB = prometheus.NewDesc(
+	c.databasesLogFilesSizeKB = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_files_size_bytes"),
 		"(Databases.LogFilesSizeKB)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFilesUsedSizeKB = prometheus.NewDesc(
+	c.databasesLogFilesUsedSizeKB = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_files_used_size_bytes"),
 		"(Databases.LogFilesUsedSizeKB)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushes = prometheus.NewDesc(
+	c.databasesLogFlushes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flushes"),
 		"(Databases.LogFlushes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushWaits = prometheus.NewDesc(
+	c.databasesLogFlushWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flush_waits"),
 		"(Databases.LogFlushWaits)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushWaitTime = prometheus.NewDesc(
+	c.databasesLogFlushWaitTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flush_wait_seconds"),
 		"(Databases.LogFlushWaitTime)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushWriteTimeMS = prometheus.NewDesc(
+	c.databasesLogFlushWriteTimeMS = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flush_write_seconds"),
 		"(Databases.LogFlushWriteTimems)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogGrowths = prometheus.NewDesc(
+	c.databasesLogGrowths = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_growths"),
 		"(Databases.LogGrowths)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolCacheMisses = prometheus.NewDesc(
+	c.databasesLogPoolCacheMisses = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_cache_misses"),
 		"(Databases.LogPoolCacheMisses)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolDiskReads = prometheus.NewDesc(
+	c.databasesLogPoolDiskReads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_disk_reads"),
 		"(Databases.LogPoolDiskReads)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolHashDeletes = prometheus.NewDesc(
+	c.databasesLogPoolHashDeletes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_hash_deletes"),
 		"(Databases.LogPoolHashDeletes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolHashInserts = prometheus.NewDesc(
+	c.databasesLogPoolHashInserts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_hash_inserts"),
 		"(Databases.LogPoolHashInserts)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolInvalidHashEntry = prometheus.NewDesc(
+	c.databasesLogPoolInvalidHashEntry = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_invalid_hash_entries"),
 		"(Databases.LogPoolInvalidHashEntry)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolLogScanPushes = prometheus.NewDesc(
+	c.databasesLogPoolLogScanPushes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_log_scan_pushes"),
 		"(Databases.LogPoolLogScanPushes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolLogWriterPushes = prometheus.NewDesc(
+	c.databasesLogPoolLogWriterPushes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_log_writer_pushes"),
 		"(Databases.LogPoolLogWriterPushes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolPushEmptyFreePool = prometheus.NewDesc(
+	c.databasesLogPoolPushEmptyFreePool = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_empty_free_pool_pushes"),
 		"(Databases.LogPoolPushEmptyFreePool)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolPushLowMemory = prometheus.NewDesc(
+	c.databasesLogPoolPushLowMemory = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_low_memory_pushes"),
 		"(Databases.LogPoolPushLowMemory)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolPushNoFreeBuffer = prometheus.NewDesc(
+	c.databasesLogPoolPushNoFreeBuffer = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_no_free_buffer_pushes"),
 		"(Databases.LogPoolPushNoFreeBuffer)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolReqBehindTrunc = prometheus.NewDesc(
+	c.databasesLogPoolReqBehindTrunc = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_req_behind_trunc"),
 		"(Databases.LogPoolReqBehindTrunc)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolRequestsOldVLF = prometheus.NewDesc(
+	c.databasesLogPoolRequestsOldVLF = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_requests_old_vlf"),
 		"(Databases.LogPoolRequestsOldVLF)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolRequests = prometheus.NewDesc(
+	c.databasesLogPoolRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_requests"),
 		"(Databases.LogPoolRequests)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolTotalActiveLogSize = prometheus.NewDesc(
+	c.databasesLogPoolTotalActiveLogSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_total_active_log_bytes"),
 		"(Databases.LogPoolTotalActiveLogSize)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolTotalSharedPoolSize = prometheus.NewDesc(
+	c.databasesLogPoolTotalSharedPoolSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_total_shared_pool_bytes"),
 		"(Databases.LogPoolTotalSharedPoolSize)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogShrinks = prometheus.NewDesc(
+	c.databasesLogShrinks = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_shrinks"),
 		"(Databases.LogShrinks)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogTruncations = prometheus.NewDesc(
+	c.databasesLogTruncations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_truncations"),
 		"(Databases.LogTruncations)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesPercentLogUsed = prometheus.NewDesc(
+	c.databasesPercentLogUsed = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_used_percent"),
 		"(Databases.PercentLogUsed)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesReplPendingXacts = prometheus.NewDesc(
+	c.databasesReplPendingXacts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_pending_repl_transactions"),
 		"(Databases.ReplPendingTransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesReplTransRate = prometheus.NewDesc(
+	c.databasesReplTransRate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_repl_transactions"),
 		"(Databases.ReplTranactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesShrinkDataMovementBytes = prometheus.NewDesc(
+	c.databasesShrinkDataMovementBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_shrink_data_movement_bytes"),
 		"(Databases.ShrinkDataMovementBytes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesTrackedTransactions = prometheus.NewDesc(
+	c.databasesTrackedTransactions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_tracked_transactions"),
 		"(Databases.Trackedtransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesTransactions = prometheus.NewDesc(
+	c.databasesTransactions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_transactions"),
 		"(Databases.Transactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesWriteTransactions = prometheus.NewDesc(
+	c.databasesWriteTransactions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_write_transactions"),
 		"(Databases.WriteTransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPControllerDLCLatencyPerFetch = prometheus.NewDesc(
+	c.databasesXTPControllerDLCLatencyPerFetch = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_controller_dlc_fetch_latency_seconds"),
 		"(Databases.XTPControllerDLCLatencyPerFetch)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPControllerDLCPeakLatency = prometheus.NewDesc(
+	c.databasesXTPControllerDLCPeakLatency = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_controller_dlc_peak_latency_seconds"),
 		"(Databases.XTPControllerDLCPeakLatency)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPControllerLogProcessed = prometheus.NewDesc(
+	c.databasesXTPControllerLogProcessed = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_controller_log_processed_bytes"),
 		"(Databases.XTPControllerLogProcessed)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPMemoryUsedKB = prometheus.NewDesc(
+	c.databasesXTPMemoryUsedKB = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_memory_used_bytes"),
 		"(Databases.XTPMemoryUsedKB)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-
-	return errors.Join(errs...)
 }
 
 func (c *Collector) collectDatabases(ch chan<- prometheus.Metric) error {
@@ -99,152 +99,156 @@ func (c *Collector) buildDatabaseReplica() error {
 	}
 
 	// Win32_PerfRawData_{instance}_SQLServerDatabaseReplica
-	c.dbReplicaDatabaseFlowControlDelay = prometheus.NewDesc(
+	c.buildDatabaseReplicaDescs()
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) buildDatabaseReplicaDescs() {
+	c.dbReplicaDatabaseFlowControlDelay = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_database_flow_control_wait_seconds"),
 		"(DatabaseReplica.DatabaseFlowControlDelay)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaDatabaseFlowControls = prometheus.NewDesc(
+	c.dbReplicaDatabaseFlowControls = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_database_initiated_flow_controls"),
 		"(DatabaseReplica.DatabaseFlowControls)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaFileBytesReceived = prometheus.NewDesc(
+	c.dbReplicaFileBytesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_received_file_bytes"),
 		"(DatabaseReplica.FileBytesReceived)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaGroupCommits = prometheus.NewDesc(
+	c.dbReplicaGroupCommits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_group_commits"),
 		"(DatabaseReplica.GroupCommits)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaGroupCommitTime = prometheus.NewDesc(
+	c.dbReplicaGroupCommitTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_group_commit_stall_seconds"),
 		"(DatabaseReplica.GroupCommitTime)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogApplyPendingQueue = prometheus.NewDesc(
+	c.dbReplicaLogApplyPendingQueue = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_apply_pending_queue"),
 		"(DatabaseReplica.LogApplyPendingQueue)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogApplyReadyQueue = prometheus.NewDesc(
+	c.dbReplicaLogApplyReadyQueue = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_apply_ready_queue"),
 		"(DatabaseReplica.LogApplyReadyQueue)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogBytesCompressed = prometheus.NewDesc(
+	c.dbReplicaLogBytesCompressed = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_compressed_bytes"),
 		"(DatabaseReplica.LogBytesCompressed)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogBytesDecompressed = prometheus.NewDesc(
+	c.dbReplicaLogBytesDecompressed = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_decompressed_bytes"),
 		"(DatabaseReplica.LogBytesDecompressed)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogBytesReceived = prometheus.NewDesc(
+	c.dbReplicaLogBytesReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_received_bytes"),
 		"(DatabaseReplica.LogBytesReceived)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogCompressionCachehits = prometheus.NewDesc(
+	c.dbReplicaLogCompressionCachehits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_compression_cachehits"),
 		"(DatabaseReplica.LogCompressionCachehits)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogCompressionCachemisses = prometheus.NewDesc(
+	c.dbReplicaLogCompressionCachemisses = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_compression_cachemisses"),
 		"(DatabaseReplica.LogCompressionCachemisses)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogCompressions = prometheus.NewDesc(
+	c.dbReplicaLogCompressions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_compressions"),
 		"(DatabaseReplica.LogCompressions)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogDecompressions = prometheus.NewDesc(
+	c.dbReplicaLogDecompressions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_decompressions"),
 		"(DatabaseReplica.LogDecompressions)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogremainingforundo = prometheus.NewDesc(
+	c.dbReplicaLogremainingforundo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_remaining_for_undo"),
 		"(DatabaseReplica.Logremainingforundo)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaLogSendQueue = prometheus.NewDesc(
+	c.dbReplicaLogSendQueue = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_log_send_queue"),
 		"(DatabaseReplica.LogSendQueue)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaMirroredWritetransactions = prometheus.NewDesc(
+	c.dbReplicaMirroredWritetransactions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_mirrored_write_transactions"),
 		"(DatabaseReplica.MirroredWriteTransactions)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaRecoveryQueue = prometheus.NewDesc(
+	c.dbReplicaRecoveryQueue = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_recovery_queue_records"),
 		"(DatabaseReplica.RecoveryQueue)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaRedoblocked = prometheus.NewDesc(
+	c.dbReplicaRedoblocked = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_redo_blocks"),
 		"(DatabaseReplica.Redoblocked)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaRedoBytesRemaining = prometheus.NewDesc(
+	c.dbReplicaRedoBytesRemaining = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_redo_remaining_bytes"),
 		"(DatabaseReplica.RedoBytesRemaining)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaRedoneBytes = prometheus.NewDesc(
+	c.dbReplicaRedoneBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_redone_bytes"),
 		"(DatabaseReplica.RedoneBytes)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaRedones = prometheus.NewDesc(
+	c.dbReplicaRedones = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_redones"),
 		"(DatabaseReplica.Redones)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaTotalLogrequiringundo = prometheus.NewDesc(
+	c.dbReplicaTotalLogrequiringundo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_total_log_requiring_undo"),
 		"(DatabaseReplica.TotalLogrequiringundo)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-	c.dbReplicaTransactionDelay = prometheus.NewDesc(
+	c.dbReplicaTransactionDelay = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dbreplica_transaction_delay_seconds"),
 		"(DatabaseReplica.TransactionDelay)",
 		[]string{"mssql_instance", "replica"},
 		nil,
 	)
-
-	return errors.Join(errs...)
 }
 
 func (c *Collector) collectDatabaseReplica(ch chan<- prometheus.Metric) error {
@@ -54,14 +54,18 @@ func (c *Collector) buildSQLErrors() error {
 	}
 
 	// Win32_PerfRawData_{instance}_SQLServerSQLErrors
-	c.sqlErrorsTotal = prometheus.NewDesc(
+	c.buildSQLErrorsDescs()
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) buildSQLErrorsDescs() {
+	c.sqlErrorsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sql_errors_total"),
 		"(SQLErrors.Total)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-
-	return errors.Join(errs...)
 }
 
 func (c *Collector) collectSQLErrors(ch chan<- prometheus.Metric) error {
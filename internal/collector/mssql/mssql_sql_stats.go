@@ -70,74 +70,78 @@ func (c *Collector) buildSQLStats() error {
 		}
 	}
 
-	c.sqlStatsAutoParamAttempts = prometheus.NewDesc(
+	c.buildSQLStatsDescs()
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) buildSQLStatsDescs() {
+	c.sqlStatsAutoParamAttempts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_auto_parameterization_attempts"),
 		"(SQLStatistics.AutoParamAttempts)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsBatchRequests = prometheus.NewDesc(
+	c.sqlStatsBatchRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_batch_requests"),
 		"(SQLStatistics.BatchRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsFailedAutoParams = prometheus.NewDesc(
+	c.sqlStatsFailedAutoParams = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_failed_auto_parameterization_attempts"),
 		"(SQLStatistics.FailedAutoParams)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsForcedParameterizations = prometheus.NewDesc(
+	c.sqlStatsForcedParameterizations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_forced_parameterizations"),
 		"(SQLStatistics.ForcedParameterizations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsGuidedplanexecutions = prometheus.NewDesc(
+	c.sqlStatsGuidedplanexecutions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_guided_plan_executions"),
 		"(SQLStatistics.Guidedplanexecutions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsMisguidedplanexecutions = prometheus.NewDesc(
+	c.sqlStatsMisguidedplanexecutions = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_misguided_plan_executions"),
 		"(SQLStatistics.Misguidedplanexecutions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSafeAutoParams = prometheus.NewDesc(
+	c.sqlStatsSafeAutoParams = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_safe_auto_parameterization_attempts"),
 		"(SQLStatistics.SafeAutoParams)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSQLAttentionrate = prometheus.NewDesc(
+	c.sqlStatsSQLAttentionrate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_sql_attentions"),
 		"(SQLStatistics.SQLAttentions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSQLCompilations = prometheus.NewDesc(
+	c.sqlStatsSQLCompilations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_sql_compilations"),
 		"(SQLStatistics.SQLCompilations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSQLReCompilations = prometheus.NewDesc(
+	c.sqlStatsSQLReCompilations = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_sql_recompilations"),
 		"(SQLStatistics.SQLReCompilations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsUnsafeAutoParams = prometheus.NewDesc(
+	c.sqlStatsUnsafeAutoParams = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_unsafe_auto_parameterization_attempts"),
 		"(SQLStatistics.UnsafeAutoParams)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-
-	return errors.Join(errs...)
 }
 
 func (c *Collector) collectSQLStats(ch chan<- prometheus.Metric) error {
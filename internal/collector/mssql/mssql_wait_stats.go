@@ -74,81 +74,85 @@ func (c *Collector) buildWaitStats() error {
 		}
 	}
 
-	c.waitStatsLockWaits = prometheus.NewDesc(
+	c.buildWaitStatsDescs()
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) buildWaitStatsDescs() {
+	c.waitStatsLockWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_lock_waits"),
 		"(WaitStats.LockWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
 
-	c.waitStatsMemoryGrantQueueWaits = prometheus.NewDesc(
+	c.waitStatsMemoryGrantQueueWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_memory_grant_queue_waits"),
 		"(WaitStats.MemoryGrantQueueWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsThreadSafeMemoryObjectsWaits = prometheus.NewDesc(
+	c.waitStatsThreadSafeMemoryObjectsWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_thread_safe_memory_objects_waits"),
 		"(WaitStats.ThreadSafeMemoryObjectsWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsLogWriteWaits = prometheus.NewDesc(
+	c.waitStatsLogWriteWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_log_write_waits"),
 		"(WaitStats.LogWriteWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsLogBufferWaits = prometheus.NewDesc(
+	c.waitStatsLogBufferWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_log_buffer_waits"),
 		"(WaitStats.LogBufferWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsNetworkIOWaits = prometheus.NewDesc(
+	c.waitStatsNetworkIOWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_network_io_waits"),
 		"(WaitStats.NetworkIOWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsPageIOLatchWaits = prometheus.NewDesc(
+	c.waitStatsPageIOLatchWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_page_io_latch_waits"),
 		"(WaitStats.PageIOLatchWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsPageLatchWaits = prometheus.NewDesc(
+	c.waitStatsPageLatchWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_page_latch_waits"),
 		"(WaitStats.PageLatchWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsNonPageLatchWaits = prometheus.NewDesc(
+	c.waitStatsNonPageLatchWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_nonpage_latch_waits"),
 		"(WaitStats.NonpageLatchWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsWaitForTheWorkerWaits = prometheus.NewDesc(
+	c.waitStatsWaitForTheWorkerWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_wait_for_the_worker_waits"),
 		"(WaitStats.WaitForTheWorkerWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsWorkspaceSynchronizationWaits = prometheus.NewDesc(
+	c.waitStatsWorkspaceSynchronizationWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_workspace_synchronization_waits"),
 		"(WaitStats.WorkspaceSynchronizationWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-	c.waitStatsTransactionOwnershipWaits = prometheus.NewDesc(
+	c.waitStatsTransactionOwnershipWaits = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "waitstats_transaction_ownership_waits"),
 		"(WaitStats.TransactionOwnershipWaits)",
 		[]string{"mssql_instance", "item"},
 		nil,
 	)
-
-	return errors.Join(errs...)
 }
 
 func (c *Collector) collectWaitStats(ch chan<- prometheus.Metric) error {
@@ -30,6 +30,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows"
@@ -43,9 +44,10 @@ const (
 )
 
 type Config struct {
-	NicExclude        *regexp.Regexp `yaml:"nic-exclude"`
-	NicInclude        *regexp.Regexp `yaml:"nic-include"`
-	CollectorsEnabled []string       `yaml:"enabled"`
+	NicExclude        *regexp.Regexp   `yaml:"nic-exclude"`
+	NicInclude        *regexp.Regexp   `yaml:"nic-include"`
+	CollectorsEnabled []string         `yaml:"enabled"`
+	CounterBackend    perfdata.Backend `yaml:"counter-backend"`
 }
 
 //nolint:gochecknoglobals
@@ -56,13 +58,14 @@ var ConfigDefaults = Config{
 		subCollectorMetrics,
 		subCollectorNicInfo,
 	},
+	CounterBackend: perfdata.BackendPDH,
 }
 
 // A Collector is a Prometheus Collector for Perflib Network Interface metrics.
 type Collector struct {
 	config Config
 
-	perfDataCollector *pdh.Collector
+	perfDataCollector *perfdata.Collector
 	perfDataObject    []perfDataCounterValues
 
 	bytesReceivedTotal       *prometheus.Desc
@@ -102,6 +105,10 @@ func New(config *Config) *Collector {
 		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
 	}
 
+	if config.CounterBackend == "" {
+		config.CounterBackend = ConfigDefaults.CounterBackend
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -134,6 +141,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Comma-separated list of collectors to use. Defaults to all, if not specified.",
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.net.counter-backend",
+		"API used to read performance counters. One of: pdh, registry.",
+	).Default(string(ConfigDefaults.CounterBackend)).EnumVar((*string)(&c.config.CounterBackend), perfdata.Backends...)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
@@ -174,123 +186,152 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		}
 	}
 
-	c.bytesReceivedTotal = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = perfdata.NewCollector[perfDataCounterValues](c.config.CounterBackend, pdh.CounterTypeRaw, "Network Interface", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create Network Interface collector: %w", err)
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorNicInfo) {
+		logger.Info("nic/addresses collector is in an experimental state! The configuration and metrics may change in future. Please report any issues.",
+			slog.String("collector", Name),
+		)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.bytesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_received_total"),
 		"(Network.BytesReceivedPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.bytesSentTotal = prometheus.NewDesc(
+	c.bytesSentTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_sent_total"),
 		"(Network.BytesSentPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.bytesTotal = prometheus.NewDesc(
+	c.bytesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_total"),
 		"(Network.BytesTotalPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.outputQueueLength = prometheus.NewDesc(
+	c.outputQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "output_queue_length_packets"),
 		"(Network.OutputQueueLength)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsOutboundDiscarded = prometheus.NewDesc(
+	c.packetsOutboundDiscarded = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_outbound_discarded_total"),
 		"(Network.PacketsOutboundDiscarded)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsOutboundErrors = prometheus.NewDesc(
+	c.packetsOutboundErrors = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_outbound_errors_total"),
 		"(Network.PacketsOutboundErrors)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedDiscarded = prometheus.NewDesc(
+	c.packetsReceivedDiscarded = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_discarded_total"),
 		"(Network.PacketsReceivedDiscarded)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedErrors = prometheus.NewDesc(
+	c.packetsReceivedErrors = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_errors_total"),
 		"(Network.PacketsReceivedErrors)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedTotal = prometheus.NewDesc(
+	c.packetsReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_total"),
 		"(Network.PacketsReceivedPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedUnknown = prometheus.NewDesc(
+	c.packetsReceivedUnknown = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_unknown_total"),
 		"(Network.PacketsReceivedUnknown)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsTotal = prometheus.NewDesc(
+	c.packetsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_total"),
 		"(Network.PacketsPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsSentTotal = prometheus.NewDesc(
+	c.packetsSentTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_sent_total"),
 		"(Network.PacketsSentPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.currentBandwidth = prometheus.NewDesc(
+	c.currentBandwidth = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_bandwidth_bytes"),
 		"(Network.CurrentBandwidth)",
 		[]string{"nic"},
 		nil,
 	)
-	c.nicIPAddressInfo = prometheus.NewDesc(
+	c.nicIPAddressInfo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_address_info"),
 		"A metric with a constant '1' value labeled with the network interface's address information.",
 		[]string{"nic", "address", "family"},
 		nil,
 	)
-	c.nicOperStatus = prometheus.NewDesc(
+	c.nicOperStatus = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_operation_status"),
 		"The operational status for the interface as defined in RFC 2863 as IfOperStatus.",
 		[]string{"nic", "status"},
 		nil,
 	)
-	c.nicInfo = prometheus.NewDesc(
+	c.nicInfo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_info"),
 		"A metric with a constant '1' value labeled with the network interface's general information.",
 		[]string{"nic", "friendly_name", "mac"},
 		nil,
 	)
-	c.routeInfo = prometheus.NewDesc(
+	c.routeInfo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "route_info"),
 		"A metric with a constant '1' value labeled with the network interface's route information.",
 		[]string{"nic", "src", "dest", "metric"},
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Network Interface", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create Network Interface collector: %w", err)
-	}
-
-	if slices.Contains(c.config.CollectorsEnabled, subCollectorNicInfo) {
-		logger.Info("nic/addresses collector is in an experimental state! The configuration and metrics may change in future. Please report any issues.",
-			slog.String("collector", Name),
-		)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.bytesReceivedTotal,
+		c.bytesSentTotal,
+		c.bytesTotal,
+		c.packetsOutboundDiscarded,
+		c.packetsOutboundErrors,
+		c.packetsTotal,
+		c.packetsReceivedDiscarded,
+		c.packetsReceivedErrors,
+		c.packetsReceivedTotal,
+		c.packetsReceivedUnknown,
+		c.packetsSentTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.outputQueueLength,
+		c.currentBandwidth,
+		c.nicIPAddressInfo,
+		c.nicOperStatus,
+		c.nicInfo,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -21,9 +21,16 @@ import (
 	"testing"
 
 	"github.com/prometheus-community/windows_exporter/internal/collector/net"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
 )
 
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, net.New, nil)
 }
+
+func TestCollectorRegistryBackend(t *testing.T) {
+	testutils.TestCollector(t, net.New, &net.Config{
+		CounterBackend: perfdata.BackendRegistry,
+	})
+}
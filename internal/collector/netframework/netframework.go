@@ -27,6 +27,7 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -244,6 +245,75 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 	return nil
 }
 
+// BuildDescs creates the descriptors of the metrics of all sub-collectors without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.buildClrExceptions()
+	c.buildClrInterop()
+	c.buildClrJIT()
+	c.buildClrLoading()
+	c.buildClrLocksAndThreads()
+	c.buildClrMemory()
+	c.buildClrRemoting()
+	c.buildClrSecurity()
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.numberOfExceptionsThrown,
+		c.numberOfFilters,
+		c.numberOfFinally,
+		c.throwToCatchDepth,
+		c.numberOfCCWs,
+		c.numberOfMarshalling,
+		c.numberOfStubs,
+		c.numberOfMethodsJitted,
+		c.totalNumberOfILBytesJitted,
+		c.totalAppDomains,
+		c.totalAppDomainsUnloaded,
+		c.totalAssemblies,
+		c.totalClassesLoaded,
+		c.totalNumberOfLoadFailures,
+		c.numberOfTotalRecognizedThreads,
+		c.queueLengthPeak,
+		c.totalNumberOfContentions,
+		c.allocatedBytes,
+		c.numberCollections,
+		c.numberInducedGC,
+		c.channels,
+		c.contextBoundObjects,
+		c.contextProxies,
+		c.totalRemoteCalls,
+		c.numberLinkTimeChecks,
+		c.totalRuntimeChecks,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.timeInJit,
+		c.standardJitFailures,
+		c.bytesInLoaderHeap,
+		c.currentAppDomains,
+		c.currentAssemblies,
+		c.currentClassesLoaded,
+		c.currentQueueLength,
+		c.numberOfCurrentLogicalThreads,
+		c.numberOfCurrentPhysicalThreads,
+		c.numberOfCurrentRecognizedThreads,
+		c.finalizationSurvivors,
+		c.heapSize,
+		c.promotedBytes,
+		c.numberGCHandles,
+		c.numberOfPinnedObjects,
+		c.numberOfSinkBlocksInUse,
+		c.numberTotalCommittedBytes,
+		c.numberTotalReservedBytes,
+		c.timeInGC,
+		c.contextBoundClassesLoaded,
+		c.contexts,
+		c.timeInRTChecks,
+		c.stackWalkDepth,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
@@ -27,25 +27,25 @@ import (
 )
 
 func (c *Collector) buildClrJIT() {
-	c.numberOfMethodsJitted = prometheus.NewDesc(
+	c.numberOfMethodsJitted = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_methods_total"),
 		"Displays the total number of methods JIT-compiled since the application started. This counter does not include pre-JIT-compiled methods.",
 		[]string{"process"},
 		nil,
 	)
-	c.timeInJit = prometheus.NewDesc(
+	c.timeInJit = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_time_percent"),
 		"Displays the percentage of time spent in JIT compilation. This counter is updated at the end of every JIT compilation phase. A JIT compilation phase occurs when a method and its dependencies are compiled.",
 		[]string{"process"},
 		nil,
 	)
-	c.standardJitFailures = prometheus.NewDesc(
+	c.standardJitFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_standard_failures_total"),
 		"Displays the peak number of methods the JIT compiler has failed to compile since the application started. This failure can occur if the MSIL cannot be verified or if there is an internal error in the JIT compiler.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalNumberOfILBytesJitted = prometheus.NewDesc(
+	c.totalNumberOfILBytesJitted = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_il_bytes_total"),
 		"Displays the total number of Microsoft intermediate language (MSIL) bytes compiled by the just-in-time (JIT) compiler since the application started",
 		[]string{"process"},
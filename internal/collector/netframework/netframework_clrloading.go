@@ -27,55 +27,55 @@ import (
 )
 
 func (c *Collector) buildClrLoading() {
-	c.bytesInLoaderHeap = prometheus.NewDesc(
+	c.bytesInLoaderHeap = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_loader_heap_size_bytes"),
 		"Displays the current size, in bytes, of the memory committed by the class loader across all application domains. Committed memory is the physical space reserved in the disk paging file.",
 		[]string{"process"},
 		nil,
 	)
-	c.currentAppDomains = prometheus.NewDesc(
+	c.currentAppDomains = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_appdomains_loaded_current"),
 		"Displays the current number of application domains loaded in this application.",
 		[]string{"process"},
 		nil,
 	)
-	c.currentAssemblies = prometheus.NewDesc(
+	c.currentAssemblies = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_assemblies_loaded_current"),
 		"Displays the current number of assemblies loaded across all application domains in the currently running application. If the assembly is loaded as domain-neutral from multiple application domains, this counter is incremented only once.",
 		[]string{"process"},
 		nil,
 	)
-	c.currentClassesLoaded = prometheus.NewDesc(
+	c.currentClassesLoaded = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_classes_loaded_current"),
 		"Displays the current number of classes loaded in all assemblies.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalAppDomains = prometheus.NewDesc(
+	c.totalAppDomains = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_appdomains_loaded_total"),
 		"Displays the peak number of application domains loaded since the application started.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalAppDomainsUnloaded = prometheus.NewDesc(
+	c.totalAppDomainsUnloaded = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_appdomains_unloaded_total"),
 		"Displays the total number of application domains unloaded since the application started. If an application domain is loaded and unloaded multiple times, this counter increments each time the application domain is unloaded.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalAssemblies = prometheus.NewDesc(
+	c.totalAssemblies = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_assemblies_loaded_total"),
 		"Displays the total number of assemblies loaded since the application started. If the assembly is loaded as domain-neutral from multiple application domains, this counter is incremented only once.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalClassesLoaded = prometheus.NewDesc(
+	c.totalClassesLoaded = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_classes_loaded_total"),
 		"Displays the cumulative number of classes loaded in all assemblies since the application started.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalNumberOfLoadFailures = prometheus.NewDesc(
+	c.totalNumberOfLoadFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_class_load_failures_total"),
 		"Displays the peak number of classes that have failed to load since the application started.",
 		[]string{"process"},
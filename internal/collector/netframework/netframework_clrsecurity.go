@@ -27,25 +27,25 @@ import (
 )
 
 func (c *Collector) buildClrSecurity() {
-	c.numberLinkTimeChecks = prometheus.NewDesc(
+	c.numberLinkTimeChecks = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrSecurity+"_link_time_checks_total"),
 		"Displays the total number of link-time code access security checks since the application started.",
 		[]string{"process"},
 		nil,
 	)
-	c.timeInRTChecks = prometheus.NewDesc(
+	c.timeInRTChecks = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrSecurity+"_rt_checks_time_percent"),
 		"Displays the percentage of time spent performing runtime code access security checks in the last sample.",
 		[]string{"process"},
 		nil,
 	)
-	c.stackWalkDepth = prometheus.NewDesc(
+	c.stackWalkDepth = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrSecurity+"_stack_walk_depth"),
 		"Displays the depth of the stack during that last runtime code access security check.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalRuntimeChecks = prometheus.NewDesc(
+	c.totalRuntimeChecks = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrSecurity+"_runtime_checks_total"),
 		"Displays the total number of runtime code access security checks performed since the application started.",
 		[]string{"process"},
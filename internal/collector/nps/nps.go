@@ -96,173 +96,209 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.accessAccepts = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	errs := make([]error, 0)
+
+	c.accessPerfDataCollector, err = pdh.NewCollector[perfDataCounterValuesAccess](pdh.CounterTypeRaw, "NPS Authentication Server", nil)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create NPS Authentication Server collector: %w", err))
+	}
+
+	c.accountingPerfDataCollector, err = pdh.NewCollector[perfDataCounterValuesAccounting](pdh.CounterTypeRaw, "NPS Accounting Server", nil)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create NPS Accounting Server collector: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.accessAccepts = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_accepts"),
 		"(AccessAccepts)",
 		nil,
 		nil,
 	)
-	c.accessChallenges = prometheus.NewDesc(
+	c.accessChallenges = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_challenges"),
 		"(AccessChallenges)",
 		nil,
 		nil,
 	)
-	c.accessRejects = prometheus.NewDesc(
+	c.accessRejects = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_rejects"),
 		"(AccessRejects)",
 		nil,
 		nil,
 	)
-	c.accessRequests = prometheus.NewDesc(
+	c.accessRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_requests"),
 		"(AccessRequests)",
 		nil,
 		nil,
 	)
-	c.accessBadAuthenticators = prometheus.NewDesc(
+	c.accessBadAuthenticators = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_bad_authenticators"),
 		"(BadAuthenticators)",
 		nil,
 		nil,
 	)
-	c.accessDroppedPackets = prometheus.NewDesc(
+	c.accessDroppedPackets = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_dropped_packets"),
 		"(DroppedPackets)",
 		nil,
 		nil,
 	)
-	c.accessInvalidRequests = prometheus.NewDesc(
+	c.accessInvalidRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_invalid_requests"),
 		"(InvalidRequests)",
 		nil,
 		nil,
 	)
-	c.accessMalformedPackets = prometheus.NewDesc(
+	c.accessMalformedPackets = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_malformed_packets"),
 		"(MalformedPackets)",
 		nil,
 		nil,
 	)
-	c.accessPacketsReceived = prometheus.NewDesc(
+	c.accessPacketsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_packets_received"),
 		"(PacketsReceived)",
 		nil,
 		nil,
 	)
-	c.accessPacketsSent = prometheus.NewDesc(
+	c.accessPacketsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_packets_sent"),
 		"(PacketsSent)",
 		nil,
 		nil,
 	)
-	c.accessServerResetTime = prometheus.NewDesc(
+	c.accessServerResetTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_server_reset_time"),
 		"(ServerResetTime)",
 		nil,
 		nil,
 	)
-	c.accessServerUpTime = prometheus.NewDesc(
+	c.accessServerUpTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_server_up_time"),
 		"(ServerUpTime)",
 		nil,
 		nil,
 	)
-	c.accessUnknownType = prometheus.NewDesc(
+	c.accessUnknownType = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_unknown_type"),
 		"(UnknownType)",
 		nil,
 		nil,
 	)
 
-	c.accountingRequests = prometheus.NewDesc(
+	c.accountingRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_requests"),
 		"(AccountingRequests)",
 		nil,
 		nil,
 	)
-	c.accountingResponses = prometheus.NewDesc(
+	c.accountingResponses = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_responses"),
 		"(AccountingResponses)",
 		nil,
 		nil,
 	)
-	c.accountingBadAuthenticators = prometheus.NewDesc(
+	c.accountingBadAuthenticators = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_bad_authenticators"),
 		"(BadAuthenticators)",
 		nil,
 		nil,
 	)
-	c.accountingDroppedPackets = prometheus.NewDesc(
+	c.accountingDroppedPackets = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_dropped_packets"),
 		"(DroppedPackets)",
 		nil,
 		nil,
 	)
-	c.accountingInvalidRequests = prometheus.NewDesc(
+	c.accountingInvalidRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_invalid_requests"),
 		"(InvalidRequests)",
 		nil,
 		nil,
 	)
-	c.accountingMalformedPackets = prometheus.NewDesc(
+	c.accountingMalformedPackets = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_malformed_packets"),
 		"(MalformedPackets)",
 		nil,
 		nil,
 	)
-	c.accountingNoRecord = prometheus.NewDesc(
+	c.accountingNoRecord = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_no_record"),
 		"(NoRecord)",
 		nil,
 		nil,
 	)
-	c.accountingPacketsReceived = prometheus.NewDesc(
+	c.accountingPacketsReceived = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_packets_received"),
 		"(PacketsReceived)",
 		nil,
 		nil,
 	)
-	c.accountingPacketsSent = prometheus.NewDesc(
+	c.accountingPacketsSent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_packets_sent"),
 		"(PacketsSent)",
 		nil,
 		nil,
 	)
-	c.accountingServerResetTime = prometheus.NewDesc(
+	c.accountingServerResetTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_server_reset_time"),
 		"(ServerResetTime)",
 		nil,
 		nil,
 	)
-	c.accountingServerUpTime = prometheus.NewDesc(
+	c.accountingServerUpTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_server_up_time"),
 		"(ServerUpTime)",
 		nil,
 		nil,
 	)
-	c.accountingUnknownType = prometheus.NewDesc(
+	c.accountingUnknownType = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_unknown_type"),
 		"(UnknownType)",
 		nil,
 		nil,
 	)
+}
 
-	var err error
-
-	errs := make([]error, 0)
-
-	c.accessPerfDataCollector, err = pdh.NewCollector[perfDataCounterValuesAccess](pdh.CounterTypeRaw, "NPS Authentication Server", nil)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create NPS Authentication Server collector: %w", err))
-	}
-
-	c.accountingPerfDataCollector, err = pdh.NewCollector[perfDataCounterValuesAccounting](pdh.CounterTypeRaw, "NPS Accounting Server", nil)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create NPS Accounting Server collector: %w", err))
-	}
-
-	return errors.Join(errs...)
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.accessAccepts,
+		c.accessChallenges,
+		c.accessRejects,
+		c.accessRequests,
+		c.accessBadAuthenticators,
+		c.accessDroppedPackets,
+		c.accessInvalidRequests,
+		c.accessMalformedPackets,
+		c.accessPacketsReceived,
+		c.accessPacketsSent,
+		c.accessServerResetTime,
+		c.accessServerUpTime,
+		c.accessUnknownType,
+		c.accountingRequests,
+		c.accountingResponses,
+		c.accountingBadAuthenticators,
+		c.accountingDroppedPackets,
+		c.accountingInvalidRequests,
+		c.accountingMalformedPackets,
+		c.accountingNoRecord,
+		c.accountingPacketsReceived,
+		c.accountingPacketsSent,
+		c.accountingServerResetTime,
+		c.accountingServerUpTime,
+		c.accountingUnknownType,
+	)
 }
 
 // Collect sends the metric values for each metric
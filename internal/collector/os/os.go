@@ -126,21 +126,35 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		productName = strings.Replace(productName, " 10 ", " 11 ", 1)
 	}
 
-	c.osInformation = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "info"),
-		`Contains full product name & version in labels. Note that the "major_version" for Windows 11 is \"10\"; a build number greater than 22000 represents Windows 11.`,
-		nil,
-		prometheus.Labels{
-			"product":       productName,
-			"version":       version.String(),
-			"major_version": strconv.FormatUint(uint64(version.MajorVersion), 10),
-			"minor_version": strconv.FormatUint(uint64(version.MinorVersion), 10),
-			"build_number":  strconv.FormatUint(uint64(version.Build), 10),
-			"revision":      revision,
-		},
-	)
+	c.BuildDescs()
+	c.buildInfoDesc(osInfo{
+		productName:  productName,
+		version:      version.String(),
+		majorVersion: strconv.FormatUint(uint64(version.MajorVersion), 10),
+		minorVersion: strconv.FormatUint(uint64(version.MinorVersion), 10),
+		buildNumber:  strconv.FormatUint(uint64(version.Build), 10),
+		revision:     revision,
+	})
+
+	return nil
+}
 
-	c.hostname = prometheus.NewDesc(
+// osInfo holds the values of the labels of the windows_os_info metric.
+type osInfo struct {
+	productName  string
+	version      string
+	majorVersion string
+	minorVersion string
+	buildNumber  string
+	revision     string
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+// The labels of windows_os_info are left empty, Build replaces the descriptor with the values of the host.
+func (c *Collector) BuildDescs() {
+	c.buildInfoDesc(osInfo{})
+
+	c.hostname = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hostname"),
 		"Labelled system hostname information as provided by ComputerSystem.DNSHostName and ComputerSystem.Domain",
 		[]string{
@@ -150,63 +164,94 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		},
 		nil,
 	)
-	c.physicalMemoryFreeBytes = prometheus.NewDesc(
+	c.physicalMemoryFreeBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "physical_memory_free_bytes"),
 		"Deprecated: Use `windows_memory_physical_free_bytes` instead.",
 		nil,
 		nil,
 	)
-	c.time = prometheus.NewDesc(
+	c.time = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "time"),
 		"Deprecated: Use windows_time_current_timestamp_seconds instead.",
 		nil,
 		nil,
 	)
-	c.timezone = prometheus.NewDesc(
+	c.timezone = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "timezone"),
 		"Deprecated: Use windows_time_timezone instead.",
 		[]string{"timezone"},
 		nil,
 	)
 
-	c.processesLimit = prometheus.NewDesc(
+	c.processesLimit = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processes_limit"),
 		"Deprecated: Use `windows_system_process_limit` instead.",
 		nil,
 		nil,
 	)
-	c.processMemoryLimitBytes = prometheus.NewDesc(
+	c.processMemoryLimitBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_limit_bytes"),
 		"Deprecated: Use `windows_memory_process_memory_limit_bytes` instead.",
 		nil,
 		nil,
 	)
-	c.users = prometheus.NewDesc(
+	c.users = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "users"),
 		"Deprecated: Use `sum(windows_terminal_services_session_info{state=\"active\"})` instead.",
 		nil,
 		nil,
 	)
-	c.virtualMemoryBytes = prometheus.NewDesc(
+	c.virtualMemoryBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_memory_bytes"),
 		"Deprecated: Use `windows_memory_commit_limit` instead.",
 		nil,
 		nil,
 	)
-	c.visibleMemoryBytes = prometheus.NewDesc(
+	c.visibleMemoryBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "visible_memory_bytes"),
 		"Deprecated: Use `windows_memory_physical_total_bytes` instead.",
 		nil,
 		nil,
 	)
-	c.virtualMemoryFreeBytes = prometheus.NewDesc(
+	c.virtualMemoryFreeBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_memory_free_bytes"),
 		"Deprecated: Use `windows_memory_commit_limit - windows_memory_committed_bytes` instead.",
 		nil,
 		nil,
 	)
+}
 
-	return nil
+func (c *Collector) buildInfoDesc(info osInfo) {
+	c.osInformation = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "info"),
+		`Contains full product name & version in labels. Note that the "major_version" for Windows 11 is \"10\"; a build number greater than 22000 represents Windows 11.`,
+		nil,
+		prometheus.Labels{
+			"product":       info.productName,
+			"version":       info.version,
+			"major_version": info.majorVersion,
+			"minor_version": info.minorVersion,
+			"build_number":  info.buildNumber,
+			"revision":      info.revision,
+		},
+	)
+}
+
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.hostname,
+		c.osInformation,
+		c.processesLimit,
+		c.users,
+		c.physicalMemoryFreeBytes,
+		c.processMemoryLimitBytes,
+		c.time,
+		c.timezone,
+		c.virtualMemoryBytes,
+		c.virtualMemoryFreeBytes,
+		c.visibleMemoryBytes,
+	)
 }
 
 // Collect sends the metric values for each metric
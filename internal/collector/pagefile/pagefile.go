@@ -76,28 +76,41 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.pagingLimitBytes = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Paging File", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create Paging File collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.pagingLimitBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "limit_bytes"),
 		"Number of bytes that can be stored in the operating system paging files. 0 (zero) indicates that there are no paging files",
 		[]string{"file"},
 		nil,
 	)
 
-	c.pagingFreeBytes = prometheus.NewDesc(
+	c.pagingFreeBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "free_bytes"),
 		"Number of bytes that can be mapped into the operating system paging files without causing any other pages to be swapped out",
 		[]string{"file"},
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Paging File", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create Paging File collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.pagingFreeBytes,
+		c.pagingLimitBytes,
+	)
 }
 
 // Collect sends the metric values for each metric
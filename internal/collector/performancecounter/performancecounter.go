@@ -18,9 +18,11 @@
 package performancecounter
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"reflect"
 	"regexp"
 	"slices"
@@ -30,7 +32,9 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/pdh/registry"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/yaml.v3"
 )
@@ -39,6 +43,7 @@ const Name = "performancecounter"
 
 var (
 	reNonAlphaNum = regexp.MustCompile(`[^a-zA-Z0-9]`)
+	reLabelName   = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 
 	//nolint:gochecknoglobals // strings.NewReplacer is safe for concurrent use
 	stringReplacer = strings.NewReplacer(
@@ -48,6 +53,23 @@ var (
 	)
 )
 
+type unit struct {
+	convert func(float64) float64
+	suffix  string
+}
+
+// units maps the supported counter units to the conversion into their base unit and the base unit suffix.
+//
+//nolint:gochecknoglobals
+var units = map[string]unit{
+	"ms":      {convert: utils.MilliSecToSec, suffix: "_seconds"},
+	"us":      {convert: utils.MicroSecToSec, suffix: "_seconds"},
+	"100ns":   {convert: func(ticks float64) float64 { return ticks * pdh.TicksToSecondScaleFactor }, suffix: "_seconds"},
+	"percent": {convert: utils.PercentageToRatio, suffix: "_ratio"},
+	"kb":      {convert: utils.KBToBytes, suffix: "_bytes"},
+	"mb":      {convert: utils.MBToBytes, suffix: "_bytes"},
+}
+
 type Config struct {
 	Objects []Object `yaml:"objects"`
 }
@@ -132,7 +154,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	var errs []error
 
-	for i, object := range c.config.Objects {
+	for _, object := range c.config.Objects {
 		if object.Name == "" {
 			return errors.New("object name is required")
 		}
@@ -150,19 +172,54 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		}
 
 		names = append(names, object.Name)
+
+		if err := translateNames(&object); err != nil {
+			errs = append(errs, fmt.Errorf("object %s: %w", object.Name, err))
+
+			continue
+		}
+
+		if object.InstanceLabel == "" {
+			object.InstanceLabel = "instance"
+		}
+
+		expandedCounters, err := expandCounters(object)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("object %s: %w", object.Name, err))
+
+			continue
+		}
+
+		object.Counters = expandedCounters
+
+		if err = compileInstanceFilters(&object); err != nil {
+			errs = append(errs, fmt.Errorf("object %s: %w", object.Name, err))
+
+			continue
+		}
+
 		counters := make([]string, 0, len(object.Counters))
+		fieldNames := make(map[string]string, len(object.Counters))
 		fields := make([]reflect.StructField, 0, len(object.Counters)+2)
+		// accepted holds the counters with a struct field. Rejected counters are not collected.
+		accepted := make([]Counter, 0, len(object.Counters))
 
-		for j, counter := range object.Counters {
-			if counter.Metric == "" {
-				c.config.Objects[i].Counters[j].Metric = sanitizeMetricName(
+		for _, counter := range object.Counters {
+			generated := counter.Metric == ""
+			if generated {
+				counter.Metric = sanitizeMetricName(
 					fmt.Sprintf("%s_%s_%s_%s", types.Namespace, Name, object.Object, counter.Name),
 				)
 			}
 
+			if err := applyUnit(&counter, generated); err != nil {
+				errs = append(errs, fmt.Errorf("counter %s: %w", counter.Name, err))
+
+				continue
+			}
+
 			if counter.Name == "" {
 				errs = append(errs, errors.New("counter name is required"))
-				c.config.Objects = slices.Delete(c.config.Objects, i, 1)
 
 				continue
 			}
@@ -175,6 +232,14 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 			counters = append(counters, counter.Name)
 
+			if other, ok := fieldNames[strings.ToUpper(sanitizeMetricName(counter.Name))]; ok {
+				errs = append(errs, fmt.Errorf("counter name %s conflicts with %s", counter.Name, other))
+
+				continue
+			}
+
+			fieldNames[strings.ToUpper(sanitizeMetricName(counter.Name))] = counter.Name
+
 			field, err := func(name string) (_ reflect.StructField, err error) {
 				defer func() {
 					if r := recover(); r != nil {
@@ -195,8 +260,11 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			}
 
 			fields = append(fields, field)
+			accepted = append(accepted, counter)
 		}
 
+		object.Counters = accepted
+
 		if object.Instances != nil {
 			fields = append(fields, reflect.StructField{
 				Name: "Name",
@@ -220,8 +288,27 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			errs = append(errs, fmt.Errorf("failed collector for %s: %w", object.Name, err))
 		}
 
-		if object.InstanceLabel == "" {
-			object.InstanceLabel = "instance"
+		resolveHelp(object.Counters, collector.Describe())
+
+		if collector != nil {
+			metricTypes := collector.MetricTypes()
+
+			if object.Type == pdh.CounterTypeRaw {
+				skipTimerConversion(object.Counters, collector.CounterTypes())
+			}
+
+			for j, counter := range object.Counters {
+				if !counter.expanded || counter.Type != "" {
+					continue
+				}
+
+				// Formatted values are always exposed as gauge.
+				if object.Type == pdh.CounterTypeRaw && metricTypes[counter.Name] == prometheus.CounterValue {
+					object.Counters[j].Type = "counter"
+				} else {
+					object.Counters[j].Type = "gauge"
+				}
+			}
 		}
 
 		object.collector = collector
@@ -296,6 +383,17 @@ func (c *Collector) collectObject(ch chan<- prometheus.Metric, perfDataObject Ob
 
 	sliceValue := reflect.ValueOf(perfDataObject.perfDataObject).Elem().Interface()
 	for i := range reflect.ValueOf(sliceValue).Len() {
+		instanceLabels, ok, err := perfDataObject.instanceLabels(reflect.ValueOf(sliceValue).Index(i))
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
 		for _, counter := range perfDataObject.Counters {
 			val := reflect.ValueOf(sliceValue).Index(i)
 
@@ -312,7 +410,7 @@ func (c *Collector) collectObject(ch chan<- prometheus.Metric, perfDataObject Ob
 				continue
 			}
 
-			collectedCounterValue := field.Float()
+			collectedCounterValue := counter.baseValue(field.Float())
 
 			field = val.FieldByName("MetricType")
 			if !field.IsValid() {
@@ -329,26 +427,10 @@ func (c *Collector) collectObject(ch chan<- prometheus.Metric, perfDataObject Ob
 
 			metricType, _ := field.Interface().(prometheus.ValueType)
 
-			labels := make(prometheus.Labels, len(counter.Labels)+1)
-
-			if perfDataObject.Instances != nil {
-				field := val.FieldByName("Name")
-				if !field.IsValid() {
-					errs = append(errs, errors.New("field Name not found in collected data"))
-
-					continue
-				}
+			labels := make(prometheus.Labels, len(counter.Labels)+len(instanceLabels))
 
-				if field.Kind() != reflect.String {
-					errs = append(errs, fmt.Errorf("failed to cast Name for %s to string", counter.Name))
-
-					continue
-				}
-
-				collectedInstance := field.String()
-				if collectedInstance != pdh.InstanceEmpty {
-					labels[perfDataObject.InstanceLabel] = collectedInstance
-				}
+			for key, value := range instanceLabels {
+				labels[key] = value
 			}
 
 			for key, value := range counter.Labels {
@@ -365,7 +447,7 @@ func (c *Collector) collectObject(ch chan<- prometheus.Metric, perfDataObject Ob
 			ch <- prometheus.MustNewConstMetric(
 				prometheus.NewDesc(
 					counter.Metric,
-					"windows_exporter: custom Performance Counter metric",
+					counter.help,
 					nil,
 					labels,
 				),
@@ -378,6 +460,289 @@ func (c *Collector) collectObject(ch chan<- prometheus.Metric, perfDataObject Ob
 	return errors.Join(errs...)
 }
 
+// compileInstanceFilters compiles the instance-include, instance-exclude and instance-label-regex options of the object.
+func compileInstanceFilters(object *Object) error {
+	if object.Instances == nil {
+		if object.InstanceInclude != "" || object.InstanceExclude != "" || object.InstanceLabelRegex != "" {
+			return errors.New("instance-include, instance-exclude and instance-label-regex require instances")
+		}
+
+		return nil
+	}
+
+	var err error
+
+	object.instanceInclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", cmp.Or(object.InstanceInclude, ".+")))
+	if err != nil {
+		return fmt.Errorf("instance-include: %w", err)
+	}
+
+	object.instanceExclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", object.InstanceExclude))
+	if err != nil {
+		return fmt.Errorf("instance-exclude: %w", err)
+	}
+
+	if object.InstanceLabelRegex == "" {
+		return nil
+	}
+
+	object.instanceLabelRegex, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", object.InstanceLabelRegex))
+	if err != nil {
+		return fmt.Errorf("instance-label-regex: %w", err)
+	}
+
+	var groups int
+
+	for _, name := range object.instanceLabelRegex.SubexpNames() {
+		if name == "" {
+			continue
+		}
+
+		if !reLabelName.MatchString(name) {
+			return fmt.Errorf("instance-label-regex: group %s is not a valid label name", name)
+		}
+
+		if name == object.InstanceLabel {
+			return fmt.Errorf("instance-label-regex: group %s conflicts with instance_label", name)
+		}
+
+		for _, counter := range object.Counters {
+			if _, ok := counter.Labels[name]; ok {
+				return fmt.Errorf("instance-label-regex: group %s conflicts with a label of counter %s", name, counter.Name)
+			}
+		}
+
+		groups++
+	}
+
+	if groups == 0 {
+		return errors.New("instance-label-regex: at least one named group is required")
+	}
+
+	return nil
+}
+
+// instanceLabels returns the labels derived from the instance name of a collected row.
+// It returns false if the instance is filtered by instance-include or instance-exclude.
+// Without instance-label-regex, the instance name is exposed as InstanceLabel.
+// With instance-label-regex, each named group and InstanceLabel are always exposed, so all series of a metric have
+// the same label names. If the regexp matches the instance name, the groups are set and InstanceLabel is empty.
+// Otherwise, the groups are empty and InstanceLabel holds the instance name.
+func (o Object) instanceLabels(row reflect.Value) (prometheus.Labels, bool, error) {
+	if o.Instances == nil {
+		return prometheus.Labels{}, true, nil
+	}
+
+	field := row.FieldByName("Name")
+	if !field.IsValid() {
+		return nil, false, errors.New("field Name not found in collected data")
+	}
+
+	if field.Kind() != reflect.String {
+		return nil, false, errors.New("failed to cast Name to string")
+	}
+
+	instance := field.String()
+	if instance == pdh.InstanceEmpty {
+		return prometheus.Labels{}, true, nil
+	}
+
+	if !o.instanceInclude.MatchString(instance) || o.instanceExclude.MatchString(instance) {
+		return nil, false, nil
+	}
+
+	if o.instanceLabelRegex == nil {
+		return prometheus.Labels{o.InstanceLabel: instance}, true, nil
+	}
+
+	match := o.instanceLabelRegex.FindStringSubmatch(instance)
+	labels := make(prometheus.Labels, len(o.instanceLabelRegex.SubexpNames())+1)
+	labels[o.InstanceLabel] = ""
+
+	if match == nil {
+		labels[o.InstanceLabel] = instance
+	}
+
+	for i, name := range o.instanceLabelRegex.SubexpNames() {
+		if name == "" {
+			continue
+		}
+
+		labels[name] = ""
+
+		if match != nil {
+			labels[name] = match[i]
+		}
+	}
+
+	return labels, true, nil
+}
+
+// resolveHelp sets the HELP of each counter to its help option or, if not set, to the explain text of the counter.
+// Counters sharing a metric name share the HELP of the first of these counters, since a metric can only have a single HELP.
+func resolveHelp(counters []Counter, explainTexts map[string]string) {
+	helps := make(map[string]string, len(counters))
+
+	for i, counter := range counters {
+		help, ok := helps[counter.Metric]
+		if !ok {
+			help = cmp.Or(counter.Help, explainTexts[counter.Name], "windows_exporter: custom Performance Counter metric")
+			helps[counter.Metric] = help
+		}
+
+		counters[i].help = help
+	}
+}
+
+// applyUnit sets up the value conversion of the counter and appends the base unit suffix to the metric name.
+// An explicit Suffix takes precedence over the suffix of the unit. The suffix of the unit is only appended
+// to generated metric names, explicit metric names are kept as they are.
+func applyUnit(counter *Counter, generated bool) error {
+	suffix := counter.Suffix
+
+	if counter.Unit != "" {
+		u, ok := units[strings.ToLower(counter.Unit)]
+		if !ok {
+			return fmt.Errorf("unknown unit %q, must be one of %s", counter.Unit, strings.Join(slices.Sorted(maps.Keys(units)), ", "))
+		}
+
+		counter.convert = u.convert
+
+		if generated {
+			suffix = cmp.Or(suffix, u.suffix)
+		}
+	}
+
+	if suffix != "" && !strings.HasSuffix(counter.Metric, suffix) {
+		counter.Metric += suffix
+	}
+
+	return nil
+}
+
+// skipTimerConversion disables the conversion of the 100ns unit for raw counters,
+// whose values are already converted into seconds by the pdh package.
+func skipTimerConversion(counters []Counter, counterTypes map[string]uint32) {
+	for i, counter := range counters {
+		if !strings.EqualFold(counter.Unit, "100ns") {
+			continue
+		}
+
+		switch counterTypes[counter.Name] {
+		case pdh.PERF_100NSEC_TIMER, pdh.PERF_PRECISION_100NS_TIMER:
+			counters[i].convert = nil
+		}
+	}
+}
+
+// baseValue converts a collected value into the base unit and applies the scale factor.
+func (c Counter) baseValue(value float64) float64 {
+	if c.convert != nil {
+		value = c.convert(value)
+	}
+
+	if c.Scale != 0 {
+		value *= c.Scale
+	}
+
+	return value
+}
+
+// expandCounters replaces the [CounterAll] counter with all counters of the object,
+// filtered by CounterInclude and CounterExclude. Explicitly listed counters take precedence.
+// Discovered counters inherit the type and labels of the [CounterAll] counter.
+func expandCounters(object Object) ([]Counter, error) {
+	index := slices.IndexFunc(object.Counters, func(counter Counter) bool {
+		return counter.Name == CounterAll
+	})
+
+	if index == -1 {
+		if object.CounterInclude != "" || object.CounterExclude != "" {
+			return nil, fmt.Errorf("counter-include and counter-exclude require the %q counter", CounterAll)
+		}
+
+		return object.Counters, nil
+	}
+
+	wildcard := object.Counters[index]
+	if wildcard.Metric != "" {
+		return nil, fmt.Errorf("metric can't be set for the %q counter", CounterAll)
+	}
+
+	counterInclude, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", cmp.Or(object.CounterInclude, ".+")))
+	if err != nil {
+		return nil, fmt.Errorf("counter-include: %w", err)
+	}
+
+	counterExclude, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", object.CounterExclude))
+	if err != nil {
+		return nil, fmt.Errorf("counter-exclude: %w", err)
+	}
+
+	// PdhEnumObjectItems expects and returns names in the UI language of the system.
+	localizedObject, err := registry.LocalizedName(object.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	objectCounters, err := pdh.ObjectCounters(localizedObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate counters: %w", err)
+	}
+
+	counters := slices.Delete(slices.Clone(object.Counters), index, index+1)
+
+	for _, name := range objectCounters {
+		name, err = registry.EnglishName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !counterInclude.MatchString(name) || counterExclude.MatchString(name) {
+			continue
+		}
+
+		if slices.ContainsFunc(counters, func(counter Counter) bool { return counter.Name == name }) {
+			continue
+		}
+
+		counters = append(counters, Counter{
+			Name:     name,
+			Type:     wildcard.Type,
+			Labels:   wildcard.Labels,
+			expanded: true,
+		})
+	}
+
+	return counters, nil
+}
+
+// translateNames replaces localized object and counter names with their English names,
+// which are used by the PDH queries and the metric names.
+func translateNames(object *Object) error {
+	var err error
+
+	object.Object, err = registry.EnglishName(object.Object)
+	if err != nil {
+		return err
+	}
+
+	object.Counters = slices.Clone(object.Counters)
+
+	for i, counter := range object.Counters {
+		if counter.Name == CounterAll {
+			continue
+		}
+
+		object.Counters[i].Name, err = registry.EnglishName(counter.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func sanitizeMetricName(name string) string {
 	return strings.Trim(reNonAlphaNum.ReplaceAllString(strings.ToLower(stringReplacer.Replace(name)), "_"), "_")
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 type collectorAdapter struct {
@@ -51,14 +52,19 @@ func TestCollector(t *testing.T) {
 	t.Parallel()
 
 	for _, tc := range []struct {
-		name            string
-		object          string
-		counterType     pdh.CounterType
-		instances       []string
-		instanceLabel   string
-		buildErr        string
-		counters        []performancecounter.Counter
-		expectedMetrics *regexp.Regexp
+		name               string
+		object             string
+		counterType        pdh.CounterType
+		instances          []string
+		instanceLabel      string
+		counterInclude     string
+		counterExclude     string
+		instanceInclude    string
+		instanceExclude    string
+		instanceLabelRegex string
+		buildErr           string
+		counters           []performancecounter.Counter
+		expectedMetrics    *regexp.Regexp
 	}{
 		{
 			name:        "memory",
@@ -73,7 +79,7 @@ windows_performancecounter_collector_duration_seconds\{collector="memory"} [0-9.
 # HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
 # TYPE windows_performancecounter_collector_success gauge
 windows_performancecounter_collector_success\{collector="memory"} 1
-# HELP windows_performancecounter_memory_available_bytes windows_exporter: custom Performance Counter metric
+# HELP windows_performancecounter_memory_available_bytes .+
 # TYPE windows_performancecounter_memory_available_bytes gauge
 windows_performancecounter_memory_available_bytes [0-9.e+-]+`),
 		},
@@ -90,7 +96,7 @@ windows_performancecounter_collector_duration_seconds\{collector="process"} [0-9
 # HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
 # TYPE windows_performancecounter_collector_success gauge
 windows_performancecounter_collector_success\{collector="process"} 1
-# HELP windows_performancecounter_process_thread_count windows_exporter: custom Performance Counter metric
+# HELP windows_performancecounter_process_thread_count .+
 # TYPE windows_performancecounter_process_thread_count counter
 windows_performancecounter_process_thread_count\{instance=".+"} [0-9.e+-]+
 .*`),
@@ -109,7 +115,7 @@ windows_performancecounter_collector_duration_seconds\{collector="processor_info
 # HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
 # TYPE windows_performancecounter_collector_success gauge
 windows_performancecounter_collector_success\{collector="processor_information"} 1
-# HELP windows_performancecounter_processor_information_processor_time windows_exporter: custom Performance Counter metric
+# HELP windows_performancecounter_processor_information_processor_time .+
 # TYPE windows_performancecounter_processor_information_processor_time counter
 windows_performancecounter_processor_information_processor_time\{core="0,0",state="active"} [0-9.e+-]+
 windows_performancecounter_processor_information_processor_time\{core="0,0",state="idle"} [0-9.e+-]+
@@ -129,12 +135,177 @@ windows_performancecounter_collector_duration_seconds\{collector="processor_info
 # HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
 # TYPE windows_performancecounter_collector_success gauge
 windows_performancecounter_collector_success\{collector="processor_information_formatted"} 1
-# HELP windows_performancecounter_processor_information_processor_time windows_exporter: custom Performance Counter metric
+# HELP windows_performancecounter_processor_information_processor_time .+
 # TYPE windows_performancecounter_processor_information_processor_time gauge
 windows_performancecounter_processor_information_processor_time\{core="0,0",state="active"} [0-9]+
 windows_performancecounter_processor_information_processor_time\{core="0,0",state="idle"} [0-9]+
 .*`),
 		},
+		{
+			name:           "memory_wildcard",
+			object:         "Memory",
+			counterType:    pdh.CounterTypeRaw,
+			instances:      nil,
+			counterInclude: "Available .*|Cache Faults/sec",
+			counterExclude: "Available [KM]Bytes",
+			buildErr:       "",
+			counters:       []performancecounter.Counter{{Name: "*"}},
+			expectedMetrics: regexp.MustCompile(`^# HELP windows_performancecounter_collector_duration_seconds windows_exporter: Duration of an performancecounter child collection.
+# TYPE windows_performancecounter_collector_duration_seconds gauge
+windows_performancecounter_collector_duration_seconds\{collector="memory_wildcard"} [0-9.e+-]+
+# HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
+# TYPE windows_performancecounter_collector_success gauge
+windows_performancecounter_collector_success\{collector="memory_wildcard"} 1
+# HELP windows_performancecounter_memory_available_bytes .+
+# TYPE windows_performancecounter_memory_available_bytes gauge
+windows_performancecounter_memory_available_bytes [0-9.e+-]+
+# HELP windows_performancecounter_memory_cache_faults_sec .+
+# TYPE windows_performancecounter_memory_cache_faults_sec counter
+windows_performancecounter_memory_cache_faults_sec [0-9.e+-]+
+$`),
+		},
+		{
+			name:               "processor_information_instance_labels",
+			object:             "Processor Information",
+			counterType:        pdh.CounterTypeRaw,
+			instances:          []string{"*"},
+			instanceInclude:    "0,[0-9]+",
+			instanceExclude:    "0,[1-9][0-9]*",
+			instanceLabelRegex: "(?P<group>[0-9]+),(?P<core>[0-9]+)",
+			buildErr:           "",
+			counters:           []performancecounter.Counter{{Name: "% Processor Time", Metric: "windows_performancecounter_processor_information_processor_time", Labels: map[string]string{"state": "active"}}, {Name: "% Idle Time", Metric: "windows_performancecounter_processor_information_processor_time", Labels: map[string]string{"state": "idle"}}},
+			expectedMetrics: regexp.MustCompile(`^# HELP windows_performancecounter_collector_duration_seconds windows_exporter: Duration of an performancecounter child collection.
+# TYPE windows_performancecounter_collector_duration_seconds gauge
+windows_performancecounter_collector_duration_seconds\{collector="processor_information_instance_labels"} [0-9.e+-]+
+# HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
+# TYPE windows_performancecounter_collector_success gauge
+windows_performancecounter_collector_success\{collector="processor_information_instance_labels"} 1
+# HELP windows_performancecounter_processor_information_processor_time .+
+# TYPE windows_performancecounter_processor_information_processor_time counter
+windows_performancecounter_processor_information_processor_time\{core="0",group="0",instance="",state="active"} [0-9.e+-]+
+windows_performancecounter_processor_information_processor_time\{core="0",group="0",instance="",state="idle"} [0-9.e+-]+
+$`),
+		},
+		{
+			name:               "instance_label_regex_without_groups",
+			object:             "Processor Information",
+			counterType:        pdh.CounterTypeRaw,
+			instances:          []string{"*"},
+			instanceLabelRegex: "[0-9]+,[0-9]+",
+			buildErr:           "instance-label-regex: at least one named group is required",
+			counters:           []performancecounter.Counter{{Name: "% Processor Time"}},
+			expectedMetrics:    nil,
+		},
+		{
+			name:               "instance_label_regex_partial_match",
+			object:             "Processor Information",
+			counterType:        pdh.CounterTypeRaw,
+			instances:          []string{"*"},
+			instanceInclude:    "0,0|0,_Total",
+			instanceLabelRegex: "(?P<group>[0-9]+),(?P<core>[0-9]+)",
+			buildErr:           "",
+			counters:           []performancecounter.Counter{{Name: "% Processor Time", Metric: "windows_performancecounter_processor_information_processor_time"}},
+			expectedMetrics: regexp.MustCompile(`^# HELP windows_performancecounter_collector_duration_seconds windows_exporter: Duration of an performancecounter child collection.
+# TYPE windows_performancecounter_collector_duration_seconds gauge
+windows_performancecounter_collector_duration_seconds\{collector="instance_label_regex_partial_match"} [0-9.e+-]+
+# HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
+# TYPE windows_performancecounter_collector_success gauge
+windows_performancecounter_collector_success\{collector="instance_label_regex_partial_match"} 1
+# HELP windows_performancecounter_processor_information_processor_time .+
+# TYPE windows_performancecounter_processor_information_processor_time counter
+windows_performancecounter_processor_information_processor_time\{core="",group="",instance="0,_Total"} [0-9.e+-]+
+windows_performancecounter_processor_information_processor_time\{core="0",group="0",instance=""} [0-9.e+-]+
+$`),
+		},
+		{
+			name:               "instance_label_regex_instance_label_conflict",
+			object:             "Processor Information",
+			counterType:        pdh.CounterTypeRaw,
+			instances:          []string{"*"},
+			instanceLabel:      "core",
+			instanceLabelRegex: "(?P<group>[0-9]+),(?P<core>[0-9]+)",
+			buildErr:           "instance-label-regex: group core conflicts with instance_label",
+			counters:           []performancecounter.Counter{{Name: "% Processor Time"}},
+			expectedMetrics:    nil,
+		},
+		{
+			name:               "instance_label_regex_counter_label_conflict",
+			object:             "Processor Information",
+			counterType:        pdh.CounterTypeRaw,
+			instances:          []string{"*"},
+			instanceLabelRegex: "(?P<group>[0-9]+),(?P<state>[0-9]+)",
+			buildErr:           "instance-label-regex: group state conflicts with a label of counter % Processor Time",
+			counters:           []performancecounter.Counter{{Name: "% Processor Time", Labels: map[string]string{"state": "active"}}},
+			expectedMetrics:    nil,
+		},
+		{
+			name:            "instance_include_without_instances",
+			object:          "Memory",
+			counterType:     pdh.CounterTypeRaw,
+			instances:       nil,
+			instanceInclude: ".+",
+			buildErr:        "instance-include, instance-exclude and instance-label-regex require instances",
+			counters:        []performancecounter.Counter{{Name: "Available Bytes"}},
+			expectedMetrics: nil,
+		},
+		{
+			name:        "memory_units",
+			object:      "Memory",
+			counterType: pdh.CounterTypeRaw,
+			instances:   nil,
+			buildErr:    "",
+			counters: []performancecounter.Counter{
+				{Name: "Available MBytes", Metric: "windows_performancecounter_memory_available_bytes", Type: "gauge", Unit: "mb", Help: "Physical memory available for allocation, in bytes."},
+				// The unit suffix is not appended to explicit metric names.
+				{Name: "Available KBytes", Metric: "windows_performancecounter_memory_free", Type: "gauge", Unit: "kb"},
+				{Name: "Cache Faults/sec", Type: "counter", Scale: 2, Suffix: "_total"},
+			},
+			expectedMetrics: regexp.MustCompile(`^# HELP windows_performancecounter_collector_duration_seconds windows_exporter: Duration of an performancecounter child collection.
+# TYPE windows_performancecounter_collector_duration_seconds gauge
+windows_performancecounter_collector_duration_seconds\{collector="memory_units"} [0-9.e+-]+
+# HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
+# TYPE windows_performancecounter_collector_success gauge
+windows_performancecounter_collector_success\{collector="memory_units"} 1
+# HELP windows_performancecounter_memory_available_bytes Physical memory available for allocation, in bytes\.
+# TYPE windows_performancecounter_memory_available_bytes gauge
+windows_performancecounter_memory_available_bytes [0-9.e+-]+
+# HELP windows_performancecounter_memory_cache_faults_sec_total .+
+# TYPE windows_performancecounter_memory_cache_faults_sec_total counter
+windows_performancecounter_memory_cache_faults_sec_total [0-9.e+-]+
+# HELP windows_performancecounter_memory_free .+
+# TYPE windows_performancecounter_memory_free gauge
+windows_performancecounter_memory_free [0-9.e+-]+
+$`),
+		},
+		{
+			name:        "invalid_unit",
+			object:      "Memory",
+			counterType: pdh.CounterTypeRaw,
+			instances:   nil,
+			buildErr:    `counter Available MBytes: unknown unit "gb"`,
+			counters:    []performancecounter.Counter{{Name: "Available Bytes", Type: "gauge"}, {Name: "Available MBytes", Unit: "gb"}},
+			// The rejected counter is not collected, so the other counters are still collected successfully.
+			expectedMetrics: regexp.MustCompile(`^# HELP windows_performancecounter_collector_duration_seconds windows_exporter: Duration of an performancecounter child collection.
+# TYPE windows_performancecounter_collector_duration_seconds gauge
+windows_performancecounter_collector_duration_seconds\{collector="invalid_unit"} [0-9.e+-]+
+# HELP windows_performancecounter_collector_success windows_exporter: Whether a performancecounter child collector was successful.
+# TYPE windows_performancecounter_collector_success gauge
+windows_performancecounter_collector_success\{collector="invalid_unit"} 1
+# HELP windows_performancecounter_memory_available_bytes .+
+# TYPE windows_performancecounter_memory_available_bytes gauge
+windows_performancecounter_memory_available_bytes [0-9.e+-]+
+$`),
+		},
+		{
+			name:            "counter_include_without_wildcard",
+			object:          "Memory",
+			counterType:     pdh.CounterTypeRaw,
+			instances:       nil,
+			counterInclude:  "Available .*",
+			buildErr:        `counter-include and counter-exclude require the "*" counter`,
+			counters:        []performancecounter.Counter{{Name: "Available Bytes"}},
+			expectedMetrics: nil,
+		},
 		{
 			name:            "",
 			object:          "Processor Information",
@@ -179,12 +350,18 @@ windows_performancecounter_processor_information_processor_time\{core="0,0",stat
 			perfDataCollector := performancecounter.New(&performancecounter.Config{
 				Objects: []performancecounter.Object{
 					{
-						Name:          tc.name,
-						Object:        tc.object,
-						Type:          tc.counterType,
-						Instances:     tc.instances,
-						InstanceLabel: tc.instanceLabel,
-						Counters:      tc.counters,
+						Name:           tc.name,
+						Object:         tc.object,
+						Type:           tc.counterType,
+						Instances:      tc.instances,
+						InstanceLabel:  tc.instanceLabel,
+						CounterInclude: tc.counterInclude,
+						CounterExclude: tc.counterExclude,
+						Counters:       tc.counters,
+
+						InstanceInclude:    tc.instanceInclude,
+						InstanceExclude:    tc.instanceExclude,
+						InstanceLabelRegex: tc.instanceLabelRegex,
 					},
 				},
 			})
@@ -195,11 +372,14 @@ windows_performancecounter_processor_information_processor_time\{core="0,0",stat
 			if tc.buildErr != "" {
 				require.ErrorContains(t, err, tc.buildErr)
 
-				return
+				// Objects with rejected counters are still collected, if metrics are expected.
+				if tc.expectedMetrics == nil {
+					return
+				}
+			} else {
+				require.NoError(t, err)
 			}
 
-			require.NoError(t, err)
-
 			registry := prometheus.NewRegistry()
 			registry.MustRegister(collectorAdapter{*perfDataCollector})
 
@@ -213,3 +393,14 @@ windows_performancecounter_processor_information_processor_time\{core="0,0",stat
 		})
 	}
 }
+
+func TestCounterUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	var objects []performancecounter.Object
+
+	require.NoError(t, yaml.Unmarshal([]byte(`[{"name":"memory","object":"Memory","counters":["*",{"name":"Cache Faults/sec","type":"counter"}],"counter-exclude":"Available .*"}]`), &objects))
+	require.Len(t, objects, 1)
+	require.Equal(t, []performancecounter.Counter{{Name: "*"}, {Name: "Cache Faults/sec", Type: "counter"}}, objects[0].Counters)
+	require.Equal(t, "Available .*", objects[0].CounterExclude)
+}
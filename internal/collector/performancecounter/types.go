@@ -18,20 +18,35 @@
 package performancecounter
 
 import (
+	"regexp"
+
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"gopkg.in/yaml.v3"
 )
 
+// CounterAll is the counter name that selects all counters of an object.
+const CounterAll = "*"
+
 type Object struct {
-	Name          string          `json:"name"           yaml:"name"`
-	Object        string          `json:"object"         yaml:"object"`
-	Type          pdh.CounterType `json:"type"           yaml:"type"`
-	Instances     []string        `json:"instances"      yaml:"instances"`
-	Counters      []Counter       `json:"counters"       yaml:"counters"`
-	InstanceLabel string          `json:"instance_label" yaml:"instance_label"`
+	Name           string          `json:"name"            yaml:"name"`
+	Object         string          `json:"object"          yaml:"object"`
+	Type           pdh.CounterType `json:"type"            yaml:"type"`
+	Instances      []string        `json:"instances"       yaml:"instances"`
+	Counters       []Counter       `json:"counters"        yaml:"counters"`
+	CounterInclude string          `json:"counter-include" yaml:"counter-include"`
+	CounterExclude string          `json:"counter-exclude" yaml:"counter-exclude"`
+	InstanceLabel  string          `json:"instance_label"  yaml:"instance_label"`
+
+	InstanceInclude    string `json:"instance-include"     yaml:"instance-include"`
+	InstanceExclude    string `json:"instance-exclude"     yaml:"instance-exclude"`
+	InstanceLabelRegex string `json:"instance-label-regex" yaml:"instance-label-regex"`
 
 	collector      *pdh.Collector
 	perfDataObject any
+
+	instanceInclude    *regexp.Regexp
+	instanceExclude    *regexp.Regexp
+	instanceLabelRegex *regexp.Regexp
 }
 
 type Counter struct {
@@ -39,6 +54,30 @@ type Counter struct {
 	Type   string            `json:"type"   yaml:"type"`
 	Metric string            `json:"metric" yaml:"metric"`
 	Labels map[string]string `json:"labels" yaml:"labels"`
+	Scale  float64           `json:"scale"  yaml:"scale"`
+	Unit   string            `json:"unit"   yaml:"unit"`
+	Suffix string            `json:"suffix" yaml:"suffix"`
+	Help   string            `json:"help"   yaml:"help"`
+
+	// expanded is set for counters discovered through [CounterAll].
+	expanded bool
+	// convert converts the collected value from Unit into the base unit.
+	convert func(float64) float64
+	// help is the HELP of the metric, resolved from Help or the explain text of the counter.
+	help string
+}
+
+// UnmarshalYAML allows counters to be given by name only, e.g. `counters: ["*"]`.
+func (c *Counter) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*c = Counter{Name: node.Value}
+
+		return nil
+	}
+
+	type plain Counter
+
+	return node.Decode((*plain)(c))
 }
 
 // https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/54691ebe11bb9ec32b4e35cd31fcb94a352de134/receiver/windowsperfcountersreceiver/README.md?plain=1#L150
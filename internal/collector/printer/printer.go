@@ -128,24 +128,7 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
-	c.printerJobStatus = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "job_status"),
-		"A counter of printer jobs by status",
-		[]string{"printer", "status"},
-		nil,
-	)
-	c.printerStatus = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "status"),
-		"Printer status",
-		[]string{"printer", "status"},
-		nil,
-	)
-	c.printerJobCount = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "job_count"),
-		"Number of jobs processed by the printer since the last reset",
-		[]string{"printer"},
-		nil,
-	)
+	c.BuildDescs()
 
 	if miSession == nil {
 		return errors.New("miSession is nil")
@@ -169,6 +152,28 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 	return nil
 }
 
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.printerJobStatus = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "job_status"),
+		"A counter of printer jobs by status",
+		[]string{"printer", "status"},
+		nil,
+	)
+	c.printerStatus = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "status"),
+		"Printer status",
+		[]string{"printer", "status"},
+		nil,
+	)
+	c.printerJobCount = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "job_count"),
+		"Number of jobs processed by the printer since the last reset",
+		[]string{"printer"},
+		nil,
+	)
+}
+
 func (c *Collector) GetName() string { return Name }
 
 type wmiPrinter struct {
@@ -183,6 +188,17 @@ type wmiPrintJob struct {
 	Status string `mi:"Status"`
 }
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.printerStatus,
+		c.printerJobStatus,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.printerJobCount,
+	)
+}
+
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 	var errs []error
 
@@ -30,7 +30,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
-	"github.com/prometheus-community/windows_exporter/internal/pdh/registry"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows"
@@ -39,9 +39,10 @@ import (
 const Name = "process"
 
 type Config struct {
-	ProcessInclude      *regexp.Regexp `yaml:"include"`
-	ProcessExclude      *regexp.Regexp `yaml:"exclude"`
-	EnableWorkerProcess bool           `yaml:"iis"`
+	ProcessInclude      *regexp.Regexp   `yaml:"include"`
+	ProcessExclude      *regexp.Regexp   `yaml:"exclude"`
+	EnableWorkerProcess bool             `yaml:"iis"`
+	CounterBackend      perfdata.Backend `yaml:"counter-backend"`
 }
 
 //nolint:gochecknoglobals
@@ -49,6 +50,7 @@ var ConfigDefaults = Config{
 	ProcessInclude:      types.RegExpAny,
 	ProcessExclude:      types.RegExpEmpty,
 	EnableWorkerProcess: false,
+	CounterBackend:      perfdata.BackendPDH,
 }
 
 type Collector struct {
@@ -101,6 +103,10 @@ func New(config *Config) *Collector {
 		config.ProcessInclude = ConfigDefaults.ProcessInclude
 	}
 
+	if config.CounterBackend == "" {
+		config.CounterBackend = ConfigDefaults.CounterBackend
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -130,6 +136,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Enable IIS collectWorker process name queries. May cause the collector to leak memory.",
 	).Default(strconv.FormatBool(c.config.EnableWorkerProcess)).BoolVar(&c.config.EnableWorkerProcess)
 
+	app.Flag(
+		"collector.process.counter-backend",
+		"API used to read the Process V2 performance counters. One of: pdh, registry.",
+	).Default(string(ConfigDefaults.CounterBackend)).EnumVar((*string)(&c.config.CounterBackend), perfdata.Backends...)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		var err error
 
@@ -179,11 +190,13 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	c.miSession = miSession
 
 	c.collectorVersion = 2
-	c.perfDataCollectorV2, err = pdh.NewCollector[perfDataCounterValuesV2](pdh.CounterTypeRaw, "Process V2", pdh.InstancesAll)
+	c.perfDataCollectorV2, err = perfdata.NewCollector[perfDataCounterValuesV2](c.config.CounterBackend, pdh.CounterTypeRaw, "Process V2", pdh.InstancesAll)
 
+	// Windows versions without the Process V2 object fall back to the Process object.
+	// It is always read from the registry, since PDH merges processes with the same name into one instance.
 	if errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) {
 		c.collectorVersion = 1
-		c.perfDataCollectorV1, err = registry.NewCollector[perfDataCounterValuesV1]("Process", pdh.InstancesAll)
+		c.perfDataCollectorV1, err = perfdata.NewCollector[perfDataCounterValuesV1](perfdata.BackendRegistry, pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
 	}
 
 	if err != nil {
@@ -211,112 +224,142 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 		logger.Warn("No filters specified for process collector. This will generate a very large number of metrics!")
 	}
 
-	c.info = prometheus.NewDesc(
+	c.BuildDescs()
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.info = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"Process information.",
 		[]string{"process", "process_id", "creating_process_id", "process_group_id", "owner", "cmdline"},
 		nil,
 	)
 
-	c.startTimeOld = prometheus.NewDesc(
+	c.startTimeOld = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "start_time"),
 		"DEPRECATED: Use start_time_seconds_timestamp instead",
 		[]string{"process", "process_id"},
 		nil,
 	)
 
-	c.startTime = prometheus.NewDesc(
+	c.startTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "start_time_seconds_timestamp"),
 		"Time of process start.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.cpuTimeTotal = prometheus.NewDesc(
+	c.cpuTimeTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_time_total"),
 		"Returns elapsed time that all of the threads of this process used the processor to execute instructions by mode (privileged, user).",
 		[]string{"process", "process_id", "mode"},
 		nil,
 	)
-	c.handleCount = prometheus.NewDesc(
+	c.handleCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "handles"),
 		"Total number of handles the process has open. This number is the sum of the handles currently open by each thread in the process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.ioBytesTotal = prometheus.NewDesc(
+	c.ioBytesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "io_bytes_total"),
 		"Bytes issued to I/O operations in different modes (read, write, other).",
 		[]string{"process", "process_id", "mode"},
 		nil,
 	)
-	c.ioOperationsTotal = prometheus.NewDesc(
+	c.ioOperationsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "io_operations_total"),
 		"I/O operations issued in different modes (read, write, other).",
 		[]string{"process", "process_id", "mode"},
 		nil,
 	)
-	c.pageFaultsTotal = prometheus.NewDesc(
+	c.pageFaultsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_faults_total"),
 		"Page faults by the threads executing in this process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.pageFileBytes = prometheus.NewDesc(
+	c.pageFileBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_file_bytes"),
 		"Current number of bytes this process has used in the paging file(s).",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.poolBytes = prometheus.NewDesc(
+	c.poolBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_bytes"),
 		"Pool Bytes is the last observed number of bytes in the paged or nonpaged pool.",
 		[]string{"process", "process_id", "pool"},
 		nil,
 	)
-	c.priorityBase = prometheus.NewDesc(
+	c.priorityBase = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "priority_base"),
 		"Current base priority of this process. Threads within a process can raise and lower their own base priority relative to the process base priority of the process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.privateBytes = prometheus.NewDesc(
+	c.privateBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "private_bytes"),
 		"Current number of bytes this process has allocated that cannot be shared with other processes.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.threadCount = prometheus.NewDesc(
+	c.threadCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "threads"),
 		"Number of threads currently active in this process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.virtualBytes = prometheus.NewDesc(
+	c.virtualBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_bytes"),
 		"Current size, in bytes, of the virtual address space that the process is using.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.workingSetPrivate = prometheus.NewDesc(
+	c.workingSetPrivate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_private_bytes"),
 		"Size of the working set, in bytes, that is use for this process only and not shared nor shareable by other processes.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.workingSetPeak = prometheus.NewDesc(
+	c.workingSetPeak = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_peak_bytes"),
 		"Maximum size, in bytes, of the Working Set of this process at any point in time. The Working Set is the set of memory pages touched recently by the threads in the process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.workingSet = prometheus.NewDesc(
+	c.workingSet = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_bytes"),
 		"Maximum number of bytes in the working set of this process at any point in time. The working set is the set of memory pages touched recently by the threads in the process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
+}
 
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.info,
+		c.handleCount,
+		c.pageFileBytes,
+		c.poolBytes,
+		c.priorityBase,
+		c.privateBytes,
+		c.startTimeOld,
+		c.startTime,
+		c.threadCount,
+		c.virtualBytes,
+		c.workingSet,
+		c.workingSetPeak,
+		c.workingSetPrivate,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.cpuTimeTotal,
+		c.ioBytesTotal,
+		c.ioOperationsTotal,
+		c.pageFaultsTotal,
+	)
 }
 
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
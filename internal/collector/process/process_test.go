@@ -22,6 +22,7 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/collector/process"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
 )
 
@@ -37,3 +38,9 @@ func BenchmarkProcessCollector(b *testing.B) {
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, process.New, nil)
 }
+
+func TestCollectorRegistryBackend(t *testing.T) {
+	testutils.TestCollector(t, process.New, &process.Config{
+		CounterBackend: perfdata.BackendRegistry,
+	})
+}
@@ -26,12 +26,12 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/prometheus-community/windows_exporter/internal/pdh/registry"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type collectorV1 struct {
-	perfDataCollectorV1 *registry.Collector
+	perfDataCollectorV1 *perfdata.Collector
 	perfDataObjectV1    []perfDataCounterValuesV1
 	workerChV1          chan processWorkerRequestV1
 }
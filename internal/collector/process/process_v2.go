@@ -27,12 +27,12 @@ import (
 	"sync"
 	"time"
 
-	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type collectorV2 struct {
-	perfDataCollectorV2 *pdh.Collector
+	perfDataCollectorV2 *perfdata.Collector
 	perfDataObjectV2    []perfDataCounterValuesV2
 	workerChV2          chan processWorkerRequestV2
 }
@@ -137,7 +137,11 @@ func (c *Collector) collectWorkerV2() {
 				name, pidString, parentPID, strconv.Itoa(int(processGroupID)), processOwner, cmdLine,
 			)
 
-			startTime := float64(time.Now().Unix() - int64(data.ElapsedTime))
+			// PDH returns the elapsed seconds since the process start, the registry the start time itself.
+			startTime := data.ElapsedTime
+			if c.perfDataCollectorV2.Backend() == perfdata.BackendPDH {
+				startTime = float64(time.Now().Unix() - int64(data.ElapsedTime))
+			}
 
 			ch <- prometheus.MustNewConstMetric(
 				c.startTimeOld,
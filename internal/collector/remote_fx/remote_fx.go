@@ -104,80 +104,101 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(*slog.Logger, *mi.Session) error {
+	c.BuildDescs()
+
+	var err error
+
+	errs := make([]error, 0)
+
+	c.perfDataCollectorNetwork, err = pdh.NewCollector[perfDataCounterValuesNetwork](pdh.CounterTypeRaw, "RemoteFX Network", pdh.InstancesAll)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create RemoteFX Network collector: %w", err))
+	}
+
+	c.perfDataCollectorGraphics, err = pdh.NewCollector[perfDataCounterValuesGraphics](pdh.CounterTypeRaw, "RemoteFX Graphics", pdh.InstancesAll)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create RemoteFX Graphics collector: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
 	// net
-	c.baseTCPRTT = prometheus.NewDesc(
+	c.baseTCPRTT = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_base_tcp_rtt_seconds"),
 		"Base TCP round-trip time (RTT) detected in seconds",
 		[]string{"session_name"},
 		nil,
 	)
-	c.baseUDPRTT = prometheus.NewDesc(
+	c.baseUDPRTT = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_base_udp_rtt_seconds"),
 		"Base UDP round-trip time (RTT) detected in seconds.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentTCPBandwidth = prometheus.NewDesc(
+	c.currentTCPBandwidth = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_tcp_bandwidth"),
 		"TCP Bandwidth detected in bytes per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentTCPRTT = prometheus.NewDesc(
+	c.currentTCPRTT = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_tcp_rtt_seconds"),
 		"Average TCP round-trip time (RTT) detected in seconds.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentUDPBandwidth = prometheus.NewDesc(
+	c.currentUDPBandwidth = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_udp_bandwidth"),
 		"UDP Bandwidth detected in bytes per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentUDPRTT = prometheus.NewDesc(
+	c.currentUDPRTT = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_udp_rtt_seconds"),
 		"Average UDP round-trip time (RTT) detected in seconds.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.totalReceivedBytes = prometheus.NewDesc(
+	c.totalReceivedBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_received_bytes_total"),
 		"(TotalReceivedBytes)",
 		[]string{"session_name"},
 		nil,
 	)
-	c.totalSentBytes = prometheus.NewDesc(
+	c.totalSentBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_sent_bytes_total"),
 		"(TotalSentBytes)",
 		[]string{"session_name"},
 		nil,
 	)
-	c.udpPacketsReceivedPerSec = prometheus.NewDesc(
+	c.udpPacketsReceivedPerSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_udp_packets_received_total"),
 		"Rate in packets per second at which packets are received over UDP.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.udpPacketsSentPerSec = prometheus.NewDesc(
+	c.udpPacketsSentPerSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_udp_packets_sent_total"),
 		"Rate in packets per second at which packets are sent over UDP.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.fecRate = prometheus.NewDesc(
+	c.fecRate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_fec_rate"),
 		"Forward Error Correction (FEC) percentage",
 		[]string{"session_name"},
 		nil,
 	)
-	c.lossRate = prometheus.NewDesc(
+	c.lossRate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_loss_rate"),
 		"Loss percentage",
 		[]string{"session_name"},
 		nil,
 	)
-	c.retransmissionRate = prometheus.NewDesc(
+	c.retransmissionRate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_retransmission_rate"),
 		"Percentage of packets that have been retransmitted",
 		[]string{"session_name"},
@@ -185,64 +206,76 @@ func (c *Collector) Build(*slog.Logger, *mi.Session) error {
 	)
 
 	// gfx
-	c.averageEncodingTime = prometheus.NewDesc(
+	c.averageEncodingTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_average_encoding_time_seconds"),
 		"Average frame encoding time in seconds",
 		[]string{"session_name"},
 		nil,
 	)
-	c.frameQuality = prometheus.NewDesc(
+	c.frameQuality = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_frame_quality"),
 		"Quality of the output frame expressed as a percentage of the quality of the source frame.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.framesSkippedPerSecondInsufficientResources = prometheus.NewDesc(
+	c.framesSkippedPerSecondInsufficientResources = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_frames_skipped_insufficient_resource_total"),
 		"Number of frames skipped per second due to insufficient client resources.",
 		[]string{"session_name", "resource"},
 		nil,
 	)
-	c.graphicsCompressionRatio = prometheus.NewDesc(
+	c.graphicsCompressionRatio = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_graphics_compression_ratio"),
 		"Ratio of the number of bytes encoded to the number of bytes input.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.inputFramesPerSecond = prometheus.NewDesc(
+	c.inputFramesPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_input_frames_total"),
 		"Number of sources frames provided as input to RemoteFX graphics per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.outputFramesPerSecond = prometheus.NewDesc(
+	c.outputFramesPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_output_frames_total"),
 		"Number of frames sent to the client per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.sourceFramesPerSecond = prometheus.NewDesc(
+	c.sourceFramesPerSecond = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_source_frames_total"),
 		"Number of frames composed by the source (DWM) per second.",
 		[]string{"session_name"},
 		nil,
 	)
+}
 
-	var err error
-
-	errs := make([]error, 0)
-
-	c.perfDataCollectorNetwork, err = pdh.NewCollector[perfDataCounterValuesNetwork](pdh.CounterTypeRaw, "RemoteFX Network", pdh.InstancesAll)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create RemoteFX Network collector: %w", err))
-	}
-
-	c.perfDataCollectorGraphics, err = pdh.NewCollector[perfDataCounterValuesGraphics](pdh.CounterTypeRaw, "RemoteFX Graphics", pdh.InstancesAll)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create RemoteFX Graphics collector: %w", err))
-	}
-
-	return errors.Join(errs...)
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.baseTCPRTT,
+		c.baseUDPRTT,
+		c.currentTCPBandwidth,
+		c.currentTCPRTT,
+		c.currentUDPBandwidth,
+		c.currentUDPRTT,
+		c.fecRate,
+		c.lossRate,
+		c.retransmissionRate,
+		c.averageEncodingTime,
+		c.frameQuality,
+		c.graphicsCompressionRatio,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.totalReceivedBytes,
+		c.totalSentBytes,
+		c.udpPacketsReceivedPerSec,
+		c.udpPacketsSentPerSec,
+		c.framesSkippedPerSecondInsufficientResources,
+		c.inputFramesPerSecond,
+		c.outputFramesPerSecond,
+		c.sourceFramesPerSecond,
+	)
 }
 
 // Collect sends the metric values for each metric
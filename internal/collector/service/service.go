@@ -145,30 +145,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.queryAllServicesBuffer = make([]byte, 1024*100)
 
-	c.info = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "info"),
-		"A metric with a constant '1' value labeled with service information",
-		[]string{"name", "display_name", "run_as", "path_name"},
-		nil,
-	)
-	c.state = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "state"),
-		"The state of the service (State)",
-		[]string{"name", "state"},
-		nil,
-	)
-	c.startMode = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "start_mode"),
-		"The start mode of the service (StartMode)",
-		[]string{"name", "start_mode"},
-		nil,
-	)
-	c.processID = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "process"),
-		"Process of started service. The value is the creation time of the process as a unix timestamp.",
-		[]string{"name", "process_id"},
-		nil,
-	)
+	c.BuildDescs()
 
 	c.apiStateValues = map[uint32]string{
 		windows.SERVICE_CONTINUE_PENDING: "continue pending",
@@ -199,6 +176,34 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	return nil
 }
 
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.info = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "info"),
+		"A metric with a constant '1' value labeled with service information",
+		[]string{"name", "display_name", "run_as", "path_name"},
+		nil,
+	)
+	c.state = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "state"),
+		"The state of the service (State)",
+		[]string{"name", "state"},
+		nil,
+	)
+	c.startMode = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "start_mode"),
+		"The start mode of the service (StartMode)",
+		[]string{"name", "start_mode"},
+		nil,
+	)
+	c.processID = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "process"),
+		"Process of started service. The value is the creation time of the process as a unix timestamp.",
+		[]string{"name", "process_id"},
+		nil,
+	)
+}
+
 func (c *Collector) Close() error {
 	if err := c.serviceManagerHandle.Disconnect(); err != nil {
 		c.logger.Warn("Failed to disconnect from scm",
@@ -209,6 +214,16 @@ func (c *Collector) Close() error {
 	return nil
 }
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.state,
+		c.processID,
+		c.info,
+		c.startMode,
+	)
+}
+
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
@@ -78,63 +78,82 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.currentOpenFileCount = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "SMB Server Shares", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create SMB Server Shares collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.currentOpenFileCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_current_open_file_count"),
 		"Current total count open files on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.treeConnectCount = prometheus.NewDesc(
+	c.treeConnectCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_tree_connect_count"),
 		"Count of user connections to the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.receivedBytes = prometheus.NewDesc(
+	c.receivedBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_received_bytes_total"),
 		"Received bytes on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.writeRequests = prometheus.NewDesc(
+	c.writeRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_write_requests_count_total"),
 		"Writes requests on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.readRequests = prometheus.NewDesc(
+	c.readRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_read_requests_count_total"),
 		"Read requests on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.metadataRequests = prometheus.NewDesc(
+	c.metadataRequests = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_metadata_requests_count_total"),
 		"Metadata requests on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.sentBytes = prometheus.NewDesc(
+	c.sentBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_sent_bytes_total"),
 		"Sent bytes on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.filesOpened = prometheus.NewDesc(
+	c.filesOpened = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_files_opened_count_total"),
 		"Files opened on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "SMB Server Shares", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create SMB Server Shares collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.treeConnectCount,
+		c.currentOpenFileCount,
+		c.receivedBytes,
+		c.writeRequests,
+		c.readRequests,
+		c.metadataRequests,
+		c.sentBytes,
+		c.filesOpened,
+	)
 }
 
 // Collect collects smb metrics and sends them to prometheus.
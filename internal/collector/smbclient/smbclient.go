@@ -93,9 +93,23 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "SMB Client Shares", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create SMB Client Shares collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
 	// desc creates a new prometheus description
 	desc := func(metricName string, description string, labels []string) *prometheus.Desc {
-		return prometheus.NewDesc(
+		return types.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, metricName),
 			description,
 			labels,
@@ -187,15 +201,35 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		"Seconds waiting for write requests on this share",
 		[]string{"server", "share"},
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "SMB Client Shares", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create SMB Client Shares collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.readBytesTotal,
+		c.readBytesTransmittedViaSMBDirectTotal,
+		c.readRequestQueueSecsTotal,
+		c.readRequestsTransmittedViaSMBDirectTotal,
+		c.readSecsTotal,
+		c.readsTotal,
+		c.turboIOReadsTotal,
+		c.TurboIOWritesTotal,
+		c.writeBytesTotal,
+		c.writeBytesTransmittedViaSMBDirectTotal,
+		c.writeRequestQueueSecsTotal,
+		c.writeRequestsTransmittedViaSMBDirectTotal,
+		c.writeSecsTotal,
+		c.writesTotal,
+		c.creditStallsTotal,
+		c.dataBytesTotal,
+		c.dataRequestsTotal,
+		c.metadataRequestsTotal,
+		c.requestQueueSecsTotal,
+		c.requestSecs,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.currentDataQueued,
+	)
 }
 
 // Collect collects smb client metrics and sends them to prometheus.
@@ -163,267 +163,321 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		slog.String("collector", Name),
 	)
 
-	c.badMailedMessagesBadPickupFileTotal = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "SMTP Server", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create SMTP Server collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.badMailedMessagesBadPickupFileTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "badmailed_messages_bad_pickup_file_total"),
 		"Total number of malformed pickup messages sent to badmail",
 		[]string{"site"},
 		nil,
 	)
-	c.badMailedMessagesGeneralFailureTotal = prometheus.NewDesc(
+	c.badMailedMessagesGeneralFailureTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "badmailed_messages_general_failure_total"),
 		"Total number of messages sent to badmail for reasons not associated with a specific counter",
 		[]string{"site"},
 		nil,
 	)
-	c.badMailedMessagesHopCountExceededTotal = prometheus.NewDesc(
+	c.badMailedMessagesHopCountExceededTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "badmailed_messages_hop_count_exceeded_total"),
 		"Total number of messages sent to badmail because they had exceeded the maximum hop count",
 		[]string{"site"},
 		nil,
 	)
-	c.badMailedMessagesNDROfDSNTotal = prometheus.NewDesc(
+	c.badMailedMessagesNDROfDSNTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "badmailed_messages_ndr_of_dns_total"),
 		"Total number of Delivery Status Notifications sent to badmail because they could not be delivered",
 		[]string{"site"},
 		nil,
 	)
-	c.badMailedMessagesNoRecipientsTotal = prometheus.NewDesc(
+	c.badMailedMessagesNoRecipientsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "badmailed_messages_no_recipients_total"),
 		"Total number of messages sent to badmail because they had no recipients",
 		[]string{"site"},
 		nil,
 	)
-	c.badMailedMessagesTriggeredViaEventTotal = prometheus.NewDesc(
+	c.badMailedMessagesTriggeredViaEventTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "badmailed_messages_triggered_via_event_total"),
 		"Total number of messages sent to badmail at the request of a server event sink",
 		[]string{"site"},
 		nil,
 	)
-	c.bytesSentTotal = prometheus.NewDesc(
+	c.bytesSentTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_sent_total"),
 		"Total number of bytes sent",
 		[]string{"site"},
 		nil,
 	)
-	c.bytesReceivedTotal = prometheus.NewDesc(
+	c.bytesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_received_total"),
 		"Total number of bytes received",
 		[]string{"site"},
 		nil,
 	)
-	c.categorizerQueueLength = prometheus.NewDesc(
+	c.categorizerQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "categorizer_queue_length"),
 		"Number of messages in the categorizer queue",
 		[]string{"site"},
 		nil,
 	)
-	c.connectionErrorsTotal = prometheus.NewDesc(
+	c.connectionErrorsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_errors_total"),
 		"Total number of connection errors",
 		[]string{"site"},
 		nil,
 	)
-	c.currentMessagesInLocalDelivery = prometheus.NewDesc(
+	c.currentMessagesInLocalDelivery = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_messages_in_local_delivery"),
 		"Number of messages that are currently being processed by a server event sink for local delivery",
 		[]string{"site"},
 		nil,
 	)
-	c.directoryDropsTotal = prometheus.NewDesc(
+	c.directoryDropsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "directory_drops_total"),
 		"Total number of messages placed in a drop directory",
 		[]string{"site"},
 		nil,
 	)
-	c.dsnFailuresTotal = prometheus.NewDesc(
+	c.dsnFailuresTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dsn_failures_total"),
 		"Total number of failed DSN generation attempts",
 		[]string{"site"},
 		nil,
 	)
-	c.dnsQueriesTotal = prometheus.NewDesc(
+	c.dnsQueriesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dns_queries_total"),
 		"Total number of DNS lookups",
 		[]string{"site"},
 		nil,
 	)
-	c.etrnMessagesTotal = prometheus.NewDesc(
+	c.etrnMessagesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "etrn_messages_total"),
 		"Total number of ETRN messages received by the server",
 		[]string{"site"},
 		nil,
 	)
-	c.inboundConnectionsCurrent = prometheus.NewDesc(
+	c.inboundConnectionsCurrent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "inbound_connections_current"),
 		"Total number of connections currently inbound",
 		[]string{"site"},
 		nil,
 	)
-	c.inboundConnectionsTotal = prometheus.NewDesc(
+	c.inboundConnectionsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "inbound_connections_total"),
 		"Total number of inbound connections received",
 		[]string{"site"},
 		nil,
 	)
-	c.localQueueLength = prometheus.NewDesc(
+	c.localQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "local_queue_length"),
 		"Number of messages in the local queue",
 		[]string{"site"},
 		nil,
 	)
-	c.localRetryQueueLength = prometheus.NewDesc(
+	c.localRetryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "local_retry_queue_length"),
 		"Number of messages in the local retry queue",
 		[]string{"site"},
 		nil,
 	)
-	c.mailFilesOpen = prometheus.NewDesc(
+	c.mailFilesOpen = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mail_files_open"),
 		"Number of handles to open mail files",
 		[]string{"site"},
 		nil,
 	)
-	c.messageBytesReceivedTotal = prometheus.NewDesc(
+	c.messageBytesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "message_bytes_received_total"),
 		"Total number of bytes received in messages",
 		[]string{"site"},
 		nil,
 	)
-	c.messageBytesSentTotal = prometheus.NewDesc(
+	c.messageBytesSentTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "message_bytes_sent_total"),
 		"Total number of bytes sent in messages",
 		[]string{"site"},
 		nil,
 	)
-	c.messageDeliveryRetriesTotal = prometheus.NewDesc(
+	c.messageDeliveryRetriesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "message_delivery_retries_total"),
 		"Total number of local deliveries that were retried",
 		[]string{"site"},
 		nil,
 	)
-	c.messageSendRetriesTotal = prometheus.NewDesc(
+	c.messageSendRetriesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "message_send_retries_total"),
 		"Total number of outbound message sends that were retried",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesCurrentlyUndeliverable = prometheus.NewDesc(
+	c.messagesCurrentlyUndeliverable = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_currently_undeliverable"),
 		"Number of messages that have been reported as currently undeliverable by routing",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesDeliveredTotal = prometheus.NewDesc(
+	c.messagesDeliveredTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_delivered_total"),
 		"Total number of messages delivered to local mailboxes",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesPendingRouting = prometheus.NewDesc(
+	c.messagesPendingRouting = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_pending_routing"),
 		"Number of messages that have been categorized but not routed",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesReceivedTotal = prometheus.NewDesc(
+	c.messagesReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_received_total"),
 		"Total number of inbound messages accepted",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesRefusedForAddressObjectsTotal = prometheus.NewDesc(
+	c.messagesRefusedForAddressObjectsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_refused_for_address_objects_total"),
 		"Total number of messages refused due to no address objects",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesRefusedForMailObjectsTotal = prometheus.NewDesc(
+	c.messagesRefusedForMailObjectsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_refused_for_mail_objects_total"),
 		"Total number of messages refused due to no mail objects",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesRefusedForSizeTotal = prometheus.NewDesc(
+	c.messagesRefusedForSizeTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_refused_for_size_total"),
 		"Total number of messages rejected because they were too big",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesSentTotal = prometheus.NewDesc(
+	c.messagesSentTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_sent_total"),
 		"Total number of outbound messages sent",
 		[]string{"site"},
 		nil,
 	)
-	c.messagesSubmittedTotal = prometheus.NewDesc(
+	c.messagesSubmittedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_submitted_total"),
 		"Total number of messages submitted to queuing for delivery",
 		[]string{"site"},
 		nil,
 	)
-	c.ndrsGeneratedTotal = prometheus.NewDesc(
+	c.ndrsGeneratedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ndrs_generated_total"),
 		"Total number of non-delivery reports that have been generated",
 		[]string{"site"},
 		nil,
 	)
-	c.outboundConnectionsCurrent = prometheus.NewDesc(
+	c.outboundConnectionsCurrent = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "outbound_connections_current"),
 		"Number of connections currently outbound",
 		[]string{"site"},
 		nil,
 	)
-	c.outboundConnectionsRefusedTotal = prometheus.NewDesc(
+	c.outboundConnectionsRefusedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "outbound_connections_refused_total"),
 		"Total number of connection attempts refused by remote sites",
 		[]string{"site"},
 		nil,
 	)
-	c.outboundConnectionsTotal = prometheus.NewDesc(
+	c.outboundConnectionsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "outbound_connections_total"),
 		"Total number of outbound connections attempted",
 		[]string{"site"},
 		nil,
 	)
-	c.pickupDirectoryMessagesRetrievedTotal = prometheus.NewDesc(
+	c.pickupDirectoryMessagesRetrievedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pickup_directory_messages_retrieved_total"),
 		"Total number of messages retrieved from the mail pick-up directory",
 		[]string{"site"},
 		nil,
 	)
-	c.queueFilesOpen = prometheus.NewDesc(
+	c.queueFilesOpen = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "queue_files_open"),
 		"Number of handles to open queue files",
 		[]string{"site"},
 		nil,
 	)
-	c.remoteQueueLength = prometheus.NewDesc(
+	c.remoteQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "remote_queue_length"),
 		"Number of messages in the remote queue",
 		[]string{"site"},
 		nil,
 	)
-	c.remoteRetryQueueLength = prometheus.NewDesc(
+	c.remoteRetryQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "remote_retry_queue_length"),
 		"Number of messages in the retry queue for remote delivery",
 		[]string{"site"},
 		nil,
 	)
-	c.routingTableLookupsTotal = prometheus.NewDesc(
+	c.routingTableLookupsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "routing_table_lookups_total"),
 		"Total number of routing table lookups",
 		[]string{"site"},
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "SMTP Server", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create SMTP Server collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.badMailedMessagesBadPickupFileTotal,
+		c.badMailedMessagesHopCountExceededTotal,
+		c.badMailedMessagesNDROfDSNTotal,
+		c.badMailedMessagesNoRecipientsTotal,
+		c.badMailedMessagesTriggeredViaEventTotal,
+		c.bytesReceivedTotal,
+		c.bytesSentTotal,
+		c.connectionErrorsTotal,
+		c.dnsQueriesTotal,
+		c.dsnFailuresTotal,
+		c.directoryDropsTotal,
+		c.etrnMessagesTotal,
+		c.inboundConnectionsTotal,
+		c.messageBytesReceivedTotal,
+		c.messageBytesSentTotal,
+		c.messageDeliveryRetriesTotal,
+		c.messageSendRetriesTotal,
+		c.messagesDeliveredTotal,
+		c.messagesReceivedTotal,
+		c.messagesRefusedForAddressObjectsTotal,
+		c.messagesRefusedForMailObjectsTotal,
+		c.messagesRefusedForSizeTotal,
+		c.messagesSentTotal,
+		c.messagesSubmittedTotal,
+		c.ndrsGeneratedTotal,
+		c.outboundConnectionsRefusedTotal,
+		c.outboundConnectionsTotal,
+		c.pickupDirectoryMessagesRetrievedTotal,
+		c.routingTableLookupsTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.categorizerQueueLength,
+		c.currentMessagesInLocalDelivery,
+		c.inboundConnectionsCurrent,
+		c.localQueueLength,
+		c.localRetryQueueLength,
+		c.mailFilesOpen,
+		c.messagesCurrentlyUndeliverable,
+		c.messagesPendingRouting,
+		c.outboundConnectionsCurrent,
+		c.queueFilesOpen,
+		c.remoteQueueLength,
+		c.remoteRetryQueueLength,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -26,16 +26,21 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 const Name = "system"
 
-type Config struct{}
+type Config struct {
+	CounterBackend perfdata.Backend `yaml:"counter-backend"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	CounterBackend: perfdata.BackendPDH,
+}
 
 // A Collector is a Prometheus Collector for WMI metrics.
 type Collector struct {
@@ -43,7 +48,7 @@ type Collector struct {
 
 	bootTimeTimestamp float64
 
-	perfDataCollector *pdh.Collector
+	perfDataCollector *perfdata.Collector
 	perfDataObject    []perfDataCounterValues
 
 	contextSwitchesTotal     *prometheus.Desc
@@ -63,6 +68,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CounterBackend == "" {
+		config.CounterBackend = ConfigDefaults.CounterBackend
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -70,8 +79,17 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	app.Flag(
+		"collector.system.counter-backend",
+		"API used to read performance counters. One of: pdh, registry.",
+	).Default(string(ConfigDefaults.CounterBackend)).EnumVar((*string)(&c.config.CounterBackend), perfdata.Backends...)
+
+	return c
 }
 
 func (c *Collector) GetName() string {
@@ -85,72 +103,94 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.bootTime = prometheus.NewDesc(
+	c.BuildDescs()
+
+	c.bootTimeTimestamp = float64(time.Now().Unix() - int64(kernel32.GetTickCount64()/1000))
+
+	var err error
+
+	c.perfDataCollector, err = perfdata.NewCollector[perfDataCounterValues](c.config.CounterBackend, pdh.CounterTypeRaw, "System", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create System collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.bootTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "boot_time_timestamp"),
 		"Unix timestamp of system boot time",
 		nil,
 		nil,
 	)
-	c.bootTimeSeconds = prometheus.NewDesc(
+	c.bootTimeSeconds = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "boot_time_timestamp_seconds"),
 		"Deprecated: Use windows_system_boot_time_timestamp instead",
 		nil,
 		nil,
 	)
-	c.contextSwitchesTotal = prometheus.NewDesc(
+	c.contextSwitchesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "context_switches_total"),
 		"Total number of context switches (WMI source: PerfOS_System.ContextSwitchesPersec)",
 		nil,
 		nil,
 	)
-	c.exceptionDispatchesTotal = prometheus.NewDesc(
+	c.exceptionDispatchesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "exception_dispatches_total"),
 		"Total number of exceptions dispatched (WMI source: PerfOS_System.ExceptionDispatchesPersec)",
 		nil,
 		nil,
 	)
-	c.processes = prometheus.NewDesc(
+	c.processes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processes"),
 		"Current number of processes (WMI source: PerfOS_System.Processes)",
 		nil,
 		nil,
 	)
-	c.processesLimit = prometheus.NewDesc(
+	c.processesLimit = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processes_limit"),
 		"Maximum number of processes.",
 		nil,
 		nil,
 	)
 
-	c.processorQueueLength = prometheus.NewDesc(
+	c.processorQueueLength = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_queue_length"),
 		"Length of processor queue (WMI source: PerfOS_System.ProcessorQueueLength)",
 		nil,
 		nil,
 	)
-	c.systemCallsTotal = prometheus.NewDesc(
+	c.systemCallsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "system_calls_total"),
 		"Total number of system calls (WMI source: PerfOS_System.SystemCallsPersec)",
 		nil,
 		nil,
 	)
-	c.threads = prometheus.NewDesc(
+	c.threads = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "threads"),
 		"Current number of threads (WMI source: PerfOS_System.Threads)",
 		nil,
 		nil,
 	)
+}
 
-	c.bootTimeTimestamp = float64(time.Now().Unix() - int64(kernel32.GetTickCount64()/1000))
-
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "System", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create System collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.contextSwitchesTotal,
+		c.exceptionDispatchesTotal,
+		c.systemCallsTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.processorQueueLength,
+		c.processes,
+		c.processesLimit,
+		c.bootTimeSeconds,
+		c.bootTime,
+		c.threads,
+	)
 }
 
 // Collect sends the metric values for each metric
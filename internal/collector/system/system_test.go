@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/prometheus-community/windows_exporter/internal/collector/system"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
 	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
 )
 
@@ -31,3 +32,9 @@ func BenchmarkCollector(b *testing.B) {
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, system.New, nil)
 }
+
+func TestCollectorRegistryBackend(t *testing.T) {
+	testutils.TestCollector(t, system.New, &system.Config{
+		CounterBackend: perfdata.BackendRegistry,
+	})
+}
@@ -128,86 +128,109 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
+	c.BuildDescs()
+
+	errs := make([]error, 0)
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		var err error
+
+		c.perfDataCollector4, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "TCPv4", nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create TCPv4 collector: %w", err))
+		}
+
+		c.perfDataCollector6, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "TCPv6", nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create TCPv6 collector: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
 	labels := []string{"af"}
 
-	c.connectionFailures = prometheus.NewDesc(
+	c.connectionFailures = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_failures_total"),
 		"(TCP.ConnectionFailures)",
 		labels,
 		nil,
 	)
-	c.connectionsActive = prometheus.NewDesc(
+	c.connectionsActive = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_active_total"),
 		"(TCP.ConnectionsActive)",
 		labels,
 		nil,
 	)
-	c.connectionsEstablished = prometheus.NewDesc(
+	c.connectionsEstablished = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_established"),
 		"(TCP.ConnectionsEstablished)",
 		labels,
 		nil,
 	)
-	c.connectionsPassive = prometheus.NewDesc(
+	c.connectionsPassive = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_passive_total"),
 		"(TCP.ConnectionsPassive)",
 		labels,
 		nil,
 	)
-	c.connectionsReset = prometheus.NewDesc(
+	c.connectionsReset = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_reset_total"),
 		"(TCP.ConnectionsReset)",
 		labels,
 		nil,
 	)
-	c.segmentsTotal = prometheus.NewDesc(
+	c.segmentsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_total"),
 		"(TCP.SegmentsTotal)",
 		labels,
 		nil,
 	)
-	c.segmentsReceivedTotal = prometheus.NewDesc(
+	c.segmentsReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_received_total"),
 		"(TCP.SegmentsReceivedTotal)",
 		labels,
 		nil,
 	)
-	c.segmentsRetransmittedTotal = prometheus.NewDesc(
+	c.segmentsRetransmittedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_retransmitted_total"),
 		"(TCP.SegmentsRetransmittedTotal)",
 		labels,
 		nil,
 	)
-	c.segmentsSentTotal = prometheus.NewDesc(
+	c.segmentsSentTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_sent_total"),
 		"(TCP.SegmentsSentTotal)",
 		labels,
 		nil,
 	)
-	c.connectionsStateCount = prometheus.NewDesc(
+	c.connectionsStateCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_state_count"),
 		"Number of TCP connections by state and address family",
 		[]string{"af", "state"},
 		nil,
 	)
+}
 
-	errs := make([]error, 0)
-
-	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
-		var err error
-
-		c.perfDataCollector4, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "TCPv4", nil)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("failed to create TCPv4 collector: %w", err))
-		}
-
-		c.perfDataCollector6, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "TCPv6", nil)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("failed to create TCPv6 collector: %w", err))
-		}
-	}
-
-	return errors.Join(errs...)
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.connectionFailures,
+		c.connectionsActive,
+		c.connectionsPassive,
+		c.connectionsReset,
+		c.segmentsTotal,
+		c.segmentsReceivedTotal,
+		c.segmentsRetransmittedTotal,
+		c.segmentsSentTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.connectionsEstablished,
+		c.connectionsStateCount,
+	)
 }
 
 // Collect sends the metric values for each metric
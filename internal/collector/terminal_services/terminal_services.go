@@ -137,125 +137,153 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
-	c.sessionInfo = prometheus.NewDesc(
+	c.BuildDescs()
+
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.connectionBrokerEnabled = isConnectionBrokerServer(miSession)
+
+	if c.connectionBrokerEnabled {
+		c.perfDataCollectorBroker, err = pdh.NewCollector[perfDataCounterValuesBroker](pdh.CounterTypeRaw, "Remote Desktop Connection Broker Counterset", pdh.InstancesAll)
+		if err != nil {
+			return fmt.Errorf("failed to create Remote Desktop Connection Broker Counterset collector: %w", err)
+		}
+	} else {
+		logger.Debug("host is not a connection broker skipping Connection Broker performance metrics.")
+	}
+
+	c.hServer, err = wtsapi32.WTSOpenServer("")
+	if err != nil {
+		return fmt.Errorf("failed to open WTS server: %w", err)
+	}
+
+	c.perfDataCollectorTerminalServicesSession, err = pdh.NewCollector[perfDataCounterValuesTerminalServicesSession](pdh.CounterTypeRaw, "Terminal Services Session", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create Terminal Services Session collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.sessionInfo = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "session_info"),
 		"Terminal Services sessions info",
 		[]string{"session_name", "user", "host", "state", "id"},
 		nil,
 	)
-	c.connectionBrokerPerformance = prometheus.NewDesc(
+	c.connectionBrokerPerformance = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_broker_performance_total"),
 		"The total number of connections handled by the Connection Brokers since the service started.",
 		[]string{"connection"},
 		nil,
 	)
-	c.handleCount = prometheus.NewDesc(
+	c.handleCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "handles"),
 		"Total number of handles currently opened by this process. This number is the sum of the handles currently opened by each thread in this process.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.pageFaultsPerSec = prometheus.NewDesc(
+	c.pageFaultsPerSec = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_fault_total"),
 		"Rate at which page faults occur in the threads executing in this process. A page fault occurs when a thread refers to a virtual memory page that is not in its working set in main memory. The page may not be retrieved from disk if it is on the standby list and therefore already in main memory. The page also may not be retrieved if it is in use by another process which shares the page.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.pageFileBytes = prometheus.NewDesc(
+	c.pageFileBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_file_bytes"),
 		"Current number of bytes this process has used in the paging file(s). Paging files are used to store pages of memory used by the process that are not contained in other files. Paging files are shared by all processes, and lack of space in paging files can prevent other processes from allocating memory.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.pageFileBytesPeak = prometheus.NewDesc(
+	c.pageFileBytesPeak = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_file_bytes_peak"),
 		"Maximum number of bytes this process has used in the paging file(s). Paging files are used to store pages of memory used by the process that are not contained in other files. Paging files are shared by all processes, and lack of space in paging files can prevent other processes from allocating memory.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.percentCPUTime = prometheus.NewDesc(
+	c.percentCPUTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_time_seconds_total"),
 		"Total elapsed time that this process's threads have spent executing code.",
 		[]string{"mode", "session_name"},
 		nil,
 	)
-	c.poolNonPagedBytes = prometheus.NewDesc(
+	c.poolNonPagedBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_non_paged_bytes"),
 		"Number of bytes in the non-paged pool, an area of system memory (physical memory used by the operating system) for objects that cannot be written to disk, but must remain in physical memory as long as they are allocated. This property displays the last observed value only; it is not an average.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.poolPagedBytes = prometheus.NewDesc(
+	c.poolPagedBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_paged_bytes"),
 		"Number of bytes in the paged pool, an area of system memory (physical memory used by the operating system) for objects that can be written to disk when they are not being used. This property displays the last observed value only; it is not an average.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.privateBytes = prometheus.NewDesc(
+	c.privateBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "private_bytes"),
 		"Current number of bytes this process has allocated that cannot be shared with other processes.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.threadCount = prometheus.NewDesc(
+	c.threadCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "threads"),
 		"Number of threads currently active in this process. An instruction is the basic unit of execution in a processor, and a thread is the object that executes instructions. Every running process has at least one thread.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.virtualBytes = prometheus.NewDesc(
+	c.virtualBytes = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_bytes"),
 		"Current size, in bytes, of the virtual address space the process is using. Use of virtual address space does not necessarily imply corresponding use of either disk or main memory pages. Virtual space is finite and, by using too much, the process can limit its ability to load libraries.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.virtualBytesPeak = prometheus.NewDesc(
+	c.virtualBytesPeak = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_bytes_peak"),
 		"Maximum number of bytes of virtual address space the process has used at any one time. Use of virtual address space does not necessarily imply corresponding use of either disk or main memory pages. Virtual space is finite and, by using too much, the process might limit its ability to load libraries.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.workingSet = prometheus.NewDesc(
+	c.workingSet = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_bytes"),
 		"Current number of bytes in the working set of this process. The working set is the set of memory pages touched recently by the threads in the process. If free memory in the computer is above a threshold, pages are left in the working set of a process even if they are not in use. When free memory falls below a threshold, pages are trimmed from working sets. If they are needed, they are then soft-faulted back into the working set before they leave main memory.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.workingSetPeak = prometheus.NewDesc(
+	c.workingSetPeak = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_bytes_peak"),
 		"Maximum number of bytes in the working set of this process at any point in time. The working set is the set of memory pages touched recently by the threads in the process. If free memory in the computer is above a threshold, pages are left in the working set of a process even if they are not in use. When free memory falls below a threshold, pages are trimmed from working sets. If they are needed, they are then soft-faulted back into the working set before they leave main memory.",
 		[]string{"session_name"},
 		nil,
 	)
+}
 
-	if miSession == nil {
-		return errors.New("miSession is nil")
-	}
-
-	var err error
-
-	c.connectionBrokerEnabled = isConnectionBrokerServer(miSession)
-
-	if c.connectionBrokerEnabled {
-		c.perfDataCollectorBroker, err = pdh.NewCollector[perfDataCounterValuesBroker](pdh.CounterTypeRaw, "Remote Desktop Connection Broker Counterset", pdh.InstancesAll)
-		if err != nil {
-			return fmt.Errorf("failed to create Remote Desktop Connection Broker Counterset collector: %w", err)
-		}
-	} else {
-		logger.Debug("host is not a connection broker skipping Connection Broker performance metrics.")
-	}
-
-	c.hServer, err = wtsapi32.WTSOpenServer("")
-	if err != nil {
-		return fmt.Errorf("failed to open WTS server: %w", err)
-	}
-
-	c.perfDataCollectorTerminalServicesSession, err = pdh.NewCollector[perfDataCounterValuesTerminalServicesSession](pdh.CounterTypeRaw, "Terminal Services Session", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create Terminal Services Session collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.sessionInfo,
+		c.handleCount,
+		c.pageFileBytes,
+		c.pageFileBytesPeak,
+		c.poolNonPagedBytes,
+		c.poolPagedBytes,
+		c.privateBytes,
+		c.threadCount,
+		c.virtualBytes,
+		c.virtualBytesPeak,
+		c.workingSet,
+		c.workingSetPeak,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.connectionBrokerPerformance,
+		c.pageFaultsPerSec,
+		c.percentCPUTime,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -0,0 +1,594 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package textfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// openMetricsEOF terminates every exposition in the OpenMetrics text format.
+const openMetricsEOF = "# EOF"
+
+//nolint:gochecknoglobals
+var openMetricsHelpUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\"`, `"`)
+
+// isOpenMetrics reports whether a file uses the OpenMetrics text format,
+// either by its .om extension or by the # EOF line terminating the content.
+func isOpenMetrics(path string, content []byte) bool {
+	if strings.EqualFold(filepath.Ext(path), ".om") {
+		return true
+	}
+
+	content = bytes.TrimRight(content, " \t\n")
+
+	return string(content[bytes.LastIndexByte(content, '\n')+1:]) == openMetricsEOF
+}
+
+// openMetricsFamily collects the samples of one metric family while parsing.
+type openMetricsFamily struct {
+	name     string
+	typ      string
+	help     *string
+	unit     *string
+	sampled  bool
+	metrics  []*dto.Metric
+	byLabels map[string]*dto.Metric
+}
+
+// openMetricsSample is a single sample line, like `foo_bucket{le="1"} 3 1700000000 # {trace_id="abc"} 0.5`.
+type openMetricsSample struct {
+	name        string
+	labels      []*dto.LabelPair
+	value       float64
+	timestampMs *int64
+	exemplar    *dto.Exemplar
+}
+
+// openMetricsParser holds the state of [parseOpenMetrics].
+type openMetricsParser struct {
+	families []*openMetricsFamily
+	// family is the family of the last metadata or sample line.
+	family *openMetricsFamily
+	seen   map[string]struct{}
+	eof    bool
+}
+
+// parseOpenMetrics parses content in the OpenMetrics text format into metric families,
+// keyed by the metric names the classic text format would use for them.
+// Counters are named with their _total suffix and info metrics with their _info suffix, so the series keep their names.
+// Sample timestamps, exemplars of counters and histogram buckets and _created series are preserved.
+//
+// https://github.com/prometheus/OpenMetrics/blob/main/specification/OpenMetrics.md
+func parseOpenMetrics(content []byte) (map[string]*dto.MetricFamily, error) {
+	p := &openMetricsParser{seen: map[string]struct{}{}}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		if err := p.parseLine(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+
+	if !p.eof {
+		return nil, fmt.Errorf("missing %s", openMetricsEOF)
+	}
+
+	metricFamilies := make(map[string]*dto.MetricFamily, len(p.families))
+
+	for _, family := range p.families {
+		if len(family.metrics) == 0 {
+			continue
+		}
+
+		metricFamily := family.metricFamily()
+		metricFamilies[metricFamily.GetName()] = metricFamily
+	}
+
+	return metricFamilies, nil
+}
+
+func (p *openMetricsParser) parseLine(line string) error {
+	switch {
+	case strings.TrimSpace(line) == "":
+		return nil
+	case p.eof:
+		return fmt.Errorf("content after %s", openMetricsEOF)
+	case line == openMetricsEOF:
+		p.eof = true
+
+		return nil
+	case strings.HasPrefix(line, "#"):
+		return p.parseMetadata(line)
+	}
+
+	sample, err := parseOpenMetricsSample(line)
+	if err != nil {
+		return err
+	}
+
+	suffix, ok := p.family.suffix(sample.name)
+	if !ok {
+		if p.family != nil && sample.name == p.family.name {
+			return fmt.Errorf("invalid sample %q of %s metric family %q", sample.name, p.family.typ, p.family.name)
+		}
+
+		if err = p.newFamily(sample.name); err != nil {
+			return err
+		}
+	}
+
+	return p.family.add(suffix, sample)
+}
+
+// parseMetadata parses a # HELP, # TYPE or # UNIT line.
+func (p *openMetricsParser) parseMetadata(line string) error {
+	parts := strings.SplitN(line, " ", 4)
+	if len(parts) < 3 || parts[0] != "#" {
+		return fmt.Errorf("invalid metadata line %q", line)
+	}
+
+	keyword, name := parts[1], parts[2]
+
+	var text string
+	if len(parts) == 4 {
+		text = parts[3]
+	}
+
+	if p.family == nil || p.family.name != name {
+		if err := p.newFamily(name); err != nil {
+			return err
+		}
+	} else if p.family.sampled {
+		return fmt.Errorf("metadata of metric family %q after its samples", name)
+	}
+
+	switch keyword {
+	case "HELP":
+		help := openMetricsHelpUnescaper.Replace(text)
+		p.family.help = &help
+	case "UNIT":
+		p.family.unit = &text
+	case "TYPE":
+		switch text {
+		case "counter", "gauge", "histogram", "summary", "info", "stateset", "unknown":
+			p.family.typ = text
+		case "gaugehistogram":
+			return fmt.Errorf("unsupported metric type %q of metric family %q", text, name)
+		default:
+			return fmt.Errorf("invalid metric type %q of metric family %q", text, name)
+		}
+	default:
+		return fmt.Errorf("invalid metadata line %q", line)
+	}
+
+	return nil
+}
+
+func (p *openMetricsParser) newFamily(name string) error {
+	if _, ok := p.seen[name]; ok {
+		return fmt.Errorf("metric family %q is not contiguous", name)
+	}
+
+	p.seen[name] = struct{}{}
+	p.family = &openMetricsFamily{name: name, typ: "unknown", byLabels: map[string]*dto.Metric{}}
+	p.families = append(p.families, p.family)
+
+	return nil
+}
+
+// suffix returns the suffix of the sample name, if the sample belongs to the family.
+func (f *openMetricsFamily) suffix(name string) (string, bool) {
+	if f == nil || !strings.HasPrefix(name, f.name) {
+		return "", false
+	}
+
+	var suffixes []string
+
+	switch f.typ {
+	case "counter":
+		suffixes = []string{"_total", "_created"}
+	case "histogram":
+		suffixes = []string{"_bucket", "_count", "_sum", "_created"}
+	case "summary":
+		suffixes = []string{"", "_count", "_sum", "_created"}
+	case "info":
+		suffixes = []string{"_info"}
+	default:
+		suffixes = []string{""}
+	}
+
+	suffix := name[len(f.name):]
+
+	for _, s := range suffixes {
+		if s == suffix {
+			return suffix, true
+		}
+	}
+
+	return "", false
+}
+
+// add adds a sample to the metric of its label set.
+func (f *openMetricsFamily) add(suffix string, sample openMetricsSample) error {
+	f.sampled = true
+
+	if sample.exemplar != nil && !(f.typ == "counter" && suffix == "_total") && !(f.typ == "histogram" && suffix == "_bucket") {
+		return fmt.Errorf("exemplar on %q is not supported, only counters and histogram buckets have exemplars", sample.name)
+	}
+
+	// Buckets and quantiles of the same histogram or summary share one metric.
+	var bound string
+
+	switch {
+	case f.typ == "histogram" && suffix == "_bucket":
+		bound = "le"
+	case f.typ == "summary" && suffix == "":
+		bound = "quantile"
+	}
+
+	labels := make([]*dto.LabelPair, 0, len(sample.labels))
+
+	var boundValue *float64
+
+	for _, label := range sample.labels {
+		if bound != "" && label.GetName() == bound {
+			value, err := strconv.ParseFloat(label.GetValue(), 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s label of %q: %w", bound, sample.name, err)
+			}
+
+			boundValue = &value
+
+			continue
+		}
+
+		labels = append(labels, label)
+	}
+
+	if bound != "" && boundValue == nil {
+		return fmt.Errorf("missing %s label of %q", bound, sample.name)
+	}
+
+	metric := f.metric(labels)
+
+	if sample.timestampMs != nil {
+		metric.TimestampMs = sample.timestampMs
+	}
+
+	switch {
+	case suffix == "_created":
+		created := timestamppb.New(secondsToTime(sample.value))
+
+		switch f.typ {
+		case "counter":
+			metric.Counter.CreatedTimestamp = created
+		case "histogram":
+			metric.Histogram.CreatedTimestamp = created
+		case "summary":
+			metric.Summary.CreatedTimestamp = created
+		}
+	case f.typ == "counter":
+		metric.Counter.Value = &sample.value
+		metric.Counter.Exemplar = sample.exemplar
+	case f.typ == "histogram" && suffix == "_bucket":
+		count, err := sampleCount(sample)
+		if err != nil {
+			return err
+		}
+
+		metric.Histogram.Bucket = append(metric.Histogram.Bucket, &dto.Bucket{
+			UpperBound:      boundValue,
+			CumulativeCount: &count,
+			Exemplar:        sample.exemplar,
+		})
+	case f.typ == "summary" && suffix == "":
+		metric.Summary.Quantile = append(metric.Summary.Quantile, &dto.Quantile{
+			Quantile: boundValue,
+			Value:    &sample.value,
+		})
+	case suffix == "_count":
+		count, err := sampleCount(sample)
+		if err != nil {
+			return err
+		}
+
+		if f.typ == "histogram" {
+			metric.Histogram.SampleCount = &count
+		} else {
+			metric.Summary.SampleCount = &count
+		}
+	case suffix == "_sum":
+		if f.typ == "histogram" {
+			metric.Histogram.SampleSum = &sample.value
+		} else {
+			metric.Summary.SampleSum = &sample.value
+		}
+	case f.typ == "unknown":
+		metric.Untyped = &dto.Untyped{Value: &sample.value}
+	default:
+		metric.Gauge = &dto.Gauge{Value: &sample.value}
+	}
+
+	return nil
+}
+
+// metric returns the metric of the label set, creating it on first use.
+func (f *openMetricsFamily) metric(labels []*dto.LabelPair) *dto.Metric {
+	signature := make([]string, 0, len(labels))
+	for _, label := range labels {
+		signature = append(signature, label.GetName()+"\xff"+label.GetValue())
+	}
+
+	sort.Strings(signature)
+
+	key := strings.Join(signature, "\xff")
+
+	if metric, ok := f.byLabels[key]; ok {
+		return metric
+	}
+
+	metric := &dto.Metric{Label: labels}
+
+	switch f.typ {
+	case "counter":
+		metric.Counter = &dto.Counter{}
+	case "histogram":
+		metric.Histogram = &dto.Histogram{}
+	case "summary":
+		metric.Summary = &dto.Summary{}
+	}
+
+	f.byLabels[key] = metric
+	f.metrics = append(f.metrics, metric)
+
+	return metric
+}
+
+func (f *openMetricsFamily) metricFamily() *dto.MetricFamily {
+	name := f.name
+	metricType := dto.MetricType_UNTYPED
+
+	switch f.typ {
+	case "counter":
+		name += "_total"
+		metricType = dto.MetricType_COUNTER
+	case "info":
+		name += "_info"
+		metricType = dto.MetricType_GAUGE
+	case "gauge", "stateset":
+		metricType = dto.MetricType_GAUGE
+	case "histogram":
+		metricType = dto.MetricType_HISTOGRAM
+	case "summary":
+		metricType = dto.MetricType_SUMMARY
+	}
+
+	return &dto.MetricFamily{
+		Name:   &name,
+		Help:   f.help,
+		Type:   &metricType,
+		Unit:   f.unit,
+		Metric: f.metrics,
+	}
+}
+
+// parseOpenMetricsSample parses a sample line with an optional timestamp and exemplar.
+func parseOpenMetricsSample(line string) (openMetricsSample, error) {
+	var (
+		sample openMetricsSample
+		err    error
+	)
+
+	i := metricNameEnd(line)
+	if i == 0 {
+		return sample, fmt.Errorf("invalid metric name in %q", line)
+	}
+
+	sample.name = line[:i]
+
+	if i < len(line) && line[i] == '{' {
+		if sample.labels, i, err = parseOpenMetricsLabels(line, i); err != nil {
+			return sample, fmt.Errorf("invalid labels of %q: %w", sample.name, err)
+		}
+	}
+
+	if i >= len(line) || line[i] != ' ' {
+		return sample, fmt.Errorf("missing value of %q", sample.name)
+	}
+
+	rest := line[i+1:]
+
+	var exemplar string
+	if before, after, ok := strings.Cut(rest, " # "); ok {
+		rest, exemplar = before, after
+	}
+
+	if sample.value, sample.timestampMs, err = parseOpenMetricsValue(rest); err != nil {
+		return sample, fmt.Errorf("invalid value of %q: %w", sample.name, err)
+	}
+
+	if exemplar == "" {
+		return sample, nil
+	}
+
+	if !strings.HasPrefix(exemplar, "{") {
+		return sample, fmt.Errorf("invalid exemplar of %q", sample.name)
+	}
+
+	exemplarLabels, i, err := parseOpenMetricsLabels(exemplar, 0)
+	if err != nil || i >= len(exemplar) || exemplar[i] != ' ' {
+		return sample, errors.Join(fmt.Errorf("invalid exemplar of %q", sample.name), err)
+	}
+
+	value, timestampMs, err := parseOpenMetricsValue(exemplar[i+1:])
+	if err != nil {
+		return sample, fmt.Errorf("invalid exemplar of %q: %w", sample.name, err)
+	}
+
+	sample.exemplar = &dto.Exemplar{Label: exemplarLabels, Value: &value}
+
+	if timestampMs != nil {
+		sample.exemplar.Timestamp = timestamppb.New(time.UnixMilli(*timestampMs))
+	}
+
+	return sample, nil
+}
+
+// parseOpenMetricsValue parses a value followed by an optional timestamp in seconds.
+func parseOpenMetricsValue(s string) (float64, *int64, error) {
+	fields := strings.Split(s, " ")
+	if len(fields) > 2 {
+		return 0, nil, fmt.Errorf("unexpected %q", s)
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(fields) == 1 {
+		return value, nil, nil
+	}
+
+	seconds, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, nil, fmt.Errorf("invalid timestamp %q", fields[1])
+	}
+
+	timestampMs := secondsToTime(seconds).UnixMilli()
+
+	return value, &timestampMs, nil
+}
+
+// parseOpenMetricsLabels parses the label set starting with the opening brace at s[i]
+// and returns the labels and the index after the closing brace.
+func parseOpenMetricsLabels(s string, i int) ([]*dto.LabelPair, int, error) {
+	labels := make([]*dto.LabelPair, 0)
+	names := map[string]struct{}{}
+
+	i++ // skip {
+
+	for i < len(s) && s[i] != '}' {
+		end := i + labelNameEnd(s[i:])
+		if end == i || end+1 >= len(s) || s[end] != '=' || s[end+1] != '"' {
+			return nil, 0, errors.New("invalid label name")
+		}
+
+		name := s[i:end]
+		if _, ok := names[name]; ok {
+			return nil, 0, fmt.Errorf("duplicate label %q", name)
+		}
+
+		names[name] = struct{}{}
+
+		var value strings.Builder
+
+		for i = end + 2; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				case '\\', '"':
+					value.WriteByte(s[i])
+				default:
+					value.WriteByte('\\')
+					value.WriteByte(s[i])
+				}
+
+				continue
+			}
+
+			value.WriteByte(s[i])
+		}
+
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated value of label %q", name)
+		}
+
+		labelValue := value.String()
+		labels = append(labels, &dto.LabelPair{Name: &name, Value: &labelValue})
+
+		i++ // skip "
+
+		if i < len(s) && s[i] == ',' {
+			i++
+		} else if i < len(s) && s[i] != '}' {
+			return nil, 0, fmt.Errorf("unexpected %q after label %q", s[i], name)
+		}
+	}
+
+	if i >= len(s) {
+		return nil, 0, errors.New("missing closing brace")
+	}
+
+	return labels, i + 1, nil
+}
+
+// metricNameEnd returns the length of the metric name at the start of s.
+func metricNameEnd(s string) int {
+	for i := range len(s) {
+		c := s[i]
+		if c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+
+		return i
+	}
+
+	return len(s)
+}
+
+// labelNameEnd returns the length of the label name at the start of s.
+func labelNameEnd(s string) int {
+	for i := range len(s) {
+		c := s[i]
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+
+		return i
+	}
+
+	return len(s)
+}
+
+func sampleCount(sample openMetricsSample) (uint64, error) {
+	if sample.value < 0 || math.IsNaN(sample.value) || math.IsInf(sample.value, 0) || sample.value != math.Trunc(sample.value) {
+		return 0, fmt.Errorf("invalid count %v of %q", sample.value, sample.name)
+	}
+
+	return uint64(sample.value), nil
+}
+
+func secondsToTime(seconds float64) time.Time {
+	sec, frac := math.Modf(seconds)
+
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
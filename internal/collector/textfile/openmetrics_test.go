@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package textfile
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOpenMetrics(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isOpenMetrics("metrics.om", []byte("foo 1\n")))
+	require.True(t, isOpenMetrics("metrics.prom", []byte("foo 1\n# EOF\n")))
+	require.True(t, isOpenMetrics("metrics.prom", []byte("# EOF")))
+	require.False(t, isOpenMetrics("metrics.prom", []byte("foo 1\n")))
+	require.False(t, isOpenMetrics("metrics.prom", []byte("")))
+}
+
+func TestParseOpenMetrics(t *testing.T) {
+	t.Parallel()
+
+	families, err := parseOpenMetrics([]byte(`# HELP requests Requests with "quotes" and \\ backslash.
+# TYPE requests counter
+requests_total{path="/a\"b",code="200"} 10 1700000000.5 # {trace_id="abc"} 1 1700000000
+requests_created{code="200",path="/a\"b"} 1699990000.25
+# TYPE latency summary
+latency{quantile="0.5"} 0.25
+latency{quantile="0.99"} 1.5
+latency_count 17
+latency_sum 9.5
+# TYPE state stateset
+state{state="running"} 1
+state{state="stopped"} 0
+untyped_metric{a="b"} NaN
+# EOF
+`))
+	require.NoError(t, err)
+	require.Len(t, families, 4)
+
+	requests := families["requests_total"]
+	require.Equal(t, dto.MetricType_COUNTER, requests.GetType())
+	require.Equal(t, `Requests with "quotes" and \ backslash.`, requests.GetHelp())
+	require.Len(t, requests.GetMetric(), 1)
+
+	counter := requests.GetMetric()[0]
+	require.Equal(t, `/a"b`, counter.GetLabel()[0].GetValue())
+	require.InDelta(t, 10.0, counter.GetCounter().GetValue(), 0)
+	require.Equal(t, int64(1700000000500), counter.GetTimestampMs())
+	require.Equal(t, int64(1699990000), counter.GetCounter().GetCreatedTimestamp().GetSeconds())
+	require.Equal(t, int32(250000000), counter.GetCounter().GetCreatedTimestamp().GetNanos())
+	require.Equal(t, "trace_id", counter.GetCounter().GetExemplar().GetLabel()[0].GetName())
+	require.Equal(t, int64(1700000000), counter.GetCounter().GetExemplar().GetTimestamp().GetSeconds())
+
+	latency := families["latency"]
+	require.Equal(t, dto.MetricType_SUMMARY, latency.GetType())
+	require.Len(t, latency.GetMetric(), 1)
+	require.Len(t, latency.GetMetric()[0].GetSummary().GetQuantile(), 2)
+	require.Equal(t, uint64(17), latency.GetMetric()[0].GetSummary().GetSampleCount())
+	require.InDelta(t, 9.5, latency.GetMetric()[0].GetSummary().GetSampleSum(), 0)
+
+	state := families["state"]
+	require.Equal(t, dto.MetricType_GAUGE, state.GetType())
+	require.Len(t, state.GetMetric(), 2)
+
+	require.Equal(t, dto.MetricType_UNTYPED, families["untyped_metric"].GetType())
+}
+
+func TestParseOpenMetricsErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"missing EOF", "foo 1\n", "missing # EOF"},
+		{"content after EOF", "# EOF\nfoo 1\n", "content after # EOF"},
+		{"gauge histogram", "# TYPE foo gaugehistogram\n# EOF\n", `unsupported metric type "gaugehistogram"`},
+		{"invalid type", "# TYPE foo bar\n# EOF\n", `invalid metric type "bar"`},
+		{"counter without suffix", "# TYPE foo counter\nfoo 1\n# EOF\n", `invalid sample "foo" of counter metric family "foo"`},
+		{"not contiguous", "foo 1\nbar 1\nfoo{a=\"b\"} 1\n# EOF\n", `metric family "foo" is not contiguous`},
+		{"metadata after samples", "# TYPE foo gauge\nfoo 1\n# HELP foo help\n# EOF\n", "after its samples"},
+		{"exemplar on gauge", "# TYPE foo gauge\nfoo 1 # {a=\"b\"} 1\n# EOF\n", "only counters and histogram buckets have exemplars"},
+		{"bucket without le", "# TYPE foo histogram\nfoo_bucket 1\n# EOF\n", "missing le label"},
+		{"fractional bucket count", "# TYPE foo histogram\nfoo_bucket{le=\"1\"} 1.5\n# EOF\n", "invalid count"},
+		{"unterminated label value", "foo{a=\"b} 1\n# EOF\n", "unterminated value"},
+		{"duplicate label", "foo{a=\"b\",a=\"c\"} 1\n# EOF\n", `duplicate label "a"`},
+		{"invalid value", "foo abc\n# EOF\n", "invalid value"},
+		{"invalid timestamp", "foo 1 abc\n# EOF\n", "invalid timestamp"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseOpenMetrics([]byte(tc.content))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
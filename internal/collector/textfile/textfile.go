@@ -18,6 +18,7 @@
 package textfile
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -25,28 +26,70 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/dimchansky/utfbom"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
+	"gopkg.in/yaml.v3"
 )
 
 const Name = "textfile"
 
+// dirPlaceholder in a label value of a [Source] is replaced by the name of the directory containing the file.
+const dirPlaceholder = "{dir}"
+
+//nolint:gochecknoglobals
+var reLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 type Config struct {
 	TextFileDirectories []string `yaml:"directories"`
+	// Sources are directories or glob patterns of files with labels added to all series of their files.
+	Sources []Source `yaml:"sources"`
+	// Timestamps passes the sample timestamps of the files through. Otherwise, files with timestamps are rejected.
+	Timestamps bool `yaml:"timestamps"`
+	// MaxTimestampAge rejects files with sample timestamps older than the given age. 0 disables the check.
+	MaxTimestampAge time.Duration `yaml:"max-timestamp-age"`
+	// MaxAge skips files whose mtime is older than the given age. 0 disables the check.
+	MaxAge time.Duration `yaml:"max-age"`
 }
 
 //nolint:gochecknoglobals
 var ConfigDefaults = Config{
 	TextFileDirectories: []string{getDefaultPath()},
+	Timestamps:          false,
+	MaxTimestampAge:     0,
+	MaxAge:              0,
+	Sources:             make([]Source, 0),
+}
+
+// UnmarshalYAML skips the validation of the config file section. It is applied through the flags,
+// since the sources are given as YAML string, e.g. `sources: |-`.
+func (*Config) UnmarshalYAML(*yaml.Node) error {
+	return nil
+}
+
+// Source is a directory, which is read recursively, or a glob pattern of text files.
+type Source struct {
+	Path string `yaml:"path"`
+	// Labels are added to all series of the files, unless the series already have the label.
+	// The placeholder {dir} in a value is replaced by the name of the directory containing the file.
+	Labels map[string]string `yaml:"labels"`
+
+	// directory is set for the entries of Config.TextFileDirectories.
+	// Their files are labeled with their file name, which must be unique.
+	directory bool
 }
 
 type Collector struct {
@@ -56,7 +99,18 @@ type Collector struct {
 	// Only set for testing to get predictable output.
 	mTime *float64
 
-	modTimeDesc *prometheus.Desc
+	modTimeDesc    *prometheus.Desc
+	staleDesc      *prometheus.Desc
+	parseErrorDesc *prometheus.Desc
+}
+
+// fileStatus holds the state of a file during a collection.
+type fileStatus struct {
+	modTime time.Time
+	// stale is set, if the file was skipped because its mtime is older than the max age.
+	stale bool
+	// parseError is set, if the file could not be read or parsed.
+	parseError bool
 }
 
 func New(config *Config) *Collector {
@@ -68,6 +122,10 @@ func New(config *Config) *Collector {
 		config.TextFileDirectories = ConfigDefaults.TextFileDirectories
 	}
 
+	if config.Sources == nil {
+		config.Sources = ConfigDefaults.Sources
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -80,16 +138,44 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		config: ConfigDefaults,
 	}
 
-	var textFileDirectories string
+	var textFileDirectories, sources string
 
 	app.Flag(
 		"collector.textfile.directories",
-		"Directory or Directories to read text files with metrics from.",
+		"Directory or Directories to read text files with metrics from. Each entry can also be a glob pattern that can contain `*`, `?`, and `**` (recursive). See https://github.com/bmatcuk/doublestar#patterns",
 	).Default(strings.Join(ConfigDefaults.TextFileDirectories, ",")).StringVar(&textFileDirectories)
 
+	app.Flag(
+		"collector.textfile.sources",
+		"Directories or glob patterns with labels added to all series of their files. See docs for more information on how to use this flag.",
+	).Default("").StringVar(&sources)
+
+	app.Flag(
+		"collector.textfile.timestamps",
+		"If true, sample timestamps of the text files are passed through. Otherwise, files with timestamps are rejected.",
+	).Default(strconv.FormatBool(ConfigDefaults.Timestamps)).BoolVar(&c.config.Timestamps)
+
+	app.Flag(
+		"collector.textfile.max-timestamp-age",
+		"Reject text files with sample timestamps older than this age. 0 disables the check.",
+	).Default(ConfigDefaults.MaxTimestampAge.String()).DurationVar(&c.config.MaxTimestampAge)
+
+	app.Flag(
+		"collector.textfile.max-age",
+		"Skip text files whose mtime is older than this age and report them as stale. 0 disables the check.",
+	).Default(ConfigDefaults.MaxAge.String()).DurationVar(&c.config.MaxAge)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.TextFileDirectories = strings.Split(textFileDirectories, ",")
 
+		if sources == "" {
+			return nil
+		}
+
+		if err := yaml.Unmarshal([]byte(sources), &c.config.Sources); err != nil {
+			return fmt.Errorf("failed to parse sources %s: %w", sources, err)
+		}
+
 		return nil
 	})
 
@@ -109,9 +195,37 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.logger.Info("textfile directories: " + strings.Join(c.config.TextFileDirectories, ","))
 
+	for _, source := range c.sources() {
+		if isGlob(source.Path) {
+			if _, pattern := doublestar.SplitPattern(filepath.ToSlash(source.Path)); !doublestar.ValidatePattern(pattern) {
+				return fmt.Errorf("invalid glob pattern %q", source.Path)
+			}
+		}
+
+		for name := range source.Labels {
+			if !reLabelName.MatchString(name) {
+				return fmt.Errorf("source %s: invalid label name %q", source.Path, name)
+			}
+		}
+	}
+
 	c.modTimeDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, "textfile", "mtime_seconds"),
-		"Unixtime mtime of textfiles successfully read.",
+		"Unixtime mtime of textfiles successfully read or skipped as stale.",
+		[]string{"file"},
+		nil,
+	)
+
+	c.staleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "textfile", "file_stale"),
+		"1 if the textfile was skipped, because its mtime is older than the max age, 0 otherwise.",
+		[]string{"file"},
+		nil,
+	)
+
+	c.parseErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "textfile", "file_parse_error"),
+		"1 if the textfile could not be read or parsed, 0 otherwise.",
 		[]string{"file"},
 		nil,
 	)
@@ -166,10 +280,6 @@ func (c *Collector) convertMetricFamily(logger *slog.Logger, metricFamily *dto.M
 	}
 
 	for _, metric := range metricFamily.GetMetric() {
-		if metric.TimestampMs != nil {
-			logger.Warn(fmt.Sprintf("Ignoring unsupported custom timestamp on textfile metric %v", metric))
-		}
-
 		labels := metric.GetLabel()
 
 		var names []string
@@ -198,12 +308,27 @@ func (c *Collector) convertMetricFamily(logger *slog.Logger, metricFamily *dto.M
 			}
 		}
 
+		desc := prometheus.NewDesc(
+			metricFamily.GetName(),
+			metricFamily.GetHelp(),
+			names, nil,
+		)
+
+		var (
+			m         prometheus.Metric
+			exemplars []prometheus.Exemplar
+		)
+
 		metricType := metricFamily.GetType()
 		switch metricType {
 		case dto.MetricType_COUNTER:
 			valType = prometheus.CounterValue
 			val = metric.GetCounter().GetValue()
 
+			if exemplar := metric.GetCounter().GetExemplar(); exemplar != nil {
+				exemplars = append(exemplars, convertExemplar(exemplar))
+			}
+
 		case dto.MetricType_GAUGE:
 			valType = prometheus.GaugeValue
 			val = metric.GetGauge().GetValue()
@@ -217,31 +342,47 @@ func (c *Collector) convertMetricFamily(logger *slog.Logger, metricFamily *dto.M
 			for _, q := range metric.GetSummary().GetQuantile() {
 				quantiles[q.GetQuantile()] = q.GetValue()
 			}
-			ch <- prometheus.MustNewConstSummary(
-				prometheus.NewDesc(
-					metricFamily.GetName(),
-					metricFamily.GetHelp(),
-					names, nil,
-				),
-				metric.GetSummary().GetSampleCount(),
-				metric.GetSummary().GetSampleSum(),
-				quantiles, values...,
-			)
+
+			if created := metric.GetSummary().GetCreatedTimestamp(); created != nil {
+				m = prometheus.MustNewConstSummaryWithCreatedTimestamp(
+					desc,
+					metric.GetSummary().GetSampleCount(),
+					metric.GetSummary().GetSampleSum(),
+					quantiles, created.AsTime(), values...,
+				)
+			} else {
+				m = prometheus.MustNewConstSummary(
+					desc,
+					metric.GetSummary().GetSampleCount(),
+					metric.GetSummary().GetSampleSum(),
+					quantiles, values...,
+				)
+			}
 		case dto.MetricType_HISTOGRAM:
 			buckets := map[float64]uint64{}
 			for _, b := range metric.GetHistogram().GetBucket() {
 				buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+
+				if exemplar := b.GetExemplar(); exemplar != nil {
+					exemplars = append(exemplars, convertExemplar(exemplar))
+				}
+			}
+
+			if created := metric.GetHistogram().GetCreatedTimestamp(); created != nil {
+				m = prometheus.MustNewConstHistogramWithCreatedTimestamp(
+					desc,
+					metric.GetHistogram().GetSampleCount(),
+					metric.GetHistogram().GetSampleSum(),
+					buckets, created.AsTime(), values...,
+				)
+			} else {
+				m = prometheus.MustNewConstHistogram(
+					desc,
+					metric.GetHistogram().GetSampleCount(),
+					metric.GetHistogram().GetSampleSum(),
+					buckets, values...,
+				)
 			}
-			ch <- prometheus.MustNewConstHistogram(
-				prometheus.NewDesc(
-					metricFamily.GetName(),
-					metricFamily.GetHelp(),
-					names, nil,
-				),
-				metric.GetHistogram().GetSampleCount(),
-				metric.GetHistogram().GetSampleSum(),
-				buckets, values...,
-			)
 		default:
 			logger.Error("unknown metric type for file")
 
@@ -249,37 +390,78 @@ func (c *Collector) convertMetricFamily(logger *slog.Logger, metricFamily *dto.M
 		}
 
 		if metricType == dto.MetricType_GAUGE || metricType == dto.MetricType_COUNTER || metricType == dto.MetricType_UNTYPED {
-			ch <- prometheus.MustNewConstMetric(
-				prometheus.NewDesc(
-					metricFamily.GetName(),
-					metricFamily.GetHelp(),
-					names, nil,
-				),
-				valType, val, values...,
-			)
+			if created := metric.GetCounter().GetCreatedTimestamp(); created != nil {
+				m = prometheus.MustNewConstMetricWithCreatedTimestamp(desc, valType, val, created.AsTime(), values...)
+			} else {
+				m = prometheus.MustNewConstMetric(desc, valType, val, values...)
+			}
+		}
+
+		if len(exemplars) > 0 {
+			withExemplars, err := prometheus.NewMetricWithExemplars(m, exemplars...)
+			if err != nil {
+				logger.Warn("dropping invalid exemplars of textfile metric "+metricFamily.GetName(),
+					slog.Any("err", err),
+				)
+			} else {
+				m = withExemplars
+			}
+		}
+
+		// Files with timestamps are rejected by scrapeFile, unless timestamps are enabled.
+		if metric.TimestampMs != nil {
+			m = prometheus.NewMetricWithTimestamp(time.UnixMilli(metric.GetTimestampMs()), m)
 		}
+
+		ch <- m
 	}
 }
 
-func (c *Collector) exportMTimes(modTimes map[string]time.Time, ch chan<- prometheus.Metric) {
-	// Export the mtimes of the successful files.
-	if len(modTimes) > 0 {
-		// Sorting is needed for predictable output comparison in tests.
-		filenames := make([]string, 0, len(modTimes))
-		for filename := range modTimes {
-			filenames = append(filenames, filename)
-		}
+// convertExemplar converts an exemplar parsed from an OpenMetrics file.
+func convertExemplar(exemplar *dto.Exemplar) prometheus.Exemplar {
+	labels := make(prometheus.Labels, len(exemplar.GetLabel()))
+	for _, label := range exemplar.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
 
-		sort.Strings(filenames)
+	var timestamp time.Time
+	if exemplar.GetTimestamp() != nil {
+		timestamp = exemplar.GetTimestamp().AsTime()
+	}
 
-		for _, filename := range filenames {
-			modTime := float64(modTimes[filename].UnixNano() / 1e9)
-			if c.mTime != nil {
-				modTime = *c.mTime
-			}
+	return prometheus.Exemplar{
+		Value:     exemplar.GetValue(),
+		Labels:    labels,
+		Timestamp: timestamp,
+	}
+}
 
-			ch <- prometheus.MustNewConstMetric(c.modTimeDesc, prometheus.GaugeValue, modTime, filename)
+func (c *Collector) exportFileMetrics(files map[string]fileStatus, ch chan<- prometheus.Metric) {
+	// Sorting is needed for predictable output comparison in tests.
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		file := files[filename]
+
+		ch <- prometheus.MustNewConstMetric(c.staleDesc, prometheus.GaugeValue, utils.BoolToFloat(file.stale), filename)
+		ch <- prometheus.MustNewConstMetric(c.parseErrorDesc, prometheus.GaugeValue, utils.BoolToFloat(file.parseError), filename)
+
+		// Export the mtimes of the successful and the stale files.
+		if file.parseError {
+			continue
 		}
+
+		modTime := float64(file.modTime.UnixNano() / 1e9)
+		if c.mTime != nil {
+			modTime = *c.mTime
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.modTimeDesc, prometheus.GaugeValue, modTime, filename)
 	}
 }
 
@@ -308,92 +490,224 @@ func (cr carriageReturnFilteringReader) Read(p []byte) (int, error) {
 	return pi, err
 }
 
+// collection holds the state of a single [Collector.Collect] call.
+type collection struct {
+	// files holds the status of the files by their file label, paths holds the full paths of all files read.
+	files map[string]fileStatus
+	paths map[string]struct{}
+	// Create empty metricFamily slice here and append parsedFamilies to it for every file.
+	// Once all files are read, raise error if any duplicates are present.
+	// This will ensure that duplicate metrics are correctly detected between multiple .prom files.
+	metricFamilies []*dto.MetricFamily
+	errs           []error
+}
+
 // Collect implements the Collector interface.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
-	mTimes := map[string]time.Time{}
+	state := &collection{
+		files: map[string]fileStatus{},
+		paths: map[string]struct{}{},
+		errs:  make([]error, 0),
+	}
 
-	// Create empty metricFamily slice here and append parsedFamilies to it inside the loop.
-	// Once loop is complete, raise error if any duplicates are present.
-	// This will ensure that duplicate metrics are correctly detected between multiple .prom files.
-	var metricFamilies []*dto.MetricFamily
+	// Iterate over files and accumulate their metrics.
+	for _, source := range c.sources() {
+		if isGlob(source.Path) {
+			c.collectGlob(state, source)
 
-	errs := make([]error, 0)
+			continue
+		}
 
-	// Iterate over files and accumulate their metrics.
-	for _, directory := range c.config.TextFileDirectories {
-		err := filepath.WalkDir(directory, func(path string, dirEntry os.DirEntry, err error) error {
+		err := filepath.WalkDir(source.Path, func(path string, dirEntry os.DirEntry, err error) error {
 			if err != nil {
 				return fmt.Errorf("error reading directory: %w", err)
 			}
 
-			if !dirEntry.IsDir() && strings.HasSuffix(dirEntry.Name(), ".prom") {
-				c.logger.Debug("Processing file: " + path)
+			if dirEntry.IsDir() {
+				return nil
+			}
 
-				families_array, err := scrapeFile(path, c.logger)
-				if err != nil {
-					errs = append(errs, fmt.Errorf("error scraping file %q: %w", path, err))
+			c.collectFile(state, path, fileName(source, path), source)
 
-					return nil
-				}
+			return nil
+		})
 
-				fileInfo, err := os.Stat(path)
-				if err != nil {
-					errs = append(errs, fmt.Errorf("error reading file info %q: %w", path, err))
+		if err != nil && source.Path != "" {
+			state.errs = append(state.errs, fmt.Errorf("error reading textfile directory %q: %w", source.Path, err))
+		}
+	}
 
-					return nil
-				}
+	c.exportFileMetrics(state.files, ch)
 
-				if _, hasName := mTimes[fileInfo.Name()]; hasName {
-					errs = append(errs, fmt.Errorf("duplicate filename detected: %q", path))
+	// If duplicates are detected across *multiple* files, return error.
+	if duplicateMetricEntry(state.metricFamilies) {
+		c.logger.Warn("duplicate metrics detected across multiple files")
+	} else {
+		for _, mf := range state.metricFamilies {
+			c.convertMetricFamily(c.logger, mf, ch)
+		}
+	}
 
-					return nil
-				}
+	return errors.Join(state.errs...)
+}
 
-				mTimes[fileInfo.Name()] = fileInfo.ModTime()
+// sources returns the directories without labels, followed by the configured sources.
+func (c *Collector) sources() []Source {
+	sources := make([]Source, 0, len(c.config.TextFileDirectories)+len(c.config.Sources))
 
-				metricFamilies = append(metricFamilies, families_array...)
-			}
+	for _, directory := range c.config.TextFileDirectories {
+		sources = append(sources, Source{Path: directory, directory: true})
+	}
 
-			return nil
-		})
+	return append(sources, c.config.Sources...)
+}
+
+func (c *Collector) collectGlob(state *collection, source Source) {
+	// doublestar.Glob() requires forward slashes
+	basePath, pattern := doublestar.SplitPattern(filepath.ToSlash(source.Path))
 
-		if err != nil && directory != "" {
-			errs = append(errs, fmt.Errorf("error reading textfile directory %q: %w", directory, err))
+	matches, err := doublestar.Glob(os.DirFS(basePath), pattern, doublestar.WithFilesOnly())
+	if err != nil {
+		state.errs = append(state.errs, fmt.Errorf("error matching textfile pattern %q: %w", source.Path, err))
+
+		return
+	}
+
+	for _, match := range matches {
+		name := match
+		if source.directory {
+			name = filepath.Base(match)
 		}
+
+		c.collectFile(state, filepath.Join(basePath, match), name, source)
 	}
+}
 
-	c.exportMTimes(mTimes, ch)
+// fileName returns the file label of a file found in the directory of source.
+// Files of directories are labeled with their file name, files of sources with their path relative to the directory.
+func fileName(source Source, path string) string {
+	if source.directory {
+		return filepath.Base(path)
+	}
 
-	// If duplicates are detected across *multiple* files, return error.
-	if duplicateMetricEntry(metricFamilies) {
-		c.logger.Warn("duplicate metrics detected across multiple files")
-	} else {
-		for _, mf := range metricFamilies {
-			c.convertMetricFamily(c.logger, mf, ch)
+	name, err := filepath.Rel(source.Path, path)
+	if err != nil {
+		return path
+	}
+
+	return name
+}
+
+// collectFile reads the file at path. name is exposed as file label, see [fileName].
+// Files of directories must have unique file names. Files of sources with the same relative path
+// as a previous file are labeled with their full path instead.
+func (c *Collector) collectFile(state *collection, path string, name string, source Source) {
+	if !strings.HasSuffix(path, ".prom") && !strings.HasSuffix(path, ".om") {
+		return
+	}
+
+	c.logger.Debug("Processing file: " + path)
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		state.errs = append(state.errs, fmt.Errorf("error reading file info %q: %w", path, err))
+
+		return
+	}
+
+	path = filepath.Clean(path)
+	if _, ok := state.paths[path]; ok {
+		state.errs = append(state.errs, fmt.Errorf("file %q is matched by multiple sources", path))
+
+		return
+	}
+
+	state.paths[path] = struct{}{}
+
+	name = filepath.ToSlash(name)
+	if _, ok := state.files[name]; ok {
+		if source.directory {
+			state.errs = append(state.errs, fmt.Errorf("duplicate filename detected: %q", path))
+
+			return
+		}
+
+		name = path
+	}
+
+	if c.config.MaxAge > 0 && time.Since(fileInfo.ModTime()) > c.config.MaxAge {
+		c.logger.Debug("Skipping stale file: " + path)
+
+		state.files[name] = fileStatus{modTime: fileInfo.ModTime(), stale: true}
+
+		return
+	}
+
+	families_array, err := c.scrapeFile(path)
+	if err != nil {
+		state.files[name] = fileStatus{modTime: fileInfo.ModTime(), parseError: true}
+		state.errs = append(state.errs, fmt.Errorf("error scraping file %q: %w", path, err))
+
+		return
+	}
+
+	state.files[name] = fileStatus{modTime: fileInfo.ModTime()}
+
+	addLabels(families_array, source.Labels, filepath.Base(filepath.Dir(path)))
+
+	state.metricFamilies = append(state.metricFamilies, families_array...)
+}
+
+// addLabels adds the labels of the source to all metrics, which don't have the label yet.
+func addLabels(metricFamilies []*dto.MetricFamily, labels map[string]string, dir string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			for _, name := range names {
+				if slices.ContainsFunc(metric.GetLabel(), func(label *dto.LabelPair) bool { return label.GetName() == name }) {
+					continue
+				}
+
+				value := strings.ReplaceAll(labels[name], dirPlaceholder, dir)
+				metric.Label = append(metric.Label, &dto.LabelPair{Name: &name, Value: &value})
+			}
 		}
 	}
+}
 
-	return errors.Join(errs...)
+// isGlob reports whether the path is a glob pattern instead of a directory.
+func isGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[{")
 }
 
-func scrapeFile(path string, logger *slog.Logger) ([]*dto.MetricFamily, error) {
+func (c *Collector) scrapeFile(path string) ([]*dto.MetricFamily, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var parser expfmt.TextParser
-
 	r, encoding := utfbom.Skip(carriageReturnFilteringReader{r: file})
 	if err = checkBOM(encoding); err != nil {
+		_ = file.Close()
+
 		return nil, err
 	}
 
-	parsedFamilies, err := parser.TextToMetricFamilies(r)
+	content, err := io.ReadAll(r)
 
 	closeErr := file.Close()
 	if closeErr != nil {
-		logger.Warn("error closing file "+path,
+		c.logger.Warn("error closing file "+path,
 			slog.Any("err", closeErr),
 		)
 	}
@@ -402,6 +716,20 @@ func scrapeFile(path string, logger *slog.Logger) ([]*dto.MetricFamily, error) {
 		return nil, err
 	}
 
+	var parsedFamilies map[string]*dto.MetricFamily
+
+	if isOpenMetrics(path, content) {
+		parsedFamilies, err = parseOpenMetrics(content)
+	} else {
+		var parser expfmt.TextParser
+
+		parsedFamilies, err = parser.TextToMetricFamilies(bytes.NewReader(content))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
 	// Use temporary array to check for duplicates
 	families_array := make([]*dto.MetricFamily, 0, len(parsedFamilies))
 
@@ -409,8 +737,8 @@ func scrapeFile(path string, logger *slog.Logger) ([]*dto.MetricFamily, error) {
 		families_array = append(families_array, mf)
 
 		for _, m := range mf.GetMetric() {
-			if m.TimestampMs != nil {
-				return nil, errors.New("textfile contains unsupported client-side timestamps")
+			if err = c.checkTimestamp(m); err != nil {
+				return nil, err
 			}
 		}
 
@@ -428,6 +756,25 @@ func scrapeFile(path string, logger *slog.Logger) ([]*dto.MetricFamily, error) {
 	return families_array, nil
 }
 
+// checkTimestamp rejects sample timestamps, unless they are enabled and not older than the configured age.
+func (c *Collector) checkTimestamp(metric *dto.Metric) error {
+	if metric.TimestampMs == nil {
+		return nil
+	}
+
+	if !c.config.Timestamps {
+		return errors.New("textfile contains unsupported client-side timestamps")
+	}
+
+	if c.config.MaxTimestampAge > 0 {
+		if age := time.Since(time.UnixMilli(metric.GetTimestampMs())); age > c.config.MaxTimestampAge {
+			return fmt.Errorf("textfile contains timestamps older than %s", c.config.MaxTimestampAge)
+		}
+	}
+
+	return nil
+}
+
 func checkBOM(encoding utfbom.Encoding) error {
 	if encoding == utfbom.Unknown || encoding == utfbom.UTF8 {
 		return nil
@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/collector/textfile"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
@@ -73,19 +76,17 @@ func TestMultipleDirectories(t *testing.T) {
 	}
 }
 
-//nolint:paralleltest
-func TestDuplicateFileName(t *testing.T) {
+// collectMetrics builds the collector and returns the collected metrics by name.
+func collectMetrics(t *testing.T, textFileCollector *textfile.Collector) (map[string][]*dto.Metric, error) {
+	t.Helper()
+
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	testDir := baseDir + "/duplicate-filename"
-	textFileCollector := textfile.New(&textfile.Config{
-		TextFileDirectories: []string{testDir},
-	})
 
 	collectors := collector.New(map[string]collector.Collector{textfile.Name: textFileCollector})
 	require.NoError(t, collectors.Build(t.Context(), logger))
 
 	metrics := make(chan prometheus.Metric)
-	got := ""
+	got := map[string][]*dto.Metric{}
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -97,14 +98,216 @@ func TestDuplicateFileName(t *testing.T) {
 	for val := range metrics {
 		var metric dto.Metric
 
-		err := val.Write(&metric)
-		require.NoError(t, err)
+		require.NoError(t, val.Write(&metric))
 
-		got += metric.String()
+		desc := val.Desc().String()
+		name := desc[strings.Index(desc, `"`)+1 : strings.Index(desc, `", help`)]
+		got[name] = append(got[name], &metric)
+	}
+
+	return got, <-errCh
+}
+
+// fileLabels returns the file labels of the status metrics together with the flag labels of windows_test.
+func fileLabels(got map[string][]*dto.Metric) ([]string, []string) {
+	files := make([]string, 0, len(got["windows_textfile_file_parse_error"]))
+	for _, metric := range got["windows_textfile_file_parse_error"] {
+		files = append(files, metric.GetLabel()[0].GetValue())
+	}
+
+	flags := make([]string, 0, len(got["windows_test"]))
+	for _, metric := range got["windows_test"] {
+		flags = append(flags, metric.GetLabel()[0].GetValue())
+	}
+
+	return files, flags
+}
+
+//nolint:paralleltest
+func TestDuplicateFileName(t *testing.T) {
+	testDir := baseDir + "/duplicate-filename"
+
+	got, err := collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{testDir},
+	}))
+	require.ErrorContains(t, err, "duplicate filename detected")
+
+	files, flags := fileLabels(got)
+	require.ElementsMatch(t, []string{"file.prom"}, files)
+	require.Contains(t, flags, "file")
+	require.NotContains(t, flags, "sub_file")
+
+	// Files of sources are labeled with their relative path, so the same file name may be used in subdirectories.
+	got, err = collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{},
+		Sources:             []textfile.Source{{Path: testDir}},
+	}))
+	require.NoError(t, err)
+
+	files, flags = fileLabels(got)
+	require.ElementsMatch(t, []string{"file.prom", "sub/file.prom"}, files)
+	require.ElementsMatch(t, []string{"file", "sub_file"}, flags)
+}
+
+//nolint:paralleltest
+func TestSameFileNameInSources(t *testing.T) {
+	testDir := baseDir + "/same-filename"
+
+	got, err := collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{},
+		Sources:             []textfile.Source{{Path: testDir + "/*/metrics.prom"}},
+	}))
+	require.NoError(t, err)
+
+	files, flags := fileLabels(got)
+	require.ElementsMatch(t, []string{"dir1/metrics.prom", "dir2/metrics.prom"}, files)
+	require.ElementsMatch(t, []string{"dir1", "dir2"}, flags)
+
+	// Files of different sources with the same relative path are labeled with their full path.
+	got, err = collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{},
+		Sources:             []textfile.Source{{Path: testDir + "/dir1"}, {Path: testDir + "/dir2"}},
+	}))
+	require.NoError(t, err)
+
+	files, flags = fileLabels(got)
+	require.ElementsMatch(t, []string{"metrics.prom", filepath.Clean(testDir + "/dir2/metrics.prom")}, files)
+	require.ElementsMatch(t, []string{"dir1", "dir2"}, flags)
+
+	// A file matched by multiple sources is only read once.
+	got, err = collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{testDir + "/dir1"},
+		Sources:             []textfile.Source{{Path: testDir + "/*/metrics.prom"}},
+	}))
+	require.ErrorContains(t, err, "is matched by multiple sources")
+
+	_, flags = fileLabels(got)
+	require.ElementsMatch(t, []string{"dir1", "dir2"}, flags)
+}
+
+//nolint:paralleltest
+func TestOpenMetrics(t *testing.T) {
+	got, err := collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{baseDir + "/openmetrics"},
+		Timestamps:          true,
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, got["batch_jobs_total"], 1)
+	counter := got["batch_jobs_total"][0]
+	require.InDelta(t, 42.0, counter.GetCounter().GetValue(), 0)
+	require.Equal(t, int64(1700000000000), counter.GetTimestampMs())
+	require.Equal(t, int64(1699990000), counter.GetCounter().GetCreatedTimestamp().GetSeconds())
+	require.Equal(t, "4bf92f3577b34da6", counter.GetCounter().GetExemplar().GetLabel()[0].GetValue())
+
+	require.Len(t, got["batch_job_duration_seconds"], 1)
+	histogram := got["batch_job_duration_seconds"][0]
+	require.Equal(t, uint64(6), histogram.GetHistogram().GetSampleCount())
+	require.InDelta(t, 42.5, histogram.GetHistogram().GetSampleSum(), 0)
+	require.Equal(t, int64(1700000000000), histogram.GetTimestampMs())
+	require.Equal(t, int64(1699990000), histogram.GetHistogram().GetCreatedTimestamp().GetSeconds())
+	require.Len(t, histogram.GetHistogram().GetBucket(), 3)
+	require.InDelta(t, 7.5, histogram.GetHistogram().GetBucket()[1].GetExemplar().GetValue(), 0)
+
+	require.Len(t, got["batch_build_info"], 1)
+	require.InDelta(t, 1.0, got["batch_build_info"][0].GetGauge().GetValue(), 0)
+	require.Nil(t, got["batch_build_info"][0].TimestampMs)
+}
+
+//nolint:paralleltest
+func TestOpenMetricsTimestamps(t *testing.T) {
+	for _, config := range []textfile.Config{
+		{TextFileDirectories: []string{baseDir + "/openmetrics"}},
+		{TextFileDirectories: []string{baseDir + "/openmetrics"}, Timestamps: true, MaxTimestampAge: time.Hour},
+	} {
+		_, err := collectMetrics(t, textfile.New(&config))
+		require.ErrorContains(t, err, "timestamps")
 	}
+}
+
+//nolint:paralleltest
+func TestMaxAge(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"fresh.prom":  "fresh_metric 1\n",
+		"stale.prom":  "stale_metric 1\n",
+		"broken.prom": "broken_metric{\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	staleTime := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "stale.prom"), staleTime, staleTime))
+
+	got, err := collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{dir},
+		MaxAge:              time.Hour,
+	}))
+	require.ErrorContains(t, err, "broken.prom")
+
+	require.Contains(t, got, "fresh_metric")
+	require.NotContains(t, got, "stale_metric")
+
+	fileValues := func(name string) map[string]float64 {
+		values := map[string]float64{}
+		for _, metric := range got[name] {
+			values[metric.GetLabel()[0].GetValue()] = metric.GetGauge().GetValue()
+		}
 
-	require.ErrorContains(t, <-errCh, "duplicate filename detected")
+		return values
+	}
+
+	require.Equal(t, map[string]float64{"broken.prom": 0, "fresh.prom": 0, "stale.prom": 1}, fileValues("windows_textfile_file_stale"))
+	require.Equal(t, map[string]float64{"broken.prom": 1, "fresh.prom": 0, "stale.prom": 0}, fileValues("windows_textfile_file_parse_error"))
+
+	mTimes := fileValues("windows_textfile_mtime_seconds")
+	require.Len(t, mTimes, 2)
+	require.Contains(t, mTimes, "fresh.prom")
+	require.InDelta(t, float64(staleTime.Unix()), mTimes["stale.prom"], 0)
+}
+
+//nolint:paralleltest
+func TestSources(t *testing.T) {
+	got, err := collectMetrics(t, textfile.New(&textfile.Config{
+		TextFileDirectories: []string{},
+		Sources: []textfile.Source{
+			{
+				Path:   baseDir + "/sources/*/*.prom",
+				Labels: map[string]string{"app": "{dir}", "env": "test"},
+			},
+		},
+	}))
+	require.NoError(t, err)
+
+	// The pattern only matches files directly inside the application directories.
+	require.NotContains(t, got, "app_nested")
+	require.Len(t, got["app_up"], 2)
+
+	labels := make([]map[string]string, 0, len(got["app_up"]))
+
+	for _, metric := range got["app_up"] {
+		metricLabels := map[string]string{}
+		for _, label := range metric.GetLabel() {
+			metricLabels[label.GetName()] = label.GetValue()
+		}
+
+		labels = append(labels, metricLabels)
+	}
+
+	// Labels of the file take precedence over the labels of the source.
+	require.ElementsMatch(t, []map[string]string{
+		{"app": "app1", "env": "test"},
+		{"app": "app2", "env": "prod"},
+	}, labels)
+}
+
+//nolint:paralleltest
+func TestSourcesInvalidLabel(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	textFileCollector := textfile.New(&textfile.Config{
+		Sources: []textfile.Source{{Path: baseDir + "/sources", Labels: map[string]string{"invalid-name": "x"}}},
+	})
 
-	require.Contains(t, got, "file")
-	require.NotContains(t, got, "sub_file")
+	require.ErrorContains(t, textFileCollector.Build(logger, nil), `invalid label name "invalid-name"`)
 }
@@ -72,7 +72,21 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.temperature = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Thermal Zone Information", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create Thermal Zone Information collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.temperature = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "temperature_celsius"),
 		"(Temperature)",
 		[]string{
@@ -80,7 +94,7 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		},
 		nil,
 	)
-	c.percentPassiveLimit = prometheus.NewDesc(
+	c.percentPassiveLimit = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "percent_passive_limit"),
 		"(PercentPassiveLimit)",
 		[]string{
@@ -88,7 +102,7 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		},
 		nil,
 	)
-	c.throttleReasons = prometheus.NewDesc(
+	c.throttleReasons = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "throttle_reasons"),
 		"(ThrottleReasons)",
 		[]string{
@@ -96,15 +110,15 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		},
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Thermal Zone Information", pdh.InstancesAll)
-	if err != nil {
-		return fmt.Errorf("failed to create Thermal Zone Information collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.percentPassiveLimit,
+		c.temperature,
+		c.throttleReasons,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -134,69 +134,91 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 	// https://github.com/prometheus-community/windows_exporter/issues/1891
 	c.ppbCounterPresent = osversion.Build() >= osversion.LTSC2019
 
-	c.currentTime = prometheus.NewDesc(
+	c.BuildDescs()
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Windows Time Service", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Windows Time Service collector: %w", err)
+	}
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.currentTime = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_timestamp_seconds"),
 		"OperatingSystem.LocalDateTime",
 		nil,
 		nil,
 	)
-	c.timezone = prometheus.NewDesc(
+	c.timezone = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "timezone"),
 		"OperatingSystem.LocalDateTime",
 		[]string{"timezone"},
 		nil,
 	)
-	c.clockFrequencyAdjustment = prometheus.NewDesc(
+	c.clockFrequencyAdjustment = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "clock_frequency_adjustment"),
 		"This value reflects the adjustment made to the local system clock frequency by W32Time in nominal clock units. This counter helps visualize the finer adjustments being made by W32time to synchronize the local clock.",
 		nil,
 		nil,
 	)
-	c.clockFrequencyAdjustmentPPB = prometheus.NewDesc(
+	c.clockFrequencyAdjustmentPPB = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "clock_frequency_adjustment_ppb"),
 		"This value reflects the adjustment made to the local system clock frequency by W32Time in Parts Per Billion (PPB) units. 1 PPB adjustment imples the system clock was adjusted at a rate of 1 nanosecond per second. The smallest possible adjustment can vary and can be expected to be in the order of 100&apos;s of PPB. This counter helps visualize the finer actions being taken by W32time to synchronize the local clock.",
 		nil,
 		nil,
 	)
-	c.computedTimeOffset = prometheus.NewDesc(
+	c.computedTimeOffset = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "computed_time_offset_seconds"),
 		"Absolute time offset between the system clock and the chosen time source, in seconds",
 		nil,
 		nil,
 	)
-	c.ntpClientTimeSourceCount = prometheus.NewDesc(
+	c.ntpClientTimeSourceCount = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_client_time_sources"),
 		"Active number of NTP Time sources being used by the client",
 		nil,
 		nil,
 	)
-	c.ntpRoundTripDelay = prometheus.NewDesc(
+	c.ntpRoundTripDelay = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_round_trip_delay_seconds"),
 		"Roundtrip delay experienced by the NTP client in receiving a response from the server for the most recent request, in seconds",
 		nil,
 		nil,
 	)
-	c.ntpServerOutgoingResponsesTotal = prometheus.NewDesc(
+	c.ntpServerOutgoingResponsesTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_server_outgoing_responses_total"),
 		"Total number of requests responded to by NTP server",
 		nil,
 		nil,
 	)
-	c.ntpServerIncomingRequestsTotal = prometheus.NewDesc(
+	c.ntpServerIncomingRequestsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_server_incoming_requests_total"),
 		"Total number of requests received by NTP server",
 		nil,
 		nil,
 	)
+}
 
-	var err error
-
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "Windows Time Service", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create Windows Time Service collector: %w", err)
-	}
-
-	return nil
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.currentTime,
+		c.timezone,
+		c.clockFrequencyAdjustment,
+		c.clockFrequencyAdjustmentPPB,
+		c.computedTimeOffset,
+		c.ntpClientTimeSourceCount,
+		c.ntpRoundTripDelay,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.ntpServerIncomingRequestsTotal,
+		c.ntpServerOutgoingResponsesTotal,
+	)
 }
 
 // Collect sends the metric values for each metric
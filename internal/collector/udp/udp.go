@@ -83,46 +83,63 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.datagramsNoPortTotal = prometheus.NewDesc(
+	c.BuildDescs()
+
+	errs := make([]error, 0)
+
+	var err error
+
+	c.perfDataCollector4, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "UDPv4", nil)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create UDPv4 collector: %w", err))
+	}
+
+	c.perfDataCollector6, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "UDPv6", nil)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create UDPv6 collector: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.datagramsNoPortTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_no_port_total"),
 		"Number of received UDP datagrams for which there was no application at the destination port",
 		[]string{"af"},
 		nil,
 	)
-	c.datagramsReceivedTotal = prometheus.NewDesc(
+	c.datagramsReceivedTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_received_total"),
 		"UDP datagrams are delivered to UDP users",
 		[]string{"af"},
 		nil,
 	)
-	c.datagramsReceivedErrorsTotal = prometheus.NewDesc(
+	c.datagramsReceivedErrorsTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_received_errors_total"),
 		"Number of received UDP datagrams that could not be delivered for reasons other than the lack of an application at the destination port",
 		[]string{"af"},
 		nil,
 	)
-	c.datagramsSentTotal = prometheus.NewDesc(
+	c.datagramsSentTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_sent_total"),
 		"UDP datagrams are sent from the entity",
 		[]string{"af"},
 		nil,
 	)
+}
 
-	errs := make([]error, 0)
-
-	var err error
-
-	c.perfDataCollector4, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "UDPv4", nil)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create UDPv4 collector: %w", err))
-	}
-
-	c.perfDataCollector6, err = pdh.NewCollector[perfDataCounterValues](pdh.CounterTypeRaw, "UDPv6", nil)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to create UDPv6 collector: %w", err))
-	}
-
-	return errors.Join(errs...)
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.CounterValue,
+		c.datagramsNoPortTotal,
+		c.datagramsReceivedErrorsTotal,
+		c.datagramsSentTotal,
+	)
+	types.Describe(ch, prometheus.GaugeValue,
+		c.datagramsReceivedTotal,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -164,39 +164,54 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		return fmt.Errorf("failed to initialize Windows Update collector: %w", err)
 	}
 
-	c.pendingUpdate = prometheus.NewDesc(
+	c.BuildDescs()
+
+	return nil
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.pendingUpdate = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pending_info"),
 		"Expose information for a single pending update item",
 		[]string{"id", "revision", "category", "severity", "title"},
 		nil,
 	)
 
-	c.pendingUpdateLastPublished = prometheus.NewDesc(
+	c.pendingUpdateLastPublished = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pending_published_timestamp"),
 		"Expose last published timestamp for a single pending update item",
 		[]string{"id", "revision"},
 		nil,
 	)
 
-	c.queryDurationSeconds = prometheus.NewDesc(
+	c.queryDurationSeconds = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "scrape_query_duration_seconds"),
 		"Duration of the last scrape query to the Windows Update API",
 		nil,
 		nil,
 	)
 
-	c.lastScrapeMetric = prometheus.NewDesc(
+	c.lastScrapeMetric = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "scrape_timestamp_seconds"),
 		"Timestamp of the last scrape",
 		nil,
 		nil,
 	)
-
-	return nil
 }
 
 func (c *Collector) GetName() string { return Name }
 
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.pendingUpdate,
+		c.pendingUpdateLastPublished,
+		c.queryDurationSeconds,
+		c.lastScrapeMetric,
+	)
+}
+
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
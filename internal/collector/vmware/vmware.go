@@ -110,123 +110,155 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		errs = append(errs, fmt.Errorf("failed to create VM Memory collector: %w", err))
 	}
 
-	c.cpuLimitMHz = prometheus.NewDesc(
+	c.BuildDescs()
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of all metrics without touching the OS.
+func (c *Collector) BuildDescs() {
+	c.cpuLimitMHz = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_limit_mhz"),
 		"The maximum processing power in MHz allowed to the virtual machine. Assigning a CPU Limit ensures that this virtual machine never consumes more than a certain amount of the available processor power. By limiting the amount of processing power consumed, a portion of the processing power becomes available to other virtual machines.",
 		nil,
 		nil,
 	)
-	c.cpuReservationMHz = prometheus.NewDesc(
+	c.cpuReservationMHz = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_reservation_mhz"),
 		"The minimum processing power in MHz available to the virtual machine. Assigning a CPU Reservation ensures that even as other virtual machines on the same host consume shared processing power, there is still a certain minimum amount for this virtual machine.",
 		nil,
 		nil,
 	)
-	c.cpuShares = prometheus.NewDesc(
+	c.cpuShares = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_shares"),
 		"The number of CPU shares allocated to the virtual machine.",
 		nil,
 		nil,
 	)
-	c.cpuStolenTotal = prometheus.NewDesc(
+	c.cpuStolenTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_stolen_seconds_total"),
 		"The time that the VM was runnable but not scheduled to run.",
 		nil,
 		nil,
 	)
-	c.cpuTimeTotal = prometheus.NewDesc(
+	c.cpuTimeTotal = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_time_seconds_total"),
 		"Current load of the VM’s virtual processor",
 		nil,
 		nil,
 	)
-	c.cpuEffectiveVMSpeedMHz = prometheus.NewDesc(
+	c.cpuEffectiveVMSpeedMHz = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_effective_vm_speed_mhz_total"),
 		"The effective speed of the VM’s virtual CPU",
 		nil,
 		nil,
 	)
-	c.hostProcessorSpeedMHz = prometheus.NewDesc(
+	c.hostProcessorSpeedMHz = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "host_processor_speed_mhz"),
 		"Host Processor speed",
 		nil,
 		nil,
 	)
 
-	c.memActive = prometheus.NewDesc(
+	c.memActive = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_active_bytes"),
 		"The estimated amount of memory the virtual machine is actively using.",
 		nil,
 		nil,
 	)
-	c.memBallooned = prometheus.NewDesc(
+	c.memBallooned = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_ballooned_bytes"),
 		"The amount of memory that has been reclaimed from this virtual machine via the VMware Memory Balloon mechanism.",
 		nil,
 		nil,
 	)
-	c.memLimit = prometheus.NewDesc(
+	c.memLimit = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_limit_bytes"),
 		"The maximum amount of memory that is allowed to the virtual machine. Assigning a Memory Limit ensures that this virtual machine never consumes more than a certain amount of the allowed memory. By limiting the amount of memory consumed, a portion of this shared resource is allowed to other virtual machines.",
 		nil,
 		nil,
 	)
-	c.memMapped = prometheus.NewDesc(
+	c.memMapped = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_mapped_bytes"),
 		"The mapped memory size of this virtual machine. This is the current total amount of guest memory that is backed by physical memory. Note that this number may include pages of memory shared between multiple virtual machines and thus may be an overestimate of the amount of physical host memory consumed by this virtual machine.",
 		nil,
 		nil,
 	)
-	c.memOverhead = prometheus.NewDesc(
+	c.memOverhead = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_overhead_bytes"),
 		"The amount of overhead memory associated with this virtual machine consumed on the host system.",
 		nil,
 		nil,
 	)
-	c.memReservation = prometheus.NewDesc(
+	c.memReservation = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_reservation_bytes"),
 		"The minimum amount of memory that is guaranteed to the virtual machine. Assigning a Memory Reservation ensures that even as other virtual machines on the same host consume memory, there is still a certain minimum amount for this virtual machine.",
 		nil,
 		nil,
 	)
-	c.memShared = prometheus.NewDesc(
+	c.memShared = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_shared_bytes"),
 		"The amount of physical memory associated with this virtual machine that is copy-on-write (COW) shared on the host.",
 		nil,
 		nil,
 	)
-	c.memSharedSaved = prometheus.NewDesc(
+	c.memSharedSaved = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_shared_saved_bytes"),
 		"The estimated amount of physical memory on the host saved from copy-on-write (COW) shared guest physical memory.",
 		nil,
 		nil,
 	)
-	c.memShares = prometheus.NewDesc(
+	c.memShares = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_shares"),
 		"The number of memory shares allocated to the virtual machine.",
 		nil,
 		nil,
 	)
-	c.memSwapped = prometheus.NewDesc(
+	c.memSwapped = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_swapped_bytes"),
 		"The amount of memory associated with this virtual machine that has been swapped by ESX.",
 		nil,
 		nil,
 	)
-	c.memTargetSize = prometheus.NewDesc(
+	c.memTargetSize = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_target_size_bytes"),
 		"Memory Target Size.",
 		nil,
 		nil,
 	)
-	c.memUsed = prometheus.NewDesc(
+	c.memUsed = types.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mem_used_bytes"),
 		"The estimated amount of physical host memory currently consumed for this virtual machine’s physical memory.",
 		nil,
 		nil,
 	)
+}
 
-	return errors.Join(errs...)
+// Describe sends the descriptors of all metrics created in Build.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.memActive,
+		c.memBallooned,
+		c.memLimit,
+		c.memMapped,
+		c.memOverhead,
+		c.memReservation,
+		c.memShared,
+		c.memSharedSaved,
+		c.memShares,
+		c.memSwapped,
+		c.memTargetSize,
+		c.memUsed,
+		c.cpuLimitMHz,
+		c.cpuReservationMHz,
+		c.cpuShares,
+		c.cpuEffectiveVMSpeedMHz,
+		c.hostProcessorSpeedMHz,
+	)
+	types.Describe(ch, prometheus.CounterValue,
+		c.cpuStolenTotal,
+		c.cpuTimeTotal,
+	)
 }
 
 // Collect sends the metric values for each metric
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package wmi
+
+import (
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultNamespace is the namespace of queries which don't set one.
+const DefaultNamespace = `root\CIMV2`
+
+type Query struct {
+	Name      string   `json:"name"      yaml:"name"`
+	Namespace string   `json:"namespace" yaml:"namespace"`
+	Query     string   `json:"query"     yaml:"query"`
+	Labels    []Label  `json:"labels"    yaml:"labels"`
+	Metrics   []Metric `json:"metrics"   yaml:"metrics"`
+
+	namespace  mi.Namespace
+	labelNames []string
+}
+
+// Label exposes a property of each instance as label.
+type Label struct {
+	Property string `json:"property" yaml:"property"`
+	Name     string `json:"name"     yaml:"name"`
+}
+
+// Metric exposes a numeric property of each instance as metric.
+type Metric struct {
+	Property string            `json:"property" yaml:"property"`
+	Metric   string            `json:"metric"   yaml:"metric"`
+	Type     string            `json:"type"     yaml:"type"`
+	Help     string            `json:"help"     yaml:"help"`
+	Labels   map[string]string `json:"labels"   yaml:"labels"`
+	Scale    float64           `json:"scale"    yaml:"scale"`
+
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+// UnmarshalYAML allows labels to be given by property only, e.g. `labels: ["Name"]`.
+func (l *Label) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = Label{Property: node.Value}
+
+		return nil
+	}
+
+	type plain Label
+
+	return node.Decode((*plain)(l))
+}
+
+// UnmarshalYAML allows metrics to be given by property only, e.g. `metrics: ["FreeSpace"]`.
+func (m *Metric) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*m = Metric{Property: node.Value}
+
+		return nil
+	}
+
+	type plain Metric
+
+	return node.Decode((*plain)(m))
+}
+
+func (*Config) UnmarshalYAML(*yaml.Node) error {
+	return nil
+}
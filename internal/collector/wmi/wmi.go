@@ -0,0 +1,455 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package wmi
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+)
+
+const Name = "wmi"
+
+type Config struct {
+	Queries []Query `yaml:"queries"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	Queries: make([]Query, 0),
+}
+
+// A Collector is a Prometheus collector for metrics of arbitrary WMI queries.
+type Collector struct {
+	config Config
+
+	logger    *slog.Logger
+	miSession *mi.Session
+
+	queries []Query
+
+	// meta
+	queryDurationDesc *prometheus.Desc
+	querySuccessDesc  *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.Queries == nil {
+		config.Queries = ConfigDefaults.Queries
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	var queries string
+
+	app.Flag(
+		"collector.wmi.queries",
+		"WMI queries to expose as metrics. See docs for more information on how to use this flag. By default, no queries are run.",
+	).Default("").StringVar(&queries)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		if queries == "" {
+			return nil
+		}
+
+		if err := yaml.Unmarshal([]byte(queries), &c.config.Queries); err != nil {
+			return fmt.Errorf("failed to parse queries %s: %w", queries, err)
+		}
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	c.logger = logger.With(slog.String("collector", Name))
+	c.miSession = miSession
+	c.queries = make([]Query, 0, len(c.config.Queries))
+	names := make([]string, 0, len(c.config.Queries))
+
+	var errs []error
+
+	for _, query := range c.config.Queries {
+		if query.Name == "" {
+			return errors.New("query name is required")
+		}
+
+		if slices.Contains(names, query.Name) {
+			errs = append(errs, fmt.Errorf("query %s: name is duplicated", query.Name))
+
+			continue
+		}
+
+		names = append(names, query.Name)
+
+		if err := buildQuery(&query); err != nil {
+			errs = append(errs, fmt.Errorf("query %s: %w", query.Name, err))
+
+			continue
+		}
+
+		c.queries = append(c.queries, query)
+	}
+
+	c.BuildDescs()
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of the query metrics without touching the OS.
+// The descriptors of the configured queries are created by Build.
+func (c *Collector) BuildDescs() {
+	c.queryDurationDesc = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "query_duration_seconds"),
+		"windows_exporter: Duration of a WMI query.",
+		[]string{"query"},
+		nil,
+	)
+	c.querySuccessDesc = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "query_success"),
+		"windows_exporter: Whether a WMI query was successful.",
+		[]string{"query"},
+		nil,
+	)
+}
+
+// Describe sends the descriptors of the query metrics and of the metrics of all configured queries.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	types.Describe(ch, prometheus.GaugeValue,
+		c.queryDurationDesc,
+		c.querySuccessDesc,
+	)
+
+	for _, query := range c.queries {
+		for _, metric := range query.Metrics {
+			types.Describe(ch, metric.valueType, metric.desc)
+		}
+	}
+}
+
+// buildQuery validates the query and creates the descriptors of its metrics.
+func buildQuery(query *Query) error {
+	if query.Query == "" {
+		return errors.New("query is required")
+	}
+
+	if len(query.Metrics) == 0 {
+		return errors.New("at least one metric is required")
+	}
+
+	var err error
+
+	query.namespace, err = mi.ParseNamespace(cmp.Or(query.Namespace, DefaultNamespace))
+	if err != nil {
+		return fmt.Errorf("namespace: %w", err)
+	}
+
+	query.Labels = slices.Clone(query.Labels)
+	query.labelNames = make([]string, 0, len(query.Labels))
+
+	for i, label := range query.Labels {
+		if label.Property == "" {
+			return errors.New("label property is required")
+		}
+
+		label.Name = cmp.Or(label.Name, types.SanitizeName(label.Property))
+		if !types.RegExpLabelName.MatchString(label.Name) {
+			return fmt.Errorf("label %s: %q is not a valid label name", label.Property, label.Name)
+		}
+
+		if slices.Contains(query.labelNames, label.Name) {
+			return fmt.Errorf("label %s: name %s is duplicated", label.Property, label.Name)
+		}
+
+		query.Labels[i] = label
+		query.labelNames = append(query.labelNames, label.Name)
+	}
+
+	query.Metrics = slices.Clone(query.Metrics)
+	helps := make(map[string]string, len(query.Metrics))
+
+	for i, metric := range query.Metrics {
+		if metric.Property == "" {
+			return errors.New("metric property is required")
+		}
+
+		metric.Metric = cmp.Or(metric.Metric, types.SanitizeName(fmt.Sprintf("%s_%s_%s_%s", types.Namespace, Name, query.Name, metric.Property)))
+		if !types.RegExpMetricName.MatchString(metric.Metric) {
+			return fmt.Errorf("metric %s: %q is not a valid metric name", metric.Property, metric.Metric)
+		}
+
+		switch metric.Type {
+		case "", "gauge":
+			metric.valueType = prometheus.GaugeValue
+		case "counter":
+			metric.valueType = prometheus.CounterValue
+		default:
+			return fmt.Errorf("metric %s: unknown type %q, must be gauge or counter", metric.Property, metric.Type)
+		}
+
+		// Metrics sharing a metric name share the HELP of the first of these metrics, since a metric can only have a single HELP.
+		help, ok := helps[metric.Metric]
+		if !ok {
+			help = cmp.Or(metric.Help, "windows_exporter: custom WMI metric")
+			helps[metric.Metric] = help
+		}
+
+		metric.desc = types.NewDesc(metric.Metric, help, query.labelNames, metric.Labels)
+		query.Metrics[i] = metric
+	}
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
+	var errs []error
+
+	for _, query := range c.queries {
+		startTime := time.Now()
+		err := c.collectQuery(ch, query)
+		duration := time.Since(startTime)
+		success := 1.0
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect query %s: %w", query.Name, err))
+			success = 0.0
+
+			c.logger.Debug(fmt.Sprintf("wmi query %s failed after %s", query.Name, duration),
+				slog.Any("err", err),
+			)
+		} else {
+			c.logger.Debug(fmt.Sprintf("wmi query %s succeeded after %s", query.Name, duration))
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.querySuccessDesc,
+			prometheus.GaugeValue,
+			success,
+			query.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.queryDurationDesc,
+			prometheus.GaugeValue,
+			duration.Seconds(),
+			query.Name,
+		)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectQuery(ch chan<- prometheus.Metric, query Query) error {
+	operation, err := c.miSession.QueryInstances(mi.OperationFlagsStandardRTTI, nil, query.namespace, mi.QueryDialectWQL, query.Query)
+	if err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	err = c.collectInstances(ch, query, operation)
+
+	if closeErr := operation.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close operation: %w", closeErr))
+	}
+
+	return err
+}
+
+func (c *Collector) collectInstances(ch chan<- prometheus.Metric, query Query, operation *mi.Operation) error {
+	var errs []error
+
+	for {
+		instance, moreResults, err := operation.GetInstance()
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("failed to get instance: %w", err))...)
+		}
+
+		// If WMI returns nil, it means there are no more results.
+		if instance == nil {
+			break
+		}
+
+		if err = c.collectInstance(ch, query, instance); err != nil {
+			errs = append(errs, err)
+		}
+
+		if !moreResults {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectInstance(ch chan<- prometheus.Metric, query Query, instance *mi.Instance) error {
+	labelValues := make([]string, len(query.Labels))
+
+	for i, label := range query.Labels {
+		element, err := instance.GetElement(label.Property)
+		if err != nil {
+			return fmt.Errorf("failed to get property %s: %w", label.Property, err)
+		}
+
+		// Properties without a value are exposed as empty label.
+		value, err := element.GetValue()
+		if err != nil {
+			c.logger.Debug("failed to get value of property "+label.Property,
+				slog.String("query", query.Name),
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		labelValues[i] = types.LabelValue(value)
+	}
+
+	var errs []error
+
+	for _, metric := range query.Metrics {
+		element, err := instance.GetElement(metric.Property)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get property %s: %w", metric.Property, err))
+
+			continue
+		}
+
+		value, err := element.GetValue()
+		if err != nil {
+			c.logger.Debug("failed to get value of property "+metric.Property,
+				slog.String("query", query.Name),
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		// Properties without a value are skipped.
+		if value == nil {
+			continue
+		}
+
+		metricValue, err := numericValue(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("property %s: %w", metric.Property, err))
+
+			continue
+		}
+
+		if metric.Scale != 0 {
+			metricValue *= metric.Scale
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			metric.desc,
+			metric.valueType,
+			metricValue,
+			labelValues...,
+		)
+	}
+
+	return errors.Join(errs...)
+}
+
+// numericValue converts a property value into a metric value. Booleans are exposed as 0 or 1,
+// timestamps as Unix time and intervals in seconds. Strings are parsed, since WMI providers
+// often return 64-bit integers as strings.
+func numericValue(value any) (float64, error) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return 1, nil
+		}
+
+		return 0, nil
+	case uint8:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case mi.Char16:
+		return float64(v), nil
+	case time.Time:
+		return float64(v.UnixMicro()) / 1e6, nil
+	case time.Duration:
+		return v.Seconds(), nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %q as number: %w", v, err)
+		}
+
+		return f, nil
+	default:
+		return 0, fmt.Errorf("unsupported value type %T", value)
+	}
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package wmi_test
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type collectorAdapter struct {
+	*wmi.Collector
+}
+
+// Describe implements the prometheus.Collector interface.
+func (a collectorAdapter) Describe(_ chan<- *prometheus.Desc) {}
+
+// Collect implements the prometheus.Collector interface.
+func (a collectorAdapter) Collect(ch chan<- prometheus.Metric) {
+	if err := a.Collector.Collect(ch); err != nil {
+		panic(fmt.Sprintf("failed to update collector: %v", err))
+	}
+}
+
+func BenchmarkCollector(b *testing.B) {
+	queries := `[{"name":"os","query":"SELECT Caption, NumberOfProcesses FROM Win32_OperatingSystem","labels":["Caption"],"metrics":["NumberOfProcesses"]}]`
+
+	testutils.FuncBenchmarkCollector(b, wmi.Name, wmi.NewWithFlags, func(app *kingpin.Application) {
+		app.GetFlag("collector.wmi.queries").StringVar(&queries)
+	})
+}
+
+func TestCollector(t *testing.T) {
+	testutils.TestCollector(t, wmi.New, nil)
+}
+
+func TestCollectorQueries(t *testing.T) {
+	t.Parallel()
+
+	miApp, err := mi.ApplicationInitialize()
+	require.NoError(t, err)
+
+	miSession, err := miApp.NewSession(nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, miSession.Close())
+		require.NoError(t, miApp.Close())
+	})
+
+	for _, tc := range []struct {
+		name            string
+		query           wmi.Query
+		buildErr        string
+		expectedMetrics *regexp.Regexp
+	}{
+		{
+			name: "os",
+			query: wmi.Query{
+				Query:   "SELECT Caption, NumberOfProcesses, FreePhysicalMemory FROM Win32_OperatingSystem",
+				Labels:  []wmi.Label{{Property: "Caption", Name: "product"}},
+				Metrics: []wmi.Metric{{Property: "NumberOfProcesses"}, {Property: "FreePhysicalMemory", Metric: "windows_wmi_os_free_physical_memory_bytes", Scale: 1024, Help: "Free physical memory"}},
+			},
+			expectedMetrics: regexp.MustCompile(`^# HELP windows_wmi_os_free_physical_memory_bytes Free physical memory
+# TYPE windows_wmi_os_free_physical_memory_bytes gauge
+windows_wmi_os_free_physical_memory_bytes\{product="Microsoft Windows .+"} [0-9.e+]+
+# HELP windows_wmi_os_numberofprocesses windows_exporter: custom WMI metric
+# TYPE windows_wmi_os_numberofprocesses gauge
+windows_wmi_os_numberofprocesses\{product="Microsoft Windows .+"} [0-9]+
+# HELP windows_wmi_query_duration_seconds windows_exporter: Duration of a WMI query.
+# TYPE windows_wmi_query_duration_seconds gauge
+windows_wmi_query_duration_seconds\{query="os"} [0-9.e+-]+
+# HELP windows_wmi_query_success windows_exporter: Whether a WMI query was successful.
+# TYPE windows_wmi_query_success gauge
+windows_wmi_query_success\{query="os"} 1
+$`),
+		},
+		{
+			name: "process",
+			query: wmi.Query{
+				Namespace: `root\cimv2`,
+				Query:     "SELECT Name, ProcessId, ThreadCount FROM Win32_Process WHERE ProcessId = 4",
+				Labels:    []wmi.Label{{Property: "Name"}, {Property: "ProcessId", Name: "pid"}},
+				Metrics:   []wmi.Metric{{Property: "ThreadCount", Metric: "windows_wmi_process_threads"}},
+			},
+			expectedMetrics: regexp.MustCompile(`^# HELP windows_wmi_process_threads windows_exporter: custom WMI metric
+# TYPE windows_wmi_process_threads gauge
+windows_wmi_process_threads\{name="System",pid="4"} [0-9]+
+`),
+		},
+		{
+			name:     "missing_query",
+			query:    wmi.Query{Metrics: []wmi.Metric{{Property: "NumberOfProcesses"}}},
+			buildErr: "query is required",
+		},
+		{
+			name:     "missing_metrics",
+			query:    wmi.Query{Query: "SELECT Caption FROM Win32_OperatingSystem"},
+			buildErr: "at least one metric is required",
+		},
+		{
+			name:     "invalid_type",
+			query:    wmi.Query{Query: "SELECT NumberOfProcesses FROM Win32_OperatingSystem", Metrics: []wmi.Metric{{Property: "NumberOfProcesses", Type: "histogram"}}},
+			buildErr: `metric NumberOfProcesses: unknown type "histogram"`,
+		},
+		{
+			name:     "invalid_label_name",
+			query:    wmi.Query{Query: "SELECT Caption, NumberOfProcesses FROM Win32_OperatingSystem", Labels: []wmi.Label{{Property: "Caption", Name: "os-name"}}, Metrics: []wmi.Metric{{Property: "NumberOfProcesses"}}},
+			buildErr: `label Caption: "os-name" is not a valid label name`,
+		},
+		{
+			name:     "duplicated_label",
+			query:    wmi.Query{Query: "SELECT Caption, NumberOfProcesses FROM Win32_OperatingSystem", Labels: []wmi.Label{{Property: "Caption"}, {Property: "Name", Name: "caption"}}, Metrics: []wmi.Metric{{Property: "NumberOfProcesses"}}},
+			buildErr: "label Name: name caption is duplicated",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.query.Name = tc.name
+
+			collector := wmi.New(&wmi.Config{Queries: []wmi.Query{tc.query}})
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			err := collector.Build(logger, miSession)
+
+			if tc.buildErr != "" {
+				require.ErrorContains(t, err, tc.buildErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(collectorAdapter{collector})
+
+			rw := httptest.NewRecorder()
+			promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}).ServeHTTP(rw, &http.Request{})
+			got := rw.Body.String()
+
+			require.NotEmpty(t, got)
+			require.Regexp(t, tc.expectedMetrics, got)
+		})
+	}
+}
+
+func TestQueryUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	var queries []wmi.Query
+
+	require.NoError(t, yaml.Unmarshal([]byte(`[{"name":"disk","namespace":"root\\cimv2","query":"SELECT * FROM Win32_LogicalDisk","labels":["DeviceID",{"property":"VolumeName","name":"volume"}],"metrics":["FreeSpace",{"property":"Size","type":"gauge"}]}]`), &queries))
+	require.Len(t, queries, 1)
+	require.Equal(t, `root\cimv2`, queries[0].Namespace)
+	require.Equal(t, []wmi.Label{{Property: "DeviceID"}, {Property: "VolumeName", Name: "volume"}}, queries[0].Labels)
+	require.Equal(t, []wmi.Metric{{Property: "FreeSpace"}, {Property: "Size", Type: "gauge"}}, queries[0].Metrics)
+}
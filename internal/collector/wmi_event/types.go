@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package wmi_event
+
+import (
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultNamespace is the namespace of subscriptions which don't set one.
+	DefaultNamespace = `root\CIMV2`
+	// DefaultMaxSeries is the maximum number of series of subscriptions which don't set one.
+	DefaultMaxSeries = 1000
+)
+
+type Subscription struct {
+	Name      string  `json:"name"       yaml:"name"`
+	Namespace string  `json:"namespace"  yaml:"namespace"`
+	Query     string  `json:"query"      yaml:"query"`
+	Labels    []Label `json:"labels"     yaml:"labels"`
+	Metric    string  `json:"metric"     yaml:"metric"`
+	Help      string  `json:"help"       yaml:"help"`
+	MaxSeries int     `json:"max_series" yaml:"max_series"`
+}
+
+// Label exposes a property of each event as label. Properties of embedded instances are selected with a dot,
+// e.g. `TargetInstance.Name` for the process name of an __InstanceCreationEvent of Win32_Process.
+type Label struct {
+	Property string `json:"property" yaml:"property"`
+	Name     string `json:"name"     yaml:"name"`
+}
+
+// UnmarshalYAML allows labels to be given by property only, e.g. `labels: ["ProcessName"]`.
+func (l *Label) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = Label{Property: node.Value}
+
+		return nil
+	}
+
+	type plain Label
+
+	return node.Decode((*plain)(l))
+}
+
+func (*Config) UnmarshalYAML(*yaml.Node) error {
+	return nil
+}
+
+// subscription holds the state of a configured subscription.
+type subscription struct {
+	Subscription
+
+	namespace mi.Namespace
+	desc      *prometheus.Desc
+
+	// miSubscription is guarded by the mutex of the collector, since it is replaced if the subscription ended.
+	miSubscription *mi.Subscription
+
+	// mu guards counts and dropped, which are updated by the goroutine of the subscription.
+	mu     sync.Mutex
+	counts map[string]*eventCount
+	// dropped is the number of events dropped, because counting them would exceed MaxSeries.
+	dropped float64
+}
+
+// eventCount is the number of events with the same label values.
+type eventCount struct {
+	labelValues []string
+	count       float64
+}
@@ -0,0 +1,439 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package wmi_event
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+)
+
+const Name = "wmi_event"
+
+type Config struct {
+	Subscriptions []Subscription `yaml:"subscriptions"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	Subscriptions: make([]Subscription, 0),
+}
+
+// A Collector is a Prometheus collector counting the events of WMI event subscriptions.
+type Collector struct {
+	config Config
+
+	logger    *slog.Logger
+	miSession *mi.Session
+
+	// mu guards the MI subscriptions of the subscriptions.
+	mu            sync.Mutex
+	subscriptions []*subscription
+
+	subscriptionUpDesc *prometheus.Desc
+	droppedEventsDesc  *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.Subscriptions == nil {
+		config.Subscriptions = ConfigDefaults.Subscriptions
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	var subscriptions string
+
+	app.Flag(
+		"collector.wmi_event.subscriptions",
+		"WMI event subscriptions to count the events of. See docs for more information on how to use this flag. By default, no subscriptions are created.",
+	).Default("").StringVar(&subscriptions)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		if subscriptions == "" {
+			return nil
+		}
+
+		if err := yaml.Unmarshal([]byte(subscriptions), &c.config.Subscriptions); err != nil {
+			return fmt.Errorf("failed to parse subscriptions %s: %w", subscriptions, err)
+		}
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+
+	for _, sub := range c.subscriptions {
+		if sub.miSubscription == nil {
+			continue
+		}
+
+		if err := sub.miSubscription.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", sub.Name, err))
+		}
+
+		sub.miSubscription = nil
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	c.logger = logger.With(slog.String("collector", Name))
+	c.miSession = miSession
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscriptions = make([]*subscription, 0, len(c.config.Subscriptions))
+	names := make([]string, 0, len(c.config.Subscriptions))
+
+	var errs []error
+
+	for _, config := range c.config.Subscriptions {
+		if config.Name == "" {
+			return errors.New("subscription name is required")
+		}
+
+		if slices.Contains(names, config.Name) {
+			errs = append(errs, fmt.Errorf("subscription %s: name is duplicated", config.Name))
+
+			continue
+		}
+
+		names = append(names, config.Name)
+
+		sub, err := newSubscription(config)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", config.Name, err))
+
+			continue
+		}
+
+		if err = c.subscribe(sub); err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", config.Name, err))
+
+			continue
+		}
+
+		c.subscriptions = append(c.subscriptions, sub)
+	}
+
+	c.BuildDescs()
+
+	return errors.Join(errs...)
+}
+
+// BuildDescs creates the descriptors of the subscription metrics without touching the OS.
+// The descriptors of the event counters are created by Build.
+func (c *Collector) BuildDescs() {
+	c.subscriptionUpDesc = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "subscription_up"),
+		"windows_exporter: Whether a WMI event subscription is active.",
+		[]string{"subscription"},
+		nil,
+	)
+	c.droppedEventsDesc = types.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "dropped_events_total"),
+		"windows_exporter: Number of WMI events dropped, because the subscription reached its maximum number of series.",
+		[]string{"subscription"},
+		nil,
+	)
+}
+
+// newSubscription validates the configuration of a subscription and creates the descriptor of its metric.
+func newSubscription(config Subscription) (*subscription, error) {
+	if config.Query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	if config.MaxSeries < 0 {
+		return nil, errors.New("max_series must not be negative")
+	}
+
+	config.MaxSeries = cmp.Or(config.MaxSeries, DefaultMaxSeries)
+
+	namespace, err := mi.ParseNamespace(cmp.Or(config.Namespace, DefaultNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("namespace: %w", err)
+	}
+
+	config.Labels = slices.Clone(config.Labels)
+	labelNames := make([]string, 0, len(config.Labels))
+
+	for i, label := range config.Labels {
+		if label.Property == "" {
+			return nil, errors.New("label property is required")
+		}
+
+		label.Name = cmp.Or(label.Name, types.SanitizeName(label.Property[strings.LastIndex(label.Property, ".")+1:]))
+		if !types.RegExpLabelName.MatchString(label.Name) {
+			return nil, fmt.Errorf("label %s: %q is not a valid label name", label.Property, label.Name)
+		}
+
+		if slices.Contains(labelNames, label.Name) {
+			return nil, fmt.Errorf("label %s: name %s is duplicated", label.Property, label.Name)
+		}
+
+		config.Labels[i] = label
+		labelNames = append(labelNames, label.Name)
+	}
+
+	config.Metric = cmp.Or(config.Metric, types.SanitizeName(fmt.Sprintf("%s_%s_%s", types.Namespace, Name, config.Name))+"_total")
+	if !types.RegExpMetricName.MatchString(config.Metric) {
+		return nil, fmt.Errorf("%q is not a valid metric name", config.Metric)
+	}
+
+	return &subscription{
+		Subscription: config,
+		namespace:    namespace,
+		desc: types.NewDesc(
+			config.Metric,
+			cmp.Or(config.Help, "windows_exporter: Number of WMI events of the subscription "+config.Name),
+			labelNames,
+			nil,
+		),
+		counts: make(map[string]*eventCount),
+	}, nil
+}
+
+// subscribe creates the MI subscription of sub. The caller must hold c.mu.
+func (c *Collector) subscribe(sub *subscription) error {
+	miSubscription, err := c.miSession.Subscribe(sub.namespace, mi.QueryDialectWQL, sub.Query, func(instance *mi.Instance) {
+		c.countEvent(sub, instance)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	sub.miSubscription = miSubscription
+
+	return nil
+}
+
+// countEvent increments the count of the label values of the event.
+// It is called by the goroutine of the MI subscription.
+func (c *Collector) countEvent(sub *subscription, instance *mi.Instance) {
+	labelValues := make([]string, len(sub.Labels))
+
+	for i, label := range sub.Labels {
+		value, err := propertyValue(instance, label.Property)
+		if err != nil {
+			// Properties that can't be read are exposed as empty label, like properties without a value.
+			c.logger.LogAttrs(context.Background(), slog.LevelDebug, "failed to get value of property "+label.Property,
+				slog.String("subscription", sub.Name),
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		labelValues[i] = types.LabelValue(value)
+	}
+
+	if !sub.count(labelValues) {
+		c.logger.LogAttrs(context.Background(), slog.LevelDebug, "dropped WMI event, the subscription reached its maximum number of series",
+			slog.String("subscription", sub.Name),
+			slog.Any("labels", labelValues),
+		)
+	}
+}
+
+// count increments the count of the label values. It returns false if the event is dropped, because its label values
+// are new and the subscription already has MaxSeries series. This bounds the memory of properties with many distinct values.
+func (s *subscription) count(labelValues []string) bool {
+	key := strings.Join(labelValues, "\xff")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= s.MaxSeries {
+			s.dropped++
+
+			return false
+		}
+
+		count = &eventCount{labelValues: labelValues}
+		s.counts[key] = count
+	}
+
+	count.count++
+
+	return true
+}
+
+// Describe sends the descriptors of the subscription metrics and of the event counters of all configured subscriptions.
+func (c *Collector) Describe(ch chan<- types.Desc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	types.Describe(ch, prometheus.GaugeValue,
+		c.subscriptionUpDesc,
+	)
+
+	types.Describe(ch, prometheus.CounterValue,
+		c.droppedEventsDesc,
+	)
+
+	for _, sub := range c.subscriptions {
+		types.Describe(ch, prometheus.CounterValue, sub.desc)
+	}
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+
+	for _, sub := range c.subscriptions {
+		if err := c.resubscribeIfEnded(sub); err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", sub.Name, err))
+		}
+
+		up := 0.0
+		if sub.miSubscription != nil {
+			up = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.subscriptionUpDesc,
+			prometheus.GaugeValue,
+			up,
+			sub.Name,
+		)
+
+		sub.mu.Lock()
+
+		ch <- prometheus.MustNewConstMetric(
+			c.droppedEventsDesc,
+			prometheus.CounterValue,
+			sub.dropped,
+			sub.Name,
+		)
+
+		for _, count := range sub.counts {
+			ch <- prometheus.MustNewConstMetric(
+				sub.desc,
+				prometheus.CounterValue,
+				count.count,
+				count.labelValues...,
+			)
+		}
+
+		sub.mu.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+// resubscribeIfEnded replaces the MI subscription of sub, if it ended with an error, e.g. because the WMI service was restarted.
+// Events between the end of the subscription and the next scrape are lost. The caller must hold c.mu.
+func (c *Collector) resubscribeIfEnded(sub *subscription) error {
+	if sub.miSubscription != nil {
+		select {
+		case <-sub.miSubscription.Done():
+		default:
+			return nil
+		}
+
+		c.logger.LogAttrs(context.Background(), slog.LevelWarn, "WMI event subscription ended, resubscribing",
+			slog.String("subscription", sub.Name),
+			slog.Any("err", sub.miSubscription.Err()),
+		)
+
+		_ = sub.miSubscription.Close()
+		sub.miSubscription = nil
+	}
+
+	return c.subscribe(sub)
+}
+
+// propertyValue returns the value of a property of the instance.
+// Properties of embedded instances are selected with a dot, e.g. `TargetInstance.Name`.
+func propertyValue(instance *mi.Instance, property string) (any, error) {
+	names := strings.Split(property, ".")
+
+	for i, name := range names {
+		element, err := instance.GetElement(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get property %s: %w", name, err)
+		}
+
+		value, err := element.GetValue()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value of property %s: %w", name, err)
+		}
+
+		if i == len(names)-1 {
+			return value, nil
+		}
+
+		embedded, ok := value.(*mi.Instance)
+		if !ok {
+			return nil, fmt.Errorf("property %s is not an embedded instance", name)
+		}
+
+		instance = embedded
+	}
+
+	return nil, fmt.Errorf("invalid property %q", property)
+}
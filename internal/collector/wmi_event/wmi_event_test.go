@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package wmi_event_test
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi_event"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type collectorAdapter struct {
+	*wmi_event.Collector
+}
+
+// Describe implements the prometheus.Collector interface.
+func (a collectorAdapter) Describe(_ chan<- *prometheus.Desc) {}
+
+// Collect implements the prometheus.Collector interface.
+func (a collectorAdapter) Collect(ch chan<- prometheus.Metric) {
+	if err := a.Collector.Collect(ch); err != nil {
+		panic(fmt.Sprintf("failed to update collector: %v", err))
+	}
+}
+
+const processCreationQuery = "SELECT * FROM __InstanceCreationEvent WITHIN 1 WHERE TargetInstance ISA 'Win32_Process'"
+
+func BenchmarkCollector(b *testing.B) {
+	subscriptions := `[{"name":"process_start","query":"` + processCreationQuery + `","labels":["TargetInstance.Name"]}]`
+
+	testutils.FuncBenchmarkCollector(b, wmi_event.Name, wmi_event.NewWithFlags, func(app *kingpin.Application) {
+		app.GetFlag("collector.wmi_event.subscriptions").StringVar(&subscriptions)
+	})
+}
+
+func TestCollector(t *testing.T) {
+	testutils.TestCollector(t, wmi_event.New, nil)
+}
+
+func TestCollectorCountsEvents(t *testing.T) {
+	t.Parallel()
+
+	miApp, err := mi.ApplicationInitialize()
+	require.NoError(t, err)
+
+	miSession, err := miApp.NewSession(nil)
+	require.NoError(t, err)
+
+	collector := wmi_event.New(&wmi_event.Config{
+		Subscriptions: []wmi_event.Subscription{
+			{
+				Name:   "process_start",
+				Query:  processCreationQuery,
+				Labels: []wmi_event.Label{{Property: "TargetInstance.Name", Name: "executable"}},
+			},
+		},
+	})
+
+	t.Cleanup(func() {
+		require.NoError(t, collector.Close())
+		require.NoError(t, miSession.Close())
+		require.NoError(t, miApp.Close())
+	})
+
+	require.NoError(t, collector.Build(slog.New(slog.NewTextHandler(io.Discard, nil)), miSession))
+
+	// Give WMI time to set up the event polling.
+	time.Sleep(2 * time.Second)
+
+	require.NoError(t, exec.Command("cmd.exe", "/c", "ping -n 3 127.0.0.1 > nul").Run())
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectorAdapter{collector})
+
+	expectedMetrics := regexp.MustCompile(`(?s)windows_wmi_event_process_start_total\{executable="cmd.exe"} [1-9][0-9]*\n.*windows_wmi_event_subscription_up\{subscription="process_start"} 1\n`)
+
+	require.Eventually(t, func() bool {
+		rw := httptest.NewRecorder()
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}).ServeHTTP(rw, &http.Request{})
+
+		return expectedMetrics.MatchString(rw.Body.String())
+	}, 10*time.Second, 500*time.Millisecond)
+}
+
+func TestCollectorMaxSeries(t *testing.T) {
+	t.Parallel()
+
+	miApp, err := mi.ApplicationInitialize()
+	require.NoError(t, err)
+
+	miSession, err := miApp.NewSession(nil)
+	require.NoError(t, err)
+
+	collector := wmi_event.New(&wmi_event.Config{
+		Subscriptions: []wmi_event.Subscription{
+			{
+				Name:      "process_start",
+				Query:     processCreationQuery,
+				Labels:    []wmi_event.Label{{Property: "TargetInstance.Name", Name: "executable"}},
+				MaxSeries: 1,
+			},
+		},
+	})
+
+	t.Cleanup(func() {
+		require.NoError(t, collector.Close())
+		require.NoError(t, miSession.Close())
+		require.NoError(t, miApp.Close())
+	})
+
+	require.NoError(t, collector.Build(slog.New(slog.NewTextHandler(io.Discard, nil)), miSession))
+
+	// Give WMI time to set up the event polling.
+	time.Sleep(2 * time.Second)
+
+	// cmd.exe starts ping.exe, so the events have at least two distinct executables.
+	require.NoError(t, exec.Command("cmd.exe", "/c", "ping -n 3 127.0.0.1 > nul").Run())
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectorAdapter{collector})
+
+	droppedMetric := regexp.MustCompile(`windows_wmi_event_dropped_events_total\{subscription="process_start"} [1-9][0-9]*\n`)
+	eventMetric := regexp.MustCompile(`windows_wmi_event_process_start_total\{executable="[^"]+"} [1-9][0-9]*\n`)
+
+	require.Eventually(t, func() bool {
+		rw := httptest.NewRecorder()
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}).ServeHTTP(rw, &http.Request{})
+
+		return droppedMetric.MatchString(rw.Body.String()) && len(eventMetric.FindAllString(rw.Body.String(), -1)) == 1
+	}, 10*time.Second, 500*time.Millisecond)
+}
+
+func TestCollectorBuildErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name         string
+		subscription wmi_event.Subscription
+		buildErr     string
+	}{
+		{
+			name:         "missing_query",
+			subscription: wmi_event.Subscription{Name: "missing_query"},
+			buildErr:     "query is required",
+		},
+		{
+			name:         "invalid_label_name",
+			subscription: wmi_event.Subscription{Name: "invalid_label_name", Query: processCreationQuery, Labels: []wmi_event.Label{{Property: "TargetInstance.Name", Name: "process-name"}}},
+			buildErr:     `label TargetInstance.Name: "process-name" is not a valid label name`,
+		},
+		{
+			name:         "duplicated_label",
+			subscription: wmi_event.Subscription{Name: "duplicated_label", Query: processCreationQuery, Labels: []wmi_event.Label{{Property: "TargetInstance.Name"}, {Property: "TargetInstance.Caption", Name: "name"}}},
+			buildErr:     "label TargetInstance.Caption: name name is duplicated",
+		},
+		{
+			name:         "negative_max_series",
+			subscription: wmi_event.Subscription{Name: "negative_max_series", Query: processCreationQuery, MaxSeries: -1},
+			buildErr:     "max_series must not be negative",
+		},
+		{
+			name:         "invalid_metric_name",
+			subscription: wmi_event.Subscription{Name: "invalid_metric_name", Query: processCreationQuery, Metric: "process-starts"},
+			buildErr:     `"process-starts" is not a valid metric name`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			collector := wmi_event.New(&wmi_event.Config{Subscriptions: []wmi_event.Subscription{tc.subscription}})
+
+			err := collector.Build(slog.New(slog.NewTextHandler(io.Discard, nil)), &mi.Session{})
+			require.ErrorContains(t, err, tc.buildErr)
+		})
+	}
+}
+
+func TestSubscriptionUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	var subscriptions []wmi_event.Subscription
+
+	require.NoError(t, yaml.Unmarshal([]byte(`[{"name":"process_start","query":"SELECT * FROM Win32_ProcessStartTrace","labels":["ProcessName",{"property":"SessionID","name":"session"}]}]`), &subscriptions))
+	require.Len(t, subscriptions, 1)
+	require.Equal(t, []wmi_event.Label{{Property: "ProcessName"}, {Property: "SessionID", Name: "session"}}, subscriptions[0].Labels)
+}
@@ -20,7 +20,9 @@ package config
 import (
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -32,10 +34,14 @@ import (
 // including configuration from the collector and web packages.
 type configFile struct {
 	Debug struct {
-		Enabled bool `yaml:"enabled"`
+		Enabled       bool   `yaml:"enabled"`
+		RecordFile    string `yaml:"record-file"`
+		RecordSamples int    `yaml:"record-samples"`
 	} `yaml:"debug"`
 	Collectors struct {
-		Enabled string `yaml:"enabled"`
+		Enabled               string       `yaml:"enabled"`
+		MaxSeries             int          `yaml:"max-series"`
+		MaxSeriesPerCollector seriesLimits `yaml:"max-series-per-collector"`
 	} `yaml:"collectors"`
 	Collector collector.Config `yaml:"collector"`
 	Log       struct {
@@ -43,6 +49,13 @@ type configFile struct {
 		Format string `yaml:"format"`
 		File   string `yaml:"file"`
 	} `yaml:"log"`
+	MI struct {
+		SlowQueryThreshold string `yaml:"slow-query-threshold"`
+	} `yaml:"mi"`
+	PDH struct {
+		SharedQuery        bool `yaml:"shared-query"`
+		CalculateFormatted bool `yaml:"calculate-formatted"`
+	} `yaml:"pdh"`
 	Process struct {
 		Priority    string `yaml:"priority"`
 		MemoryLimit string `yaml:"memory-limit"`
@@ -137,6 +150,13 @@ func NewConfigFileResolver(filePath string) (*Resolver, error) {
 		return nil, fmt.Errorf("configuration file validation error: %w", err)
 	}
 
+	available := collector.Available()
+	for name := range configFileStructure.Collector.Registered {
+		if !slices.Contains(available, name) {
+			return nil, fmt.Errorf("configuration file validation error: unknown collector %q", name)
+		}
+	}
+
 	_, err = file.Seek(0, io.SeekStart)
 	if err != nil {
 		return nil, fmt.Errorf("failed to rewind file: %w", err)
@@ -157,9 +177,40 @@ func NewConfigFileResolver(filePath string) (*Resolver, error) {
 		}
 	}
 
+	// The per-collector series limits may be given as map, which has no flag of its own after flattening.
+	if limits := configFileStructure.Collectors.MaxSeriesPerCollector; limits != "" {
+		flags["collectors.max-series-per-collector"] = string(limits)
+	}
+
 	return &Resolver{flags: flags}, nil
 }
 
+// seriesLimits holds the per-collector series limits in the collector=limit format of --collectors.max-series-per-collector.
+// In the configuration file, they are given either in the same format or as map of collector names to limits.
+type seriesLimits string
+
+func (s *seriesLimits) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*s = seriesLimits(value.Value)
+
+		return nil
+	}
+
+	var limits map[string]int
+	if err := value.Decode(&limits); err != nil {
+		return fmt.Errorf("max-series-per-collector must be a string or a map of collector names to limits: %w", err)
+	}
+
+	pairs := make([]string, 0, len(limits))
+	for _, name := range slices.Sorted(maps.Keys(limits)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, limits[name]))
+	}
+
+	*s = seriesLimits(strings.Join(pairs, ","))
+
+	return nil
+}
+
 func (c *Resolver) setDefault(v getFlagger) {
 	for name, value := range c.flags {
 		if f := v.GetFlag(name); f != nil {
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesLimits(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{
+			name:     "string",
+			config:   "collectors:\n  max-series-per-collector: process=5000,service=1000\n",
+			expected: "process=5000,service=1000",
+		},
+		{
+			name:     "map",
+			config:   "collectors:\n  max-series-per-collector:\n    service: 1000\n    process: 5000\n",
+			expected: "process=5000,service=1000",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o600))
+
+			resolver, err := NewConfigFileResolver(path)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, resolver.flags["collectors.max-series-per-collector"])
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("collectors:\n  max-series-per-collector:\n    process: many\n"), 0o600))
+
+	_, err := NewConfigFileResolver(path)
+	require.ErrorContains(t, err, "max-series-per-collector")
+}
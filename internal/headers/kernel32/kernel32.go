@@ -32,6 +32,7 @@ var (
 	procGetTickCount                     = modkernel32.NewProc("GetTickCount64")
 	procOpenJobObject                    = modkernel32.NewProc("OpenJobObjectW")
 	procIsProcessInJob                   = modkernel32.NewProc("IsProcessInJob")
+	procGetThreadTimes                   = modkernel32.NewProc("GetThreadTimes")
 )
 
 // SYSTEMTIME contains a date and time.
@@ -82,6 +83,23 @@ func LocalFileTimeToFileTime(localFileTime, utcFileTime *windows.Filetime) uint3
 	return uint32(ret)
 }
 
+// GetThreadTimes retrieves timing information for the specified thread.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/processthreadsapi/nf-processthreadsapi-getthreadtimes
+func GetThreadTimes(thread windows.Handle, creationTime, exitTime, kernelTime, userTime *windows.Filetime) error {
+	r0, _, err := procGetThreadTimes.Call(
+		uintptr(thread),
+		uintptr(unsafe.Pointer(creationTime)),
+		uintptr(unsafe.Pointer(exitTime)),
+		uintptr(unsafe.Pointer(kernelTime)),
+		uintptr(unsafe.Pointer(userTime)),
+	)
+	if r0 == 0 {
+		return err
+	}
+
+	return nil
+}
+
 func GetTickCount64() uint64 {
 	ret, _, _ := procGetTickCount.Call()
 
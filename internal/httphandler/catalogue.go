@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+)
+
+// CatalogueHandler lists the metrics of all enabled collectors as JSON.
+// The collect[] query parameter limits the output to the given collectors.
+type CatalogueHandler struct {
+	metricCollectors *collector.Collection
+}
+
+// Interface guard.
+var _ http.Handler = (*CatalogueHandler)(nil)
+
+func NewCatalogueHandler(metricCollectors *collector.Collection) CatalogueHandler {
+	return CatalogueHandler{
+		metricCollectors: metricCollectors,
+	}
+}
+
+func (h CatalogueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	collection := h.metricCollectors
+
+	if requestedCollectors := r.URL.Query()["collect[]"]; len(requestedCollectors) != 0 {
+		var err error
+
+		collection, err = h.metricCollectors.WithCollectors(requestedCollectors)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Couldn't create filtered catalogue: %s", err), http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(collection.Catalogue()); err != nil {
+		http.Error(w, fmt.Sprintf("error encoding JSON: %s", err), http.StatusInternalServerError)
+	}
+}
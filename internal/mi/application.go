@@ -177,10 +177,60 @@ func (application *Application) NewSession(options *DestinationOptions) (*Sessio
 	}
 
 	session.defaultOperationOptions = defaultOperationOptions
+	session.cache = newQueryCache()
 
 	return session, nil
 }
 
+// NewInstance creates an empty instance of a class, e.g. to pass the key properties or the in-parameters to [Session.Invoke].
+// The instance must be deleted with [Instance.Delete].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_application_newinstance
+func (application *Application) NewInstance(className string) (*Instance, error) {
+	if application == nil || application.ft == nil {
+		return nil, ErrNotInitialized
+	}
+
+	classNameUTF16, err := windows.UTF16PtrFromString(className)
+	if err != nil {
+		return nil, err
+	}
+
+	var instance *Instance
+
+	r0, _, _ := syscall.SyscallN(
+		application.ft.NewInstance,
+		uintptr(unsafe.Pointer(application)),
+		uintptr(unsafe.Pointer(classNameUTF16)),
+		0,
+		uintptr(unsafe.Pointer(&instance)),
+	)
+
+	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
+		return nil, result
+	}
+
+	return instance, nil
+}
+
+// newInstanceWithProperties creates an instance of a class with the given properties.
+func (application *Application) newInstanceWithProperties(className string, properties map[string]any, flags ElementFlags) (*Instance, error) {
+	instance, err := application.NewInstance(className)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instance of %s: %w", className, err)
+	}
+
+	for name, value := range properties {
+		if err = instance.AddElement(name, value, flags); err != nil {
+			_ = instance.Delete()
+
+			return nil, fmt.Errorf("failed to add element %s: %w", name, err)
+		}
+	}
+
+	return instance, nil
+}
+
 // NewOperationOptions creates an OperationOptions object that can be used with the operation functions on the Session object.
 //
 // https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_application_newoperationoptions
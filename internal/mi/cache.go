@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// QueryStats holds the counters of the query cache of a session since its creation.
+// The number and the duration of the queries executed by MI are returned by [OperationStats].
+type QueryStats struct {
+	// CacheHits is the number of queries answered from the cache.
+	CacheHits uint64
+	// CacheMisses is the number of queries that required an MI operation.
+	CacheMisses uint64
+	// CacheCoalesced is the number of queries that waited for an identical running query instead of starting their own.
+	CacheCoalesced uint64
+}
+
+// instanceSnapshot holds the properties of an instance, which remain valid after the operation of the instance is closed.
+// The property names are stored in lower case, since MI property names are case-insensitive.
+type instanceSnapshot map[string]any
+
+// GetProperty implements [Properties].
+func (i instanceSnapshot) GetProperty(name string) (any, bool, error) {
+	value, ok := i[strings.ToLower(name)]
+
+	return value, ok, nil
+}
+
+// errQueryPanicked is returned to the callers waiting for a query, which panicked.
+var errQueryPanicked = errors.New("query panicked")
+
+type queryKey struct {
+	namespace string
+	query     string
+}
+
+// queryCache caches the results of queries and coalesces identical concurrent queries into one operation.
+type queryCache struct {
+	mu      sync.Mutex
+	entries map[queryKey]*queryCacheEntry
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	coalesced atomic.Uint64
+}
+
+type queryCacheEntry struct {
+	// done is closed once the query completed.
+	done      chan struct{}
+	completed time.Time
+	instances []instanceSnapshot
+	err       error
+}
+
+func newQueryCache() *queryCache {
+	return &queryCache{
+		entries: make(map[queryKey]*queryCacheEntry),
+	}
+}
+
+// query returns the result of the query, if it completed successfully less than ttl ago.
+// Otherwise, it waits for an identical running query or runs fn. Only results of queries
+// started with a ttl greater than zero are kept after they have been handed out.
+func (c *queryCache) query(key queryKey, ttl time.Duration, fn func() ([]instanceSnapshot, error)) ([]instanceSnapshot, error) {
+	c.mu.Lock()
+
+	if entry, ok := c.entries[key]; ok {
+		select {
+		case <-entry.done:
+			if entry.err == nil && time.Since(entry.completed) < ttl {
+				c.mu.Unlock()
+				c.hits.Add(1)
+
+				return entry.instances, nil
+			}
+		default:
+			c.mu.Unlock()
+			c.coalesced.Add(1)
+
+			<-entry.done
+
+			return entry.instances, entry.err
+		}
+	}
+
+	entry := &queryCacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	c.misses.Add(1)
+
+	// The entry is released in a defer, so a panic of fn doesn't block the waiting and all later identical queries.
+	entry.err = errQueryPanicked
+
+	defer func() {
+		entry.completed = time.Now()
+
+		close(entry.done)
+
+		if entry.err != nil || ttl <= 0 {
+			c.mu.Lock()
+
+			if c.entries[key] == entry {
+				delete(c.entries, key)
+			}
+
+			c.mu.Unlock()
+		}
+	}()
+
+	entry.instances, entry.err = fn()
+
+	return entry.instances, entry.err
+}
+
+func (c *queryCache) stats() QueryStats {
+	return QueryStats{
+		CacheHits:      c.hits.Load(),
+		CacheMisses:    c.misses.Load(),
+		CacheCoalesced: c.coalesced.Load(),
+	}
+}
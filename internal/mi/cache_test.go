@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newQueryCache()
+	key := queryKey{namespace: "root/CIMv2", query: "SELECT Name FROM Win32_Processor"}
+
+	var calls int
+
+	query := func() ([]instanceSnapshot, error) {
+		calls++
+
+		return []instanceSnapshot{{"name": "cpu0"}}, nil
+	}
+
+	instances, err := cache.query(key, time.Hour, query)
+	require.NoError(t, err)
+	require.Equal(t, []instanceSnapshot{{"name": "cpu0"}}, instances)
+
+	instances, err = cache.query(key, time.Hour, query)
+	require.NoError(t, err)
+	require.Equal(t, []instanceSnapshot{{"name": "cpu0"}}, instances)
+	require.Equal(t, 1, calls)
+
+	// The ttl of the caller decides, whether the cached result is fresh enough.
+	_, err = cache.query(key, time.Nanosecond, query)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	stats := cache.stats()
+	require.Equal(t, uint64(1), stats.CacheHits)
+	require.Equal(t, uint64(2), stats.CacheMisses)
+	require.Zero(t, stats.CacheCoalesced)
+}
+
+func TestQueryCacheWithoutTTL(t *testing.T) {
+	t.Parallel()
+
+	cache := newQueryCache()
+	key := queryKey{namespace: "root/CIMv2", query: "SELECT Name FROM Win32_Process"}
+
+	var calls int
+
+	for range 3 {
+		_, err := cache.query(key, 0, func() ([]instanceSnapshot, error) {
+			calls++
+
+			return nil, nil
+		})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 3, calls)
+	require.Empty(t, cache.entries)
+}
+
+func TestQueryCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	cache := newQueryCache()
+	key := queryKey{namespace: "root/CIMv2", query: "SELECT Name FROM Win32_Processor"}
+	errQuery := errors.New("query failed")
+
+	_, err := cache.query(key, time.Hour, func() ([]instanceSnapshot, error) {
+		return nil, errQuery
+	})
+	require.ErrorIs(t, err, errQuery)
+
+	// Failed queries are not cached.
+	instances, err := cache.query(key, time.Hour, func() ([]instanceSnapshot, error) {
+		return []instanceSnapshot{{"name": "cpu0"}}, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+}
+
+func TestQueryCachePanic(t *testing.T) {
+	t.Parallel()
+
+	cache := newQueryCache()
+	key := queryKey{namespace: "root/CIMv2", query: "SELECT Name FROM Win32_Processor"}
+
+	require.Panics(t, func() {
+		_, _ = cache.query(key, time.Hour, func() ([]instanceSnapshot, error) {
+			panic("query failed")
+		})
+	})
+
+	require.Empty(t, cache.entries)
+
+	// Later identical queries must not wait for the panicked query.
+	instances, err := cache.query(key, time.Hour, func() ([]instanceSnapshot, error) {
+		return []instanceSnapshot{{"name": "cpu0"}}, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+}
+
+func TestQueryCacheCoalescing(t *testing.T) {
+	t.Parallel()
+
+	const callers = 5
+
+	cache := newQueryCache()
+	key := queryKey{namespace: "root/CIMv2", query: "SELECT Name FROM Win32_Process"}
+	release := make(chan struct{})
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		calls int
+	)
+
+	results := make([][]instanceSnapshot, callers)
+
+	for i := range callers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			instances, err := cache.query(key, 0, func() ([]instanceSnapshot, error) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+
+				<-release
+
+				return []instanceSnapshot{{"name": "System"}}, nil
+			})
+			if err == nil {
+				results[i] = instances
+			}
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		return cache.stats().CacheCoalesced == callers-1
+	}, 5*time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, 1, calls)
+
+	for _, instances := range results {
+		require.Equal(t, []instanceSnapshot{{"name": "System"}}, instances)
+	}
+
+	stats := cache.stats()
+	require.Equal(t, uint64(1), stats.CacheMisses)
+}
+
+func TestInstanceSnapshotUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	snapshot := instanceSnapshot{
+		"deviceid":      "CPU0",
+		"numberofcores": uint32(8),
+		"cache": instanceSnapshot{
+			"size": uint64(1024),
+		},
+	}
+
+	var dst struct {
+		DeviceID      string `mi:"DeviceID"`
+		NumberOfCores int    `mi:"NumberOfCores"`
+		Cache         struct {
+			Size uint64 `mi:"Size"`
+		} `mi:"Cache"`
+	}
+
+	require.NoError(t, unmarshalProperties(snapshot, reflect.ValueOf(&dst).Elem()))
+	require.Equal(t, "CPU0", dst.DeviceID)
+	require.Equal(t, 8, dst.NumberOfCores)
+	require.Equal(t, uint64(1024), dst.Cache.Size)
+}
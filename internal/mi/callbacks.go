@@ -24,7 +24,6 @@ import (
 	"reflect"
 	"sync"
 	"time"
-	"unsafe"
 
 	"golang.org/x/sys/windows"
 )
@@ -61,8 +60,7 @@ type OperationUnmarshalCallbacks struct {
 	dv    reflect.Value
 	errCh chan<- error
 
-	elemType  reflect.Type
-	elemValue reflect.Value
+	elemType reflect.Type
 }
 
 func NewUnmarshalOperationsCallbacks(dst any, errCh chan<- error) (*OperationCallbacks[OperationUnmarshalCallbacks], error) {
@@ -74,7 +72,6 @@ func NewUnmarshalOperationsCallbacks(dst any, errCh chan<- error) (*OperationCal
 	dv = dv.Elem()
 
 	elemType := dv.Type().Elem()
-	elemValue := reflect.ValueOf(reflect.New(elemType).Interface()).Elem()
 
 	if dv.Kind() != reflect.Slice || elemType.Kind() != reflect.Struct {
 		return nil, ErrInvalidEntityType
@@ -84,11 +81,10 @@ func NewUnmarshalOperationsCallbacks(dst any, errCh chan<- error) (*OperationCal
 
 	return &OperationCallbacks[OperationUnmarshalCallbacks]{
 		CallbackContext: &OperationUnmarshalCallbacks{
-			errCh:     errCh,
-			dst:       dst,
-			dv:        dv,
-			elemType:  elemType,
-			elemValue: elemValue,
+			errCh:    errCh,
+			dst:      dst,
+			dv:       dv,
+			elemType: elemType,
 		},
 		InstanceResult: operationUnmarshalCallbacksInstanceResult(),
 	}, nil
@@ -129,53 +125,15 @@ func (o *OperationUnmarshalCallbacks) InstanceResult(
 		return 0
 	}
 
-	for i := range o.elemType.NumField() {
-		field := o.elemValue.Field(i)
+	elemValue := reflect.New(o.elemType).Elem()
 
-		// Check if the field has an `mi` tag
-		miTag := o.elemType.Field(i).Tag.Get("mi")
-		if miTag == "" {
-			continue
-		}
-
-		element, err := instance.GetElement(miTag)
-		if err != nil {
-			if errors.Is(err, MI_RESULT_NO_SUCH_PROPERTY) {
-				continue
-			}
+	if err := unmarshalProperties(instance, elemValue); err != nil {
+		o.errCh <- err
 
-			o.errCh <- fmt.Errorf("failed to get element %s: %w", miTag, err)
-
-			return 0
-		}
-
-		switch element.valueType {
-		case ValueTypeBOOLEAN:
-			field.SetBool(element.value == 1)
-		case ValueTypeUINT8, ValueTypeUINT16, ValueTypeUINT32, ValueTypeUINT64:
-			field.SetUint(uint64(element.value))
-		case ValueTypeSINT8, ValueTypeSINT16, ValueTypeSINT32, ValueTypeSINT64:
-			field.SetInt(int64(element.value))
-		case ValueTypeSTRING:
-			if element.value == 0 {
-				// value is null
-				continue
-			}
-
-			// Convert the UTF-16 string to a Go string
-			stringValue := windows.UTF16PtrToString((*uint16)(unsafe.Pointer(element.value)))
-
-			field.SetString(stringValue)
-		case ValueTypeREAL32, ValueTypeREAL64:
-			field.SetFloat(float64(element.value))
-		default:
-			o.errCh <- fmt.Errorf("unsupported value type: %d", element.valueType)
-
-			return 0
-		}
+		return 0
 	}
 
-	o.dv.Set(reflect.Append(o.dv, o.elemValue))
+	o.dv.Set(reflect.Append(o.dv, elemValue))
 
 	return 0
 }
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//nolint:gochecknoglobals
+var (
+	logger             atomic.Pointer[slog.Logger]
+	slowQueryThreshold atomic.Int64
+
+	operationStatsMu sync.Mutex
+	operationStats   = map[QueryClass]*operationStatsEntry{}
+
+	// OperationDurationBuckets are the upper bounds in seconds of the duration histograms returned by [OperationStats].
+	OperationDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+)
+
+// QueryClass identifies the WMI class queried by an operation.
+type QueryClass struct {
+	Namespace string
+	// Class is the class of the FROM clause of the query. It is empty, if the query has no FROM clause.
+	Class string
+}
+
+// ClassOperationStats holds the counters of the query operations of one class since the start.
+type ClassOperationStats struct {
+	// Operations is the number of query operations, including failed ones.
+	Operations uint64
+	// Instances is the number of instances returned by the operations.
+	Instances uint64
+	// Duration is the total duration of the operations.
+	Duration time.Duration
+	// Buckets holds the cumulative number of operations per upper bound of [OperationDurationBuckets].
+	Buckets map[float64]uint64
+	// Errors holds the number of failed operations per MI result, like MI_RESULT_ACCESS_DENIED.
+	// Errors not returned by MI are counted as "other".
+	Errors map[string]uint64
+}
+
+type operationStatsEntry struct {
+	operations uint64
+	instances  uint64
+	duration   time.Duration
+	buckets    []uint64
+	errors     map[string]uint64
+}
+
+// SetLogger sets the logger used to report slow queries. By default, nothing is logged.
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+// SetSlowQueryThreshold sets the duration after which a query operation is logged as slow, together with its WQL text.
+// 0 disables the logging.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// OperationStats returns the counters of all query operations executed by MI since the start, per namespace and class.
+// Queries answered by the query cache of a session are not included.
+func OperationStats() map[QueryClass]ClassOperationStats {
+	operationStatsMu.Lock()
+	defer operationStatsMu.Unlock()
+
+	stats := make(map[QueryClass]ClassOperationStats, len(operationStats))
+
+	for class, entry := range operationStats {
+		buckets := make(map[float64]uint64, len(OperationDurationBuckets))
+
+		var count uint64
+
+		for i, upperBound := range OperationDurationBuckets {
+			count += entry.buckets[i]
+			buckets[upperBound] = count
+		}
+
+		stats[class] = ClassOperationStats{
+			Operations: entry.operations,
+			Instances:  entry.instances,
+			Duration:   entry.duration,
+			Buckets:    buckets,
+			Errors:     maps.Clone(entry.errors),
+		}
+	}
+
+	return stats
+}
+
+// observeOperation records a finished query operation and logs it, if it exceeded the slow query threshold.
+func observeOperation(namespace, query string, duration time.Duration, instances int, err error) {
+	class := QueryClass{Namespace: namespace, Class: queryClassName(query)}
+
+	operationStatsMu.Lock()
+
+	entry, ok := operationStats[class]
+	if !ok {
+		entry = &operationStatsEntry{
+			buckets: make([]uint64, len(OperationDurationBuckets)),
+			errors:  map[string]uint64{},
+		}
+
+		operationStats[class] = entry
+	}
+
+	entry.operations++
+	entry.instances += uint64(max(instances, 0))
+	entry.duration += duration
+
+	for i, upperBound := range OperationDurationBuckets {
+		if duration.Seconds() <= upperBound {
+			entry.buckets[i]++
+
+			break
+		}
+	}
+
+	if err != nil {
+		entry.errors[resultName(err)]++
+	}
+
+	operationStatsMu.Unlock()
+
+	threshold := time.Duration(slowQueryThreshold.Load())
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+
+	if l := logger.Load(); l != nil {
+		attrs := []slog.Attr{
+			slog.String("namespace", class.Namespace),
+			slog.String("class", class.Class),
+			slog.String("query", query),
+			slog.Duration("duration", duration),
+			slog.Int("instances", instances),
+		}
+
+		if err != nil {
+			attrs = append(attrs, slog.Any("err", err))
+		}
+
+		l.LogAttrs(context.Background(), slog.LevelWarn, "slow WMI query", attrs...)
+	}
+}
+
+// resultName returns the name of the MI result of err.
+func resultName(err error) string {
+	var result ResultError
+	if errors.As(err, &result) {
+		return result.String()
+	}
+
+	return "other"
+}
+
+// queryClassName returns the class of the FROM clause of a WQL query.
+func queryClassName(query string) string {
+	fields := strings.Fields(query)
+
+	for i, field := range fields[:max(len(fields)-1, 0)] {
+		if strings.EqualFold(field, "FROM") {
+			return strings.TrimRight(fields[i+1], ",;)")
+		}
+	}
+
+	return ""
+}
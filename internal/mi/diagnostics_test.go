@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryClassName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		query string
+		class string
+	}{
+		{"SELECT * FROM Win32_Processor", "Win32_Processor"},
+		{"select Name from win32_process where handle = 0", "win32_process"},
+		{"SELECT Name\n\tFROM\tMSFT_Disk", "MSFT_Disk"},
+		{"SELECT * FROM __InstanceCreationEvent WITHIN 5 WHERE TargetInstance ISA 'Win32_Process'", "__InstanceCreationEvent"},
+		{"ASSOCIATORS OF {Win32_Service.Name='winmgmt'}", ""},
+		{"SELECT * FROM", ""},
+		{"", ""},
+	} {
+		require.Equal(t, tc.class, queryClassName(tc.query), tc.query)
+	}
+}
+
+func TestObserveOperation(t *testing.T) {
+	t.Parallel()
+
+	class := QueryClass{Namespace: "root/TestObserveOperation", Class: "Win32_Process"}
+	query := "SELECT Name FROM Win32_Process"
+
+	observeOperation(class.Namespace, query, 20*time.Millisecond, 3, nil)
+	observeOperation(class.Namespace, query, 2*time.Second, 0, fmt.Errorf("instance result: %w", MI_RESULT_ACCESS_DENIED))
+	observeOperation(class.Namespace, query, time.Minute, 0, errors.New("failed to unmarshal"))
+
+	stats, ok := OperationStats()[class]
+	require.True(t, ok)
+	require.Equal(t, uint64(3), stats.Operations)
+	require.Equal(t, uint64(3), stats.Instances)
+	require.Equal(t, 20*time.Millisecond+2*time.Second+time.Minute, stats.Duration)
+	require.Equal(t, uint64(0), stats.Buckets[0.01])
+	require.Equal(t, uint64(1), stats.Buckets[0.025])
+	require.Equal(t, uint64(1), stats.Buckets[1])
+	require.Equal(t, uint64(2), stats.Buckets[2.5])
+	require.Equal(t, uint64(2), stats.Buckets[30])
+	require.Equal(t, map[string]uint64{"MI_RESULT_ACCESS_DENIED": 1, "other": 1}, stats.Errors)
+}
+
+//nolint:paralleltest // modifies the package logger
+func TestObserveOperationSlowQuery(t *testing.T) {
+	var buf bytes.Buffer
+
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	SetSlowQueryThreshold(time.Second)
+
+	t.Cleanup(func() {
+		SetLogger(nil)
+		SetSlowQueryThreshold(0)
+	})
+
+	observeOperation("root/TestObserveOperationSlowQuery", "SELECT Name FROM Win32_Service", 10*time.Millisecond, 1, nil)
+	require.Empty(t, buf.String())
+
+	observeOperation("root/TestObserveOperationSlowQuery", "SELECT Name FROM Win32_Service", 2*time.Second, 1, nil)
+	require.Contains(t, buf.String(), "slow WMI query")
+	require.Contains(t, buf.String(), "class=Win32_Service")
+	require.Contains(t, buf.String(), `query="SELECT Name FROM Win32_Service"`)
+}
@@ -13,8 +13,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build windows
-
 package mi
 
 import "errors"
@@ -22,4 +20,6 @@ import "errors"
 var (
 	ErrNotInitialized    = errors.New("not initialized")
 	ErrInvalidEntityType = errors.New("invalid entity type")
+	ErrSubscriptionEnded = errors.New("subscription ended")
+	ErrMethodNotFound    = errors.New("method not found")
 )
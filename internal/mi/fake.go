@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FakeInstance is an instance backed by a map of property values, for use with [FakeTransport].
+// Embedded instances are represented by nested FakeInstance values.
+type FakeInstance map[string]any
+
+// GetProperty implements [Properties].
+func (f FakeInstance) GetProperty(name string) (any, bool, error) {
+	value, ok := f[name]
+
+	return value, ok, nil
+}
+
+// FakeCall records a method invocation of a [FakeTransport].
+type FakeCall struct {
+	Namespace  string
+	ClassName  string
+	MethodName string
+	Keys       map[string]any
+	In         map[string]any
+}
+
+// FakeTransport is a [Transport] that returns canned out-parameters, so code invoking methods
+// can be tested without MI. It is safe for concurrent use.
+type FakeTransport struct {
+	mu      sync.Mutex
+	methods map[string]fakeMethod
+	calls   []FakeCall
+}
+
+type fakeMethod struct {
+	out FakeInstance
+	err error
+}
+
+func NewFakeTransport() *FakeTransport {
+	return &FakeTransport{
+		methods: make(map[string]fakeMethod),
+	}
+}
+
+// SetMethod sets the out-parameters, or the error, returned by all invocations of the method.
+func (f *FakeTransport) SetMethod(namespace, className, methodName string, out FakeInstance, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.methods[fakeMethodKey(namespace, className, methodName)] = fakeMethod{out: out, err: err}
+}
+
+// Calls returns the invocations in the order they were made.
+func (f *FakeTransport) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]FakeCall(nil), f.calls...)
+}
+
+// InvokeMethod implements [Transport]. Methods without canned out-parameters return [ErrMethodNotFound].
+func (f *FakeTransport) InvokeMethod(namespace, className, methodName string, keys, in map[string]any, fn func(out Properties) error) error {
+	f.mu.Lock()
+	f.calls = append(f.calls, FakeCall{
+		Namespace:  namespace,
+		ClassName:  className,
+		MethodName: methodName,
+		Keys:       keys,
+		In:         in,
+	})
+	method, ok := f.methods[fakeMethodKey(namespace, className, methodName)]
+	f.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s.%s", ErrMethodNotFound, className, methodName)
+	}
+
+	if method.err != nil {
+		return method.err
+	}
+
+	return fn(method.out)
+}
+
+func fakeMethodKey(namespace, className, methodName string) string {
+	return namespace + ":" + className + "." + methodName
+}
@@ -20,6 +20,8 @@ package mi
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"syscall"
 	"unsafe"
 
@@ -55,6 +57,23 @@ type InstanceFT struct {
 	GetClass        uintptr
 }
 
+// ElementFlags represents the flags of an instance element.
+//
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/wmi_v2/mi-flags
+type ElementFlags uint32
+
+const (
+	ElementFlagsNone ElementFlags = 0x0000
+	ElementFlagsKey  ElementFlags = 0x1000
+)
+
+// Element is a property of an instance.
+type Element struct {
+	value     rawValue
+	valueType ValueType
+	flags     uint32
+}
+
 type ClassDecl struct {
 	Flags          uint32
 	Code           uint32
@@ -98,18 +117,15 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 		return nil, fmt.Errorf("failed to convert element name %s to UTF-16: %w", elementName, err)
 	}
 
-	var (
-		value     uintptr
-		valueType ValueType
-	)
+	element := &Element{}
 
 	r0, _, _ := syscall.SyscallN(
 		instance.ft.GetElement,
 		uintptr(unsafe.Pointer(instance)),
 		uintptr(unsafe.Pointer(elementNameUTF16)),
-		uintptr(unsafe.Pointer(&value)),
-		uintptr(unsafe.Pointer(&valueType)),
-		0,
+		uintptr(unsafe.Pointer(&element.value)),
+		uintptr(unsafe.Pointer(&element.valueType)),
+		uintptr(unsafe.Pointer(&element.flags)),
 		0,
 	)
 
@@ -117,10 +133,210 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 		return nil, result
 	}
 
-	return &Element{
-		value:     value,
-		valueType: valueType,
-	}, nil
+	return element, nil
+}
+
+// GetValue returns the value of the element converted into the matching Go type, or nil if the element is null.
+// Embedded instances and references are returned as [*Instance] and are owned by the instance of the element.
+// See [decodeValue] for the other types.
+func (e *Element) GetValue() (any, error) {
+	if e.flags&flagNull != 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	switch e.valueType {
+	case ValueTypeINSTANCE, ValueTypeREFERENCE:
+		instance := *(**Instance)(unsafe.Pointer(&e.value))
+		if instance == nil {
+			return nil, nil //nolint:nilnil
+		}
+
+		return instance, nil
+	case ValueTypeINSTANCEA, ValueTypeREFERENCEA:
+		return copyArray[*Instance]((*rawArray)(unsafe.Pointer(&e.value))), nil
+	default:
+		return decodeValue(&e.value, e.valueType)
+	}
+}
+
+// GetElementAt returns the name and the element at the index, in the range of [Instance.GetElementCount].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_instance_getelementat
+func (instance *Instance) GetElementAt(index uint32) (string, *Element, error) {
+	if instance == nil || instance.ft == nil {
+		return "", nil, ErrNotInitialized
+	}
+
+	var nameUTF16 *uint16
+
+	element := &Element{}
+
+	r0, _, _ := syscall.SyscallN(
+		instance.ft.GetElementAt,
+		uintptr(unsafe.Pointer(instance)),
+		uintptr(index),
+		uintptr(unsafe.Pointer(&nameUTF16)),
+		uintptr(unsafe.Pointer(&element.value)),
+		uintptr(unsafe.Pointer(&element.valueType)),
+		uintptr(unsafe.Pointer(&element.flags)),
+	)
+
+	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
+		return "", nil, result
+	}
+
+	return windows.UTF16PtrToString(nameUTF16), element, nil
+}
+
+// snapshot copies all properties of the instance, including embedded instances,
+// so they remain valid after the operation of the instance is closed.
+func (instance *Instance) snapshot() (instanceSnapshot, error) {
+	count, err := instance.GetElementCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get element count: %w", err)
+	}
+
+	snapshot := make(instanceSnapshot, count)
+
+	for i := range count {
+		name, element, err := instance.GetElementAt(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get element %d: %w", i, err)
+		}
+
+		value, err := element.GetValue()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value of %s: %w", name, err)
+		}
+
+		switch v := value.(type) {
+		case *Instance:
+			if value, err = v.snapshot(); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		case []*Instance:
+			instances := make([]instanceSnapshot, len(v))
+
+			for j, embedded := range v {
+				if instances[j], err = embedded.snapshot(); err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+			}
+
+			value = instances
+		}
+
+		snapshot[strings.ToLower(name)] = value
+	}
+
+	return snapshot, nil
+}
+
+// GetProperty implements [Properties]. See [Element.GetValue] for the returned types.
+func (instance *Instance) GetProperty(name string) (any, bool, error) {
+	element, err := instance.GetElement(name)
+	if err != nil {
+		if errors.Is(err, MI_RESULT_NO_SUCH_PROPERTY) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	value, err := element.GetValue()
+	if err != nil {
+		return nil, true, err
+	}
+
+	return value, true, nil
+}
+
+// AddElement adds a property to the instance. value must be a bool, a sized integer, a float, a string or a []string.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_instance_addelement
+func (instance *Instance) AddElement(elementName string, value any, flags ElementFlags) error {
+	if instance == nil || instance.ft == nil {
+		return ErrNotInitialized
+	}
+
+	elementNameUTF16, err := windows.UTF16PtrFromString(elementName)
+	if err != nil {
+		return fmt.Errorf("failed to convert element name %s to UTF-16: %w", elementName, err)
+	}
+
+	var (
+		valueType ValueType
+		scalar    uint64
+	)
+
+	// MI_Value is a union. Scalars are stored in the low bytes, strings and arrays as pointers.
+	valuePtr := unsafe.Pointer(&scalar)
+
+	switch v := value.(type) {
+	case bool:
+		valueType = ValueTypeBOOLEAN
+
+		if v {
+			scalar = 1
+		}
+	case uint8:
+		valueType, scalar = ValueTypeUINT8, uint64(v)
+	case int8:
+		valueType, scalar = ValueTypeSINT8, uint64(uint8(v))
+	case uint16:
+		valueType, scalar = ValueTypeUINT16, uint64(v)
+	case int16:
+		valueType, scalar = ValueTypeSINT16, uint64(uint16(v))
+	case uint32:
+		valueType, scalar = ValueTypeUINT32, uint64(v)
+	case int32:
+		valueType, scalar = ValueTypeSINT32, uint64(uint32(v))
+	case uint64:
+		valueType, scalar = ValueTypeUINT64, v
+	case int64:
+		valueType, scalar = ValueTypeSINT64, uint64(v)
+	case float32:
+		valueType, scalar = ValueTypeREAL32, uint64(math.Float32bits(v))
+	case float64:
+		valueType, scalar = ValueTypeREAL64, math.Float64bits(v)
+	case string:
+		stringValue, err := windows.UTF16PtrFromString(v)
+		if err != nil {
+			return fmt.Errorf("failed to convert value of %s to UTF-16: %w", elementName, err)
+		}
+
+		valueType, valuePtr = ValueTypeSTRING, unsafe.Pointer(&stringValue)
+	case []string:
+		ptrArray := make([]*uint16, len(v))
+
+		for i, str := range v {
+			if ptrArray[i], err = windows.UTF16PtrFromString(str); err != nil {
+				return fmt.Errorf("failed to convert value of %s to UTF-16: %w", elementName, err)
+			}
+		}
+
+		valueType, valuePtr = ValueTypeSTRINGA, unsafe.Pointer(&rawArray{
+			data: unsafe.Pointer(unsafe.SliceData(ptrArray)),
+			size: uint32(len(ptrArray)),
+		})
+	default:
+		return fmt.Errorf("%s: unsupported value type %T", elementName, value)
+	}
+
+	r0, _, _ := syscall.SyscallN(
+		instance.ft.AddElement,
+		uintptr(unsafe.Pointer(instance)),
+		uintptr(unsafe.Pointer(elementNameUTF16)),
+		uintptr(valuePtr),
+		uintptr(valueType),
+		uintptr(flags),
+	)
+
+	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
+		return result
+	}
+
+	return nil
 }
 
 func (instance *Instance) GetElementCount() (uint32, error) {
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Transport invokes methods of MI classes and instances. It is implemented by [*Session] and [FakeTransport].
+type Transport interface {
+	// InvokeMethod invokes a method. keys selects the instance of an instance method and is empty for static methods.
+	// fn is called with the out-parameters, which are only valid until fn returns.
+	InvokeMethod(namespace, className, methodName string, keys, in map[string]any, fn func(out Properties) error) error
+}
+
+// InvokeUnmarshal invokes a method and unmarshals the out-parameters into dst, which must be a pointer to a struct.
+// The fields of dst are mapped by their mi tag, e.g. `mi:"ReturnValue"`. Embedded instances are unmarshalled
+// into struct or pointer to struct fields.
+//
+// keys selects the instance of an instance method and must be nil for static methods.
+// keys and in are either a map[string]any or a struct with mi tags. Nil pointer fields are omitted.
+func InvokeUnmarshal(transport Transport, dst any, namespace, className, methodName string, keys, in any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidEntityType
+	}
+
+	keyProperties, err := marshalProperties(keys)
+	if err != nil {
+		return fmt.Errorf("failed to marshal keys: %w", err)
+	}
+
+	inProperties, err := marshalProperties(in)
+	if err != nil {
+		return fmt.Errorf("failed to marshal in-parameters: %w", err)
+	}
+
+	dv = dv.Elem()
+	dv.SetZero()
+
+	return transport.InvokeMethod(namespace, className, methodName, keyProperties, inProperties, func(out Properties) error {
+		return unmarshalProperties(out, dv)
+	})
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/stretchr/testify/require"
+)
+
+type defragAnalysis struct {
+	FilePercentFragmentation  uint32 `mi:"FilePercentFragmentation"`
+	TotalPercentFragmentation uint32 `mi:"TotalPercentFragmentation"`
+	VolumeSize                uint64 `mi:"VolumeSize"`
+}
+
+type defragAnalysisResult struct {
+	ReturnValue       uint32          `mi:"ReturnValue"`
+	DefragRecommended bool            `mi:"DefragRecommended"`
+	DefragAnalysis    *defragAnalysis `mi:"DefragAnalysis"`
+}
+
+type volumeKey struct {
+	DeviceID string `mi:"DeviceID"`
+}
+
+func TestInvokeUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	transport := mi.NewFakeTransport()
+	transport.SetMethod("root/CIMv2", "Win32_Volume", "DefragAnalysis", mi.FakeInstance{
+		"ReturnValue":       uint32(0),
+		"DefragRecommended": true,
+		"DefragAnalysis": mi.FakeInstance{
+			"FilePercentFragmentation":  uint32(3),
+			"TotalPercentFragmentation": uint32(7),
+			"VolumeSize":                uint64(1 << 40),
+		},
+	}, nil)
+
+	var result defragAnalysisResult
+
+	err := mi.InvokeUnmarshal(transport, &result, "root/CIMv2", "Win32_Volume", "DefragAnalysis", volumeKey{DeviceID: `\\?\Volume{1}\`}, nil)
+	require.NoError(t, err)
+	require.Equal(t, defragAnalysisResult{
+		DefragRecommended: true,
+		DefragAnalysis: &defragAnalysis{
+			FilePercentFragmentation:  3,
+			TotalPercentFragmentation: 7,
+			VolumeSize:                1 << 40,
+		},
+	}, result)
+
+	require.Equal(t, []mi.FakeCall{
+		{
+			Namespace:  "root/CIMv2",
+			ClassName:  "Win32_Volume",
+			MethodName: "DefragAnalysis",
+			Keys:       map[string]any{"DeviceID": `\\?\Volume{1}\`},
+			In:         map[string]any{},
+		},
+	}, transport.Calls())
+}
+
+func TestInvokeUnmarshalValues(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Name string `mi:"Name"`
+	}
+
+	type result struct {
+		Bool       bool     `mi:"Bool"`
+		Int        int      `mi:"Int"`
+		Uint16     uint16   `mi:"Uint16"`
+		Float      float64  `mi:"Float"`
+		String     string   `mi:"String"`
+		Strings    []string `mi:"Strings"`
+		Nested     nested   `mi:"Nested"`
+		Missing    string   `mi:"Missing"`
+		Null       string   `mi:"Null"`
+		Any        any      `mi:"Any"`
+		Untagged   string
+		unexported string //nolint:unused
+	}
+
+	for _, tc := range []struct {
+		name     string
+		out      mi.FakeInstance
+		expected result
+		err      string
+	}{
+		{
+			name: "all types",
+			out: mi.FakeInstance{
+				"Bool":    true,
+				"Int":     int32(-5),
+				"Uint16":  uint8(200),
+				"Float":   float32(0.5),
+				"String":  "foo",
+				"Strings": []string{"a", "b"},
+				"Nested":  mi.FakeInstance{"Name": "bar"},
+				"Null":    nil,
+				"Any":     uint64(42),
+			},
+			expected: result{
+				Bool:    true,
+				Int:     -5,
+				Uint16:  200,
+				Float:   0.5,
+				String:  "foo",
+				Strings: []string{"a", "b"},
+				Nested:  nested{Name: "bar"},
+				Any:     uint64(42),
+			},
+		},
+		{
+			name:     "integer to float",
+			out:      mi.FakeInstance{"Float": uint64(3)},
+			expected: result{Float: 3},
+		},
+		{
+			name:     "unsigned to signed",
+			out:      mi.FakeInstance{"Int": uint32(7)},
+			expected: result{Int: 7},
+		},
+		{
+			name: "overflow",
+			out:  mi.FakeInstance{"Uint16": uint32(1 << 20)},
+			err:  "Uint16: cannot unmarshal uint32 into uint16",
+		},
+		{
+			name: "negative to unsigned",
+			out:  mi.FakeInstance{"Uint16": int8(-1)},
+			err:  "Uint16: cannot unmarshal int8 into uint16",
+		},
+		{
+			name: "type mismatch",
+			out:  mi.FakeInstance{"String": uint32(1)},
+			err:  "String: cannot unmarshal uint32 into string",
+		},
+		{
+			name: "instance into scalar",
+			out:  mi.FakeInstance{"String": mi.FakeInstance{}},
+			err:  "String: invalid entity type: cannot unmarshal instance into string",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := mi.NewFakeTransport()
+			transport.SetMethod("root/test", "Test", "Get", tc.out, nil)
+
+			dst := result{String: "stale"}
+
+			err := mi.InvokeUnmarshal(transport, &dst, "root/test", "Test", "Get", nil, nil)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, dst)
+		})
+	}
+}
+
+func TestInvokeUnmarshalInParameters(t *testing.T) {
+	t.Parallel()
+
+	type mode uint16
+
+	type parameters struct {
+		Mode     mode     `mi:"Mode"`
+		Force    *bool    `mi:"Force"`
+		Timeout  *uint32  `mi:"Timeout"`
+		Names    []string `mi:"Names"`
+		Untagged string
+	}
+
+	transport := mi.NewFakeTransport()
+	transport.SetMethod("root/test", "Test", "Run", mi.FakeInstance{}, nil)
+
+	force := true
+
+	var dst struct{}
+
+	err := mi.InvokeUnmarshal(transport, &dst, "root/test", "Test", "Run", nil, &parameters{Mode: 2, Force: &force, Names: []string{"a"}})
+	require.NoError(t, err)
+
+	err = mi.InvokeUnmarshal(transport, &dst, "root/test", "Test", "Run", map[string]any{"Id": 1}, map[string]any{"Mode": mode(3)})
+	require.NoError(t, err)
+
+	calls := transport.Calls()
+	require.Len(t, calls, 2)
+	require.Equal(t, map[string]any{}, calls[0].Keys)
+	require.Equal(t, map[string]any{"Mode": uint16(2), "Force": true, "Names": []string{"a"}}, calls[0].In)
+	require.Equal(t, map[string]any{"Id": int64(1)}, calls[1].Keys)
+	require.Equal(t, map[string]any{"Mode": uint16(3)}, calls[1].In)
+
+	err = mi.InvokeUnmarshal(transport, &dst, "root/test", "Test", "Run", nil, map[string]any{"Map": map[string]any{}})
+	require.EqualError(t, err, "failed to marshal in-parameters: Map: unsupported type map[string]interface {}")
+
+	err = mi.InvokeUnmarshal(transport, &dst, "root/test", "Test", "Run", nil, "Mode")
+	require.ErrorIs(t, err, mi.ErrInvalidEntityType)
+}
+
+func TestInvokeUnmarshalErrors(t *testing.T) {
+	t.Parallel()
+
+	errAccessDenied := errors.New("access denied")
+
+	transport := mi.NewFakeTransport()
+	transport.SetMethod("root/test", "Test", "Denied", nil, errAccessDenied)
+
+	var dst defragAnalysisResult
+
+	err := mi.InvokeUnmarshal(transport, &dst, "root/test", "Test", "Denied", nil, nil)
+	require.ErrorIs(t, err, errAccessDenied)
+
+	err = mi.InvokeUnmarshal(transport, &dst, "root/test", "Test", "Unknown", nil, nil)
+	require.ErrorIs(t, err, mi.ErrMethodNotFound)
+
+	err = mi.InvokeUnmarshal(transport, dst, "root/test", "Test", "Denied", nil, nil)
+	require.ErrorIs(t, err, mi.ErrInvalidEntityType)
+
+	err = mi.InvokeUnmarshal(transport, &[]defragAnalysisResult{}, "root/test", "Test", "Denied", nil, nil)
+	require.ErrorIs(t, err, mi.ErrInvalidEntityType)
+}
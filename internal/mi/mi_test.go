@@ -18,6 +18,10 @@
 package mi_test
 
 import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -234,3 +238,118 @@ func Test_MI_Query_Unmarshal(t *testing.T) {
 	err = application.Close()
 	require.NoError(t, err)
 }
+
+func Test_MI_InvokeUnmarshal(t *testing.T) {
+	application, err := mi.ApplicationInitialize()
+	require.NoError(t, err)
+	require.NotEmpty(t, application)
+
+	session, err := application.NewSession(nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+
+	var owner struct {
+		ReturnValue uint32 `mi:"ReturnValue"`
+		User        string `mi:"User"`
+		Domain      string `mi:"Domain"`
+	}
+
+	err = session.InvokeUnmarshal(&owner, mi.NamespaceRootCIMv2, "Win32_Process", "GetOwner",
+		map[string]any{"Handle": strconv.Itoa(os.Getpid())}, nil,
+	)
+	require.NoError(t, err)
+	require.Zero(t, owner.ReturnValue)
+	require.NotEmpty(t, owner.User)
+
+	err = session.InvokeUnmarshal(&owner, mi.NamespaceRootCIMv2, "Win32_Process", "NoSuchMethod",
+		map[string]any{"Handle": strconv.Itoa(os.Getpid())}, nil,
+	)
+	require.Error(t, err)
+
+	err = session.Close()
+	require.NoError(t, err)
+
+	err = application.Close()
+	require.NoError(t, err)
+}
+
+func Test_MI_Subscribe(t *testing.T) {
+	application, err := mi.ApplicationInitialize()
+	require.NoError(t, err)
+	require.NotEmpty(t, application)
+
+	session, err := application.NewSession(nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+
+	processes := make(chan string, 100)
+
+	subscription, err := session.Subscribe(mi.NamespaceRootCIMv2, mi.QueryDialectWQL,
+		"SELECT * FROM __InstanceCreationEvent WITHIN 1 WHERE TargetInstance ISA 'Win32_Process'",
+		func(instance *mi.Instance) {
+			// The callback runs on the goroutine of the subscription, so failures are reported by the missing event.
+			element, err := instance.GetElement("TargetInstance")
+			if err != nil {
+				return
+			}
+
+			value, err := element.GetValue()
+			if err != nil {
+				return
+			}
+
+			targetInstance, ok := value.(*mi.Instance)
+			if !ok {
+				return
+			}
+
+			if element, err = targetInstance.GetElement("Name"); err != nil {
+				return
+			}
+
+			if value, err = element.GetValue(); err != nil {
+				return
+			}
+
+			name, _ := value.(string)
+
+			select {
+			case processes <- name:
+			default:
+			}
+		},
+	)
+	require.NoError(t, err)
+
+	// Give WMI time to set up the event polling.
+	time.Sleep(2 * time.Second)
+
+	require.NoError(t, exec.Command("cmd.exe", "/c", "ping -n 3 127.0.0.1 > nul").Run())
+
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case name := <-processes:
+				if strings.EqualFold(name, "cmd.exe") {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, subscription.Err())
+
+	err = subscription.Close()
+	require.NoError(t, err)
+
+	<-subscription.Done()
+	require.NoError(t, subscription.Err())
+
+	err = session.Close()
+	require.NoError(t, err)
+
+	err = application.Close()
+	require.NoError(t, err)
+}
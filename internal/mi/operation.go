@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"syscall"
 	"time"
 	"unsafe"
@@ -35,6 +36,24 @@ import (
 //nolint:gochecknoglobals
 var OperationOptionsTimeout = UTF16PtrFromString[*uint16]("__MI_OPERATIONOPTIONS_TIMEOUT")
 
+//nolint:gochecknoglobals
+var (
+	// operationTraces holds the traces of the running query operations created by [Session.QueryInstances].
+	// The traces are kept outside of [Operation], since MI passes its own operation handles to callbacks.
+	operationTracesMu sync.Mutex
+	operationTraces   = map[*Operation]*operationTrace{}
+)
+
+// operationTrace records a query operation from its start until the last instance is retrieved.
+type operationTrace struct {
+	namespace string
+	query     string
+	start     time.Time
+	end       time.Time
+	instances int
+	err       error
+}
+
 // OperationFlags represents the flags for an operation.
 //
 // https://learn.microsoft.com/en-us/previous-versions/windows/desktop/wmi_v2/mi-flags
@@ -111,6 +130,8 @@ func (o *Operation) Close() error {
 
 	r0, _, _ := syscall.SyscallN(o.ft.Close, uintptr(unsafe.Pointer(o)))
 
+	o.finishTrace()
+
 	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
 		return result
 	}
@@ -118,12 +139,44 @@ func (o *Operation) Close() error {
 	return nil
 }
 
+// startTrace records the operation in the operation statistics once it is closed.
+func (o *Operation) startTrace(namespace, query string, start time.Time) {
+	operationTracesMu.Lock()
+	operationTraces[o] = &operationTrace{namespace: namespace, query: query, start: start}
+	operationTracesMu.Unlock()
+}
+
+func (o *Operation) finishTrace() {
+	operationTracesMu.Lock()
+
+	trace, ok := operationTraces[o]
+	delete(operationTraces, o)
+
+	operationTracesMu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	// Operations closed before the last instance was retrieved last until they are closed.
+	if trace.end.IsZero() {
+		trace.end = time.Now()
+	}
+
+	observeOperation(trace.namespace, trace.query, trace.end.Sub(trace.start), trace.instances, trace.err)
+}
+
+// Cancel cancels a running operation. It may be called from any goroutine,
+// and unblocks a pending [Operation.GetInstance] or [Operation.GetIndication] call.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_operation_cancel
 func (o *Operation) Cancel() error {
 	if o == nil || o.ft == nil {
 		return ErrNotInitialized
 	}
 
-	r0, _, _ := syscall.SyscallN(o.ft.Close, uintptr(unsafe.Pointer(o)), 0)
+	// MI_REASON_NONE
+	r0, _, _ := syscall.SyscallN(o.ft.Cancel, uintptr(unsafe.Pointer(o)), 0)
 
 	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
 		return result
@@ -133,6 +186,27 @@ func (o *Operation) Cancel() error {
 }
 
 func (o *Operation) GetInstance() (*Instance, bool, error) {
+	instance, moreResults, err := o.getInstance()
+
+	operationTracesMu.Lock()
+
+	if trace, ok := operationTraces[o]; ok {
+		if instance != nil {
+			trace.instances++
+		}
+
+		if err != nil || !moreResults {
+			trace.end = time.Now()
+			trace.err = err
+		}
+	}
+
+	operationTracesMu.Unlock()
+
+	return instance, moreResults, err
+}
+
+func (o *Operation) getInstance() (*Instance, bool, error) {
 	if o == nil || o.ft == nil {
 		return nil, false, ErrNotInitialized
 	}
@@ -166,6 +240,48 @@ func (o *Operation) GetInstance() (*Instance, bool, error) {
 	return instance, moreResults == True, nil
 }
 
+// GetIndication waits for the next indication of a subscription created by [Session.Subscribe].
+// The returned instance is owned by the operation and only valid until the next call.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_operation_getindication
+func (o *Operation) GetIndication() (*Instance, bool, error) {
+	if o == nil || o.ft == nil {
+		return nil, false, ErrNotInitialized
+	}
+
+	var (
+		instance          *Instance
+		bookmarkUTF16     *uint16
+		machineIDUTF16    *uint16
+		moreResults       Boolean
+		indicationResult  ResultError
+		errorMessageUTF16 *uint16
+		completionDetails *Instance
+	)
+
+	r0, _, _ := syscall.SyscallN(
+		o.ft.GetIndication,
+		uintptr(unsafe.Pointer(o)),
+		uintptr(unsafe.Pointer(&instance)),
+		uintptr(unsafe.Pointer(&bookmarkUTF16)),
+		uintptr(unsafe.Pointer(&machineIDUTF16)),
+		uintptr(unsafe.Pointer(&moreResults)),
+		uintptr(unsafe.Pointer(&indicationResult)),
+		uintptr(unsafe.Pointer(&errorMessageUTF16)),
+		uintptr(unsafe.Pointer(&completionDetails)),
+	)
+
+	if !errors.Is(indicationResult, MI_RESULT_OK) {
+		return nil, false, fmt.Errorf("indication result: %w (%s)", indicationResult, windows.UTF16PtrToString(errorMessageUTF16))
+	}
+
+	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
+		return nil, false, result
+	}
+
+	return instance, moreResults == True, nil
+}
+
 func (o *Operation) Unmarshal(dst any) error {
 	if o == nil || o.ft == nil {
 		return ErrNotInitialized
@@ -179,7 +295,6 @@ func (o *Operation) Unmarshal(dst any) error {
 	dv = dv.Elem()
 
 	elemType := dv.Type().Elem()
-	elemValue := reflect.ValueOf(reflect.New(elemType).Interface()).Elem()
 
 	if dv.Kind() != reflect.Slice || elemType.Kind() != reflect.Struct {
 		return ErrInvalidEntityType
@@ -207,41 +322,10 @@ func (o *Operation) Unmarshal(dst any) error {
 			break
 		}
 
-		for i := range elemType.NumField() {
-			field := elemValue.Field(i)
-
-			// Check if the field has an `mi` tag
-			miTag := elemType.Field(i).Tag.Get("mi")
-			if miTag == "" {
-				continue
-			}
-
-			element, err := instance.GetElement(miTag)
-			if err != nil {
-				return fmt.Errorf("failed to get element %s: %w", miTag, err)
-			}
-
-			switch element.valueType {
-			case ValueTypeBOOLEAN:
-				field.SetBool(element.value == 1)
-			case ValueTypeUINT8, ValueTypeUINT16, ValueTypeUINT32, ValueTypeUINT64:
-				field.SetUint(uint64(element.value))
-			case ValueTypeSINT8, ValueTypeSINT16, ValueTypeSINT32, ValueTypeSINT64:
-				field.SetInt(int64(element.value))
-			case ValueTypeSTRING:
-				if element.value == 0 {
-					return fmt.Errorf("%s: invalid pointer: value is nil", miTag)
-				}
-
-				// Convert the UTF-16 string to a Go string
-				stringValue := windows.UTF16PtrToString((*uint16)(unsafe.Pointer(element.value)))
-
-				field.SetString(stringValue)
-			case ValueTypeREAL32, ValueTypeREAL64:
-				field.SetFloat(float64(element.value))
-			default:
-				return fmt.Errorf("unsupported value type: %d", element.valueType)
-			}
+		elemValue := reflect.New(elemType).Elem()
+
+		if err = unmarshalProperties(instance, elemValue); err != nil {
+			return err
 		}
 
 		dv.Set(reflect.Append(dv, elemValue))
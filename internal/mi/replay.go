@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package mi
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus-community/windows_exporter/internal/replay"
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var recorder atomic.Pointer[replay.Recorder]
+
+// SetRecorder records the instances returned by all subsequent queries.
+// Passing nil disables recording.
+func SetRecorder(r *replay.Recorder) {
+	recorder.Store(r)
+}
+
+// NewReplaySession returns a session that answers queries from a recording instead of MI.
+// All other operations of the session return [ErrNotInitialized].
+func NewReplaySession(r *replay.Replayer) *Session {
+	return &Session{
+		replayer: r,
+	}
+}
+
+func namespaceString(namespace Namespace) string {
+	return windows.UTF16PtrToString(namespace)
+}
+
+func queryString(query Query) string {
+	return windows.UTF16PtrToString(query)
+}
@@ -20,10 +20,13 @@ package mi
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
 	"syscall"
+	"time"
 	"unsafe"
 
+	"github.com/prometheus-community/windows_exporter/internal/replay"
 	"golang.org/x/sys/windows"
 )
 
@@ -36,6 +39,9 @@ type Session struct {
 	ft        *SessionFT
 
 	defaultOperationOptions *OperationOptions
+	cache                   *queryCache
+
+	replayer *replay.Replayer
 }
 
 // SessionFT represents the function table for Session.
@@ -63,6 +69,10 @@ type SessionFT struct {
 //
 // https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_session_close
 func (s *Session) Close() error {
+	if s != nil && s.replayer != nil {
+		return nil
+	}
+
 	if s == nil || s.ft == nil {
 		return ErrNotInitialized
 	}
@@ -166,6 +176,8 @@ func (s *Session) QueryInstances(flags OperationFlags, operationOptions *Operati
 		operationOptions = s.defaultOperationOptions
 	}
 
+	start := time.Now()
+
 	r0, _, _ := syscall.SyscallN(
 		s.ft.QueryInstances,
 		uintptr(unsafe.Pointer(s)),
@@ -179,9 +191,13 @@ func (s *Session) QueryInstances(flags OperationFlags, operationOptions *Operati
 	)
 
 	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
+		observeOperation(namespaceString(namespaceName), queryExpression, time.Since(start), 0, result)
+
 		return nil, result
 	}
 
+	operation.startTrace(namespaceString(namespaceName), queryExpression, start)
+
 	return operation, nil
 }
 
@@ -192,10 +208,32 @@ func (s *Session) QueryUnmarshal(dst any,
 	flags OperationFlags, operationOptions *OperationOptions,
 	namespaceName Namespace, queryDialect QueryDialect, queryExpression Query,
 ) error {
+	if s != nil && s.replayer != nil {
+		return s.replayer.QueryMI(namespaceString(namespaceName), queryString(queryExpression), dst)
+	}
+
 	if s == nil || s.ft == nil {
 		return ErrNotInitialized
 	}
 
+	start := time.Now()
+	err := s.queryUnmarshal(dst, flags, operationOptions, namespaceName, queryDialect, queryExpression)
+
+	var instances int
+
+	if dv := reflect.ValueOf(dst); dv.Kind() == reflect.Ptr && !dv.IsNil() && dv.Elem().Kind() == reflect.Slice {
+		instances = dv.Elem().Len()
+	}
+
+	observeOperation(namespaceString(namespaceName), queryString(queryExpression), time.Since(start), instances, err)
+
+	return err
+}
+
+func (s *Session) queryUnmarshal(dst any,
+	flags OperationFlags, operationOptions *OperationOptions,
+	namespaceName Namespace, queryDialect QueryDialect, queryExpression Query,
+) error {
 	operation := &Operation{}
 
 	if operationOptions == nil {
@@ -243,11 +281,27 @@ func (s *Session) QueryUnmarshal(dst any,
 	// KeepAlive is used to ensure that the callbacks are not garbage collected before the operation is closed.
 	runtime.KeepAlive(operationCallbacks.CallbackContext)
 
-	return errors.Join(errs...)
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if r := recorder.Load(); r != nil {
+		if err := r.RecordMI(namespaceString(namespaceName), queryString(queryExpression), dst); err != nil {
+			return fmt.Errorf("failed to record query: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // Query queries for a set of instances based on a query expression.
+// The instances are unmarshalled directly into dst and are not cached. Use [Session.QueryWithTTL] for classes,
+// which rarely change, to share the result with other collectors.
 func (s *Session) Query(dst any, namespaceName Namespace, queryExpression Query) error {
+	if s == nil || s.ft == nil || s.replayer != nil {
+		return s.QueryWithTTL(dst, namespaceName, queryExpression, 0)
+	}
+
 	err := s.QueryUnmarshal(dst, OperationFlagsStandardRTTI, nil, namespaceName, QueryDialectWQL, queryExpression)
 	if err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
@@ -255,3 +309,232 @@ func (s *Session) Query(dst any, namespaceName Namespace, queryExpression Query)
 
 	return nil
 }
+
+// QueryWithTTL queries for a set of instances based on a query expression, like [Session.Query].
+// The result is cached for ttl, so collectors querying rarely changing classes can share the result of one operation,
+// even if they unmarshal it into different types. Identical concurrent queries are coalesced into one operation.
+// The properties of all instances are copied for this, so a query without ttl is cheaper with [Session.Query].
+func (s *Session) QueryWithTTL(dst any, namespaceName Namespace, queryExpression Query, ttl time.Duration) error {
+	if s != nil && s.replayer != nil {
+		if err := s.replayer.QueryMI(namespaceString(namespaceName), queryString(queryExpression), dst); err != nil {
+			return fmt.Errorf("WMI query failed: %w", err)
+		}
+
+		return nil
+	}
+
+	if s == nil || s.ft == nil {
+		return ErrNotInitialized
+	}
+
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Slice || dv.Elem().Type().Elem().Kind() != reflect.Struct {
+		return ErrInvalidEntityType
+	}
+
+	key := queryKey{namespace: namespaceString(namespaceName), query: queryString(queryExpression)}
+
+	instances, err := s.cache.query(key, ttl, func() ([]instanceSnapshot, error) {
+		return s.querySnapshots(namespaceName, key.query)
+	})
+	if err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	dv = dv.Elem()
+	elemType := dv.Type().Elem()
+	result := reflect.MakeSlice(dv.Type(), 0, len(instances))
+
+	for _, instance := range instances {
+		elemValue := reflect.New(elemType).Elem()
+
+		if err = unmarshalProperties(instance, elemValue); err != nil {
+			return fmt.Errorf("WMI query failed: %w", err)
+		}
+
+		result = reflect.Append(result, elemValue)
+	}
+
+	dv.Set(result)
+
+	if r := recorder.Load(); r != nil {
+		if err := r.RecordMI(key.namespace, key.query, dst); err != nil {
+			return fmt.Errorf("failed to record query: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// QueryStats returns the counters of the queries executed by [Session.Query] and [Session.QueryWithTTL].
+func (s *Session) QueryStats() QueryStats {
+	if s == nil || s.cache == nil {
+		return QueryStats{}
+	}
+
+	return s.cache.stats()
+}
+
+// querySnapshots runs the query and copies the properties of all instances before the operation is closed.
+func (s *Session) querySnapshots(namespaceName Namespace, queryExpression string) ([]instanceSnapshot, error) {
+	operation, err := s.QueryInstances(OperationFlagsStandardRTTI, nil, namespaceName, QueryDialectWQL, queryExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := snapshotInstances(operation)
+
+	if closeErr := operation.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close operation: %w", closeErr))
+	}
+
+	return instances, err
+}
+
+func snapshotInstances(operation *Operation) ([]instanceSnapshot, error) {
+	instances := make([]instanceSnapshot, 0)
+
+	for {
+		instance, moreResults, err := operation.GetInstance()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get instance: %w", err)
+		}
+
+		// If WMI returns nil, it means there are no more results.
+		if instance == nil {
+			break
+		}
+
+		snapshot, err := instance.snapshot()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(snapshot) == 0 {
+			break
+		}
+
+		instances = append(instances, snapshot)
+
+		if !moreResults {
+			break
+		}
+	}
+
+	return instances, nil
+}
+
+// Invoke invokes a method of a class or an instance. inboundInstance holds the key properties of the instance
+// for instance methods and is nil for static methods. inboundProperties holds the in-parameters and may be nil.
+// Without callbacks, the operation is synchronous and the out-parameters are retrieved with [Operation.GetInstance].
+// The operation must be closed with [Operation.Close].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_session_invoke
+func (s *Session) Invoke(flags OperationFlags, operationOptions *OperationOptions, namespaceName Namespace,
+	className, methodName string, inboundInstance, inboundProperties *Instance,
+) (*Operation, error) {
+	if s == nil || s.ft == nil {
+		return nil, ErrNotInitialized
+	}
+
+	classNameUTF16, err := windows.UTF16PtrFromString(className)
+	if err != nil {
+		return nil, err
+	}
+
+	methodNameUTF16, err := windows.UTF16PtrFromString(methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	operation := &Operation{}
+
+	if operationOptions == nil {
+		operationOptions = s.defaultOperationOptions
+	}
+
+	r0, _, _ := syscall.SyscallN(
+		s.ft.Invoke,
+		uintptr(unsafe.Pointer(s)),
+		uintptr(flags),
+		uintptr(unsafe.Pointer(operationOptions)),
+		uintptr(unsafe.Pointer(namespaceName)),
+		uintptr(unsafe.Pointer(classNameUTF16)),
+		uintptr(unsafe.Pointer(methodNameUTF16)),
+		uintptr(unsafe.Pointer(inboundInstance)),
+		uintptr(unsafe.Pointer(inboundProperties)),
+		0,
+		uintptr(unsafe.Pointer(operation)),
+	)
+
+	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
+		return nil, result
+	}
+
+	return operation, nil
+}
+
+// InvokeMethod implements [Transport].
+func (s *Session) InvokeMethod(namespace, className, methodName string, keys, in map[string]any, fn func(out Properties) error) error {
+	if s == nil || s.ft == nil {
+		return ErrNotInitialized
+	}
+
+	namespaceName, err := NewNamespace(namespace)
+	if err != nil {
+		return err
+	}
+
+	application, err := s.GetApplication()
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+
+	var inboundInstance, inboundProperties *Instance
+
+	if len(keys) > 0 {
+		if inboundInstance, err = application.newInstanceWithProperties(className, keys, ElementFlagsKey); err != nil {
+			return err
+		}
+
+		defer inboundInstance.Delete()
+	}
+
+	if len(in) > 0 {
+		// The in-parameters are passed as an instance of the __parameters class.
+		if inboundProperties, err = application.newInstanceWithProperties("__parameters", in, ElementFlagsNone); err != nil {
+			return err
+		}
+
+		defer inboundProperties.Delete()
+	}
+
+	operation, err := s.Invoke(OperationFlagsStandardRTTI, nil, namespaceName, className, methodName, inboundInstance, inboundProperties)
+	if err != nil {
+		return err
+	}
+
+	// The out-parameters are owned by the operation and released by closing it.
+	out, _, err := operation.GetInstance()
+	if err == nil && out != nil {
+		err = fn(out)
+	}
+
+	if closeErr := operation.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close operation: %w", closeErr))
+	}
+
+	return err
+}
+
+// InvokeUnmarshal invokes a method and unmarshals the out-parameters into dst. See [InvokeUnmarshal] for details.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_session_invoke
+func (s *Session) InvokeUnmarshal(dst any, namespaceName Namespace, className, methodName string, keys, in any) error {
+	err := InvokeUnmarshal(s, dst, namespaceString(namespaceName), className, methodName, keys, in)
+	if err != nil {
+		return fmt.Errorf("WMI method %s.%s failed: %w", className, methodName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package mi
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// IndicationFunc is called for each event delivered to a [Subscription].
+// The instance is owned by the subscription and only valid until the function returns,
+// so the required properties must be read within the function.
+type IndicationFunc func(instance *Instance)
+
+// Subscription is an event subscription created by [Session.Subscribe].
+// The events are delivered to the [IndicationFunc] of the subscription from a dedicated goroutine, until the subscription is closed.
+type Subscription struct {
+	operation        Operation
+	operationOptions *OperationOptions
+
+	closed atomic.Bool
+	done   chan struct{}
+	err    error
+}
+
+// Subscribe subscribes to the events selected by the query, e.g. `SELECT * FROM Win32_ProcessStartTrace`
+// or `SELECT * FROM __InstanceCreationEvent WITHIN 5 WHERE TargetInstance ISA 'Win32_Process'`.
+// fn is called for each event, one event at a time. The subscription must be closed with [Subscription.Close].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/nf-mi-mi_session_subscribe
+func (s *Session) Subscribe(namespaceName Namespace, queryDialect QueryDialect, queryExpression string, fn IndicationFunc) (*Subscription, error) {
+	if s == nil || s.ft == nil {
+		return nil, ErrNotInitialized
+	}
+
+	queryExpressionUTF16, err := windows.UTF16PtrFromString(queryExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	application, err := s.GetApplication()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	// Subscriptions run until they are closed, so they don't use the default operation options of the session, which have a timeout.
+	operationOptions, err := application.NewOperationOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operation options: %w", err)
+	}
+
+	subscription := &Subscription{
+		operationOptions: operationOptions,
+		done:             make(chan struct{}),
+	}
+
+	// Without callbacks, the operation is synchronous and the events are retrieved with GetIndication.
+	r0, _, _ := syscall.SyscallN(
+		s.ft.Subscribe,
+		uintptr(unsafe.Pointer(s)),
+		0,
+		uintptr(unsafe.Pointer(operationOptions)),
+		uintptr(unsafe.Pointer(namespaceName)),
+		uintptr(unsafe.Pointer(queryDialect)),
+		uintptr(unsafe.Pointer(queryExpressionUTF16)),
+		0,
+		0,
+		uintptr(unsafe.Pointer(&subscription.operation)),
+	)
+
+	if result := ResultError(r0); !errors.Is(result, MI_RESULT_OK) {
+		_ = operationOptions.Delete()
+
+		return nil, result
+	}
+
+	go subscription.run(fn)
+
+	return subscription, nil
+}
+
+func (s *Subscription) run(fn IndicationFunc) {
+	defer close(s.done)
+
+	for {
+		instance, moreResults, err := s.operation.GetIndication()
+		if err != nil {
+			// Closing the subscription cancels the pending GetIndication call.
+			if !s.closed.Load() {
+				s.err = err
+			}
+
+			return
+		}
+
+		if instance != nil {
+			fn(instance)
+		}
+
+		if !moreResults {
+			if !s.closed.Load() {
+				s.err = ErrSubscriptionEnded
+			}
+
+			return
+		}
+	}
+}
+
+// Done returns a channel that is closed once the subscription ended, either by [Subscription.Close] or by an error.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that ended the subscription. It returns nil while the subscription is running, or if it was closed.
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close cancels the subscription and waits until the last event was delivered.
+func (s *Subscription) Close() error {
+	if s == nil {
+		return ErrNotInitialized
+	}
+
+	if !s.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	var errs []error
+
+	select {
+	case <-s.done:
+	default:
+		if err := s.operation.Cancel(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to cancel operation: %w", err))
+		}
+
+		<-s.done
+	}
+
+	if err := s.operation.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close operation: %w", err))
+	}
+
+	if err := s.operationOptions.Delete(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete operation options: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
@@ -18,7 +18,7 @@
 package mi
 
 import (
-	"time"
+	"strings"
 	"unsafe"
 
 	"github.com/prometheus-community/windows_exporter/internal/utils"
@@ -47,6 +47,12 @@ func NewNamespace(namespace string) (Namespace, error) {
 	return windows.UTF16PtrFromString(namespace)
 }
 
+// ParseNamespace converts a namespace given by the user, e.g. in the configuration of a collector.
+// MI accepts both separators, but the backslash is the common notation in WMI tools.
+func ParseNamespace(namespace string) (Namespace, error) {
+	return NewNamespace(strings.ReplaceAll(namespace, `\`, "/"))
+}
+
 //nolint:gochecknoglobals
 var (
 	NamespaceRootCIMv2             = utils.Must(NewNamespace("root/CIMv2"))
@@ -74,48 +80,6 @@ func UTF16PtrFromString[T *uint16](s string) T {
 	return val
 }
 
-type Timestamp struct {
-	Year         uint32
-	Month        uint32
-	Day          uint32
-	Hour         uint32
-	Minute       uint32
-	Second       uint32
-	Microseconds uint32
-	UTC          int32
-}
-
-type Interval struct {
-	Days         uint32
-	Hours        uint32
-	Minutes      uint32
-	Seconds      uint32
-	Microseconds uint32
-	Padding1     uint32
-	Padding2     uint32
-	Padding3     uint32
-}
-
-func NewInterval(interval time.Duration) *Interval {
-	// Convert the duration to a number of microseconds
-	microseconds := interval.Microseconds()
-
-	// Create a new interval with the microseconds
-	return &Interval{
-		Days:         uint32(microseconds / (24 * 60 * 60 * 1000000)),
-		Hours:        uint32(microseconds / (60 * 60 * 1000000)),
-		Minutes:      uint32(microseconds / (60 * 1000000)),
-		Seconds:      uint32(microseconds / 1000000),
-		Microseconds: uint32(microseconds % 1000000),
-	}
-}
-
-type Datetime struct {
-	IsTimestamp bool
-	Timestamp   *Timestamp // Used when IsTimestamp is true
-	Interval    *Interval  // Used when IsTimestamp is false
-}
-
 type PropertyDecl struct {
 	Flags         uint32
 	Code          uint32
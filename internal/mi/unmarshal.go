@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"unicode/utf16"
+)
+
+// Properties provides the properties of an instance, e.g. the out-parameters of a method.
+// It is implemented by [*Instance] and [FakeInstance].
+type Properties interface {
+	// GetProperty returns the value of a property. The bool is false, if the instance has no such property.
+	GetProperty(name string) (any, bool, error)
+}
+
+// marshalProperties converts a map or a struct with mi tags into a map of the basic Go types supported by MI.
+func marshalProperties(src any) (map[string]any, error) {
+	properties := make(map[string]any)
+
+	if src == nil {
+		return properties, nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Ptr {
+		if sv.IsNil() {
+			return properties, nil
+		}
+
+		sv = sv.Elem()
+	}
+
+	switch {
+	case sv.Kind() == reflect.Map && sv.Type().Key().Kind() == reflect.String:
+		iter := sv.MapRange()
+		for iter.Next() {
+			if err := marshalProperty(properties, iter.Key().String(), iter.Value()); err != nil {
+				return nil, err
+			}
+		}
+	case sv.Kind() == reflect.Struct:
+		st := sv.Type()
+
+		for i := range st.NumField() {
+			miTag := st.Field(i).Tag.Get("mi")
+			if miTag == "" {
+				continue
+			}
+
+			if err := marshalProperty(properties, miTag, sv.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEntityType, sv.Type())
+	}
+
+	return properties, nil
+}
+
+func marshalProperty(properties map[string]any, name string, value reflect.Value) error {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		properties[name] = value.Bool()
+	case reflect.Int8:
+		properties[name] = int8(value.Int())
+	case reflect.Int16:
+		properties[name] = int16(value.Int())
+	case reflect.Int32:
+		properties[name] = int32(value.Int())
+	case reflect.Int, reflect.Int64:
+		properties[name] = value.Int()
+	case reflect.Uint8:
+		properties[name] = uint8(value.Uint())
+	case reflect.Uint16:
+		properties[name] = uint16(value.Uint())
+	case reflect.Uint32:
+		properties[name] = uint32(value.Uint())
+	case reflect.Uint, reflect.Uint64:
+		properties[name] = value.Uint()
+	case reflect.Float32:
+		properties[name] = float32(value.Float())
+	case reflect.Float64:
+		properties[name] = value.Float()
+	case reflect.String:
+		properties[name] = value.String()
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%s: unsupported type %s", name, value.Type())
+		}
+
+		if value.IsNil() {
+			return nil
+		}
+
+		strArray := make([]string, value.Len())
+		for i := range strArray {
+			strArray[i] = value.Index(i).String()
+		}
+
+		properties[name] = strArray
+	default:
+		return fmt.Errorf("%s: unsupported type %s", name, value.Type())
+	}
+
+	return nil
+}
+
+// unmarshalProperties sets the fields of the struct dv with an mi tag to the matching properties of src.
+// Properties that are missing or null leave the field untouched.
+//
+// Numbers are converted into any numeric field they fit into. Embedded instances and references are unmarshalled
+// into struct or pointer to struct fields, arrays into slices of any supported element type. DATETIME timestamps
+// are unmarshalled into [time.Time] and intervals into [time.Duration] fields. CHAR16 values and arrays can
+// also be unmarshalled into strings.
+func unmarshalProperties(src Properties, dv reflect.Value) error {
+	dt := dv.Type()
+
+	for i := range dt.NumField() {
+		miTag := dt.Field(i).Tag.Get("mi")
+		if miTag == "" {
+			continue
+		}
+
+		value, ok, err := src.GetProperty(miTag)
+		if err != nil {
+			return fmt.Errorf("failed to get property %s: %w", miTag, err)
+		}
+
+		if !ok || value == nil {
+			continue
+		}
+
+		if err = unmarshalValue(dv.Field(i), value); err != nil {
+			return fmt.Errorf("%s: %w", miTag, err)
+		}
+	}
+
+	return nil
+}
+
+func unmarshalValue(field reflect.Value, value any) error {
+	if properties, ok := value.(Properties); ok {
+		switch {
+		case field.Kind() == reflect.Struct:
+			return unmarshalProperties(properties, field)
+		case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct:
+			field.Set(reflect.New(field.Type().Elem()))
+
+			return unmarshalProperties(properties, field.Elem())
+		default:
+			return fmt.Errorf("%w: cannot unmarshal instance into %s", ErrInvalidEntityType, field.Type())
+		}
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch field.Kind() {
+	case reflect.Bool:
+		if v.Kind() == reflect.Bool {
+			field.SetBool(v.Bool())
+
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case v.CanInt() && !field.OverflowInt(v.Int()):
+			field.SetInt(v.Int())
+
+			return nil
+		case v.CanUint() && v.Uint() <= math.MaxInt64 && !field.OverflowInt(int64(v.Uint())):
+			field.SetInt(int64(v.Uint()))
+
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch {
+		case v.CanUint() && !field.OverflowUint(v.Uint()):
+			field.SetUint(v.Uint())
+
+			return nil
+		case v.CanInt() && v.Int() >= 0 && !field.OverflowUint(uint64(v.Int())):
+			field.SetUint(uint64(v.Int()))
+
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch {
+		case v.CanFloat():
+			field.SetFloat(v.Float())
+
+			return nil
+		case v.CanInt():
+			field.SetFloat(float64(v.Int()))
+
+			return nil
+		case v.CanUint():
+			field.SetFloat(float64(v.Uint()))
+
+			return nil
+		}
+	case reflect.String:
+		switch value := value.(type) {
+		case string:
+			field.SetString(value)
+
+			return nil
+		case Char16:
+			field.SetString(value.String())
+
+			return nil
+		case []Char16:
+			units := make([]uint16, len(value))
+			for i, c := range value {
+				units[i] = uint16(c)
+			}
+
+			field.SetString(string(utf16.Decode(units)))
+
+			return nil
+		}
+	case reflect.Slice:
+		if v.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(field.Type(), v.Len(), v.Len())
+
+			for i := range v.Len() {
+				if err := unmarshalValue(slice.Index(i), v.Index(i).Interface()); err != nil {
+					return fmt.Errorf("index %d: %w", i, err)
+				}
+			}
+
+			field.Set(slice)
+
+			return nil
+		}
+	default:
+		if v.Type().AssignableTo(field.Type()) {
+			field.Set(v)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot unmarshal %s into %s", v.Type(), field.Type())
+}
@@ -13,16 +13,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build windows
-
 package mi
 
 import (
-	"errors"
 	"fmt"
+	"slices"
+	"time"
+	"unicode/utf16"
 	"unsafe"
-
-	"golang.org/x/sys/windows"
 )
 
 type ValueType int
@@ -63,65 +61,201 @@ const (
 	ValueTypeARRAY ValueType = 16
 )
 
-type Element struct {
-	value     uintptr
-	valueType ValueType
+// flagNull is set in the flags of an element without a value.
+//
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/wmi_v2/mi-flags
+const flagNull = 0x20000000
+
+// Char16 is a UTF-16 code unit, the value of CHAR16 properties.
+type Char16 uint16
+
+func (c Char16) String() string {
+	return string(utf16.Decode([]uint16{uint16(c)}))
+}
+
+// rawValue represents MI_Value, the union of all value types. The largest member is MI_Datetime with 36 bytes.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/ns-mi-mi_value
+type rawValue [5]uint64
+
+// rawArray represents MI_Array, the value of all array types.
+type rawArray struct {
+	data unsafe.Pointer
+	size uint32
+}
+
+type Timestamp struct {
+	Year         uint32
+	Month        uint32
+	Day          uint32
+	Hour         uint32
+	Minute       uint32
+	Second       uint32
+	Microseconds uint32
+	UTC          int32 // offset from UTC in minutes
+}
+
+// Time returns the timestamp in UTC.
+func (t *Timestamp) Time() time.Time {
+	return time.Date(
+		int(t.Year), time.Month(t.Month), int(t.Day),
+		int(t.Hour), int(t.Minute), int(t.Second), int(t.Microseconds)*int(time.Microsecond),
+		time.FixedZone("", int(t.UTC)*60),
+	).UTC()
+}
+
+type Interval struct {
+	Days         uint32
+	Hours        uint32
+	Minutes      uint32
+	Seconds      uint32
+	Microseconds uint32
+	Padding1     uint32
+	Padding2     uint32
+	Padding3     uint32
+}
+
+func NewInterval(interval time.Duration) *Interval {
+	// Convert the duration to a number of microseconds
+	microseconds := interval.Microseconds()
+
+	// Create a new interval with the microseconds
+	return &Interval{
+		Days:         uint32(microseconds / (24 * 60 * 60 * 1000000)),
+		Hours:        uint32(microseconds / (60 * 60 * 1000000) % 24),
+		Minutes:      uint32(microseconds / (60 * 1000000) % 60),
+		Seconds:      uint32(microseconds / 1000000 % 60),
+		Microseconds: uint32(microseconds % 1000000),
+	}
+}
+
+func (i *Interval) Duration() time.Duration {
+	return time.Duration(i.Days)*24*time.Hour +
+		time.Duration(i.Hours)*time.Hour +
+		time.Duration(i.Minutes)*time.Minute +
+		time.Duration(i.Seconds)*time.Second +
+		time.Duration(i.Microseconds)*time.Microsecond
+}
+
+// Datetime represents MI_Datetime, the value of DATETIME properties.
+// Depending on IsTimestamp, it holds a [Timestamp] or an [Interval].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/mi/ns-mi-mi_datetime
+type Datetime struct {
+	IsTimestamp uint32
+	value       [8]uint32
 }
 
-func (e *Element) GetValue() (any, error) {
-	switch e.valueType {
+func (d *Datetime) Timestamp() *Timestamp {
+	return (*Timestamp)(unsafe.Pointer(&d.value))
+}
+
+func (d *Datetime) Interval() *Interval {
+	return (*Interval)(unsafe.Pointer(&d.value))
+}
+
+// Value returns a timestamp as [time.Time] and an interval as [time.Duration].
+func (d *Datetime) Value() any {
+	if d.IsTimestamp != 0 {
+		return d.Timestamp().Time()
+	}
+
+	return d.Interval().Duration()
+}
+
+// decodeValue converts a value into the matching Go type. Arrays are returned as typed slices,
+// except DATETIME arrays, which may mix timestamps and intervals and are returned as []any.
+// Instances and references are not handled, since they are only valid as long as the owning instance.
+func decodeValue(value *rawValue, valueType ValueType) (any, error) {
+	ptr := unsafe.Pointer(value)
+
+	switch valueType {
 	case ValueTypeBOOLEAN:
-		return e.value == 1, nil
+		return *(*uint8)(ptr) != 0, nil
 	case ValueTypeUINT8:
-		return uint8(e.value), nil
+		return *(*uint8)(ptr), nil
 	case ValueTypeSINT8:
-		return int8(e.value), nil
+		return *(*int8)(ptr), nil
 	case ValueTypeUINT16:
-		return uint16(e.value), nil
+		return *(*uint16)(ptr), nil
 	case ValueTypeSINT16:
-		return int16(e.value), nil
+		return *(*int16)(ptr), nil
 	case ValueTypeUINT32:
-		return uint32(e.value), nil
+		return *(*uint32)(ptr), nil
 	case ValueTypeSINT32:
-		return int32(e.value), nil
+		return *(*int32)(ptr), nil
 	case ValueTypeUINT64:
-		return uint64(e.value), nil
+		return *(*uint64)(ptr), nil
 	case ValueTypeSINT64:
-		return int64(e.value), nil
+		return *(*int64)(ptr), nil
 	case ValueTypeREAL32:
-		return float32(e.value), nil
+		return *(*float32)(ptr), nil
 	case ValueTypeREAL64:
-		return float64(e.value), nil
+		return *(*float64)(ptr), nil
 	case ValueTypeCHAR16:
-		return uint16(e.value), nil
+		return *(*Char16)(ptr), nil
 	case ValueTypeDATETIME:
-		if e.value == 0 {
-			return nil, errors.New("invalid pointer: value is nil")
-		}
-
-		return *(*Datetime)(unsafe.Pointer(e.value)), nil
+		return (*Datetime)(ptr).Value(), nil
 	case ValueTypeSTRING:
-		if e.value == 0 {
-			return nil, errors.New("invalid pointer: value is nil")
-		}
-
-		// Convert the UTF-16 string to a Go string
-		return windows.UTF16PtrToString((*uint16)(unsafe.Pointer(e.value))), nil
+		return utf16PtrToString(*(**uint16)(ptr)), nil
+	case ValueTypeBOOLEANA:
+		return decodeArray((*rawArray)(ptr), func(v uint8) bool { return v != 0 }), nil
+	case ValueTypeUINT8A:
+		return copyArray[uint8]((*rawArray)(ptr)), nil
+	case ValueTypeSINT8A:
+		return copyArray[int8]((*rawArray)(ptr)), nil
+	case ValueTypeUINT16A:
+		return copyArray[uint16]((*rawArray)(ptr)), nil
+	case ValueTypeSINT16A:
+		return copyArray[int16]((*rawArray)(ptr)), nil
+	case ValueTypeUINT32A:
+		return copyArray[uint32]((*rawArray)(ptr)), nil
+	case ValueTypeSINT32A:
+		return copyArray[int32]((*rawArray)(ptr)), nil
+	case ValueTypeUINT64A:
+		return copyArray[uint64]((*rawArray)(ptr)), nil
+	case ValueTypeSINT64A:
+		return copyArray[int64]((*rawArray)(ptr)), nil
+	case ValueTypeREAL32A:
+		return copyArray[float32]((*rawArray)(ptr)), nil
+	case ValueTypeREAL64A:
+		return copyArray[float64]((*rawArray)(ptr)), nil
+	case ValueTypeCHAR16A:
+		return copyArray[Char16]((*rawArray)(ptr)), nil
+	case ValueTypeDATETIMEA:
+		return decodeArray((*rawArray)(ptr), func(v Datetime) any { return v.Value() }), nil
 	case ValueTypeSTRINGA:
-		if e.value == 0 {
-			return nil, errors.New("invalid pointer: value is nil")
-		}
+		return decodeArray((*rawArray)(ptr), utf16PtrToString), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type: %d", valueType)
+	}
+}
 
-		// Assuming array of pointers to UTF-16 strings
-		ptrArray := *(*[]*uint16)(unsafe.Pointer(e.value))
-		strArray := make([]string, len(ptrArray))
+func copyArray[T any](array *rawArray) []T {
+	return slices.Clone(unsafe.Slice((*T)(array.data), array.size))
+}
 
-		for i, ptr := range ptrArray {
-			strArray[i] = windows.UTF16PtrToString(ptr)
-		}
+func decodeArray[T, V any](array *rawArray, fn func(T) V) []V {
+	items := unsafe.Slice((*T)(array.data), array.size)
+	values := make([]V, len(items))
 
-		return strArray, nil
-	default:
-		return nil, fmt.Errorf("unsupported value type: %d", e.valueType)
+	for i, item := range items {
+		values[i] = fn(item)
 	}
+
+	return values
+}
+
+// utf16PtrToString is [windows.UTF16PtrToString], which is not available on other platforms.
+func utf16PtrToString(p *uint16) string {
+	if p == nil {
+		return ""
+	}
+
+	n := 0
+	for ptr := unsafe.Pointer(p); *(*uint16)(ptr) != 0; n++ {
+		ptr = unsafe.Add(ptr, 2)
+	}
+
+	return string(utf16.Decode(unsafe.Slice(p, n)))
 }
@@ -0,0 +1,569 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+type win32OperatingSystem struct {
+	Caption            string    `mi:"Caption"`
+	Description        string    `mi:"Description"`
+	Primary            bool      `mi:"Primary"`
+	CurrentTimeZone    int16     `mi:"CurrentTimeZone"`
+	NumberOfProcesses  uint32    `mi:"NumberOfProcesses"`
+	FreePhysicalMemory float64   `mi:"FreePhysicalMemory"`
+	LastBootUpTime     time.Time `mi:"LastBootUpTime"`
+	MUILanguages       []string  `mi:"MUILanguages"`
+}
+
+type msftStorageReliabilityCounter struct {
+	DeviceID        string  `mi:"DeviceId"`
+	Temperature     uint8   `mi:"Temperature"`
+	TemperatureMax  uint8   `mi:"TemperatureMax"`
+	Wear            uint8   `mi:"Wear"`
+	PowerOnHours    uint32  `mi:"PowerOnHours"`
+	ReadErrorsTotal uint64  `mi:"ReadErrorsTotal"`
+	ReadLatencyMax  uint64  `mi:"ReadLatencyMax"`
+	ManufactureDate *string `mi:"ManufactureDate"`
+}
+
+type getReliabilityCounterResult struct {
+	ReturnValue        uint32                         `mi:"ReturnValue"`
+	ReliabilityCounter *msftStorageReliabilityCounter `mi:"ReliabilityCounter"`
+}
+
+type volumeReference struct {
+	DeviceID string `mi:"DeviceID"`
+}
+
+type nestedInstance struct {
+	Name string `mi:"Name"`
+	Size int    `mi:"Size"`
+}
+
+type allTypes struct {
+	Boolean        bool              `mi:"Boolean"`
+	UInt8          uint8             `mi:"UInt8"`
+	SInt8          int8              `mi:"SInt8"`
+	UInt16         uint16            `mi:"UInt16"`
+	SInt16         int16             `mi:"SInt16"`
+	UInt32         uint32            `mi:"UInt32"`
+	SInt32         int32             `mi:"SInt32"`
+	UInt64         uint64            `mi:"UInt64"`
+	SInt64         int64             `mi:"SInt64"`
+	Real32         float32           `mi:"Real32"`
+	Real64         float64           `mi:"Real64"`
+	Char16         Char16            `mi:"Char16"`
+	Timestamp      time.Time         `mi:"Timestamp"`
+	Interval       time.Duration     `mi:"Interval"`
+	String         string            `mi:"String"`
+	EmptyString    string            `mi:"EmptyString"`
+	Null           string            `mi:"Null"`
+	Reference      volumeReference   `mi:"Reference"`
+	Instance       *nestedInstance   `mi:"Instance"`
+	BooleanArray   []bool            `mi:"BooleanArray"`
+	UInt8Array     []uint8           `mi:"UInt8Array"`
+	SInt8Array     []int8            `mi:"SInt8Array"`
+	UInt16Array    []uint16          `mi:"UInt16Array"`
+	SInt16Array    []int16           `mi:"SInt16Array"`
+	UInt32Array    []uint32          `mi:"UInt32Array"`
+	SInt32Array    []int32           `mi:"SInt32Array"`
+	UInt64Array    []uint64          `mi:"UInt64Array"`
+	SInt64Array    []int64           `mi:"SInt64Array"`
+	Real32Array    []float32         `mi:"Real32Array"`
+	Real64Array    []float64         `mi:"Real64Array"`
+	Char16Array    []Char16          `mi:"Char16Array"`
+	TimestampArray []time.Time       `mi:"TimestampArray"`
+	IntervalArray  []time.Duration   `mi:"IntervalArray"`
+	StringArray    []string          `mi:"StringArray"`
+	EmptyArray     []uint32          `mi:"EmptyArray"`
+	NullArray      []string          `mi:"NullArray"`
+	ReferenceArray []volumeReference `mi:"ReferenceArray"`
+	InstanceArray  []*nestedInstance `mi:"InstanceArray"`
+}
+
+type convertedTypes struct {
+	UInt8       float64 `mi:"UInt8"`
+	SInt16      int64   `mi:"SInt16"`
+	UInt32      int     `mi:"UInt32"`
+	Char16      string  `mi:"Char16"`
+	Char16Array string  `mi:"Char16Array"`
+	Interval    float64 `mi:"Interval"`
+	UInt8Array  []int   `mi:"UInt8Array"`
+	Instance    struct {
+		Size float64 `mi:"Size"`
+	} `mi:"Instance"`
+	Missing string `mi:"Missing"`
+}
+
+func TestUnmarshalFixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		fixture  string
+		dst      any
+		expected any
+		err      string
+	}{
+		{
+			name:    "Win32_OperatingSystem",
+			fixture: "win32_operatingsystem.json",
+			dst:     &win32OperatingSystem{},
+			expected: &win32OperatingSystem{
+				Caption:            "Microsoft Windows Server 2022 Datacenter",
+				Primary:            true,
+				CurrentTimeZone:    -300,
+				NumberOfProcesses:  142,
+				FreePhysicalMemory: 5871236,
+				LastBootUpTime:     time.Date(2024, 1, 2, 2, 4, 5, 123456000, time.UTC),
+				MUILanguages:       []string{"en-US", "de-DE"},
+			},
+		},
+		{
+			name:    "GetReliabilityCounter",
+			fixture: "msft_storagereliabilitycounter.json",
+			dst:     &getReliabilityCounterResult{},
+			expected: &getReliabilityCounterResult{
+				ReliabilityCounter: &msftStorageReliabilityCounter{
+					DeviceID:       "0",
+					Temperature:    38,
+					TemperatureMax: 70,
+					Wear:           2,
+					PowerOnHours:   8760,
+					ReadLatencyMax: 1500,
+				},
+			},
+		},
+		{
+			name:    "all types",
+			fixture: "all_types.json",
+			dst:     &allTypes{},
+			expected: &allTypes{
+				Boolean:        true,
+				UInt8:          math.MaxUint8,
+				SInt8:          math.MinInt8,
+				UInt16:         math.MaxUint16,
+				SInt16:         math.MinInt16,
+				UInt32:         math.MaxUint32,
+				SInt32:         math.MinInt32,
+				UInt64:         math.MaxUint64,
+				SInt64:         math.MinInt64,
+				Real32:         0.25,
+				Real64:         1.5e300,
+				Char16:         'ä',
+				Timestamp:      time.Date(2024, 1, 1, 0, 0, 0, 1000, time.UTC),
+				Interval:       26*time.Hour + 3*time.Minute + 4*time.Second + 5*time.Microsecond,
+				String:         "Grüße 🌍",
+				Reference:      volumeReference{DeviceID: "C:"},
+				Instance:       &nestedInstance{Name: "nested", Size: 1024},
+				BooleanArray:   []bool{true, false},
+				UInt8Array:     []uint8{1, 2, 3},
+				SInt8Array:     []int8{-1, 1},
+				UInt16Array:    []uint16{1, math.MaxUint16},
+				SInt16Array:    []int16{-1, 1},
+				UInt32Array:    []uint32{1, math.MaxUint32},
+				SInt32Array:    []int32{-1, 1},
+				UInt64Array:    []uint64{1, math.MaxUint64},
+				SInt64Array:    []int64{-1, 1},
+				Real32Array:    []float32{0.5, -0.5},
+				Real64Array:    []float64{0.1, 0.2},
+				Char16Array:    []Char16{'a', 'b', 'c'},
+				TimestampArray: []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC)},
+				IntervalArray:  []time.Duration{time.Second, time.Minute},
+				StringArray:    []string{"a", "", "c"},
+				EmptyArray:     []uint32{},
+				ReferenceArray: []volumeReference{{DeviceID: "C:"}, {DeviceID: "D:"}},
+				InstanceArray:  []*nestedInstance{{Name: "first", Size: 1}, {Name: "second", Size: 2}},
+			},
+		},
+		{
+			name:    "conversions",
+			fixture: "all_types.json",
+			dst:     &convertedTypes{},
+			expected: &convertedTypes{
+				UInt8:       math.MaxUint8,
+				SInt16:      math.MinInt16,
+				UInt32:      math.MaxUint32,
+				Char16:      "ä",
+				Char16Array: "abc",
+				Interval:    float64(26*time.Hour + 3*time.Minute + 4*time.Second + 5*time.Microsecond),
+				UInt8Array:  []int{1, 2, 3},
+				Instance: struct {
+					Size float64 `mi:"Size"`
+				}{Size: 1024},
+			},
+		},
+		{
+			name:    "overflow",
+			fixture: "all_types.json",
+			dst: &struct {
+				UInt16 uint8 `mi:"UInt16"`
+			}{},
+			err: "UInt16: cannot unmarshal uint16 into uint8",
+		},
+		{
+			name:    "negative array element",
+			fixture: "all_types.json",
+			dst: &struct {
+				SInt8Array []uint8 `mi:"SInt8Array"`
+			}{},
+			err: "SInt8Array: index 0: cannot unmarshal int8 into uint8",
+		},
+		{
+			name:    "interval into time",
+			fixture: "all_types.json",
+			dst: &struct {
+				IntervalArray []time.Time `mi:"IntervalArray"`
+			}{},
+			err: "IntervalArray: index 0: cannot unmarshal time.Duration into time.Time",
+		},
+		{
+			name:    "instance into scalar",
+			fixture: "all_types.json",
+			dst: &struct {
+				Instance string `mi:"Instance"`
+			}{},
+			err: "Instance: invalid entity type: cannot unmarshal instance into string",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			instance := readFixture(t, tc.fixture)
+
+			err := unmarshalProperties(instance, reflect.ValueOf(tc.dst).Elem())
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tc.dst)
+		})
+	}
+}
+
+func TestInterval(t *testing.T) {
+	t.Parallel()
+
+	for _, duration := range []time.Duration{
+		0,
+		5 * time.Second,
+		90 * time.Second,
+		26*time.Hour + 3*time.Minute + 4*time.Second + 5*time.Microsecond,
+		400 * 24 * time.Hour,
+	} {
+		require.Equal(t, duration, NewInterval(duration).Duration())
+	}
+
+	require.Equal(t, &Interval{Minutes: 1, Seconds: 30}, NewInterval(90*time.Second))
+}
+
+// fixtureInstance serves the properties of an instance fixture. The values are stored in their
+// MI_Value representation and decoded like the elements of an [Instance].
+type fixtureInstance map[string]fixtureElement
+
+type fixtureElement struct {
+	valueType ValueType
+	value     rawValue
+	null      bool
+
+	// instance holds embedded instances and references.
+	instance any
+	// memory keeps the memory referenced by value alive.
+	memory any
+}
+
+func (f fixtureInstance) GetProperty(name string) (any, bool, error) {
+	element, ok := f[name]
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch {
+	case element.null:
+		return nil, true, nil
+	case element.instance != nil:
+		return element.instance, true, nil
+	default:
+		value, err := decodeValue(&element.value, element.valueType)
+
+		return value, true, err
+	}
+}
+
+type fixtureProperty struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+//nolint:gochecknoglobals
+var fixtureValueTypes = map[string]ValueType{
+	"BOOLEAN": ValueTypeBOOLEAN, "UINT8": ValueTypeUINT8, "SINT8": ValueTypeSINT8, "UINT16": ValueTypeUINT16,
+	"SINT16": ValueTypeSINT16, "UINT32": ValueTypeUINT32, "SINT32": ValueTypeSINT32, "UINT64": ValueTypeUINT64,
+	"SINT64": ValueTypeSINT64, "REAL32": ValueTypeREAL32, "REAL64": ValueTypeREAL64, "CHAR16": ValueTypeCHAR16,
+	"DATETIME": ValueTypeDATETIME, "STRING": ValueTypeSTRING, "REFERENCE": ValueTypeREFERENCE, "INSTANCE": ValueTypeINSTANCE,
+	"BOOLEANA": ValueTypeBOOLEANA, "UINT8A": ValueTypeUINT8A, "SINT8A": ValueTypeSINT8A, "UINT16A": ValueTypeUINT16A,
+	"SINT16A": ValueTypeSINT16A, "UINT32A": ValueTypeUINT32A, "SINT32A": ValueTypeSINT32A, "UINT64A": ValueTypeUINT64A,
+	"SINT64A": ValueTypeSINT64A, "REAL32A": ValueTypeREAL32A, "REAL64A": ValueTypeREAL64A, "CHAR16A": ValueTypeCHAR16A,
+	"DATETIMEA": ValueTypeDATETIMEA, "STRINGA": ValueTypeSTRINGA, "REFERENCEA": ValueTypeREFERENCEA, "INSTANCEA": ValueTypeINSTANCEA,
+}
+
+func readFixture(t *testing.T, name string) fixtureInstance {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	var fixture struct {
+		Properties map[string]fixtureProperty `json:"properties"`
+	}
+
+	require.NoError(t, json.Unmarshal(data, &fixture))
+
+	return encodeInstance(t, fixture.Properties)
+}
+
+func encodeInstance(t *testing.T, properties map[string]fixtureProperty) fixtureInstance {
+	t.Helper()
+
+	instance := make(fixtureInstance, len(properties))
+
+	for name, property := range properties {
+		valueType, ok := fixtureValueTypes[property.Type]
+		require.True(t, ok, "unknown type %s of %s", property.Type, name)
+
+		element := fixtureElement{valueType: valueType}
+
+		switch {
+		case string(property.Value) == "null":
+			element.null = true
+		case valueType == ValueTypeINSTANCE || valueType == ValueTypeREFERENCE:
+			var nested map[string]fixtureProperty
+
+			require.NoError(t, json.Unmarshal(property.Value, &nested))
+
+			element.instance = encodeInstance(t, nested)
+		case valueType == ValueTypeINSTANCEA || valueType == ValueTypeREFERENCEA:
+			var nested []map[string]fixtureProperty
+
+			require.NoError(t, json.Unmarshal(property.Value, &nested))
+
+			instances := make([]fixtureInstance, len(nested))
+			for i, properties := range nested {
+				instances[i] = encodeInstance(t, properties)
+			}
+
+			element.instance = instances
+		default:
+			element.memory = encodeValue(t, &element.value, valueType, property.Value)
+		}
+
+		instance[name] = element
+	}
+
+	return instance
+}
+
+// encodeValue stores a JSON value in its MI_Value representation. It returns the memory referenced by the value.
+func encodeValue(t *testing.T, dst *rawValue, valueType ValueType, data json.RawMessage) any {
+	t.Helper()
+
+	switch valueType {
+	case ValueTypeBOOLEAN:
+		encodeScalar[bool](t, dst, data)
+	case ValueTypeUINT8:
+		encodeScalar[uint8](t, dst, data)
+	case ValueTypeSINT8:
+		encodeScalar[int8](t, dst, data)
+	case ValueTypeUINT16:
+		encodeScalar[uint16](t, dst, data)
+	case ValueTypeSINT16:
+		encodeScalar[int16](t, dst, data)
+	case ValueTypeUINT32:
+		encodeScalar[uint32](t, dst, data)
+	case ValueTypeSINT32:
+		encodeScalar[int32](t, dst, data)
+	case ValueTypeUINT64:
+		encodeScalar[uint64](t, dst, data)
+	case ValueTypeSINT64:
+		encodeScalar[int64](t, dst, data)
+	case ValueTypeREAL32:
+		encodeScalar[float32](t, dst, data)
+	case ValueTypeREAL64:
+		encodeScalar[float64](t, dst, data)
+	case ValueTypeCHAR16:
+		units := encodeChar16(t, data)
+		require.Len(t, units, 1)
+
+		*(*Char16)(unsafe.Pointer(dst)) = units[0]
+	case ValueTypeDATETIME:
+		var s string
+
+		require.NoError(t, json.Unmarshal(data, &s))
+
+		*(*Datetime)(unsafe.Pointer(dst)) = encodeDatetime(t, s)
+	case ValueTypeSTRING:
+		var s string
+
+		require.NoError(t, json.Unmarshal(data, &s))
+
+		str := encodeString(s)
+		*(**uint16)(unsafe.Pointer(dst)) = str
+
+		return str
+	case ValueTypeBOOLEANA:
+		return encodeArray[bool](t, dst, data)
+	case ValueTypeUINT8A:
+		return encodeArray[uint8](t, dst, data)
+	case ValueTypeSINT8A:
+		return encodeArray[int8](t, dst, data)
+	case ValueTypeUINT16A:
+		return encodeArray[uint16](t, dst, data)
+	case ValueTypeSINT16A:
+		return encodeArray[int16](t, dst, data)
+	case ValueTypeUINT32A:
+		return encodeArray[uint32](t, dst, data)
+	case ValueTypeSINT32A:
+		return encodeArray[int32](t, dst, data)
+	case ValueTypeUINT64A:
+		return encodeArray[uint64](t, dst, data)
+	case ValueTypeSINT64A:
+		return encodeArray[int64](t, dst, data)
+	case ValueTypeREAL32A:
+		return encodeArray[float32](t, dst, data)
+	case ValueTypeREAL64A:
+		return encodeArray[float64](t, dst, data)
+	case ValueTypeCHAR16A:
+		return storeArray(dst, encodeChar16(t, data))
+	case ValueTypeDATETIMEA:
+		var values []string
+
+		require.NoError(t, json.Unmarshal(data, &values))
+
+		datetimes := make([]Datetime, len(values))
+		for i, s := range values {
+			datetimes[i] = encodeDatetime(t, s)
+		}
+
+		return storeArray(dst, datetimes)
+	case ValueTypeSTRINGA:
+		var values []string
+
+		require.NoError(t, json.Unmarshal(data, &values))
+
+		strArray := make([]*uint16, len(values))
+		for i, s := range values {
+			strArray[i] = encodeString(s)
+		}
+
+		return storeArray(dst, strArray)
+	default:
+		require.Failf(t, "unsupported value type", "%d", valueType)
+	}
+
+	return nil
+}
+
+func encodeScalar[T any](t *testing.T, dst *rawValue, data json.RawMessage) {
+	t.Helper()
+
+	require.NoError(t, json.Unmarshal(data, (*T)(unsafe.Pointer(dst))))
+}
+
+func encodeArray[T any](t *testing.T, dst *rawValue, data json.RawMessage) []T {
+	t.Helper()
+
+	var values []T
+
+	require.NoError(t, json.Unmarshal(data, &values))
+
+	return storeArray(dst, values)
+}
+
+func storeArray[T any](dst *rawValue, values []T) []T {
+	*(*rawArray)(unsafe.Pointer(dst)) = rawArray{
+		data: unsafe.Pointer(unsafe.SliceData(values)),
+		size: uint32(len(values)),
+	}
+
+	return values
+}
+
+func encodeChar16(t *testing.T, data json.RawMessage) []Char16 {
+	t.Helper()
+
+	var s string
+
+	require.NoError(t, json.Unmarshal(data, &s))
+
+	units := utf16.Encode([]rune(s))
+	chars := make([]Char16, len(units))
+
+	for i, unit := range units {
+		chars[i] = Char16(unit)
+	}
+
+	return chars
+}
+
+func encodeString(s string) *uint16 {
+	return &utf16.Encode([]rune(s + "\x00"))[0]
+}
+
+// encodeDatetime parses a CIM DATETIME, either a timestamp like `20240102030405.123456+060`
+// or an interval like `00000001020304.000005:000`.
+func encodeDatetime(t *testing.T, s string) Datetime {
+	t.Helper()
+
+	var datetime Datetime
+
+	if s[21] == ':' {
+		interval := datetime.Interval()
+
+		_, err := fmt.Sscanf(s, "%8d%2d%2d%2d.%6d:000",
+			&interval.Days, &interval.Hours, &interval.Minutes, &interval.Seconds, &interval.Microseconds,
+		)
+		require.NoError(t, err)
+
+		return datetime
+	}
+
+	datetime.IsTimestamp = 1
+	timestamp := datetime.Timestamp()
+
+	_, err := fmt.Sscanf(s, "%4d%2d%2d%2d%2d%2d.%6d%4d",
+		&timestamp.Year, &timestamp.Month, &timestamp.Day,
+		&timestamp.Hour, &timestamp.Minute, &timestamp.Second, &timestamp.Microseconds, &timestamp.UTC,
+	)
+	require.NoError(t, err)
+
+	return datetime
+}
@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdh
+
+import (
+	"maps"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// calculationWindow is the minimum interval the values of calculated rate counters are computed over.
+// Computing against a sample at least this old, instead of the previous sample,
+// keeps the values independent of how often and by how many scrapers the exporter is scraped.
+const calculationWindow = 30 * time.Second
+
+// perfDisplayPercent is set on counter types which PDH displays as a percentage. PDH caps their formatted values at 100.
+const perfDisplayPercent = 0x20000000
+
+//nolint:gochecknoglobals
+var calculateFormatted atomic.Bool
+
+// SetCalculateFormatted makes all collectors created afterward with [CounterTypeFormatted]
+// read raw values and calculate the formatted values in the exporter, see [CounterTypeCalculated].
+func SetCalculateFormatted(enabled bool) {
+	calculateFormatted.Store(enabled)
+}
+
+// calculationKey identifies the sample history of a counter instance.
+type calculationKey struct {
+	counter  string
+	instance string
+}
+
+// instanceSamples is the sample history of a counter instance, and the collection it was last seen in.
+type instanceSamples struct {
+	history    sampleHistory
+	collection uint64
+}
+
+// calculator calculates the formatted values of counters from their raw values, see [CounterTypeCalculated].
+// It is only used by the collect worker, which serializes the access to the sample histories.
+type calculator struct {
+	samples     map[calculationKey]*instanceSamples
+	collections uint64
+}
+
+func newCalculator() *calculator {
+	return &calculator{
+		samples: make(map[calculationKey]*instanceSamples),
+	}
+}
+
+// setValue adds the raw value of the counter instance to its sample history
+// and sets the value calculated from it on the field of the counter in the row of the instance.
+// If no value can be calculated yet, e.g. on the first sample of the instance, the sample is skipped
+// and the row isn't created by this counter, like PDH skips formatted values without valid data.
+func (c *calculator) setValue(rows *rowWriter, counter Counter, instance string, current rawSample) {
+	instance, ok := rows.name(instance)
+	if !ok {
+		return
+	}
+
+	key := calculationKey{counter: counter.Name, instance: instance}
+
+	samples, ok := c.samples[key]
+	if !ok {
+		samples = &instanceSamples{}
+		c.samples[key] = samples
+	}
+
+	samples.collection = c.collections
+
+	previous, hasPrevious := samples.history.add(current, calculationWindow)
+
+	if counter.FieldIndexValue == -1 {
+		return
+	}
+
+	value, ok := calculateCounterValue(counter.Type, counter.Frequency, previous, current, hasPrevious)
+	if !ok {
+		return
+	}
+
+	elem, _, _ := rows.row(instance, prometheus.GaugeValue)
+	elem.Field(counter.FieldIndexValue).SetFloat(value)
+}
+
+// prune drops the sample histories of instances which were not part of the current collection.
+func (c *calculator) prune() {
+	maps.DeleteFunc(c.samples, func(_ calculationKey, samples *instanceSamples) bool {
+		return samples.collection != c.collections
+	})
+
+	c.collections++
+}
+
+// rawSample is a raw counter value of one instance, with the time it was sampled at in 100ns intervals.
+// MultiCount is the number of timers of the multi timer counter types.
+type rawSample struct {
+	Timestamp   int64
+	FirstValue  int64
+	SecondValue int64
+	MultiCount  uint32
+}
+
+// sampleHistory holds the recent samples of one counter instance, oldest first.
+type sampleHistory []rawSample
+
+// add appends s to the history and returns the sample to calculate the value of s against:
+// the newest sample at least window older than s, or the oldest sample if none is old enough yet.
+// Samples older than the returned one are dropped, and s is only kept if it is at least window/8 newer
+// than the newest sample, which bounds the length of the history independent of the scrape interval.
+func (h *sampleHistory) add(s rawSample, window time.Duration) (rawSample, bool) {
+	var (
+		reference rawSample
+		ok        bool
+	)
+
+	samples := *h
+	windowTicks := int64(window / 100)
+
+	if len(samples) != 0 {
+		index := 0
+
+		for i := len(samples) - 1; i >= 0; i-- {
+			if s.Timestamp-samples[i].Timestamp >= windowTicks {
+				index = i
+
+				break
+			}
+		}
+
+		reference, ok = samples[index], true
+		samples = samples[index:]
+	}
+
+	if len(samples) == 0 || s.Timestamp-samples[len(samples)-1].Timestamp >= windowTicks/8 {
+		samples = append(samples, s)
+	}
+
+	*h = samples
+
+	return reference, ok
+}
+
+// isCalculatedCounterType reports whether the formatted value of the counter type can be calculated by [calculateCounterValue].
+func isCalculatedCounterType(counterType uint32) bool {
+	switch counterType {
+	case PERF_COUNTER_RAWCOUNT, PERF_COUNTER_RAWCOUNT_HEX, PERF_COUNTER_LARGE_RAWCOUNT, PERF_COUNTER_LARGE_RAWCOUNT_HEX,
+		PERF_RAW_FRACTION, PERF_LARGE_RAW_FRACTION, PERF_ELAPSED_TIME,
+		PERF_COUNTER_DELTA, PERF_COUNTER_LARGE_DELTA, PERF_COUNTER_COUNTER, PERF_COUNTER_BULK_COUNT,
+		PERF_COUNTER_TIMER, PERF_COUNTER_TIMER_INV, PERF_100NSEC_TIMER, PERF_100NSEC_TIMER_INV, PERF_PRECISION_100NS_TIMER,
+		PERF_AVERAGE_TIMER, PERF_AVERAGE_BULK, PERF_SAMPLE_FRACTION,
+		PERF_COUNTER_QUEUELEN_TYPE, PERF_COUNTER_LARGE_QUEUELEN_TYPE, PERF_COUNTER_100NS_QUEUELEN_TYPE, PERF_COUNTER_OBJ_TIME_QUEUELEN_TYPE,
+		PERF_COUNTER_MULTI_TIMER, PERF_COUNTER_MULTI_TIMER_INV, PERF_100NSEC_MULTI_TIMER, PERF_100NSEC_MULTI_TIMER_INV:
+		return true
+	default:
+		return false
+	}
+}
+
+// needsFrequency reports whether the calculation of the counter type uses the time base of the counter.
+func needsFrequency(counterType uint32) bool {
+	switch counterType {
+	case PERF_ELAPSED_TIME, PERF_COUNTER_TIMER, PERF_COUNTER_TIMER_INV, PERF_AVERAGE_TIMER,
+		PERF_COUNTER_QUEUELEN_TYPE, PERF_COUNTER_LARGE_QUEUELEN_TYPE, PERF_COUNTER_MULTI_TIMER, PERF_COUNTER_MULTI_TIMER_INV:
+		return true
+	default:
+		return false
+	}
+}
+
+// calculateCounterValue calculates the formatted value of a counter from the current and the previous raw sample,
+// following https://learn.microsoft.com/en-us/windows/win32/perfctrs/calculating-counter-values.
+// The elapsed time of rate counters is taken from the sample timestamps, which are in 100ns intervals.
+// frequency is the time base of the counter. previous is ignored for counter types which don't need two samples.
+// It returns false if the value can't be calculated, e.g. on the first sample, or if the counter was reset in between.
+func calculateCounterValue(counterType uint32, frequency int64, previous, current rawSample, hasPrevious bool) (float64, bool) {
+	var (
+		value float64
+		ok    bool
+	)
+
+	switch counterType {
+	case PERF_COUNTER_RAWCOUNT, PERF_COUNTER_RAWCOUNT_HEX, PERF_COUNTER_LARGE_RAWCOUNT, PERF_COUNTER_LARGE_RAWCOUNT_HEX:
+		return float64(current.FirstValue), true
+	case PERF_RAW_FRACTION, PERF_LARGE_RAW_FRACTION:
+		value, ok = fraction(current.FirstValue, current.SecondValue)
+		value *= 100
+	case PERF_ELAPSED_TIME:
+		if frequency <= 0 {
+			return 0, false
+		}
+
+		value, ok = float64(current.SecondValue-current.FirstValue)/float64(frequency), true
+	default:
+		if !hasPrevious {
+			return 0, false
+		}
+
+		value, ok = calculateDelta(counterType, frequency, previous, current)
+	}
+
+	if !ok || value < 0 {
+		return 0, false
+	}
+
+	if counterType&perfDisplayPercent != 0 && value > 100 {
+		value = 100
+	}
+
+	return value, true
+}
+
+func calculateDelta(counterType uint32, frequency int64, previous, current rawSample) (float64, bool) {
+	deltaValue := current.FirstValue - previous.FirstValue
+	deltaTime := current.Timestamp - previous.Timestamp
+	deltaBase := current.SecondValue - previous.SecondValue
+
+	// The counter was reset, or the same sample was read twice.
+	if deltaValue < 0 || deltaTime <= 0 {
+		return 0, false
+	}
+
+	switch counterType {
+	case PERF_COUNTER_DELTA, PERF_COUNTER_LARGE_DELTA:
+		return float64(deltaValue), true
+	case PERF_COUNTER_COUNTER, PERF_COUNTER_BULK_COUNT:
+		value, ok := fraction(deltaValue, deltaTime)
+
+		return value / TicksToSecondScaleFactor, ok
+	case PERF_COUNTER_TIMER, PERF_COUNTER_TIMER_INV:
+		if frequency <= 0 {
+			return 0, false
+		}
+
+		value, ok := fraction(deltaValue, deltaTime)
+		value = value / float64(frequency) / TicksToSecondScaleFactor
+
+		if counterType == PERF_COUNTER_TIMER_INV {
+			value = 1 - value
+		}
+
+		return 100 * value, ok
+	case PERF_100NSEC_TIMER, PERF_100NSEC_TIMER_INV:
+		value, ok := fraction(deltaValue, deltaTime)
+
+		if counterType == PERF_100NSEC_TIMER_INV {
+			value = 1 - value
+		}
+
+		return 100 * value, ok
+	case PERF_PRECISION_100NS_TIMER, PERF_SAMPLE_FRACTION:
+		value, ok := fraction(deltaValue, deltaBase)
+
+		return 100 * value, ok
+	case PERF_AVERAGE_TIMER:
+		if frequency <= 0 {
+			return 0, false
+		}
+
+		value, ok := fraction(deltaValue, deltaBase)
+
+		return value / float64(frequency), ok
+	case PERF_AVERAGE_BULK:
+		return fraction(deltaValue, deltaBase)
+	case PERF_COUNTER_QUEUELEN_TYPE, PERF_COUNTER_LARGE_QUEUELEN_TYPE:
+		// The queue length is summed up on every tick of the time base, so the average length is the delta per tick.
+		if frequency <= 0 {
+			return 0, false
+		}
+
+		value, ok := fraction(deltaValue, deltaTime)
+
+		return value / float64(frequency) / TicksToSecondScaleFactor, ok
+	case PERF_COUNTER_100NS_QUEUELEN_TYPE:
+		return fraction(deltaValue, deltaTime)
+	case PERF_COUNTER_OBJ_TIME_QUEUELEN_TYPE:
+		// The second value holds the time of the object, like for PERF_ELAPSED_TIME.
+		return fraction(deltaValue, deltaBase)
+	case PERF_COUNTER_MULTI_TIMER, PERF_COUNTER_MULTI_TIMER_INV, PERF_100NSEC_MULTI_TIMER, PERF_100NSEC_MULTI_TIMER_INV:
+		return multiTimer(counterType, frequency, deltaValue, deltaTime, current.MultiCount)
+	default:
+		return 0, false
+	}
+}
+
+// multiTimer calculates the busy time of multi timer counters in percent. The value is averaged over the timers,
+// so it is a percentage of the total time of all timers. The inverse types count the idle time of the timers.
+func multiTimer(counterType uint32, frequency, deltaValue, deltaTime int64, timers uint32) (float64, bool) {
+	if timers == 0 {
+		return 0, false
+	}
+
+	value, ok := fraction(deltaValue, deltaTime)
+
+	if counterType == PERF_COUNTER_MULTI_TIMER || counterType == PERF_COUNTER_MULTI_TIMER_INV {
+		if frequency <= 0 {
+			return 0, false
+		}
+
+		value = value / float64(frequency) / TicksToSecondScaleFactor
+	}
+
+	if counterType == PERF_COUNTER_MULTI_TIMER_INV || counterType == PERF_100NSEC_MULTI_TIMER_INV {
+		value = float64(timers) - value
+	}
+
+	return 100 * value / float64(timers), ok
+}
+
+// fraction returns numerator/denominator. Like PDH, it returns 0 for a zero denominator,
+// e.g. an average over no operations, and false for a negative one.
+func fraction(numerator, denominator int64) (float64, bool) {
+	if denominator < 0 {
+		return 0, false
+	}
+
+	if denominator == 0 {
+		return 0, true
+	}
+
+	return float64(numerator) / float64(denominator), true
+}
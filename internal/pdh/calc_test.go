@@ -0,0 +1,377 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type processorTime struct {
+	Name           string
+	ProcessorTime  float64 `perfdata:"% Processor Time"`
+	InterruptsSec  float64 `perfdata:"Interrupts/sec"`
+	ProcessorFreq  float64 `perfdata:"Processor Frequency"`
+	PercentOfLimit float64 `perfdata:"% of Maximum Frequency"`
+}
+
+// second is one second in the 100ns intervals of the sample timestamps.
+const second = int64(time.Second / 100)
+
+func TestCalculateCounterValue(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		counterType uint32
+		frequency   int64
+		previous    rawSample
+		current     rawSample
+		hasPrevious bool
+		value       float64
+		ok          bool
+	}{
+		{
+			name:        "rawcount",
+			counterType: PERF_COUNTER_RAWCOUNT,
+			current:     rawSample{Timestamp: second, FirstValue: 42},
+			value:       42,
+			ok:          true,
+		},
+		{
+			name:        "raw fraction",
+			counterType: PERF_RAW_FRACTION,
+			current:     rawSample{Timestamp: second, FirstValue: 25, SecondValue: 200},
+			value:       12.5,
+			ok:          true,
+		},
+		{
+			name:        "raw fraction without base",
+			counterType: PERF_LARGE_RAW_FRACTION,
+			current:     rawSample{Timestamp: second, FirstValue: 25},
+			value:       0,
+			ok:          true,
+		},
+		{
+			name:        "elapsed time",
+			counterType: PERF_ELAPSED_TIME,
+			frequency:   1000,
+			current:     rawSample{Timestamp: second, FirstValue: 1000, SecondValue: 6000},
+			value:       5,
+			ok:          true,
+		},
+		{
+			name:        "elapsed time without frequency",
+			counterType: PERF_ELAPSED_TIME,
+			current:     rawSample{Timestamp: second, FirstValue: 1000, SecondValue: 6000},
+		},
+		{
+			name:        "counter",
+			counterType: PERF_COUNTER_COUNTER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 100},
+			current:     rawSample{Timestamp: 2 * second, FirstValue: 300},
+			hasPrevious: true,
+			value:       100,
+			ok:          true,
+		},
+		{
+			name:        "bulk count",
+			counterType: PERF_COUNTER_BULK_COUNT,
+			previous:    rawSample{Timestamp: 0, FirstValue: 1 << 20},
+			current:     rawSample{Timestamp: 4 * second, FirstValue: 3 << 20},
+			hasPrevious: true,
+			value:       1 << 19,
+			ok:          true,
+		},
+		{
+			name:        "delta",
+			counterType: PERF_COUNTER_DELTA,
+			previous:    rawSample{Timestamp: 0, FirstValue: 100},
+			current:     rawSample{Timestamp: 2 * second, FirstValue: 300},
+			hasPrevious: true,
+			value:       200,
+			ok:          true,
+		},
+		{
+			name:        "100ns timer",
+			counterType: PERF_100NSEC_TIMER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 1000},
+			current:     rawSample{Timestamp: second, FirstValue: 1000 + second/2},
+			hasPrevious: true,
+			value:       50,
+			ok:          true,
+		},
+		{
+			name:        "100ns timer is capped at 100",
+			counterType: PERF_100NSEC_TIMER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: second * 12 / 10},
+			hasPrevious: true,
+			value:       100,
+			ok:          true,
+		},
+		{
+			name:        "100ns timer inverse",
+			counterType: PERF_100NSEC_TIMER_INV,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: second / 4},
+			hasPrevious: true,
+			value:       75,
+			ok:          true,
+		},
+		{
+			name:        "precision 100ns timer",
+			counterType: PERF_PRECISION_100NS_TIMER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0, SecondValue: 5 * second},
+			current:     rawSample{Timestamp: 2 * second, FirstValue: 3 * second / 10, SecondValue: 6 * second},
+			hasPrevious: true,
+			value:       30,
+			ok:          true,
+		},
+		{
+			name:        "counter timer",
+			counterType: PERF_COUNTER_TIMER,
+			frequency:   1_000_000,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: 250_000},
+			hasPrevious: true,
+			value:       25,
+			ok:          true,
+		},
+		{
+			name:        "counter timer inverse",
+			counterType: PERF_COUNTER_TIMER_INV,
+			frequency:   1_000_000,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: 250_000},
+			hasPrevious: true,
+			value:       75,
+			ok:          true,
+		},
+		{
+			name:        "average timer",
+			counterType: PERF_AVERAGE_TIMER,
+			frequency:   10_000_000,
+			previous:    rawSample{Timestamp: 0, FirstValue: 1000, SecondValue: 10},
+			current:     rawSample{Timestamp: second, FirstValue: 1000 + 5_000_000, SecondValue: 20},
+			hasPrevious: true,
+			value:       0.05,
+			ok:          true,
+		},
+		{
+			name:        "average timer without operations",
+			counterType: PERF_AVERAGE_TIMER,
+			frequency:   10_000_000,
+			previous:    rawSample{Timestamp: 0, FirstValue: 1000, SecondValue: 10},
+			current:     rawSample{Timestamp: second, FirstValue: 1000, SecondValue: 10},
+			hasPrevious: true,
+			value:       0,
+			ok:          true,
+		},
+		{
+			name:        "average bulk",
+			counterType: PERF_AVERAGE_BULK,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0, SecondValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: 4096, SecondValue: 4},
+			hasPrevious: true,
+			value:       1024,
+			ok:          true,
+		},
+		{
+			name:        "sample fraction",
+			counterType: PERF_SAMPLE_FRACTION,
+			previous:    rawSample{Timestamp: 0, FirstValue: 1, SecondValue: 4},
+			current:     rawSample{Timestamp: second, FirstValue: 4, SecondValue: 8},
+			hasPrevious: true,
+			value:       75,
+			ok:          true,
+		},
+		{
+			name:        "queue length",
+			counterType: PERF_COUNTER_QUEUELEN_TYPE,
+			frequency:   1000,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: 2 * second, FirstValue: 6000},
+			hasPrevious: true,
+			value:       3,
+			ok:          true,
+		},
+		{
+			name:        "100ns queue length",
+			counterType: PERF_COUNTER_100NS_QUEUELEN_TYPE,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: 5 * second},
+			hasPrevious: true,
+			value:       5,
+			ok:          true,
+		},
+		{
+			name:        "object time queue length",
+			counterType: PERF_COUNTER_OBJ_TIME_QUEUELEN_TYPE,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0, SecondValue: 1000},
+			current:     rawSample{Timestamp: second, FirstValue: 3000, SecondValue: 2000},
+			hasPrevious: true,
+			value:       3,
+			ok:          true,
+		},
+		{
+			name:        "multi timer",
+			counterType: PERF_COUNTER_MULTI_TIMER,
+			frequency:   1000,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: 1000, MultiCount: 2},
+			hasPrevious: true,
+			value:       50,
+			ok:          true,
+		},
+		{
+			name:        "100ns multi timer",
+			counterType: PERF_100NSEC_MULTI_TIMER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: 3 * second, MultiCount: 4},
+			hasPrevious: true,
+			value:       75,
+			ok:          true,
+		},
+		{
+			name:        "100ns multi timer inverse",
+			counterType: PERF_100NSEC_MULTI_TIMER_INV,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: second, MultiCount: 4},
+			hasPrevious: true,
+			value:       75,
+			ok:          true,
+		},
+		{
+			name:        "multi timer without timers",
+			counterType: PERF_100NSEC_MULTI_TIMER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: second},
+			hasPrevious: true,
+		},
+		{
+			name:        "rate without previous sample",
+			counterType: PERF_COUNTER_COUNTER,
+			current:     rawSample{Timestamp: second, FirstValue: 300},
+		},
+		{
+			name:        "counter reset",
+			counterType: PERF_COUNTER_COUNTER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 300},
+			current:     rawSample{Timestamp: second, FirstValue: 100},
+			hasPrevious: true,
+		},
+		{
+			name:        "same sample",
+			counterType: PERF_COUNTER_COUNTER,
+			previous:    rawSample{Timestamp: second, FirstValue: 300},
+			current:     rawSample{Timestamp: second, FirstValue: 300},
+			hasPrevious: true,
+		},
+		{
+			name:        "unsupported counter type",
+			counterType: PERF_PRECISION_SYSTEM_TIMER,
+			previous:    rawSample{Timestamp: 0, FirstValue: 0},
+			current:     rawSample{Timestamp: second, FirstValue: 10},
+			hasPrevious: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.ok {
+				require.True(t, isCalculatedCounterType(tc.counterType))
+			}
+
+			value, ok := calculateCounterValue(tc.counterType, tc.frequency, tc.previous, tc.current, tc.hasPrevious)
+			require.Equal(t, tc.ok, ok)
+			require.InDelta(t, tc.value, value, 1e-9)
+		})
+	}
+}
+
+func TestIsCalculatedCounterType(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isCalculatedCounterType(PERF_100NSEC_TIMER))
+	require.True(t, isCalculatedCounterType(PERF_COUNTER_QUEUELEN_TYPE))
+	require.True(t, isCalculatedCounterType(PERF_100NSEC_MULTI_TIMER_INV))
+	require.False(t, isCalculatedCounterType(PERF_PRECISION_SYSTEM_TIMER))
+	require.False(t, isCalculatedCounterType(PERF_AVERAGE_BASE))
+}
+
+func TestSampleHistory(t *testing.T) {
+	t.Parallel()
+
+	window := 30 * time.Second
+
+	var history sampleHistory
+
+	// Returns the timestamp of the reference sample in seconds, or -1 if there is none.
+	add := func(timestamp int64) int64 {
+		reference, ok := history.add(rawSample{Timestamp: timestamp * second}, window)
+		if !ok {
+			return -1
+		}
+
+		return reference.Timestamp / second
+	}
+
+	// The value is calculated over the full window once enough samples are available, independent of the scrape interval.
+	require.Equal(t, int64(-1), add(0))
+	require.Equal(t, int64(0), add(15))
+	require.Equal(t, int64(0), add(30))
+	require.Equal(t, int64(15), add(45))
+	require.Equal(t, int64(30), add(60))
+
+	// A second scraper shortly after the first one gets the same reference, and doesn't extend the history.
+	require.Equal(t, int64(30), add(61))
+	require.Len(t, history, 3)
+
+	// Longer scrape intervals use the previous sample.
+	require.Equal(t, int64(60), add(120))
+	require.Len(t, history, 2)
+}
+
+func TestCollectorCalculated(t *testing.T) {
+	t.Parallel()
+
+	collector, err := NewCollector[processorTime](CounterTypeCalculated, "Processor Information", InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(collector.Close)
+
+	time.Sleep(time.Second)
+
+	var data []processorTime
+
+	require.NoError(t, collector.Collect(&data))
+	require.NotEmpty(t, data)
+
+	for _, instance := range data {
+		require.NotEmpty(t, instance.Name)
+		require.GreaterOrEqual(t, instance.ProcessorTime, 0.0)
+		require.LessOrEqual(t, instance.ProcessorTime, 100.0)
+		require.Positive(t, instance.ProcessorFreq)
+	}
+
+	metricTypes := collector.MetricTypes()
+	require.Len(t, metricTypes, 4)
+}
@@ -25,20 +25,15 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 
-	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
+	"github.com/prometheus-community/windows_exporter/internal/replay"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows"
 )
 
-//nolint:gochecknoglobals
-var (
-	InstancesAll   = []string{"*"}
-	InstancesTotal = []string{InstanceTotal}
-)
-
 type CounterValues = map[string]map[string]CounterValue
 
 type Collector struct {
@@ -53,18 +48,20 @@ type Collector struct {
 
 	collectCh chan any
 	errorCh   chan error
-}
 
-type Counter struct {
-	Name       string
-	Desc       string
-	MetricType prometheus.ValueType
-	Instances  map[string]pdhCounterHandle
-	Type       uint32
-	Frequency  int64
+	recorder *replay.PDHRecorder
+	replay   *ReplayCollector
+
+	shared     *SharedQuery
+	generation atomic.Uint64
 
-	FieldIndexValue       int
-	FieldIndexSecondValue int
+	// instances holds the explicitly requested instances, which are re-enumerated periodically.
+	instances           []string
+	activeInstances     map[string]struct{}
+	nextInstanceRefresh atomic.Int64
+
+	// calculator is set for CounterTypeCalculated.
+	calculator *calculator
 }
 
 func NewCollector[T any](resultType CounterType, object string, instances []string) (*Collector, error) {
@@ -74,9 +71,27 @@ func NewCollector[T any](resultType CounterType, object string, instances []stri
 }
 
 func NewCollectorWithReflection(resultType CounterType, object string, instances []string, valueType reflect.Type) (*Collector, error) {
+	if r := replayer.Load(); r != nil {
+		return newReplayedCollector(r, resultType, object, instances, valueType)
+	}
+
+	if resultType == CounterTypeFormatted && calculateFormatted.Load() {
+		resultType = CounterTypeCalculated
+	}
+
+	if resultType != CounterTypeRaw && resultType != CounterTypeFormatted && resultType != CounterTypeCalculated {
+		return nil, fmt.Errorf("invalid result type: %v", resultType)
+	}
+
+	shared := sharedQuery.Load()
+
 	var handle pdhQueryHandle
 
-	if ret := OpenQuery(0, 0, &handle); ret != ErrorSuccess {
+	if shared != nil {
+		// Other collectors may read the shared query while the counters are added.
+		shared.mu.Lock()
+		handle = shared.handle
+	} else if ret := OpenQuery(0, 0, &handle); ret != ErrorSuccess {
 		return nil, NewPdhError(ret)
 	}
 
@@ -84,10 +99,6 @@ func NewCollectorWithReflection(resultType CounterType, object string, instances
 		instances = []string{InstanceEmpty}
 	}
 
-	if resultType != CounterTypeRaw && resultType != CounterTypeFormatted {
-		return nil, fmt.Errorf("invalid result type: %v", resultType)
-	}
-
 	collector := &Collector{
 		object:                object,
 		counters:              make(map[string]Counter, valueType.NumField()),
@@ -96,6 +107,15 @@ func NewCollectorWithReflection(resultType CounterType, object string, instances
 		mu:                    sync.RWMutex{},
 		nameIndexValue:        -1,
 		metricsTypeIndexValue: -1,
+		shared:                shared,
+	}
+
+	if r := recorder.Load(); r != nil {
+		collector.recorder = r.NewPDHRecorder(object)
+	}
+
+	if resultType == CounterTypeCalculated {
+		collector.calculator = newCalculator()
 	}
 
 	errs := make([]error, 0, valueType.NumField())
@@ -160,6 +180,11 @@ func NewCollectorWithReflection(resultType CounterType, object string, instances
 
 			//nolint:nestif
 			if ret := AddEnglishCounter(handle, counterPath, 0, &counterHandle); ret != ErrorSuccess {
+				// Explicitly requested instances are added once they appear, see refreshInstances.
+				if ret == CstatusNoInstance && hasExplicitInstances(instances) {
+					continue
+				}
+
 				if ret == CstatusNoCounter {
 					if minOSBuildTag, ok := f.Tag.Lookup("perfdata_min_build"); ok {
 						if minOSBuild, err := strconv.Atoi(minOSBuildTag); err == nil {
@@ -181,52 +206,31 @@ func NewCollectorWithReflection(resultType CounterType, object string, instances
 				continue
 			}
 
-			// Get the info with the current buffer size
-			var bufLen uint32
-
-			if ret := GetCounterInfo(counterHandle, 0, &bufLen, nil); ret != MoreData {
-				errs = append(errs, fmt.Errorf("GetCounterInfo: %w", NewPdhError(ret)))
-
-				continue
-			}
-
-			buf := make([]byte, bufLen)
-			if len(buf) == 0 {
-				errs = append(errs, errors.New("GetCounterInfo: buffer length is zero"))
-
-				continue
+			if err := collector.initCounterInfo(&counter, counterHandle, counterPath); err != nil {
+				errs = append(errs, err)
 			}
+		}
 
-			if ret := GetCounterInfo(counterHandle, 0, &bufLen, &buf[0]); ret != ErrorSuccess {
-				errs = append(errs, fmt.Errorf("GetCounterInfo: %w", NewPdhError(ret)))
-
-				continue
-			}
-
-			counterInfo := (*CounterInfo)(unsafe.Pointer(&buf[0]))
-			if counterInfo == nil {
-				errs = append(errs, errors.New("GetCounterInfo: counter info is nil"))
-
-				continue
-			}
+		collector.counters[counterName] = counter
+	}
 
-			counter.Type = counterInfo.DwType
-			if val, ok := SupportedCounterTypes[counter.Type]; ok {
-				counter.MetricType = val
-			} else {
-				counter.MetricType = prometheus.GaugeValue
-			}
+	if shared != nil {
+		collector.generation.Store(shared.generation.Load())
+		shared.mu.Unlock()
+	}
 
-			if counter.Type == PERF_ELAPSED_TIME {
-				if ret := GetCounterTimeBase(counterHandle, &counter.Frequency); ret != ErrorSuccess {
-					errs = append(errs, fmt.Errorf("GetCounterTimeBase: %w", NewPdhError(ret)))
+	if hasExplicitInstances(instances) && len(collector.counters) != 0 {
+		collector.instances = instances
+		collector.activeInstances = make(map[string]struct{}, len(instances))
 
-					continue
-				}
+		for _, counter := range collector.counters {
+			for instance := range counter.Instances {
+				collector.activeInstances[instance] = struct{}{}
 			}
 		}
 
-		collector.counters[counterName] = counter
+		// Drop the counters of requested instances which don't exist yet. They are added once the instance appears.
+		_, _, _ = collector.refreshInstances()
 	}
 
 	if err := errors.Join(errs...); err != nil {
@@ -240,10 +244,10 @@ func NewCollectorWithReflection(resultType CounterType, object string, instances
 	collector.collectCh = make(chan any)
 	collector.errorCh = make(chan error)
 
-	if resultType == CounterTypeRaw {
-		go collector.collectWorkerRaw()
-	} else {
+	if resultType == CounterTypeFormatted {
 		go collector.collectWorkerFormatted()
+	} else {
+		go collector.collectWorkerRaw()
 	}
 
 	// Collect initial data because some counters need to be read twice to get the correct value.
@@ -255,6 +259,55 @@ func NewCollectorWithReflection(resultType CounterType, object string, instances
 	return collector, nil
 }
 
+// initCounterInfo sets the explain text, the type and the time base of the counter from the counter handle.
+// It is called for the first handle added for a counter, either when creating the collector or once an instance appears.
+func (c *Collector) initCounterInfo(counter *Counter, counterHandle pdhCounterHandle, counterPath string) error {
+	// Get the info with the current buffer size, including the explain text of the counter.
+	var bufLen uint32
+
+	if ret := GetCounterInfo(counterHandle, 1, &bufLen, nil); ret != MoreData {
+		return fmt.Errorf("GetCounterInfo: %w", NewPdhError(ret))
+	}
+
+	buf := make([]byte, bufLen)
+	if len(buf) == 0 {
+		return errors.New("GetCounterInfo: buffer length is zero")
+	}
+
+	if ret := GetCounterInfo(counterHandle, 1, &bufLen, &buf[0]); ret != ErrorSuccess {
+		return fmt.Errorf("GetCounterInfo: %w", NewPdhError(ret))
+	}
+
+	counterInfo := (*CounterInfo)(unsafe.Pointer(&buf[0]))
+	if counterInfo == nil {
+		return errors.New("GetCounterInfo: counter info is nil")
+	}
+
+	if counterInfo.SzExplainText != nil {
+		counter.Desc = strings.TrimSpace(windows.UTF16PtrToString(counterInfo.SzExplainText))
+	}
+
+	counter.Type = counterInfo.DwType
+	if val, ok := SupportedCounterTypes[counter.Type]; ok {
+		counter.MetricType = val
+	} else {
+		counter.MetricType = prometheus.GaugeValue
+	}
+
+	if c.calculator != nil && !isCalculatedCounterType(counter.Type) {
+		return fmt.Errorf("counter %s: calculating the value of counter type 0x%08x is not supported", counterPath, counter.Type)
+	}
+
+	if counter.Type == PERF_ELAPSED_TIME || (c.calculator != nil && needsFrequency(counter.Type)) {
+		if ret := GetCounterTimeBase(counterHandle, &counter.Frequency); ret != ErrorSuccess {
+			return fmt.Errorf("GetCounterTimeBase: %w", NewPdhError(ret))
+		}
+	}
+
+	return nil
+}
+
+// Describe returns the explain text of each counter, as shown in perfmon.
 func (c *Collector) Describe() map[string]string {
 	if c == nil {
 		return map[string]string{}
@@ -272,18 +325,73 @@ func (c *Collector) Describe() map[string]string {
 	return desc
 }
 
+// MetricTypes returns the Prometheus value type of each counter, derived from the PDH counter type.
+func (c *Collector) MetricTypes() map[string]prometheus.ValueType {
+	if c == nil {
+		return map[string]prometheus.ValueType{}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	metricTypes := make(map[string]prometheus.ValueType, len(c.counters))
+
+	for _, counter := range c.counters {
+		metricTypes[counter.Name] = counter.MetricType
+	}
+
+	return metricTypes
+}
+
+// CounterTypes returns the PDH counter type of each counter, e.g. [PERF_100NSEC_TIMER].
+// The type is 0 for counters without any instance yet.
+func (c *Collector) CounterTypes() map[string]uint32 {
+	if c == nil {
+		return map[string]uint32{}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	counterTypes := make(map[string]uint32, len(c.counters))
+
+	for _, counter := range c.counters {
+		counterTypes[counter.Name] = counter.Type
+	}
+
+	return counterTypes
+}
+
 func (c *Collector) Collect(dst any) error {
 	if c == nil {
 		return ErrPerformanceCounterNotInitialized
 	}
 
+	c.refreshInstancesIfDue()
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	if c.replay != nil {
+		return c.collectReplay(dst)
+	}
+
 	if len(c.counters) == 0 || c.handle == 0 || c.collectCh == nil || c.errorCh == nil {
 		return ErrPerformanceCounterNotInitialized
 	}
 
+	return c.collect(dst)
+}
+
+func (c *Collector) collect(dst any) error {
+	if c.shared != nil {
+		return c.shared.read(&c.generation, func() error {
+			c.collectCh <- dst
+
+			return <-c.errorCh
+		})
+	}
+
 	c.collectCh <- dst
 
 	return <-c.errorCh
@@ -291,155 +399,85 @@ func (c *Collector) Collect(dst any) error {
 
 func (c *Collector) collectWorkerRaw() {
 	var (
-		err         error
-		itemCount   uint32
-		items       []RawCounterItem
-		bytesNeeded uint32
+		err   error
+		items []RawCounterItem
 	)
 
 	buf := make([]byte, 1)
 
 	for data := range c.collectCh {
 		err = (func() error {
-			if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
-				return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
-			}
-
-			dv := reflect.ValueOf(data)
-			if dv.Kind() != reflect.Ptr || dv.IsNil() {
-				return fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
+			// The shared query is sampled once per scrape by the caller.
+			if c.shared == nil {
+				if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
+					return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
+				}
 			}
 
-			dv = dv.Elem()
-
-			if dv.Kind() != reflect.Slice {
-				return fmt.Errorf("expected a pointer to a slice, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
+			rows, err := newRowWriter(data, c.nameIndexValue, c.metricsTypeIndexValue, c.totalCounterRequested)
+			if err != nil {
+				return err
 			}
 
-			elemType := dv.Type().Elem()
-
-			if elemType.Kind() != reflect.Struct {
-				return fmt.Errorf("expected a pointer to a slice of structs, got a slice of %s: %w", elemType.Kind(), mi.ErrInvalidEntityType)
-			}
+			stringMap := map[*uint16]string{}
 
-			if dv.Len() != 0 {
-				dv.Set(reflect.MakeSlice(dv.Type(), 0, 0))
+			var sample replay.PDHSample
+			if c.recorder != nil {
+				sample = make(replay.PDHSample, len(c.counters))
 			}
 
-			dv.Clear()
-
-			elemValue := reflect.ValueOf(reflect.New(elemType).Interface()).Elem()
-
-			indexMap := map[string]int{}
-			stringMap := map[*uint16]string{}
-
 			for _, counter := range c.counters {
-				for _, instance := range counter.Instances {
-					// Get the info with the current buffer size
-					bytesNeeded = uint32(cap(buf))
-
-					for {
-						ret := GetRawCounterArray(instance, &bytesNeeded, &itemCount, &buf[0])
-
-						if ret == ErrorSuccess {
-							break
-						}
-
-						if err := NewPdhError(ret); ret != MoreData {
-							if isKnownCounterDataError(err) {
-								break
-							}
-
-							return fmt.Errorf("GetRawCounterArray: %w", err)
-						}
-
-						if bytesNeeded <= uint32(cap(buf)) {
-							return fmt.Errorf("GetRawCounterArray reports buffer too small (%d), but buffer is large enough (%d): %w", uint32(cap(buf)), bytesNeeded, NewPdhError(ret))
-						}
+				// Calculated values are formatted values, like the ones of collectWorkerFormatted.
+				metricType := prometheus.GaugeValue
+				if c.calculator == nil {
+					metricType = rawMetricType(counter)
+				}
 
-						buf = make([]byte, bytesNeeded)
+				for _, instance := range counter.Instances {
+					if items, buf, err = getRawCounterArray(instance, buf); err != nil {
+						return err
 					}
 
-					items = unsafe.Slice((*RawCounterItem)(unsafe.Pointer(&buf[0])), itemCount)
-
-					var (
-						instanceName string
-						ok           bool
-					)
-
 					for _, item := range items {
 						if item.RawValue.CStatus != CstatusValidData && item.RawValue.CStatus != CstatusNewData {
 							continue
 						}
 
-						if instanceName, ok = stringMap[item.SzName]; !ok {
+						instanceName, ok := stringMap[item.SzName]
+						if !ok {
 							instanceName = windows.UTF16PtrToString(item.SzName)
 							stringMap[item.SzName] = instanceName
 						}
 
-						if strings.HasSuffix(instanceName, InstanceTotal) && !c.totalCounterRequested {
-							continue
+						if sample != nil {
+							sample[counter.Name] = append(sample[counter.Name], newPDHValue(instanceName, item.RawValue))
 						}
 
-						if instanceName == "" || instanceName == "*" {
-							instanceName = InstanceEmpty
-						}
-
-						var (
-							index int
-							ok    bool
-						)
-
-						if index, ok = indexMap[instanceName]; !ok {
-							index = dv.Len()
-							indexMap[instanceName] = index
-
-							if c.nameIndexValue != -1 {
-								elemValue.Field(c.nameIndexValue).SetString(instanceName)
-							}
-
-							if c.metricsTypeIndexValue != -1 {
-								var metricsType prometheus.ValueType
-								if metricsType, ok = SupportedCounterTypes[counter.Type]; !ok {
-									metricsType = prometheus.GaugeValue
-								}
-
-								elemValue.Field(c.metricsTypeIndexValue).Set(reflect.ValueOf(metricsType))
-							}
+						if c.calculator != nil {
+							c.calculator.setValue(rows, counter, instanceName, newRawSample(item.RawValue))
 
-							dv.Set(reflect.Append(dv, elemValue))
+							continue
 						}
 
-						// This is a workaround for the issue with the elapsed time counter type.
-						// Source: https://github.com/prometheus-community/windows_exporter/pull/335/files#diff-d5d2528f559ba2648c2866aec34b1eaa5c094dedb52bd0ff22aa5eb83226bd8dR76-R83
-						// Ref: https://learn.microsoft.com/en-us/windows/win32/perfctrs/calculating-counter-values
-						switch counter.Type {
-						case PERF_ELAPSED_TIME:
-							dv.Index(index).
-								Field(counter.FieldIndexValue).
-								SetFloat(float64((item.RawValue.SecondValue - item.RawValue.FirstValue) / counter.Frequency))
-						case PERF_100NSEC_TIMER, PERF_PRECISION_100NS_TIMER:
-							dv.Index(index).
-								Field(counter.FieldIndexValue).
-								SetFloat(float64(item.RawValue.FirstValue) * TicksToSecondScaleFactor)
-						default:
-							if counter.FieldIndexSecondValue != -1 {
-								dv.Index(index).
-									Field(counter.FieldIndexSecondValue).
-									SetFloat(float64(item.RawValue.SecondValue))
-							}
-
-							if counter.FieldIndexValue != -1 {
-								dv.Index(index).
-									Field(counter.FieldIndexValue).
-									SetFloat(float64(item.RawValue.FirstValue))
-							}
+						elem, _, ok := rows.row(instanceName, metricType)
+						if !ok {
+							continue
 						}
+
+						setRawValue(elem, counter, item.RawValue.FirstValue, item.RawValue.SecondValue)
 					}
 				}
 			}
 
-			if dv.Len() == 0 {
+			if c.calculator != nil {
+				c.calculator.prune()
+			}
+
+			if sample != nil {
+				c.recorder.Record(c.recordedCounters(), sample)
+			}
+
+			if rows.len() == 0 {
 				return ErrNoData
 			}
 
@@ -459,39 +497,22 @@ func (c *Collector) collectWorkerFormatted() {
 	)
 
 	buf := make([]byte, 1)
+	rawBuf := make([]byte, 1)
 
 	for data := range c.collectCh {
 		err = (func() error {
-			if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
-				return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
-			}
-
-			dv := reflect.ValueOf(data)
-			if dv.Kind() != reflect.Ptr || dv.IsNil() {
-				return fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
-			}
-
-			dv = dv.Elem()
-
-			if dv.Kind() != reflect.Slice {
-				return fmt.Errorf("expected a pointer to a slice, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
-			}
-
-			elemType := dv.Type().Elem()
-
-			if elemType.Kind() != reflect.Struct {
-				return fmt.Errorf("expected a pointer to a slice of structs, got a slice of %s: %w", elemType.Kind(), mi.ErrInvalidEntityType)
+			// The shared query is sampled once per scrape by the caller.
+			if c.shared == nil {
+				if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
+					return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
+				}
 			}
 
-			if dv.Len() != 0 {
-				dv.Set(reflect.MakeSlice(dv.Type(), 0, 0))
+			rows, err := newRowWriter(data, c.nameIndexValue, c.metricsTypeIndexValue, c.totalCounterRequested)
+			if err != nil {
+				return err
 			}
 
-			dv.Clear()
-
-			elemValue := reflect.ValueOf(reflect.New(elemType).Interface()).Elem()
-
-			indexMap := map[string]int{}
 			stringMap := map[*uint16]string{}
 
 			for _, counter := range c.counters {
@@ -523,59 +544,36 @@ func (c *Collector) collectWorkerFormatted() {
 
 					items = unsafe.Slice((*FmtCounterValueItemDouble)(unsafe.Pointer(&buf[0])), itemCount)
 
-					var (
-						instanceName string
-						ok           bool
-					)
-
 					for _, item := range items {
 						if item.FmtValue.CStatus != CstatusValidData && item.FmtValue.CStatus != CstatusNewData {
 							continue
 						}
 
-						if instanceName, ok = stringMap[item.SzName]; !ok {
+						instanceName, ok := stringMap[item.SzName]
+						if !ok {
 							instanceName = windows.UTF16PtrToString(item.SzName)
 							stringMap[item.SzName] = instanceName
 						}
 
-						if strings.HasSuffix(instanceName, InstanceTotal) && !c.totalCounterRequested {
+						elem, _, ok := rows.row(instanceName, prometheus.GaugeValue)
+						if !ok {
 							continue
 						}
 
-						if instanceName == "" || instanceName == "*" {
-							instanceName = InstanceEmpty
-						}
-
-						var (
-							index int
-							ok    bool
-						)
-
-						if index, ok = indexMap[instanceName]; !ok {
-							index = dv.Len()
-							indexMap[instanceName] = index
-
-							if c.nameIndexValue != -1 {
-								elemValue.Field(c.nameIndexValue).SetString(instanceName)
-							}
-
-							if c.metricsTypeIndexValue != -1 {
-								elemValue.Field(c.metricsTypeIndexValue).Set(reflect.ValueOf(prometheus.GaugeValue))
-							}
-
-							dv.Set(reflect.Append(dv, elemValue))
-						}
-
 						if counter.FieldIndexValue != -1 {
-							dv.Index(index).
-								Field(counter.FieldIndexValue).
-								SetFloat(item.FmtValue.DoubleValue)
+							elem.Field(counter.FieldIndexValue).SetFloat(item.FmtValue.DoubleValue)
 						}
 					}
 				}
 			}
 
-			if dv.Len() == 0 {
+			if c.recorder != nil {
+				if rawBuf, err = c.recordRawValues(rawBuf); err != nil {
+					return err
+				}
+			}
+
+			if rows.len() == 0 {
 				return ErrNoData
 			}
 
@@ -586,6 +584,107 @@ func (c *Collector) collectWorkerFormatted() {
 	}
 }
 
+// recordRawValues records the raw values of all counters, so the recording holds raw values
+// even though the collector reads formatted values. It returns the grown buffer.
+func (c *Collector) recordRawValues(buf []byte) ([]byte, error) {
+	var (
+		items []RawCounterItem
+		err   error
+	)
+
+	sample := make(replay.PDHSample, len(c.counters))
+
+	for _, counter := range c.counters {
+		for _, instance := range counter.Instances {
+			if items, buf, err = getRawCounterArray(instance, buf); err != nil {
+				return buf, err
+			}
+
+			for _, item := range items {
+				if item.RawValue.CStatus != CstatusValidData && item.RawValue.CStatus != CstatusNewData {
+					continue
+				}
+
+				sample[counter.Name] = append(sample[counter.Name], newPDHValue(windows.UTF16PtrToString(item.SzName), item.RawValue))
+			}
+		}
+	}
+
+	c.recorder.Record(c.recordedCounters(), sample)
+
+	return buf, nil
+}
+
+// recordedCounters returns the types of the counters for the recording.
+func (c *Collector) recordedCounters() map[string]replay.PDHCounter {
+	counters := make(map[string]replay.PDHCounter, len(c.counters))
+
+	for _, counter := range c.counters {
+		counters[counter.Name] = replay.PDHCounter{
+			Type:      counter.Type,
+			Frequency: counter.Frequency,
+		}
+	}
+
+	return counters
+}
+
+// getRawCounterArray returns the raw values of all instances of the counter.
+// The items point into buf, which is grown if needed and returned. Known data errors, e.g. a vanished instance, return no items.
+func getRawCounterArray(counterHandle pdhCounterHandle, buf []byte) ([]RawCounterItem, []byte, error) {
+	var itemCount uint32
+
+	// Get the info with the current buffer size
+	bytesNeeded := uint32(cap(buf))
+
+	for {
+		ret := GetRawCounterArray(counterHandle, &bytesNeeded, &itemCount, &buf[0])
+
+		if ret == ErrorSuccess {
+			break
+		}
+
+		if err := NewPdhError(ret); ret != MoreData {
+			if isKnownCounterDataError(err) {
+				return nil, buf, nil
+			}
+
+			return nil, buf, fmt.Errorf("GetRawCounterArray: %w", err)
+		}
+
+		if bytesNeeded <= uint32(cap(buf)) {
+			return nil, buf, fmt.Errorf("GetRawCounterArray reports buffer too small (%d), but buffer is large enough (%d): %w", uint32(cap(buf)), bytesNeeded, NewPdhError(ret))
+		}
+
+		buf = make([]byte, bytesNeeded)
+	}
+
+	return unsafe.Slice((*RawCounterItem)(unsafe.Pointer(&buf[0])), itemCount), buf, nil
+}
+
+func newRawSample(raw RawCounter) rawSample {
+	return rawSample{
+		Timestamp:   filetimeToInt64(raw.TimeStamp),
+		FirstValue:  raw.FirstValue,
+		SecondValue: raw.SecondValue,
+		MultiCount:  raw.MultiCount,
+	}
+}
+
+func newPDHValue(instance string, raw RawCounter) replay.PDHValue {
+	return replay.PDHValue{
+		Instance:    instance,
+		Timestamp:   filetimeToInt64(raw.TimeStamp),
+		FirstValue:  raw.FirstValue,
+		SecondValue: raw.SecondValue,
+		MultiCount:  raw.MultiCount,
+	}
+}
+
+func filetimeToInt64(ft windows.Filetime) int64 {
+	return int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)
+}
+
 func (c *Collector) Close() {
 	if c == nil {
 		return
@@ -594,7 +693,20 @@ func (c *Collector) Close() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	CloseQuery(c.handle)
+	if c.shared != nil {
+		// Only remove the counters of this collector, the query is owned by the caller of SetSharedQuery.
+		c.shared.mu.Lock()
+
+		for _, counter := range c.counters {
+			for _, counterHandle := range counter.Instances {
+				RemoveCounter(counterHandle)
+			}
+		}
+
+		c.shared.mu.Unlock()
+	} else if c.handle != 0 {
+		CloseQuery(c.handle)
+	}
 
 	c.handle = 0
 
@@ -610,6 +722,34 @@ func (c *Collector) Close() {
 	c.errorCh = nil
 }
 
+// newReplayedCollector returns a collector reading the counters from the recording of r, see [ReplayCollector].
+func newReplayedCollector(r *replay.Replayer, resultType CounterType, object string, instances []string, valueType reflect.Type) (*Collector, error) {
+	replayCollector, err := NewReplayCollectorWithReflection(r, resultType, object, instances, valueType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Collector{
+		object:                object,
+		counters:              replayCollector.counters,
+		nameIndexValue:        -1,
+		metricsTypeIndexValue: -1,
+		replay:                replayCollector,
+	}, nil
+}
+
+func (c *Collector) collectReplay(dst any) error {
+	if err := c.replay.Collect(dst); err != nil {
+		return err
+	}
+
+	if reflect.ValueOf(dst).Elem().Len() == 0 {
+		return ErrNoData
+	}
+
+	return nil
+}
+
 func formatCounterPath(object, instance, counterName string) string {
 	var counterPath string
 
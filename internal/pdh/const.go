@@ -13,8 +13,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build windows
-
 package pdh
 
 import "github.com/prometheus/client_golang/prometheus"
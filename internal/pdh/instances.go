@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// instanceRefreshInterval is the minimum interval between two re-enumerations of the instances of a collector.
+const instanceRefreshInterval = time.Minute
+
+//nolint:gochecknoglobals
+var (
+	logger atomic.Pointer[slog.Logger]
+
+	instanceChangesMu sync.Mutex
+	instanceChanges   = map[InstanceChange]uint64{}
+)
+
+// InstanceChange identifies the instances of an object that were added or removed by the re-enumeration.
+type InstanceChange struct {
+	Object string
+	// Change is either "added" or "removed".
+	Change string
+}
+
+// SetLogger sets the logger used to report instance changes. By default, nothing is logged.
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+// InstanceChanges returns the number of instances added and removed by the re-enumeration of all collectors since the start.
+func InstanceChanges() map[InstanceChange]uint64 {
+	instanceChangesMu.Lock()
+	defer instanceChangesMu.Unlock()
+
+	return maps.Clone(instanceChanges)
+}
+
+func countInstanceChanges(object, change string, instances []string) {
+	if len(instances) == 0 {
+		return
+	}
+
+	instanceChangesMu.Lock()
+	instanceChanges[InstanceChange{Object: object, Change: change}] += uint64(len(instances))
+	instanceChangesMu.Unlock()
+
+	if l := logger.Load(); l != nil {
+		l.LogAttrs(context.Background(), slog.LevelDebug, "PDH instances "+change,
+			slog.String("object", object),
+			slog.Any("instances", instances),
+		)
+	}
+}
+
+// hasExplicitInstances reports whether the instances are given by name.
+// Instances matched by wildcards are expanded by PDH itself on every collection.
+func hasExplicitInstances(instances []string) bool {
+	return len(instances) != 0 && !slices.ContainsFunc(instances, func(instance string) bool {
+		return instance == InstanceEmpty || strings.Contains(instance, "*")
+	})
+}
+
+// refreshInstancesIfDue re-enumerates the instances of the object, if the collector was created
+// with explicit instances and the last enumeration is older than [instanceRefreshInterval].
+func (c *Collector) refreshInstancesIfDue() {
+	if c.instances == nil || time.Now().UnixNano() < c.nextInstanceRefresh.Load() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another collection may have refreshed the instances while waiting for the lock.
+	if time.Now().UnixNano() < c.nextInstanceRefresh.Load() {
+		return
+	}
+
+	added, removed, err := c.refreshInstances()
+	if err != nil {
+		if l := logger.Load(); l != nil {
+			l.LogAttrs(context.Background(), slog.LevelDebug, "failed to enumerate PDH instances",
+				slog.String("object", c.object),
+				slog.Any("err", err),
+			)
+		}
+
+		return
+	}
+
+	countInstanceChanges(c.object, "added", added)
+	countInstanceChanges(c.object, "removed", removed)
+}
+
+// refreshInstances adds the counters of requested instances which appeared since the last refresh
+// and removes the counters of instances which disappeared, since their handles become stale.
+// The caller must hold c.mu.
+func (c *Collector) refreshInstances() ([]string, []string, error) {
+	c.nextInstanceRefresh.Store(time.Now().Add(instanceRefreshInterval).UnixNano())
+
+	current, err := c.enumerateInstances()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.shared != nil {
+		c.shared.mu.Lock()
+		defer c.shared.mu.Unlock()
+	}
+
+	var added, removed []string
+
+	for _, instance := range c.instances {
+		_, active := c.activeInstances[instance]
+		_, present := current[instance]
+
+		switch {
+		case present && !active:
+			if c.addInstance(instance) {
+				added = append(added, instance)
+			}
+		case !present && active:
+			c.removeInstance(instance)
+
+			removed = append(removed, instance)
+		}
+	}
+
+	return added, removed, nil
+}
+
+// enumerateInstances returns the names of all current instances of the object.
+// It expands a wildcard counter in a separate query, since PdhEnumObjectItems only accepts localized object names.
+func (c *Collector) enumerateInstances() (map[string]struct{}, error) {
+	var counterName string
+
+	for name := range c.counters {
+		counterName = name
+
+		break
+	}
+
+	var handle pdhQueryHandle
+
+	if ret := OpenQuery(0, 0, &handle); ret != ErrorSuccess {
+		return nil, NewPdhError(ret)
+	}
+
+	defer CloseQuery(handle)
+
+	var counterHandle pdhCounterHandle
+
+	if ret := AddEnglishCounter(handle, formatCounterPath(c.object, "*", counterName), 0, &counterHandle); ret != ErrorSuccess {
+		return nil, NewPdhError(ret)
+	}
+
+	if ret := CollectQueryData(handle); ret != ErrorSuccess {
+		return nil, NewPdhError(ret)
+	}
+
+	var bytesNeeded, itemCount uint32
+
+	if ret := GetRawCounterArray(counterHandle, &bytesNeeded, &itemCount, nil); ret != MoreData {
+		if err := NewPdhError(ret); ret != ErrorSuccess && !isKnownCounterDataError(err) {
+			return nil, err
+		}
+
+		return map[string]struct{}{}, nil
+	}
+
+	buf := make([]byte, bytesNeeded)
+
+	if ret := GetRawCounterArray(counterHandle, &bytesNeeded, &itemCount, &buf[0]); ret != ErrorSuccess {
+		return nil, NewPdhError(ret)
+	}
+
+	instances := make(map[string]struct{}, itemCount)
+
+	for _, item := range unsafe.Slice((*RawCounterItem)(unsafe.Pointer(&buf[0])), itemCount) {
+		instances[windows.UTF16PtrToString(item.SzName)] = struct{}{}
+	}
+
+	return instances, nil
+}
+
+// addInstance adds the counters of the instance to the query. The caller must hold c.mu.
+func (c *Collector) addInstance(instance string) bool {
+	handles := make(map[string]pdhCounterHandle, len(c.counters))
+
+	for name := range c.counters {
+		var counterHandle pdhCounterHandle
+
+		if ret := AddEnglishCounter(c.handle, formatCounterPath(c.object, instance, name), 0, &counterHandle); ret != ErrorSuccess {
+			for _, h := range handles {
+				RemoveCounter(h)
+			}
+
+			return false
+		}
+
+		handles[name] = counterHandle
+	}
+
+	// Counters without any instance at creation of the collector don't have their info yet.
+	for name, counterHandle := range handles {
+		counter := c.counters[name]
+		if counter.Type != 0 {
+			continue
+		}
+
+		if err := c.initCounterInfo(&counter, counterHandle, formatCounterPath(c.object, instance, name)); err != nil {
+			if l := logger.Load(); l != nil {
+				l.LogAttrs(context.Background(), slog.LevelDebug, "failed to get PDH counter info",
+					slog.String("object", c.object),
+					slog.String("instance", instance),
+					slog.Any("err", err),
+				)
+			}
+
+			for _, h := range handles {
+				RemoveCounter(h)
+			}
+
+			return false
+		}
+
+		c.counters[name] = counter
+	}
+
+	for name, counterHandle := range handles {
+		c.counters[name].Instances[instance] = counterHandle
+	}
+
+	c.activeInstances[instance] = struct{}{}
+
+	return true
+}
+
+// removeInstance removes the counters of the instance from the query. The caller must hold c.mu.
+func (c *Collector) removeInstance(instance string) {
+	for _, counter := range c.counters {
+		if counterHandle, ok := counter.Instances[instance]; ok {
+			RemoveCounter(counterHandle)
+			delete(counter.Instances, instance)
+		}
+	}
+
+	delete(c.activeInstances, instance)
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type processThreads struct {
+	Name        string
+	ThreadCount float64 `perfdata:"Thread Count"`
+}
+
+func TestHasExplicitInstances(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, hasExplicitInstances([]string{"C:", "D:"}))
+	require.True(t, hasExplicitInstances(InstancesTotal))
+	require.False(t, hasExplicitInstances(nil))
+	require.False(t, hasExplicitInstances(InstancesAll))
+	require.False(t, hasExplicitInstances([]string{InstanceEmpty}))
+	require.False(t, hasExplicitInstances([]string{"C:", "svchost*"}))
+}
+
+func TestRefreshInstances(t *testing.T) {
+	t.Parallel()
+
+	collector, err := NewCollector[processThreads](CounterTypeRaw, "Process", []string{"Idle", "windows_exporter_missing"})
+	require.NoError(t, err)
+
+	t.Cleanup(collector.Close)
+
+	// The missing instance is skipped until it appears.
+	require.Equal(t, map[string]struct{}{"Idle": {}}, collector.activeInstances)
+
+	collector.mu.Lock()
+	added, removed, err := collector.refreshInstances()
+	collector.mu.Unlock()
+
+	require.NoError(t, err)
+	require.Empty(t, added)
+	require.Empty(t, removed)
+
+	// Pretend the instance disappeared, it is added again on the next refresh.
+	collector.mu.Lock()
+	collector.removeInstance("Idle")
+	added, _, err = collector.refreshInstances()
+	collector.mu.Unlock()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Idle"}, added)
+
+	var data []processThreads
+
+	require.NoError(t, collector.Collect(&data))
+	require.Len(t, data, 1)
+	require.Equal(t, "Idle", data[0].Name)
+}
+
+func TestAddInstanceAfterBuild(t *testing.T) {
+	t.Parallel()
+
+	const instance = "windows_exporter_pdh_test"
+
+	collector, err := NewCollector[processThreads](CounterTypeRaw, "Process", []string{instance})
+	require.NoError(t, err)
+
+	t.Cleanup(collector.Close)
+
+	require.Empty(t, collector.activeInstances)
+	require.Zero(t, collector.counters["Thread Count"].Type)
+
+	// Start a copy of ping, so the process instance has a known name.
+	executable := filepath.Join(t.TempDir(), instance+".exe")
+
+	ping, err := os.ReadFile(filepath.Join(os.Getenv("SystemRoot"), "System32", "PING.EXE"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(executable, ping, 0o700))
+
+	cmd := exec.Command(executable, "-n", "60", "127.0.0.1")
+	require.NoError(t, cmd.Start())
+
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	require.Eventually(t, func() bool {
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+
+		added, _, err := collector.refreshInstances()
+
+		return err == nil && len(added) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	counter := collector.counters["Thread Count"]
+	require.Equal(t, uint32(PERF_COUNTER_RAWCOUNT), counter.Type)
+	require.Equal(t, prometheus.GaugeValue, counter.MetricType)
+	require.NotEmpty(t, counter.Desc)
+
+	var data []processThreads
+
+	require.NoError(t, collector.Collect(&data))
+	require.Len(t, data, 1)
+	require.Equal(t, instance, data[0].Name)
+	require.Positive(t, data[0].ThreadCount)
+}
+
+func TestCountInstanceChanges(t *testing.T) {
+	t.Parallel()
+
+	countInstanceChanges("windows_exporter_test", "added", []string{"a", "b"})
+	countInstanceChanges("windows_exporter_test", "removed", []string{"a"})
+	countInstanceChanges("windows_exporter_test", "removed", nil)
+
+	changes := InstanceChanges()
+	require.Equal(t, uint64(2), changes[InstanceChange{Object: "windows_exporter_test", Change: "added"}])
+	require.Equal(t, uint64(1), changes[InstanceChange{Object: "windows_exporter_test", Change: "removed"}])
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"slices"
+
+	"golang.org/x/sys/windows"
+)
+
+// PerfDetailWizard is the detail level of all counters listed in the "Add Counters" dialog of perfmon.
+const PerfDetailWizard uint32 = 400
+
+// ObjectCounters returns the names of all counters of the performance counter object.
+func ObjectCounters(object string) ([]string, error) {
+	var (
+		counterBuf, instanceBuf []uint16
+		counterLen, instanceLen uint32
+	)
+
+	for {
+		ret := EnumObjectItems(object, bufferPtr(counterBuf), &counterLen, bufferPtr(instanceBuf), &instanceLen, PerfDetailWizard)
+		if ret == ErrorSuccess && counterBuf != nil {
+			break
+		}
+
+		// The instance list may grow between the calls, retry until both buffers are large enough.
+		if ret != MoreData && ret != ErrorSuccess {
+			return nil, NewPdhError(ret)
+		}
+
+		if counterLen == 0 {
+			return []string{}, nil
+		}
+
+		if ret == MoreData && counterBuf != nil && counterLen <= uint32(len(counterBuf)) && instanceLen <= uint32(len(instanceBuf)) {
+			instanceLen = max(2*uint32(len(instanceBuf)), 1024)
+		}
+
+		counterBuf = make([]uint16, counterLen)
+		instanceBuf = make([]uint16, instanceLen)
+	}
+
+	counters := make([]string, 0)
+
+	for _, name := range splitMultiString(counterBuf[:min(counterLen, uint32(len(counterBuf)))]) {
+		if !slices.Contains(counters, name) {
+			counters = append(counters, name)
+		}
+	}
+
+	return counters, nil
+}
+
+func bufferPtr(buf []uint16) *uint16 {
+	if len(buf) == 0 {
+		return nil
+	}
+
+	return &buf[0]
+}
+
+// splitMultiString splits a list of null-terminated strings, terminated by an empty string.
+func splitMultiString(buf []uint16) []string {
+	values := make([]string, 0)
+
+	for start := 0; start < len(buf); {
+		end := slices.Index(buf[start:], 0)
+		if end <= 0 {
+			break
+		}
+
+		values = append(values, windows.UTF16ToString(buf[start:start+end]))
+		start += end + 1
+	}
+
+	return values
+}
@@ -64,10 +64,6 @@ const (
 	ErrorInvalidFunction = 1
 )
 
-type (
-	HANDLE uintptr
-)
-
 // PDH error codes, which can be returned by all Pdh* functions. Taken from mingw-w64 pdhmsg.h
 
 const (
@@ -267,11 +263,6 @@ const (
 	PerfDetailStandard = 0x0000FFFF
 )
 
-type (
-	pdhQueryHandle   HANDLE // query handle
-	pdhCounterHandle HANDLE // counter handle
-)
-
 //nolint:gochecknoglobals
 var (
 	libPdhDll = windows.NewLazySystemDLL("pdh.dll")
@@ -281,6 +272,7 @@ var (
 	pdhCloseQuery                = libPdhDll.NewProc("PdhCloseQuery")
 	pdhCollectQueryData          = libPdhDll.NewProc("PdhCollectQueryData")
 	pdhCollectQueryDataWithTime  = libPdhDll.NewProc("PdhCollectQueryDataWithTime")
+	pdhEnumObjectItemsW          = libPdhDll.NewProc("PdhEnumObjectItemsW")
 	pdhGetFormattedCounterValue  = libPdhDll.NewProc("PdhGetFormattedCounterValue")
 	pdhGetFormattedCounterArrayW = libPdhDll.NewProc("PdhGetFormattedCounterArrayW")
 	pdhOpenQuery                 = libPdhDll.NewProc("PdhOpenQuery")
@@ -290,6 +282,7 @@ var (
 	pdhGetRawCounterValue        = libPdhDll.NewProc("PdhGetRawCounterValue")
 	pdhGetRawCounterArrayW       = libPdhDll.NewProc("PdhGetRawCounterArrayW")
 	pdhPdhGetCounterTimeBase     = libPdhDll.NewProc("PdhGetCounterTimeBase")
+	pdhRemoveCounter             = libPdhDll.NewProc("PdhRemoveCounter")
 )
 
 // AddCounter adds the specified counter to the query. This is the internationalized version. Preferably, use the
@@ -366,6 +359,13 @@ func CloseQuery(hQuery pdhQueryHandle) uint32 {
 	return uint32(ret)
 }
 
+// RemoveCounter removes a counter from its query. The counter handle is invalid afterward.
+func RemoveCounter(hCounter pdhCounterHandle) uint32 {
+	ret, _, _ := pdhRemoveCounter.Call(uintptr(hCounter))
+
+	return uint32(ret)
+}
+
 // CollectQueryData collects the current raw data value for all counters in the specified query and updates the status
 // code of each counter. With some counters, this function needs to be repeatedly called before the value
 // of the counter can be extracted with PdhGetFormattedCounterValue(). For example, the following code
@@ -537,6 +537,45 @@ func ExpandWildCardPath(szWildCardPath string, mszExpandedPathList *uint16, pcch
 	return uint32(ret)
 }
 
+// EnumObjectItems returns the counter and instance names of the specified object on the local computer.
+//
+// szObjectName [in]
+// Name of the object whose counter and instance names you want to enumerate.
+//
+// mszCounterList [out]
+// Caller-allocated buffer that receives a list of null-terminated counter names. The list is terminated by two NULL characters.
+// Set to NULL if pcchCounterListLength is zero.
+//
+// pcchCounterListLength [in, out]
+// Size of the mszCounterList buffer, in TCHARs. If zero on input, the function returns MoreData and sets this parameter to the required buffer size.
+//
+// mszInstanceList [out]
+// Caller-allocated buffer that receives a list of null-terminated instance names. The list is terminated by two NULL characters.
+// Set to NULL if pcchInstanceListLength is zero.
+//
+// pcchInstanceListLength [in, out]
+// Size of the mszInstanceList buffer, in TCHARs. If zero on input, the function returns MoreData and sets this parameter to the required buffer size.
+//
+// dwDetailLevel [in]
+// Detail level of the performance items to return. All items that are of the specified detail level or less will be returned.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/pdh/nf-pdh-pdhenumobjectitemsw
+func EnumObjectItems(szObjectName string, mszCounterList *uint16, pcchCounterListLength *uint32, mszInstanceList *uint16, pcchInstanceListLength *uint32, dwDetailLevel uint32) uint32 {
+	ptxt, _ := windows.UTF16PtrFromString(szObjectName)
+	ret, _, _ := pdhEnumObjectItemsW.Call(
+		0, // use the current real-time data source
+		0, // enumerate items on the local computer
+		uintptr(unsafe.Pointer(ptxt)),
+		uintptr(unsafe.Pointer(mszCounterList)),
+		uintptr(unsafe.Pointer(pcchCounterListLength)),
+		uintptr(unsafe.Pointer(mszInstanceList)),
+		uintptr(unsafe.Pointer(pcchInstanceListLength)),
+		uintptr(dwDetailLevel),
+		0)
+
+	return uint32(ret)
+}
+
 // ValidatePath validates a path. Will return ErrorSuccess when ok, or PdhCstatusBadCountername when the path is erroneous.
 func ValidatePath(path string) uint32 {
 	ptxt, _ := windows.UTF16PtrFromString(path)
@@ -646,3 +685,27 @@ func GetCounterTimeBase(hCounter pdhCounterHandle, pTimeBase *int64) uint32 {
 
 	return uint32(ret)
 }
+
+// The RawCounter structure returns the data as it was collected from the counter provider.
+// No translation, formatting, or other interpretation is performed on the data.
+type RawCounter struct {
+	// Counter status that indicates if the counter value is valid. Check this member before using the data in a calculation or displaying its value.
+	// For a list of possible values, see https://docs.microsoft.com/windows/desktop/PerfCtrs/checking-pdh-interface-return-values
+	CStatus uint32
+	// Local time for when the data was collected
+	TimeStamp windows.Filetime
+	// First raw counter value.
+	FirstValue int64
+	// Second raw counter value. Rate counters require two values in order to compute a displayable value.
+	SecondValue int64
+	// If the counter type contains the PERF_MULTI_COUNTER flag, this member contains the additional counter data used in the calculation.
+	// For example, the PERF_100NSEC_MULTI_TIMER counter type contains the PERF_MULTI_COUNTER flag.
+	MultiCount uint32
+}
+
+type RawCounterItem struct {
+	// Pointer to a null-terminated string that specifies the instance name of the counter. The string is appended to the end of this structure.
+	SzName *uint16
+	// A RawCounter structure that contains the raw counter value of the instance
+	RawValue RawCounter
+}
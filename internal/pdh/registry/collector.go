@@ -18,8 +18,10 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
@@ -30,6 +32,9 @@ type Collector struct {
 	object string
 	query  string
 
+	instances             []string
+	totalCounterRequested bool
+
 	counters       map[string]Counter
 	nameIndexValue int
 }
@@ -45,12 +50,25 @@ type Counter struct {
 	FieldIndexSecondValue int
 }
 
-func NewCollector[T any](object string, _ []string) (*Collector, error) {
+// NewCollector creates a collector for the instances of the given object.
+// Like PDH, instances may contain * wildcards, and _Total instances are only collected if requested.
+func NewCollector[T any](object string, instances []string) (*Collector, error) {
+	if err := CounterNameTable.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fail like PDH for unknown objects, so callers can handle both APIs the same way.
+	if CounterNameTable.LookupIndex(object) == 0 {
+		return nil, fmt.Errorf("object %q: %w", object, pdh.NewPdhError(pdh.CstatusNoObject))
+	}
+
 	collector := &Collector{
-		object:         object,
-		query:          MapCounterToIndex(object),
-		nameIndexValue: -1,
-		counters:       make(map[string]Counter),
+		object:                object,
+		query:                 MapCounterToIndex(object),
+		instances:             instances,
+		totalCounterRequested: slices.Contains(instances, pdh.InstanceTotal),
+		nameIndexValue:        -1,
+		counters:              make(map[string]Counter),
 	}
 
 	var values [0]T
@@ -90,7 +108,7 @@ func NewCollector[T any](object string, _ []string) (*Collector, error) {
 
 	var collectValues []T
 
-	if err := collector.Collect(&collectValues); err != nil {
+	if err := collector.Collect(&collectValues); err != nil && !errors.Is(err, pdh.ErrNoData) {
 		return nil, fmt.Errorf("failed to collect initial data: %w", err)
 	}
 
@@ -122,7 +140,7 @@ func (c *Collector) Collect(data any) error {
 	}
 
 	if len(perfObjects) == 0 || perfObjects[0] == nil || len(perfObjects[0].Instances) == 0 {
-		return nil
+		return pdh.ErrNoData
 	}
 
 	if dv.Len() != 0 {
@@ -138,7 +156,7 @@ func (c *Collector) Collect(data any) error {
 
 		for _, perfInstance := range perfObject.Instances {
 			instanceName := perfInstance.Name
-			if strings.HasSuffix(instanceName, "_Total") {
+			if !c.includeInstance(instanceName) {
 				continue
 			}
 
@@ -153,7 +171,7 @@ func (c *Collector) Collect(data any) error {
 			dv.Set(reflect.Append(dv, elemValue))
 			index := dv.Len() - 1
 
-			for _, perfCounter := range perfInstance.Counters {
+			for i, perfCounter := range perfInstance.Counters {
 				if perfCounter.Def.IsBaseValue && !perfCounter.Def.IsNanosecondCounter {
 					continue
 				}
@@ -174,9 +192,17 @@ func (c *Collector) Collect(data any) error {
 						SetFloat(float64(perfCounter.Value) * pdh.TicksToSecondScaleFactor)
 				default:
 					if counter.FieldIndexSecondValue != -1 {
+						secondValue := perfCounter.SecondValue
+
+						// Like PDH, use the value of the base counter as second value of fraction counters.
+						// The base counter always follows the counter it belongs to.
+						if !perfCounter.Def.HasSecondValue && i+1 < len(perfInstance.Counters) && perfInstance.Counters[i+1].Def.IsBaseValue {
+							secondValue = perfInstance.Counters[i+1].Value
+						}
+
 						dv.Index(index).
 							Field(counter.FieldIndexSecondValue).
-							SetFloat(float64(perfCounter.SecondValue))
+							SetFloat(float64(secondValue))
 					}
 
 					if counter.FieldIndexValue != -1 {
@@ -189,6 +215,10 @@ func (c *Collector) Collect(data any) error {
 		}
 	}
 
+	if dv.Len() == 0 {
+		return pdh.ErrNoData
+	}
+
 	return nil
 }
 
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package registry
+
+import (
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+)
+
+// includeInstance reports whether the instance was requested when creating the collector.
+// The registry always returns all instances of an object, so they are filtered here like PDH does.
+func (c *Collector) includeInstance(instance string) bool {
+	if strings.HasSuffix(instance, pdh.InstanceTotal) && !c.totalCounterRequested {
+		return false
+	}
+
+	if len(c.instances) == 0 {
+		return true
+	}
+
+	for _, pattern := range c.instances {
+		if pattern == pdh.InstanceEmpty && (instance == "" || instance == "*") {
+			return true
+		}
+
+		if matchInstance(pattern, instance) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchInstance matches an instance name case-insensitively against a pattern, in which * matches any sequence of characters.
+// Other characters have no special meaning, since instance names often contain brackets.
+func matchInstance(pattern, instance string) bool {
+	parts := strings.Split(strings.ToLower(pattern), "*")
+	instance = strings.ToLower(instance)
+
+	if len(parts) == 1 {
+		return instance == parts[0]
+	}
+
+	if !strings.HasPrefix(instance, parts[0]) {
+		return false
+	}
+
+	instance = instance[len(parts[0]):]
+	last := len(parts) - 1
+
+	for _, part := range parts[1:last] {
+		i := strings.Index(instance, part)
+		if i == -1 {
+			return false
+		}
+
+		instance = instance[i+len(part):]
+	}
+
+	return strings.HasSuffix(instance, parts[last])
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package registry
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchInstance(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, matchInstance("*", "svchost"))
+	require.True(t, matchInstance("C:", "c:"))
+	require.True(t, matchInstance("svchost*", "svchost#1"))
+	require.True(t, matchInstance("*[R]*", "Intel[R] Ethernet"))
+	require.True(t, matchInstance("a*a", "aa"))
+	require.False(t, matchInstance("a*a", "a"))
+	require.False(t, matchInstance("C:", "D:"))
+	require.False(t, matchInstance("svchost*", "lsass"))
+}
+
+func TestIncludeInstance(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		instances []string
+		included  []string
+		excluded  []string
+	}{
+		{instances: pdh.InstancesAll, included: []string{"0,0", "C:"}, excluded: []string{"_Total", "0,_Total"}},
+		{instances: nil, included: []string{"", "C:"}, excluded: []string{"_Total"}},
+		{instances: pdh.InstancesTotal, included: []string{"_Total"}, excluded: []string{"0,0", "C:"}},
+		{instances: []string{"C:", "D*"}, included: []string{"C:", "D:"}, excluded: []string{"E:", "_Total"}},
+		{instances: []string{pdh.InstanceEmpty}, included: []string{"", "*"}, excluded: []string{"C:"}},
+	} {
+		collector := &Collector{
+			instances:             tc.instances,
+			totalCounterRequested: slices.Contains(tc.instances, pdh.InstanceTotal),
+		}
+
+		for _, instance := range tc.included {
+			require.True(t, collector.includeInstance(instance), "%v: %q", tc.instances, instance)
+		}
+
+		for _, instance := range tc.excluded {
+			require.False(t, collector.includeInstance(instance), "%v: %q", tc.instances, instance)
+		}
+	}
+}
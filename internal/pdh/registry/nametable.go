@@ -18,9 +18,10 @@
 package registry
 
 import (
-	"bytes"
-	"strconv"
+	"fmt"
 	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh/registry/perflib"
 )
 
 // CounterNameTable Initialize global name tables
@@ -31,31 +32,42 @@ import (
 //nolint:gochecknoglobals
 var CounterNameTable = *QueryNameTable("Counter 009")
 
-func (p *perfObjectType) LookupName() string {
-	return CounterNameTable.LookupString(p.ObjectNameTitleIndex)
-}
+// LocalizedCounterNameTable holds the counter names in the UI language of the system.
+// On English systems, it is identical to [CounterNameTable].
+//
+//nolint:gochecknoglobals
+var LocalizedCounterNameTable = *QueryNameTable("Counter CurrentLanguage")
 
+// NameTable is a perflib name table, which is read from the registry on first use.
+// The decoding lives in the platform independent perflib package.
 type NameTable struct {
 	once sync.Once
 
 	name string
 
-	table struct {
-		index  map[uint32]string
-		string map[string]uint32
-	}
+	table *perflib.NameTable
+	err   error
 }
 
+// LookupString returns the name of the index. It returns an empty string if the table could not be read, see [NameTable.Err].
 func (t *NameTable) LookupString(index uint32) string {
 	t.initialize()
 
-	return t.table.index[index]
+	return t.table.LookupString(index)
 }
 
+// LookupIndex returns the index of the name. It returns 0 if the table could not be read, see [NameTable.Err].
 func (t *NameTable) LookupIndex(str string) uint32 {
 	t.initialize()
 
-	return t.table.string[str]
+	return t.table.LookupIndex(str)
+}
+
+// Err returns the error of reading the table from the registry.
+func (t *NameTable) Err() error {
+	t.initialize()
+
+	return t.err
 }
 
 // QueryNameTable Query a perflib name table from the v1. Specify the type and the language
@@ -66,33 +78,49 @@ func QueryNameTable(tableName string) *NameTable {
 	}
 }
 
+// initialize reads the table from the registry. If that fails, the table stays empty and the error is kept.
 func (t *NameTable) initialize() {
 	t.once.Do(func() {
-		t.table.index = make(map[uint32]string)
-		t.table.string = make(map[string]uint32)
-
 		buffer, err := queryRawData(t.name)
 		if err != nil {
-			panic(err)
+			t.err = fmt.Errorf("failed to read name table %q: %w", t.name, err)
+			t.table = perflib.ParseNameTable(nil)
+
+			return
 		}
 
-		r := bytes.NewReader(buffer)
+		t.table = perflib.ParseNameTable(buffer)
+	})
+}
 
-		for {
-			index, err := readUTF16String(r)
-			if err != nil {
-				break
-			}
+// EnglishName returns the English name of a performance object or counter.
+// The name may be given in English or in the UI language of the system.
+// Unknown names are returned unchanged.
+func EnglishName(name string) (string, error) {
+	return translateName(name, &CounterNameTable, &LocalizedCounterNameTable)
+}
 
-			desc, err := readUTF16String(r)
-			if err != nil {
-				break
-			}
+// LocalizedName returns the name of a performance object or counter in the UI language of the system.
+// The name may be given in English or in the UI language of the system.
+// Unknown names are returned unchanged.
+func LocalizedName(name string) (string, error) {
+	return translateName(name, &LocalizedCounterNameTable, &CounterNameTable)
+}
 
-			indexInt, _ := strconv.Atoi(index)
+// translateName maps a name from the source table to the target table, see [perflib.TranslateName].
+// The target table is consulted first, so the source table is only read if the name needs translation.
+func translateName(name string, target, source *NameTable) (string, error) {
+	if err := target.Err(); err != nil {
+		return name, err
+	}
 
-			t.table.index[uint32(indexInt)] = desc
-			t.table.string[desc] = uint32(indexInt)
-		}
-	})
+	if target.LookupIndex(name) != 0 {
+		return name, nil
+	}
+
+	if err := source.Err(); err != nil {
+		return name, err
+	}
+
+	return perflib.TranslateName(name, target.table, source.table), nil
 }
@@ -128,73 +128,25 @@ Data for some of the objects is also available through WMI:
 */
 
 import (
-	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
-	"io"
 	"strings"
 	"time"
 	"unsafe"
 
-	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/pdh/registry/perflib"
 	"golang.org/x/sys/windows"
 )
 
-// There's a LittleEndian field in the PERF header - we ought to check it.
-//
-//nolint:gochecknoglobals
-var bo = binary.LittleEndian
-
-// PerfObject Top-level performance object (like "Process").
-type PerfObject struct {
-	Name string
-	// NameIndex Same index you pass to QueryPerformanceData
-	NameIndex   uint
-	Instances   []*PerfInstance
-	CounterDefs []*PerfCounterDef
-
-	Frequency int64
-
-	rawData *perfObjectType
-}
-
-// PerfInstance Each object can have multiple instances. For example,
-// In case the object has no instances, we return one single PerfInstance with an empty name.
-type PerfInstance struct {
-	// *not* resolved using a name table
-	Name     string
-	Counters []*PerfCounter
-
-	rawData         *perfInstanceDefinition
-	rawCounterBlock *perfCounterBlock
-}
-
-type PerfCounterDef struct {
-	Name      string
-	NameIndex uint
-
-	// For debugging - subject to removal. CounterType is a perflib
-	// implementation detail (see perflib.h) and should not be used outside
-	// of this package. We export it so we can show it on /dump.
-	CounterType uint32
-
-	// PERF_TYPE_COUNTER (otherwise, it's a gauge)
-	IsCounter bool
-	// PERF_COUNTER_BASE (base value of a multi-value fraction)
-	IsBaseValue bool
-	// PERF_TIMER_100NS
-	IsNanosecondCounter bool
-	HasSecondValue      bool
-
-	rawData *perfCounterDefinition
-}
-
-type PerfCounter struct {
-	Value       int64
-	Def         *PerfCounterDef
-	SecondValue int64
-}
+// The decoding of the performance data blocks lives in the platform independent perflib package.
+type (
+	// PerfObject Top-level performance object (like "Process").
+	PerfObject = perflib.Object
+	// PerfInstance Each object can have multiple instances.
+	PerfInstance   = perflib.Instance
+	PerfCounterDef = perflib.CounterDef
+	PerfCounter    = perflib.Counter
+)
 
 //nolint:gochecknoglobals
 var (
@@ -298,213 +250,14 @@ func QueryPerformanceData(query string, counterName string) ([]*PerfObject, erro
 		return nil, err
 	}
 
-	r := bytes.NewReader(buffer)
-
-	// Read global header
-
-	header := new(perfDataBlock)
-
-	err = header.BinaryReadFrom(r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read performance data block for %q with: %w", query, err)
-	}
-
-	// Check for "PERF" signature
-	if header.Signature != [4]uint16{80, 69, 82, 70} {
-		panic("Invalid performance block header")
-	}
-
-	// Parse the performance data
-
-	numObjects := int(header.NumObjectTypes)
-	numFilteredObjects := 0
-
-	objects := make([]*PerfObject, numObjects)
-
-	objOffset := int64(header.HeaderLength)
-
-	for i := range numObjects {
-		_, err := r.Seek(objOffset, io.SeekStart)
-		if err != nil {
-			return nil, err
-		}
-
-		obj := new(perfObjectType)
-
-		err = obj.BinaryReadFrom(r)
-		if err != nil {
-			return nil, err
-		}
-
-		perfCounterName := obj.LookupName()
-
-		if counterName != "" && perfCounterName != counterName {
-			objOffset += int64(obj.TotalByteLength)
-
-			continue
-		}
-
-		numCounterDefs := int(obj.NumCounters)
-		numInstances := int(obj.NumInstances)
-
-		// Perf objects can have no instances. The perflib differentiates
-		// between objects with instances and without, but we just create
-		// an empty instance in order to simplify the interface.
-		if numInstances <= 0 {
-			numInstances = 1
-		}
-
-		instances := make([]*PerfInstance, numInstances)
-		counterDefs := make([]*PerfCounterDef, numCounterDefs)
-
-		objects[i] = &PerfObject{
-			Name:        perfCounterName,
-			NameIndex:   uint(obj.ObjectNameTitleIndex),
-			Instances:   instances,
-			CounterDefs: counterDefs,
-			Frequency:   obj.PerfFreq,
-			rawData:     obj,
-		}
-
-		for i := range numCounterDefs {
-			def := new(perfCounterDefinition)
-
-			err := def.BinaryReadFrom(r)
-			if err != nil {
-				return nil, err
-			}
-
-			counterDefs[i] = &PerfCounterDef{
-				Name:      def.LookupName(),
-				NameIndex: uint(def.CounterNameTitleIndex),
-				rawData:   def,
-
-				CounterType: def.CounterType,
-
-				IsCounter:           def.CounterType&0x400 == 0x400,
-				IsBaseValue:         def.CounterType&0x00030000 == 0x00030000,
-				IsNanosecondCounter: def.CounterType&0x00100000 == 0x00100000,
-				HasSecondValue:      def.CounterType == pdh.PERF_AVERAGE_BULK,
-			}
-		}
-
-		if obj.NumInstances <= 0 { //nolint:nestif
-			blockOffset := objOffset + int64(obj.DefinitionLength)
-
-			if _, err := r.Seek(blockOffset, io.SeekStart); err != nil {
-				return nil, err
-			}
-
-			_, counters, err := parseCounterBlock(buffer, r, blockOffset, counterDefs)
-			if err != nil {
-				return nil, err
-			}
-
-			instances[0] = &PerfInstance{
-				Name:            "",
-				Counters:        counters,
-				rawData:         nil,
-				rawCounterBlock: nil,
-			}
-		} else {
-			instOffset := objOffset + int64(obj.DefinitionLength)
-
-			for i := range numInstances {
-				if _, err := r.Seek(instOffset, io.SeekStart); err != nil {
-					return nil, err
-				}
-
-				inst := new(perfInstanceDefinition)
-
-				if err = inst.BinaryReadFrom(r); err != nil {
-					return nil, err
-				}
-
-				name, _ := readUTF16StringAtPos(r, instOffset+int64(inst.NameOffset), inst.NameLength)
-				pos := instOffset + int64(inst.ByteLength)
-
-				offset, counters, err := parseCounterBlock(buffer, r, pos, counterDefs)
-				if err != nil {
-					return nil, err
-				}
-
-				instances[i] = &PerfInstance{
-					Name:     name,
-					Counters: counters,
-					rawData:  inst,
-				}
-
-				instOffset = pos + offset
-			}
-		}
-
-		if counterName != "" {
-			return objects[i : i+1], nil
-		}
-
-		// Next perfObjectType
-		objOffset += int64(obj.TotalByteLength)
-		numFilteredObjects++
-	}
-
-	return objects[:numFilteredObjects], nil
-}
-
-func parseCounterBlock(b []byte, r io.ReadSeeker, pos int64, defs []*PerfCounterDef) (int64, []*PerfCounter, error) {
-	_, err := r.Seek(pos, io.SeekStart)
-	if err != nil {
-		return 0, nil, err
+	if err = CounterNameTable.Err(); err != nil {
+		return nil, err
 	}
 
-	block := new(perfCounterBlock)
-
-	err = block.BinaryReadFrom(r)
+	objects, err := perflib.Parse(buffer, CounterNameTable.LookupString, counterName)
 	if err != nil {
-		return 0, nil, err
+		return nil, fmt.Errorf("failed to parse performance data for %q: %w", query, err)
 	}
 
-	counters := make([]*PerfCounter, len(defs))
-
-	for i, def := range defs {
-		valueOffset := pos + int64(def.rawData.CounterOffset)
-		value := convertCounterValue(def.rawData, b, valueOffset)
-		secondValue := int64(0)
-
-		if def.HasSecondValue {
-			secondValue = convertCounterValue(def.rawData, b, valueOffset+8)
-		}
-
-		counters[i] = &PerfCounter{
-			Value:       value,
-			Def:         def,
-			SecondValue: secondValue,
-		}
-	}
-
-	return int64(block.ByteLength), counters, nil
-}
-
-func convertCounterValue(counterDef *perfCounterDefinition, buffer []byte, valueOffset int64) int64 {
-	/*
-		We can safely ignore the type since we're not interested in anything except the raw value.
-		We also ignore all of the other attributes (timestamp, presentation, multi counter values...)
-
-		See also: winperf.h.
-
-		Here's the most common value for CounterType:
-
-			65536	32bit counter
-			65792	64bit counter
-			272696320	32bit rate
-			272696576	64bit rate
-
-	*/
-	switch counterDef.CounterSize {
-	case 4:
-		return int64(bo.Uint32(buffer[valueOffset:(valueOffset + 4)]))
-	case 8:
-		return int64(bo.Uint64(buffer[valueOffset:(valueOffset + 8)]))
-	default:
-		return int64(bo.Uint32(buffer[valueOffset:(valueOffset + 4)]))
-	}
+	return objects, nil
 }
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perflib
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// NameTable maps the indices of the objects and counters in a perflib name table to their names and back.
+type NameTable struct {
+	index  map[uint32]string
+	string map[string]uint32
+}
+
+// ParseNameTable decodes the raw value of a name table query, e.g. "Counter 009".
+// The value is a sequence of null-terminated UTF-16 strings with alternating index and name.
+// Entries with an invalid index are skipped.
+func ParseNameTable(buffer []byte) *NameTable {
+	words := make([]uint16, len(buffer)/2)
+	for i := range words {
+		words[i] = bo.Uint16(buffer[2*i:])
+	}
+
+	fields := strings.Split(string(utf16.Decode(words)), "\x00")
+
+	table := &NameTable{
+		index:  make(map[uint32]string, len(fields)/2),
+		string: make(map[string]uint32, len(fields)/2),
+	}
+
+	for i := 0; i+1 < len(fields); i += 2 {
+		index, err := strconv.ParseUint(fields[i], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		table.index[uint32(index)] = fields[i+1]
+		table.string[fields[i+1]] = uint32(index)
+	}
+
+	return table
+}
+
+// LookupString returns the name of the index, or an empty string if the index is unknown.
+func (t *NameTable) LookupString(index uint32) string {
+	return t.index[index]
+}
+
+// LookupIndex returns the index of the name, or 0 if the name is unknown.
+// If multiple indices share the name, the last one of the table is returned.
+func (t *NameTable) LookupIndex(name string) uint32 {
+	return t.string[name]
+}
+
+// TranslateName maps a name from the source table to the target table via the shared index.
+// Names found in the target table and unknown names are returned unchanged.
+func TranslateName(name string, target, source *NameTable) string {
+	if target.LookupIndex(name) != 0 {
+		return name
+	}
+
+	index := source.LookupIndex(name)
+	if index == 0 {
+		return name
+	}
+
+	if translated := target.LookupString(index); translated != "" {
+		return translated
+	}
+
+	return name
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perflib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func loadNameTable(t *testing.T, name string) *NameTable {
+	t.Helper()
+
+	buffer, err := os.ReadFile(filepath.Join("testdata", "synthetic", name))
+	require.NoError(t, err)
+
+	return ParseNameTable(buffer)
+}
+
+func TestNameTableParse(t *testing.T) {
+	t.Parallel()
+
+	table := loadNameTable(t, "counter_007.bin")
+
+	require.Equal(t, "Arbeitsspeicher", table.LookupString(4))
+	require.Equal(t, "Verfügbare Bytes", table.LookupString(1380))
+	require.Equal(t, uint32(238), table.LookupIndex("Prozessor"))
+	require.Equal(t, uint32(0), table.LookupIndex("Memory"))
+	require.Empty(t, table.LookupString(3))
+
+	require.Empty(t, ParseNameTable(nil).LookupString(4))
+	require.Empty(t, ParseNameTable([]byte{'4'}).LookupString(4))
+}
+
+func TestTranslateName(t *testing.T) {
+	t.Parallel()
+
+	english := loadNameTable(t, "counter_009.bin")
+	localized := loadNameTable(t, "counter_007.bin")
+
+	for _, tc := range []struct {
+		name, english, localized string
+	}{
+		{name: "Arbeitsspeicher", english: "Memory", localized: "Arbeitsspeicher"},
+		{name: "Memory", english: "Memory", localized: "Arbeitsspeicher"},
+		{name: "Prozessorzeit (%)", english: "% Processor Time", localized: "Prozessorzeit (%)"},
+		{name: "System", english: "System", localized: "System"},
+		{name: "Unknown Object", english: "Unknown Object", localized: "Unknown Object"},
+	} {
+		require.Equal(t, tc.english, TranslateName(tc.name, english, localized), tc.name)
+		require.Equal(t, tc.localized, TranslateName(tc.name, localized, english), tc.name)
+	}
+}
+
+// TestNameTableCaptured checks the name tables captured on Windows hosts, see testdata/captured/README.md.
+func TestNameTableCaptured(t *testing.T) {
+	t.Parallel()
+
+	captured := capturedFixtures(t)
+	if len(captured) == 0 {
+		t.Skip("no name tables captured on a Windows host in testdata/captured")
+	}
+
+	for host, fixture := range captured {
+		require.Equal(t, "System", fixture.english.LookupString(2), host)
+		require.Equal(t, "Memory", fixture.english.LookupString(4), host)
+		require.Equal(t, "Processor", fixture.english.LookupString(238), host)
+		require.Equal(t, uint32(238), fixture.english.LookupIndex("Processor"), host)
+
+		if fixture.localized == nil {
+			continue
+		}
+
+		for _, index := range []uint32{4, 238} {
+			localized := fixture.localized.LookupString(index)
+			require.NotEmpty(t, localized, "%s: index %d", host, index)
+			require.Equal(t, fixture.english.LookupString(index), TranslateName(localized, fixture.english, fixture.localized), host)
+			require.Equal(t, localized, TranslateName(fixture.english.LookupString(index), fixture.localized, fixture.english), host)
+		}
+	}
+}
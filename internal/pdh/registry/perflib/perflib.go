@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package perflib decodes the performance data blocks and the name tables returned by the HKEY_PERFORMANCE_DATA registry key.
+//
+// The package has no platform dependencies, so the decoder can be tested and fuzzed on any platform.
+// The blocks are untrusted input: malformed blocks result in an error wrapping [ErrMalformed].
+package perflib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+)
+
+// perfAverageBulk is the PERF_AVERAGE_BULK counter type. The value is followed by its base value.
+const perfAverageBulk = 0x40020500
+
+//nolint:gochecknoglobals
+var bo = binary.LittleEndian
+
+var (
+	ErrInvalidSignature = errors.New("invalid performance data block signature")
+	ErrMalformed        = errors.New("malformed performance data block")
+)
+
+// NameLookup resolves an object or counter name from its index in the counter name table.
+type NameLookup func(index uint32) string
+
+// Object Top-level performance object (like "Process").
+type Object struct {
+	Name string
+	// NameIndex Same index you pass to QueryPerformanceData
+	NameIndex   uint
+	Instances   []*Instance
+	CounterDefs []*CounterDef
+
+	Frequency int64
+}
+
+// Instance Each object can have multiple instances. For example,
+// In case the object has no instances, we return one single Instance with an empty name.
+type Instance struct {
+	// *not* resolved using a name table
+	Name     string
+	Counters []*Counter
+}
+
+type CounterDef struct {
+	Name      string
+	NameIndex uint
+
+	// For debugging - subject to removal. CounterType is a perflib
+	// implementation detail (see perflib.h) and should not be used outside
+	// of this package. We export it so we can show it on /dump.
+	CounterType uint32
+
+	// PERF_TYPE_COUNTER (otherwise, it's a gauge)
+	IsCounter bool
+	// PERF_COUNTER_BASE (base value of a multi-value fraction)
+	IsBaseValue bool
+	// PERF_TIMER_100NS
+	IsNanosecondCounter bool
+	HasSecondValue      bool
+
+	rawData *counterDefinition
+}
+
+type Counter struct {
+	Value       int64
+	Def         *CounterDef
+	SecondValue int64
+}
+
+// Parse decodes a performance data block. Names are resolved with lookupName.
+// If objectName is not empty, only the first object with that name is decoded and returned.
+func Parse(buffer []byte, lookupName NameLookup, objectName string) ([]*Object, error) {
+	header := new(dataBlock)
+
+	if err := readStruct(buffer, 0, header); err != nil {
+		return nil, fmt.Errorf("failed to read performance data block: %w", err)
+	}
+
+	// Check for "PERF" signature
+	if header.Signature != [4]uint16{80, 69, 82, 70} {
+		return nil, ErrInvalidSignature
+	}
+
+	numObjects := int(header.NumObjectTypes)
+	if numObjects > len(buffer)/objectTypeSize {
+		return nil, fmt.Errorf("%w: %d objects exceed the block size of %d bytes", ErrMalformed, numObjects, len(buffer))
+	}
+
+	objects := make([]*Object, 0, numObjects)
+	objOffset := int64(header.HeaderLength)
+
+	for range numObjects {
+		obj := new(objectType)
+
+		if err := readStruct(buffer, objOffset, obj); err != nil {
+			return nil, fmt.Errorf("object at offset %d: %w", objOffset, err)
+		}
+
+		if obj.TotalByteLength < uint32(objectTypeSize) || obj.DefinitionLength < uint32(objectTypeSize) {
+			return nil, fmt.Errorf("%w: object at offset %d is shorter than its header", ErrMalformed, objOffset)
+		}
+
+		name := lookupName(obj.ObjectNameTitleIndex)
+
+		if objectName != "" && name != objectName {
+			objOffset += int64(obj.TotalByteLength)
+
+			continue
+		}
+
+		object, err := parseObject(buffer, objOffset, obj, name, lookupName)
+		if err != nil {
+			return nil, fmt.Errorf("object %q at offset %d: %w", name, objOffset, err)
+		}
+
+		if objectName != "" {
+			return []*Object{object}, nil
+		}
+
+		objects = append(objects, object)
+		objOffset += int64(obj.TotalByteLength)
+	}
+
+	return objects, nil
+}
+
+func parseObject(buffer []byte, objOffset int64, obj *objectType, name string, lookupName NameLookup) (*Object, error) {
+	numCounterDefs := int(obj.NumCounters)
+	if numCounterDefs > len(buffer)/counterDefinitionSize {
+		return nil, fmt.Errorf("%w: %d counters exceed the block size", ErrMalformed, numCounterDefs)
+	}
+
+	counterDefs := make([]*CounterDef, numCounterDefs)
+	defOffset := objOffset + int64(obj.HeaderLength)
+
+	for i := range numCounterDefs {
+		def := new(counterDefinition)
+
+		if err := readStruct(buffer, defOffset, def); err != nil {
+			return nil, fmt.Errorf("counter definition at offset %d: %w", defOffset, err)
+		}
+
+		if def.ByteLength < uint32(counterDefinitionSize) {
+			return nil, fmt.Errorf("%w: counter definition at offset %d is shorter than its header", ErrMalformed, defOffset)
+		}
+
+		counterDefs[i] = &CounterDef{
+			Name:      lookupName(def.CounterNameTitleIndex),
+			NameIndex: uint(def.CounterNameTitleIndex),
+			rawData:   def,
+
+			CounterType: def.CounterType,
+
+			IsCounter:           def.CounterType&0x400 == 0x400,
+			IsBaseValue:         def.CounterType&0x00030000 == 0x00030000,
+			IsNanosecondCounter: def.CounterType&0x00100000 == 0x00100000,
+			HasSecondValue:      def.CounterType == perfAverageBulk,
+		}
+
+		defOffset += int64(def.ByteLength)
+	}
+
+	object := &Object{
+		Name:        name,
+		NameIndex:   uint(obj.ObjectNameTitleIndex),
+		CounterDefs: counterDefs,
+		Frequency:   obj.PerfFreq,
+	}
+
+	// Perf objects can have no instances. The perflib differentiates
+	// between objects with instances and without, but we just create
+	// an empty instance in order to simplify the interface.
+	if obj.NumInstances <= 0 {
+		_, counters, err := parseCounterBlock(buffer, objOffset+int64(obj.DefinitionLength), counterDefs)
+		if err != nil {
+			return nil, err
+		}
+
+		object.Instances = []*Instance{{Name: "", Counters: counters}}
+
+		return object, nil
+	}
+
+	numInstances := int(obj.NumInstances)
+	if numInstances > len(buffer)/(instanceDefinitionSize+counterBlockSize) {
+		return nil, fmt.Errorf("%w: %d instances exceed the block size", ErrMalformed, numInstances)
+	}
+
+	object.Instances = make([]*Instance, numInstances)
+	instOffset := objOffset + int64(obj.DefinitionLength)
+
+	for i := range numInstances {
+		inst := new(instanceDefinition)
+
+		if err := readStruct(buffer, instOffset, inst); err != nil {
+			return nil, fmt.Errorf("instance at offset %d: %w", instOffset, err)
+		}
+
+		if inst.ByteLength < uint32(instanceDefinitionSize) {
+			return nil, fmt.Errorf("%w: instance at offset %d is shorter than its header", ErrMalformed, instOffset)
+		}
+
+		name, err := readUTF16String(buffer, instOffset+int64(inst.NameOffset), inst.NameLength)
+		if err != nil {
+			return nil, fmt.Errorf("instance name at offset %d: %w", instOffset, err)
+		}
+
+		pos := instOffset + int64(inst.ByteLength)
+
+		blockLength, counters, err := parseCounterBlock(buffer, pos, counterDefs)
+		if err != nil {
+			return nil, fmt.Errorf("instance %q: %w", name, err)
+		}
+
+		object.Instances[i] = &Instance{
+			Name:     name,
+			Counters: counters,
+		}
+
+		instOffset = pos + blockLength
+	}
+
+	return object, nil
+}
+
+func parseCounterBlock(buffer []byte, pos int64, defs []*CounterDef) (int64, []*Counter, error) {
+	block := new(counterBlock)
+
+	if err := readStruct(buffer, pos, block); err != nil {
+		return 0, nil, fmt.Errorf("counter block at offset %d: %w", pos, err)
+	}
+
+	if block.ByteLength < uint32(counterBlockSize) {
+		return 0, nil, fmt.Errorf("%w: counter block at offset %d is shorter than its header", ErrMalformed, pos)
+	}
+
+	counters := make([]*Counter, len(defs))
+
+	for i, def := range defs {
+		valueOffset := pos + int64(def.rawData.CounterOffset)
+
+		value, err := readCounterValue(def.rawData, buffer, valueOffset)
+		if err != nil {
+			return 0, nil, fmt.Errorf("counter %q: %w", def.Name, err)
+		}
+
+		secondValue := int64(0)
+
+		if def.HasSecondValue {
+			if secondValue, err = readCounterValue(def.rawData, buffer, valueOffset+8); err != nil {
+				return 0, nil, fmt.Errorf("counter %q: %w", def.Name, err)
+			}
+		}
+
+		counters[i] = &Counter{
+			Value:       value,
+			Def:         def,
+			SecondValue: secondValue,
+		}
+	}
+
+	return int64(block.ByteLength), counters, nil
+}
+
+func readCounterValue(counterDef *counterDefinition, buffer []byte, valueOffset int64) (int64, error) {
+	/*
+		We can safely ignore the type since we're not interested in anything except the raw value.
+		We also ignore all of the other attributes (timestamp, presentation, multi counter values...)
+
+		See also: winperf.h.
+
+		Here's the most common value for CounterType:
+
+			65536	32bit counter
+			65792	64bit counter
+			272696320	32bit rate
+			272696576	64bit rate
+	*/
+	size := int64(4)
+	if counterDef.CounterSize == 8 {
+		size = 8
+	}
+
+	if valueOffset+size > int64(len(buffer)) {
+		return 0, fmt.Errorf("%w: value at offset %d exceeds the block size", ErrMalformed, valueOffset)
+	}
+
+	if size == 8 {
+		return int64(bo.Uint64(buffer[valueOffset : valueOffset+8])), nil
+	}
+
+	return int64(bo.Uint32(buffer[valueOffset : valueOffset+4])), nil
+}
+
+// readStruct decodes the fixed-length structure v at the given offset.
+func readStruct(buffer []byte, offset int64, v any) error {
+	if offset+int64(binary.Size(v)) > int64(len(buffer)) {
+		return fmt.Errorf("%w: %d bytes at offset %d exceed the block size of %d bytes", ErrMalformed, binary.Size(v), offset, len(buffer))
+	}
+
+	return binary.Read(bytes.NewReader(buffer[offset:]), bo, v)
+}
+
+// readUTF16String reads a UTF-16 string of the given length in bytes, terminated by the first null character.
+func readUTF16String(buffer []byte, offset int64, length uint32) (string, error) {
+	if offset+int64(length) > int64(len(buffer)) {
+		return "", fmt.Errorf("%w: string at offset %d exceeds the block size", ErrMalformed, offset)
+	}
+
+	value := make([]uint16, length/2)
+	for i := range value {
+		value[i] = bo.Uint16(buffer[offset+int64(2*i):])
+	}
+
+	for i, v := range value {
+		if v == 0 {
+			value = value[:i]
+
+			break
+		}
+	}
+
+	return string(utf16.Decode(value)), nil
+}
@@ -0,0 +1,490 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perflib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var update = flag.Bool("update", false, "rewrite the fixtures in testdata/synthetic")
+
+const (
+	perf100nsecTimer = 0x20510500
+	perfCounterBulk  = 0x10410500
+	perfRawFraction  = 0x20020400
+	perfRawBase      = 0x40030403
+	perfRawcount     = 0x00010000
+	perfAverageBase  = 0x40030402
+)
+
+//nolint:gochecknoglobals
+var testNames = map[uint32]string{
+	2:    "System",
+	238:  "Processor",
+	236:  "LogicalDisk",
+	6:    "% Processor Time",
+	148:  "Interrupts/sec",
+	248:  "Processes",
+	250:  "Threads",
+	408:  "% Free Space",
+	1970: "% Processor Utility",
+	1972: "% Processor Utility Base",
+}
+
+func testLookup(index uint32) string {
+	return testNames[index]
+}
+
+type testCounter struct {
+	index       uint32
+	counterType uint32
+}
+
+type testInstance struct {
+	name   string
+	values []int64
+}
+
+type testObject struct {
+	index uint32
+	// size is the size of all counter values, either 4 or 8 bytes.
+	size      uint32
+	counters  []testCounter
+	instances []testInstance
+	// values are used for objects without instances.
+	values []int64
+}
+
+// buildBlock encodes the objects in the layout of the blocks returned by HKEY_PERFORMANCE_DATA.
+func buildBlock(tb testing.TB, objects ...testObject) []byte {
+	tb.Helper()
+
+	var body bytes.Buffer
+
+	for _, object := range objects {
+		body.Write(buildObject(tb, object))
+	}
+
+	header := dataBlock{
+		Signature:       [4]uint16{'P', 'E', 'R', 'F'},
+		LittleEndian:    1,
+		Version:         1,
+		Revision:        1,
+		HeaderLength:    uint32(dataBlockSize),
+		NumObjectTypes:  uint32(len(objects)),
+		DefaultObject:   -1,
+		PerfTime:        1234567890,
+		PerfFreq:        10000000,
+		PerfTime100nSec: 133800000000000000,
+	}
+	header.TotalByteLength = uint32(dataBlockSize + body.Len())
+
+	var block bytes.Buffer
+
+	require.NoError(tb, binary.Write(&block, bo, header))
+	block.Write(body.Bytes())
+
+	return block.Bytes()
+}
+
+func buildObject(tb testing.TB, object testObject) []byte {
+	tb.Helper()
+
+	var (
+		defs   bytes.Buffer
+		data   bytes.Buffer
+		offset = uint32(8)
+	)
+
+	for _, counter := range object.counters {
+		def := counterDefinition{
+			ByteLength:            uint32(counterDefinitionSize),
+			CounterNameTitleIndex: counter.index,
+			CounterType:           counter.counterType,
+			CounterSize:           object.size,
+			CounterOffset:         offset,
+		}
+
+		require.NoError(tb, binary.Write(&defs, bo, def))
+
+		offset += object.size
+		if counter.counterType == perfAverageBulk {
+			offset += object.size
+		}
+	}
+
+	counterBlockLength := (offset + 7) &^ 7
+
+	writeCounterBlock := func(values []int64) {
+		start := data.Len()
+
+		require.NoError(tb, binary.Write(&data, bo, counterBlock{ByteLength: counterBlockLength}))
+		data.Write(make([]byte, 4))
+
+		for _, value := range values {
+			if object.size == 4 {
+				require.NoError(tb, binary.Write(&data, bo, uint32(value)))
+			} else {
+				require.NoError(tb, binary.Write(&data, bo, value))
+			}
+		}
+
+		data.Write(make([]byte, int(counterBlockLength)-(data.Len()-start)))
+	}
+
+	numInstances := int32(-1)
+
+	if object.instances == nil {
+		writeCounterBlock(object.values)
+	} else {
+		numInstances = int32(len(object.instances))
+
+		for _, instance := range object.instances {
+			name := utf16.Encode([]rune(instance.name + "\x00"))
+			nameLength := uint32(2 * len(name))
+			byteLength := (uint32(instanceDefinitionSize) + nameLength + 7) &^ 7
+
+			require.NoError(tb, binary.Write(&data, bo, instanceDefinition{
+				ByteLength: byteLength,
+				NameOffset: uint32(instanceDefinitionSize),
+				NameLength: nameLength,
+			}))
+			require.NoError(tb, binary.Write(&data, bo, name))
+			data.Write(make([]byte, int(byteLength-uint32(instanceDefinitionSize)-nameLength)))
+
+			writeCounterBlock(instance.values)
+		}
+	}
+
+	definitionLength := uint32(objectTypeSize + defs.Len())
+
+	var buf bytes.Buffer
+
+	require.NoError(tb, binary.Write(&buf, bo, objectType{
+		TotalByteLength:      definitionLength + uint32(data.Len()),
+		DefinitionLength:     definitionLength,
+		HeaderLength:         uint32(objectTypeSize),
+		ObjectNameTitleIndex: object.index,
+		NumCounters:          uint32(len(object.counters)),
+		DefaultCounter:       -1,
+		NumInstances:         numInstances,
+		PerfFreq:             10000000,
+	}))
+	buf.Write(defs.Bytes())
+	buf.Write(data.Bytes())
+
+	return buf.Bytes()
+}
+
+// testBlocks returns the fixtures of [TestUpdateFixtures].
+func testBlocks(tb testing.TB) map[string][]byte {
+	tb.Helper()
+
+	return map[string][]byte{
+		"system.bin": buildBlock(tb, testObject{
+			index: 2,
+			size:  4,
+			counters: []testCounter{
+				{index: 248, counterType: perfRawcount},
+				{index: 250, counterType: perfRawcount},
+			},
+			values: []int64{312, 4821},
+		}),
+		"processor.bin": buildBlock(tb, testObject{
+			index: 238,
+			size:  8,
+			counters: []testCounter{
+				{index: 6, counterType: perf100nsecTimer},
+				{index: 148, counterType: perfCounterBulk},
+				{index: 1970, counterType: perfAverageBulk},
+			},
+			instances: []testInstance{
+				{name: "0", values: []int64{1000000000, 52000, 700, 1000}},
+				{name: "1", values: []int64{2000000000, 41000, 300, 1000}},
+				{name: "_Total", values: []int64{3000000000, 93000, 1000, 2000}},
+			},
+		}),
+		"logicaldisk.bin": buildBlock(tb,
+			testObject{
+				index:    2,
+				size:     4,
+				counters: []testCounter{{index: 248, counterType: perfRawcount}},
+				values:   []int64{312},
+			},
+			testObject{
+				index: 236,
+				size:  4,
+				counters: []testCounter{
+					{index: 408, counterType: perfRawFraction},
+					{index: 408, counterType: perfRawBase},
+				},
+				instances: []testInstance{
+					{name: "C:", values: []int64{52000, 121000}},
+					{name: "HarddiskVolume1", values: []int64{90, 500}},
+				},
+			},
+		),
+	}
+}
+
+// fixtures returns the performance data blocks in testdata/synthetic.
+// They are encoded by [buildBlock] and cover the edge cases checked by the tests below.
+// Blocks captured on Windows hosts are returned by [capturedFixtures].
+func fixtures(tb testing.TB) map[string][]byte {
+	tb.Helper()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "synthetic", "*.bin"))
+	require.NoError(tb, err)
+	require.NotEmpty(tb, files)
+
+	blocks := make(map[string][]byte, len(files))
+
+	for _, file := range files {
+		// Name tables are stored next to the blocks, see [loadNameTable].
+		if strings.HasPrefix(filepath.Base(file), "counter_") {
+			continue
+		}
+
+		block, err := os.ReadFile(file)
+		require.NoError(tb, err)
+
+		blocks[filepath.Base(file)] = block
+	}
+
+	return blocks
+}
+
+// capturedFixture is a performance data block read from HKEY_PERFORMANCE_DATA on a Windows host,
+// together with the name tables of the same host.
+type capturedFixture struct {
+	block   []byte
+	english *NameTable
+	// localized is the name table in the UI language of the host, nil if not captured.
+	localized *NameTable
+}
+
+// capturedFixtures returns the blocks in testdata/captured, keyed by the directory of the host they were captured on.
+// They are written by TestCaptureFixtures of the registry package, see testdata/captured/README.md.
+func capturedFixtures(tb testing.TB) map[string]capturedFixture {
+	tb.Helper()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "captured", "*", "perfdata.bin"))
+	require.NoError(tb, err)
+
+	captured := make(map[string]capturedFixture, len(files))
+
+	for _, file := range files {
+		dir := filepath.Dir(file)
+
+		block, err := os.ReadFile(file)
+		require.NoError(tb, err)
+
+		english, err := os.ReadFile(filepath.Join(dir, "counter_009.bin"))
+		require.NoError(tb, err)
+
+		fixture := capturedFixture{
+			block:   block,
+			english: ParseNameTable(english),
+		}
+
+		if localized, err := os.ReadFile(filepath.Join(dir, "counter_current.bin")); err == nil {
+			fixture.localized = ParseNameTable(localized)
+		}
+
+		captured[filepath.Base(dir)] = fixture
+	}
+
+	return captured
+}
+
+// TestUpdateFixtures rewrites the fixtures in testdata/synthetic with -update. They use the layout of the blocks returned by HKEY_PERFORMANCE_DATA.
+//
+//nolint:paralleltest // The fixtures must be written before the other tests read them.
+func TestUpdateFixtures(t *testing.T) {
+	if !*update {
+		t.Skip("run with -update to rewrite the fixtures")
+	}
+
+	for name, block := range testBlocks(t) {
+		require.NoError(t, os.WriteFile(filepath.Join("testdata", "synthetic", name), block, 0o644))
+	}
+}
+
+func TestParseFixtures(t *testing.T) {
+	t.Parallel()
+
+	for name, block := range fixtures(t) {
+		objects, err := Parse(block, testLookup, "")
+		require.NoError(t, err, name)
+		require.NotEmpty(t, objects, name)
+	}
+}
+
+// TestParseCaptured checks the invariants every block returned by Windows must hold.
+// Values of captured blocks differ from host to host, so only the structure is checked.
+func TestParseCaptured(t *testing.T) {
+	t.Parallel()
+
+	captured := capturedFixtures(t)
+	if len(captured) == 0 {
+		t.Skip("no blocks captured on a Windows host in testdata/captured")
+	}
+
+	for host, fixture := range captured {
+		objects, err := Parse(fixture.block, fixture.english.LookupString, "")
+		require.NoError(t, err, host)
+		require.NotEmpty(t, objects, host)
+
+		for _, object := range objects {
+			require.NotEmpty(t, object.Name, "%s: object %d", host, object.NameIndex)
+			require.NotEmpty(t, object.Instances, "%s: %s", host, object.Name)
+
+			for _, instance := range object.Instances {
+				require.Len(t, instance.Counters, len(object.CounterDefs), "%s: %s(%s)", host, object.Name, instance.Name)
+			}
+		}
+
+		// The System object is queried by TestCaptureFixtures and never has instances.
+		objects, err = Parse(fixture.block, fixture.english.LookupString, "System")
+		require.NoError(t, err, host)
+		require.Len(t, objects, 1, host)
+		require.Len(t, objects[0].Instances, 1, host)
+		require.Empty(t, objects[0].Instances[0].Name, host)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	blocks := fixtures(t)
+
+	objects, err := Parse(blocks["system.bin"], testLookup, "")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.Equal(t, "System", objects[0].Name)
+	require.Len(t, objects[0].Instances, 1)
+	require.Empty(t, objects[0].Instances[0].Name)
+	require.Equal(t, "Threads", objects[0].Instances[0].Counters[1].Def.Name)
+	require.Equal(t, int64(4821), objects[0].Instances[0].Counters[1].Value)
+
+	objects, err = Parse(blocks["processor.bin"], testLookup, "Processor")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.Len(t, objects[0].Instances, 3)
+
+	instance := objects[0].Instances[1]
+	require.Equal(t, "1", instance.Name)
+	require.True(t, instance.Counters[0].Def.IsNanosecondCounter)
+	require.Equal(t, int64(2000000000), instance.Counters[0].Value)
+	require.True(t, instance.Counters[1].Def.IsCounter)
+	require.Equal(t, int64(41000), instance.Counters[1].Value)
+	require.True(t, instance.Counters[2].Def.HasSecondValue)
+	require.Equal(t, int64(300), instance.Counters[2].Value)
+	require.Equal(t, int64(1000), instance.Counters[2].SecondValue)
+
+	objects, err = Parse(blocks["logicaldisk.bin"], testLookup, "LogicalDisk")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.Equal(t, "HarddiskVolume1", objects[0].Instances[1].Name)
+	require.True(t, objects[0].Instances[1].Counters[1].Def.IsBaseValue)
+	require.Equal(t, int64(500), objects[0].Instances[1].Counters[1].Value)
+
+	objects, err = Parse(blocks["logicaldisk.bin"], testLookup, "Memory")
+	require.NoError(t, err)
+	require.Empty(t, objects)
+}
+
+func TestParseMalformed(t *testing.T) {
+	t.Parallel()
+
+	block := fixtures(t)["processor.bin"]
+
+	_, err := Parse(nil, testLookup, "")
+	require.ErrorIs(t, err, ErrMalformed)
+
+	invalidSignature := bytes.Clone(block)
+	invalidSignature[0] = 'X'
+
+	_, err = Parse(invalidSignature, testLookup, "")
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	for _, length := range []int{dataBlockSize, dataBlockSize + objectTypeSize, len(block) - 1} {
+		_, err = Parse(block[:length], testLookup, "")
+		require.ErrorIs(t, err, ErrMalformed, "truncated to %d bytes", length)
+	}
+
+	// NumObjectTypes must not result in an allocation larger than the block.
+	tooManyObjects := bytes.Clone(block)
+	bo.PutUint32(tooManyObjects[28:], 0xFFFFFFFF)
+
+	_, err = Parse(tooManyObjects, testLookup, "")
+	require.ErrorIs(t, err, ErrMalformed)
+}
+
+func FuzzParse(f *testing.F) {
+	for _, fixture := range capturedFixtures(f) {
+		f.Add(fixture.block)
+	}
+
+	for _, block := range fixtures(f) {
+		f.Add(block)
+		f.Add(block[:len(block)/2])
+		f.Add(block[:dataBlockSize])
+	}
+
+	f.Fuzz(func(_ *testing.T, block []byte) {
+		_, _ = Parse(block, testLookup, "")
+		_, _ = Parse(block, testLookup, "Processor")
+	})
+}
+
+// FuzzParseOffsets overwrites single fields of valid blocks, which covers out of range and overlapping offsets and lengths.
+func FuzzParseOffsets(f *testing.F) {
+	blocks := fixtures(f)
+	names := make([]string, 0, len(blocks))
+
+	for name, block := range blocks {
+		names = append(names, name)
+
+		for pos := 0; pos+4 <= len(block); pos += 4 {
+			f.Add(uint8(len(names)-1), uint16(pos), uint32(0))
+		}
+
+		f.Add(uint8(len(names)-1), uint16(dataBlockSize), uint32(0xFFFFFFFF))
+		f.Add(uint8(len(names)-1), uint16(dataBlockSize+4), uint32(1))
+	}
+
+	f.Fuzz(func(t *testing.T, index uint8, pos uint16, value uint32) {
+		block := bytes.Clone(blocks[names[int(index)%len(names)]])
+		if int(pos)+4 > len(block) {
+			t.Skip()
+		}
+
+		bo.PutUint32(block[pos:], value)
+
+		_, _ = Parse(block, testLookup, "")
+	})
+}
@@ -13,19 +13,37 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build windows
-
-package registry
+package perflib
 
 import (
 	"encoding/binary"
-	"io"
+)
 
-	"golang.org/x/sys/windows"
+// Sizes of the fixed-length structures, as encoded in the performance data block.
+//
+//nolint:gochecknoglobals
+var (
+	dataBlockSize          = binary.Size(dataBlock{})
+	objectTypeSize         = binary.Size(objectType{})
+	counterDefinitionSize  = binary.Size(counterDefinition{})
+	counterBlockSize       = binary.Size(counterBlock{})
+	instanceDefinitionSize = binary.Size(instanceDefinition{})
 )
 
+// systemTime is the layout of the Windows SYSTEMTIME structure.
+type systemTime struct {
+	Year         uint16
+	Month        uint16
+	DayOfWeek    uint16
+	Day          uint16
+	Hour         uint16
+	Minute       uint16
+	Second       uint16
+	Milliseconds uint16
+}
+
 /*
-perfDataBlock
+dataBlock
 See: https://msdn.microsoft.com/de-de/library/windows/desktop/aa373157(v=vs.85).aspx
 
 	typedef struct _PERF_DATA_BLOCK {
@@ -45,7 +63,7 @@ See: https://msdn.microsoft.com/de-de/library/windows/desktop/aa373157(v=vs.85).
 	  DWORD         SystemNameOffset;
 	} PERF_DATA_BLOCK;
 */
-type perfDataBlock struct {
+type dataBlock struct {
 	Signature        [4]uint16
 	LittleEndian     uint32
 	Version          uint32
@@ -54,7 +72,7 @@ type perfDataBlock struct {
 	HeaderLength     uint32
 	NumObjectTypes   uint32
 	DefaultObject    int32
-	SystemTime       windows.Systemtime
+	SystemTime       systemTime
 	_                uint32 // unknown field
 	PerfTime         int64
 	PerfFreq         int64
@@ -63,12 +81,8 @@ type perfDataBlock struct {
 	SystemNameOffset uint32
 }
 
-func (p *perfDataBlock) BinaryReadFrom(r io.Reader) error {
-	return binary.Read(r, bo, p)
-}
-
 /*
-perfObjectType
+objectType
 See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa373160(v=vs.85).aspx
 
 	typedef struct _PERF_OBJECT_TYPE {
@@ -88,7 +102,7 @@ See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa373160(v=vs.85).
 	  LARGE_INTEGER PerfFreq;
 	} PERF_OBJECT_TYPE;
 */
-type perfObjectType struct {
+type objectType struct {
 	TotalByteLength      uint32
 	DefinitionLength     uint32
 	HeaderLength         uint32
@@ -105,12 +119,8 @@ type perfObjectType struct {
 	PerfFreq             int64
 }
 
-func (p *perfObjectType) BinaryReadFrom(r io.Reader) error {
-	return binary.Read(r, bo, p)
-}
-
 /*
-perfCounterDefinition
+counterDefinition
 See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa373150(v=vs.85).aspx
 
 	typedef struct _PERF_COUNTER_DEFINITION {
@@ -126,7 +136,7 @@ See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa373150(v=vs.85).
 	  DWORD  CounterOffset;
 	} PERF_COUNTER_DEFINITION;
 */
-type perfCounterDefinition struct {
+type counterDefinition struct {
 	ByteLength            uint32
 	CounterNameTitleIndex uint32
 	CounterNameTitle      uint32
@@ -139,32 +149,20 @@ type perfCounterDefinition struct {
 	CounterOffset         uint32
 }
 
-func (p *perfCounterDefinition) BinaryReadFrom(r io.Reader) error {
-	return binary.Read(r, bo, p)
-}
-
-func (p *perfCounterDefinition) LookupName() string {
-	return CounterNameTable.LookupString(p.CounterNameTitleIndex)
-}
-
 /*
-perfCounterBlock
+counterBlock
 See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa373147(v=vs.85).aspx
 
 	typedef struct _PERF_COUNTER_BLOCK {
 	  DWORD ByteLength;
 	} PERF_COUNTER_BLOCK;
 */
-type perfCounterBlock struct {
+type counterBlock struct {
 	ByteLength uint32
 }
 
-func (p *perfCounterBlock) BinaryReadFrom(r io.Reader) error {
-	return binary.Read(r, bo, p)
-}
-
 /*
-perfInstanceDefinition
+instanceDefinition
 See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa373159(v=vs.85).aspx
 
 	typedef struct _PERF_INSTANCE_DEFINITION {
@@ -176,7 +174,7 @@ See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa373159(v=vs.85).
 	  DWORD NameLength;
 	} PERF_INSTANCE_DEFINITION;
 */
-type perfInstanceDefinition struct {
+type instanceDefinition struct {
 	ByteLength             uint32
 	ParentObjectTitleIndex uint32
 	ParentObjectInstance   uint32
@@ -184,7 +182,3 @@ type perfInstanceDefinition struct {
 	NameOffset             uint32
 	NameLength             uint32
 }
-
-func (p *perfInstanceDefinition) BinaryReadFrom(r io.Reader) error {
-	return binary.Read(r, bo, p)
-}
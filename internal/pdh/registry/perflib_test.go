@@ -18,11 +18,52 @@
 package registry
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
 )
 
+//nolint:gochecknoglobals
+var capture = flag.Bool("capture", false, "capture the performance data of this host into perflib/testdata/captured")
+
+// captureQuery holds the indices of the System, Memory, LogicalDisk and Processor objects.
+const captureQuery = "2 4 236 238"
+
 func BenchmarkQueryPerformanceData(b *testing.B) {
 	for b.Loop() {
 		_, _ = QueryPerformanceData("Global", "")
 	}
 }
+
+// TestCaptureFixtures writes the raw performance data block, the English counter name table
+// and the counter name table in the UI language of this host to perflib/testdata/captured/windows-<build> with -capture.
+//
+//nolint:paralleltest // The fixtures are written to the source tree.
+func TestCaptureFixtures(t *testing.T) {
+	if !*capture {
+		t.Skip("run with -capture to capture the performance data of this host")
+	}
+
+	version := windows.RtlGetVersion()
+	dir := filepath.Join("perflib", "testdata", "captured", fmt.Sprintf("windows-%d", version.BuildNumber))
+
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	block, err := queryRawData(captureQuery)
+	require.NoError(t, err)
+
+	nameTable, err := queryRawData("Counter 009")
+	require.NoError(t, err)
+
+	localizedNameTable, err := queryRawData("Counter CurrentLanguage")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "perfdata.bin"), block, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "counter_009.bin"), nameTable, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "counter_current.bin"), localizedNameTable, 0o644))
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdh
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus-community/windows_exporter/internal/replay"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+//nolint:gochecknoglobals
+var (
+	recorder atomic.Pointer[replay.Recorder]
+	replayer atomic.Pointer[replay.Replayer]
+)
+
+// SetRecorder records the raw values read by all collectors created afterward.
+// Passing nil disables recording for new collectors.
+func SetRecorder(r *replay.Recorder) {
+	recorder.Store(r)
+}
+
+// SetReplayer makes all collectors created afterward return the values of the recording
+// instead of querying PDH, see [ReplayCollector]. Passing nil restores the PDH backend for new collectors.
+func SetReplayer(r *replay.Replayer) {
+	replayer.Store(r)
+}
+
+// ReplayCollector reads the counters of a performance counter object from a recording instead of PDH.
+// The recorded raw values are converted like by a [Collector] of the same result type,
+// so the conversion logic of collectors can be tested against recordings on any platform.
+// Formatted values are calculated from the raw values, see [CounterTypeCalculated].
+type ReplayCollector struct {
+	mu       sync.Mutex
+	cursor   *replay.PDHCursor
+	counters map[string]Counter
+
+	nameIndexValue        int
+	metricsTypeIndexValue int
+	totalCounterRequested bool
+
+	calculator *calculator
+}
+
+func NewReplayCollector[T any](r *replay.Replayer, resultType CounterType, object string, instances []string) (*ReplayCollector, error) {
+	return NewReplayCollectorWithReflection(r, resultType, object, instances, reflect.TypeFor[T]())
+}
+
+func NewReplayCollectorWithReflection(r *replay.Replayer, resultType CounterType, object string, instances []string, valueType reflect.Type) (*ReplayCollector, error) {
+	if resultType != CounterTypeRaw && resultType != CounterTypeFormatted && resultType != CounterTypeCalculated {
+		return nil, fmt.Errorf("invalid result type: %v", resultType)
+	}
+
+	collector := &ReplayCollector{
+		counters:              make(map[string]Counter, valueType.NumField()),
+		nameIndexValue:        -1,
+		metricsTypeIndexValue: -1,
+		totalCounterRequested: slices.Contains(instances, InstanceTotal),
+	}
+
+	if resultType != CounterTypeRaw {
+		collector.calculator = newCalculator()
+	}
+
+	if f, ok := valueType.FieldByName("Name"); ok && f.Type.Kind() == reflect.String {
+		collector.nameIndexValue = f.Index[0]
+	}
+
+	if f, ok := valueType.FieldByName("MetricType"); ok && f.Type.Kind() == reflect.TypeOf(prometheus.ValueType(0)).Kind() {
+		collector.metricsTypeIndexValue = f.Index[0]
+	}
+
+	for _, f := range reflect.VisibleFields(valueType) {
+		counterName, ok := f.Tag.Lookup("perfdata")
+		if !ok {
+			continue
+		}
+
+		secondValue := strings.HasSuffix(counterName, ",secondvalue")
+		counterName = strings.TrimSuffix(counterName, ",secondvalue")
+
+		counter, ok := collector.counters[counterName]
+		if !ok {
+			counter = Counter{
+				Name:                  counterName,
+				FieldIndexValue:       -1,
+				FieldIndexSecondValue: -1,
+			}
+		}
+
+		if secondValue {
+			counter.FieldIndexSecondValue = f.Index[0]
+		} else {
+			counter.FieldIndexValue = f.Index[0]
+		}
+
+		collector.counters[counterName] = counter
+	}
+
+	if len(collector.counters) == 0 {
+		return nil, errors.New("no counters configured")
+	}
+
+	cursor, err := r.PDH(object, slices.Collect(maps.Keys(collector.counters)))
+	if err != nil {
+		return nil, err
+	}
+
+	collector.cursor = cursor
+
+	for name, recorded := range cursor.Counters() {
+		counter := collector.counters[name]
+		counter.Type = recorded.Type
+		counter.Frequency = recorded.Frequency
+		counter.MetricType = rawMetricType(counter)
+
+		if collector.calculator != nil && counter.Type != 0 && !isCalculatedCounterType(counter.Type) {
+			return nil, fmt.Errorf("counter %s: calculating the value of counter type 0x%08x is not supported", name, counter.Type)
+		}
+
+		collector.counters[name] = counter
+	}
+
+	// Consume the initial sample, the same way the PDH backend does.
+	collectValues := reflect.New(reflect.SliceOf(valueType)).Elem()
+	if err := collector.Collect(collectValues.Addr().Interface()); err != nil {
+		return nil, fmt.Errorf("failed to collect initial data: %w", err)
+	}
+
+	return collector, nil
+}
+
+// Collect fills dst with the values of the next sample of the recording.
+// Unlike [Collector.Collect], a sample without instances is not an error.
+func (c *ReplayCollector) Collect(dst any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rows, err := newRowWriter(dst, c.nameIndexValue, c.metricsTypeIndexValue, c.totalCounterRequested)
+	if err != nil {
+		return err
+	}
+
+	sample, ok := c.cursor.Next()
+	if !ok {
+		return nil
+	}
+
+	for _, counter := range c.counters {
+		// Calculated values are formatted values, like the ones of collectWorkerFormatted.
+		metricType := prometheus.GaugeValue
+		if c.calculator == nil {
+			metricType = rawMetricType(counter)
+		}
+
+		for _, value := range sample[counter.Name] {
+			if c.calculator != nil {
+				c.calculator.setValue(rows, counter, value.Instance, rawSample{
+					Timestamp:   value.Timestamp,
+					FirstValue:  value.FirstValue,
+					SecondValue: value.SecondValue,
+					MultiCount:  value.MultiCount,
+				})
+
+				continue
+			}
+
+			elem, _, ok := rows.row(value.Instance, metricType)
+			if !ok {
+				continue
+			}
+
+			setRawValue(elem, counter, value.FirstValue, value.SecondValue)
+		}
+	}
+
+	if c.calculator != nil {
+		c.calculator.prune()
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdh_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/replay"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type processorCounterValues struct {
+	Name       string
+	MetricType prometheus.ValueType
+
+	IdleTime          float64 `perfdata:"% Idle Time"`
+	Interrupts        float64 `perfdata:"Interrupts/sec"`
+	UtilityRate       float64 `perfdata:"% Processor Utility"`
+	UtilityRateSecond float64 `perfdata:"% Processor Utility,secondvalue"`
+}
+
+// second is one second in the 100ns intervals of the sample timestamps.
+const second = int64(10_000_000)
+
+func newProcessorRecording(object string, interrupts ...int64) replay.PDHRecording {
+	recording := replay.PDHRecording{
+		Object: object,
+		Counters: map[string]replay.PDHCounter{
+			"% Idle Time":         {Type: pdh.PERF_100NSEC_TIMER},
+			"Interrupts/sec":      {Type: pdh.PERF_COUNTER_COUNTER},
+			"% Processor Utility": {Type: pdh.PERF_AVERAGE_BULK},
+		},
+	}
+
+	for i, value := range interrupts {
+		timestamp := int64(i) * 10 * second
+
+		recording.Samples = append(recording.Samples, replay.PDHSample{
+			"% Idle Time": {
+				{Instance: "0,0", Timestamp: timestamp, FirstValue: value * second},
+				{Instance: "_Total", Timestamp: timestamp, FirstValue: value * second},
+			},
+			"Interrupts/sec": {
+				{Instance: "0,0", Timestamp: timestamp, FirstValue: value},
+				{Instance: "_Total", Timestamp: timestamp, FirstValue: value},
+			},
+			"% Processor Utility": {
+				{Instance: "0,0", Timestamp: timestamp, FirstValue: value, SecondValue: 2 * value},
+				{Instance: "_Total", Timestamp: timestamp, FirstValue: value, SecondValue: 2 * value},
+			},
+		})
+	}
+
+	return recording
+}
+
+func TestReplayCollectorRaw(t *testing.T) {
+	t.Parallel()
+
+	replayer := replay.NewReplayer(&replay.Recording{
+		PDH: []replay.PDHRecording{newProcessorRecording("Processor Information", 100, 200)},
+	})
+
+	collector, err := pdh.NewReplayCollector[processorCounterValues](replayer, pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	var data []processorCounterValues
+
+	require.NoError(t, collector.Collect(&data))
+	require.Equal(t, []processorCounterValues{
+		{
+			Name:              "0,0",
+			MetricType:        prometheus.CounterValue,
+			IdleTime:          200,
+			Interrupts:        200,
+			UtilityRate:       200,
+			UtilityRateSecond: 400,
+		},
+	}, data)
+}
+
+func TestReplayCollectorCalculated(t *testing.T) {
+	t.Parallel()
+
+	replayer := replay.NewReplayer(&replay.Recording{
+		PDH: []replay.PDHRecording{newProcessorRecording("Processor Information", 100, 200, 400)},
+	})
+
+	collector, err := pdh.NewReplayCollector[processorCounterValues](replayer, pdh.CounterTypeFormatted, "Processor Information", pdh.InstancesTotal)
+	require.NoError(t, err)
+
+	var data []processorCounterValues
+
+	require.NoError(t, collector.Collect(&data))
+	require.Len(t, data, 2)
+
+	for _, instance := range data {
+		require.Equal(t, prometheus.GaugeValue, instance.MetricType)
+		require.InDelta(t, 10.0, instance.Interrupts, 1e-9, instance.Name)
+		require.InDelta(t, 100.0, instance.IdleTime, 1e-9, instance.Name)
+	}
+}
+
+func TestReplayCollectorCalculatedNewInstance(t *testing.T) {
+	t.Parallel()
+
+	recording := newProcessorRecording("Processor Information", 100, 200, 400)
+
+	// The instance 0,1 appears in the last sample.
+	for counter, values := range recording.Samples[2] {
+		value := values[0]
+		value.Instance = "0,1"
+		recording.Samples[2][counter] = append(values, value)
+	}
+
+	replayer := replay.NewReplayer(&replay.Recording{PDH: []replay.PDHRecording{recording}})
+
+	collector, err := pdh.NewReplayCollector[processorCounterValues](replayer, pdh.CounterTypeFormatted, "Processor Information", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	var data []processorCounterValues
+
+	require.NoError(t, collector.Collect(&data))
+	require.Len(t, data, 1)
+
+	// Values of the new instance can't be calculated from a single sample, so the instance is skipped.
+	require.NoError(t, collector.Collect(&data))
+	require.Len(t, data, 1)
+	require.Equal(t, "0,0", data[0].Name)
+	require.InDelta(t, 15.0, data[0].Interrupts, 1e-9)
+}
+
+func TestReplayCollectorSameObject(t *testing.T) {
+	t.Parallel()
+
+	replayer := replay.NewReplayer(&replay.Recording{
+		PDH: []replay.PDHRecording{
+			newProcessorRecording("Processor Information", 1, 2),
+			newProcessorRecording("Processor Information", 10, 20),
+		},
+	})
+
+	first, err := pdh.NewReplayCollector[processorCounterValues](replayer, pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	second, err := pdh.NewReplayCollector[processorCounterValues](replayer, pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	var firstData, secondData []processorCounterValues
+
+	require.NoError(t, first.Collect(&firstData))
+	require.NoError(t, second.Collect(&secondData))
+	require.InDelta(t, 2.0, firstData[0].Interrupts, 0)
+	require.InDelta(t, 20.0, secondData[0].Interrupts, 0)
+
+	_, err = pdh.NewReplayCollector[processorCounterValues](replayer, pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
+	require.ErrorIs(t, err, replay.ErrNotRecorded)
+}
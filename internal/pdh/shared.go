@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+//nolint:gochecknoglobals
+var sharedQuery atomic.Pointer[SharedQuery]
+
+// SharedQuery is a PDH query used by all collectors created while it is set via [SetSharedQuery].
+// The counters of all collectors are sampled by a single PdhCollectQueryData call per scrape
+// instead of one call per collector, which reduces the syscalls per scrape
+// and gives the values of all collectors the same sample time.
+type SharedQuery struct {
+	mu     sync.RWMutex
+	handle pdhQueryHandle
+
+	// generation is incremented on every sample. Collectors compare it to the generation of their last read
+	// to detect that no sample was taken since, e.g. if the collector is used outside a scrape.
+	generation atomic.Uint64
+}
+
+func NewSharedQuery() (*SharedQuery, error) {
+	q := &SharedQuery{}
+
+	if ret := OpenQuery(0, 0, &q.handle); ret != ErrorSuccess {
+		return nil, NewPdhError(ret)
+	}
+
+	return q, nil
+}
+
+// SetSharedQuery makes all collectors created afterward add their counters to q.
+// Passing nil restores one query per collector for new collectors.
+func SetSharedQuery(q *SharedQuery) {
+	sharedQuery.Store(q)
+}
+
+// CollectSharedQuery samples the counters of the shared query, if one is set.
+// It is called once per scrape, before the collectors read their values.
+func CollectSharedQuery() error {
+	if q := sharedQuery.Load(); q != nil {
+		return q.Collect()
+	}
+
+	return nil
+}
+
+// Collect samples all counters of the query.
+func (q *SharedQuery) Collect() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.collect()
+}
+
+func (q *SharedQuery) collect() error {
+	if q.handle == 0 {
+		return ErrPerformanceCounterNotInitialized
+	}
+
+	// PDH_NO_DATA is returned as long as no collector has added counters yet.
+	if ret := CollectQueryData(q.handle); ret != ErrorSuccess && ret != NoData {
+		return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
+	}
+
+	q.generation.Add(1)
+
+	return nil
+}
+
+// read calls fn while no sample is taken. If the query was not sampled since the last read
+// of the collector with the given generation, a sample is taken first.
+func (q *SharedQuery) read(generation *atomic.Uint64, fn func() error) error {
+	if q.generation.Load() == generation.Load() {
+		q.mu.Lock()
+
+		if q.generation.Load() == generation.Load() {
+			if err := q.collect(); err != nil {
+				q.mu.Unlock()
+
+				return err
+			}
+		}
+
+		q.mu.Unlock()
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	generation.Store(q.generation.Load())
+
+	return fn()
+}
+
+// Close closes the query, including the counters of all collectors.
+func (q *SharedQuery) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.handle != 0 {
+		CloseQuery(q.handle)
+	}
+
+	q.handle = 0
+}
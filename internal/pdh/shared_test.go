@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/stretchr/testify/require"
+)
+
+type system struct {
+	Processes float64 `perfdata:"Processes"`
+	Threads   float64 `perfdata:"Threads"`
+}
+
+//nolint:paralleltest // SetSharedQuery affects all collectors created during the test.
+func TestSharedQuery(t *testing.T) {
+	sharedQuery, err := pdh.NewSharedQuery()
+	require.NoError(t, err)
+
+	t.Cleanup(sharedQuery.Close)
+
+	pdh.SetSharedQuery(sharedQuery)
+	t.Cleanup(func() { pdh.SetSharedQuery(nil) })
+
+	processCollector, err := pdh.NewCollector[process](pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	systemCollector, err := pdh.NewCollector[system](pdh.CounterTypeFormatted, "System", nil)
+	require.NoError(t, err)
+
+	var (
+		processData []process
+		systemData  []system
+	)
+
+	require.NoError(t, pdh.CollectSharedQuery())
+	require.NoError(t, processCollector.Collect(&processData))
+	require.NoError(t, systemCollector.Collect(&systemData))
+	require.NotEmpty(t, processData)
+	require.Len(t, systemData, 1)
+	require.Positive(t, systemData[0].Threads)
+
+	// The remaining collector keeps working, and samples the query itself if the caller doesn't.
+	processCollector.Close()
+
+	require.NoError(t, systemCollector.Collect(&systemData))
+	require.Len(t, systemData, 1)
+	require.Positive(t, systemData[0].Processes)
+
+	systemCollector.Close()
+}
@@ -13,20 +13,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build windows
-
 package pdh
 
-import (
-	"github.com/prometheus/client_golang/prometheus"
-	"golang.org/x/sys/windows"
-)
+import "github.com/prometheus/client_golang/prometheus"
 
 type CounterType string
 
 const (
 	CounterTypeRaw       CounterType = "raw"
 	CounterTypeFormatted CounterType = "formatted"
+	// CounterTypeCalculated reads raw values, like CounterTypeRaw, and calculates the formatted values in the exporter,
+	// based on the sample history of each counter instance instead of the last two samples of the PDH query.
+	CounterTypeCalculated CounterType = "calculated"
 )
 
 const (
@@ -34,6 +32,31 @@ const (
 	InstanceTotal = "_Total"
 )
 
+//nolint:gochecknoglobals
+var (
+	InstancesAll   = []string{"*"}
+	InstancesTotal = []string{InstanceTotal}
+)
+
+type (
+	HANDLE uintptr
+
+	pdhQueryHandle   HANDLE // query handle
+	pdhCounterHandle HANDLE // counter handle
+)
+
+type Counter struct {
+	Name       string
+	Desc       string
+	MetricType prometheus.ValueType
+	Instances  map[string]pdhCounterHandle
+	Type       uint32
+	Frequency  int64
+
+	FieldIndexValue       int
+	FieldIndexSecondValue int
+}
+
 type CounterValue struct {
 	Type        prometheus.ValueType
 	FirstValue  float64
@@ -122,27 +145,3 @@ type CounterInfo struct {
 	// Start of the string data that is appended to the structure.
 	DataBuffer [1]uint32 // pointer to an extra space
 }
-
-// The RawCounter structure returns the data as it was collected from the counter provider.
-// No translation, formatting, or other interpretation is performed on the data.
-type RawCounter struct {
-	// Counter status that indicates if the counter value is valid. Check this member before using the data in a calculation or displaying its value.
-	// For a list of possible values, see https://docs.microsoft.com/windows/desktop/PerfCtrs/checking-pdh-interface-return-values
-	CStatus uint32
-	// Local time for when the data was collected
-	TimeStamp windows.Filetime
-	// First raw counter value.
-	FirstValue int64
-	// Second raw counter value. Rate counters require two values in order to compute a displayable value.
-	SecondValue int64
-	// If the counter type contains the PERF_MULTI_COUNTER flag, this member contains the additional counter data used in the calculation.
-	// For example, the PERF_100NSEC_MULTI_TIMER counter type contains the PERF_MULTI_COUNTER flag.
-	MultiCount uint32
-}
-
-type RawCounterItem struct {
-	// Pointer to a null-terminated string that specifies the instance name of the counter. The string is appended to the end of this structure.
-	SzName *uint16
-	// A RawCounter structure that contains the raw counter value of the instance
-	RawValue RawCounter
-}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdh
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// rowWriter fills the slice of structs passed to Collect with counter values, one element per instance.
+// It is shared by the PDH and the replay backend, so both convert the counter values the same way.
+type rowWriter struct {
+	dv    reflect.Value
+	elem  reflect.Value
+	index map[string]int
+
+	nameIndexValue        int
+	metricsTypeIndexValue int
+	totalCounterRequested bool
+}
+
+func newRowWriter(dst any, nameIndexValue, metricsTypeIndexValue int, totalCounterRequested bool) (*rowWriter, error) {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return nil, fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
+	}
+
+	dv = dv.Elem()
+
+	if dv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a pointer to a slice, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
+	}
+
+	elemType := dv.Type().Elem()
+
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a pointer to a slice of structs, got a slice of %s: %w", elemType.Kind(), mi.ErrInvalidEntityType)
+	}
+
+	if dv.Len() != 0 {
+		dv.Set(reflect.MakeSlice(dv.Type(), 0, 0))
+	}
+
+	dv.Clear()
+
+	return &rowWriter{
+		dv:                    dv,
+		elem:                  reflect.ValueOf(reflect.New(elemType).Interface()).Elem(),
+		index:                 map[string]int{},
+		nameIndexValue:        nameIndexValue,
+		metricsTypeIndexValue: metricsTypeIndexValue,
+		totalCounterRequested: totalCounterRequested,
+	}, nil
+}
+
+// name returns the name of the instance, as set on its element.
+// It returns false if the instance is skipped, which is the case for _Total if it was not requested.
+func (w *rowWriter) name(instance string) (string, bool) {
+	if strings.HasSuffix(instance, InstanceTotal) && !w.totalCounterRequested {
+		return "", false
+	}
+
+	if instance == "" || instance == "*" {
+		return InstanceEmpty, true
+	}
+
+	return instance, true
+}
+
+// row returns the element of the instance, which is appended on first use.
+// It returns false if the instance is skipped, see [rowWriter.name].
+func (w *rowWriter) row(instance string, metricType prometheus.ValueType) (reflect.Value, string, bool) {
+	instance, ok := w.name(instance)
+	if !ok {
+		return reflect.Value{}, "", false
+	}
+
+	index, ok := w.index[instance]
+	if !ok {
+		index = w.dv.Len()
+		w.index[instance] = index
+
+		if w.nameIndexValue != -1 {
+			w.elem.Field(w.nameIndexValue).SetString(instance)
+		}
+
+		if w.metricsTypeIndexValue != -1 {
+			w.elem.Field(w.metricsTypeIndexValue).Set(reflect.ValueOf(metricType))
+		}
+
+		w.dv.Set(reflect.Append(w.dv, w.elem))
+	}
+
+	return w.dv.Index(index), instance, true
+}
+
+// len returns the number of instances written.
+func (w *rowWriter) len() int {
+	return w.dv.Len()
+}
+
+// rawMetricType returns the metric type of the raw values of the counter.
+func rawMetricType(counter Counter) prometheus.ValueType {
+	if metricType, ok := SupportedCounterTypes[counter.Type]; ok {
+		return metricType
+	}
+
+	return prometheus.GaugeValue
+}
+
+// setRawValue sets the raw value of a counter instance on the fields of the counter, see [CounterTypeRaw].
+func setRawValue(elem reflect.Value, counter Counter, firstValue, secondValue int64) {
+	// This is a workaround for the issue with the elapsed time counter type.
+	// Source: https://github.com/prometheus-community/windows_exporter/pull/335/files#diff-d5d2528f559ba2648c2866aec34b1eaa5c094dedb52bd0ff22aa5eb83226bd8dR76-R83
+	// Ref: https://learn.microsoft.com/en-us/windows/win32/perfctrs/calculating-counter-values
+	switch counter.Type {
+	case PERF_ELAPSED_TIME:
+		elem.Field(counter.FieldIndexValue).
+			SetFloat(float64((secondValue - firstValue) / counter.Frequency))
+	case PERF_100NSEC_TIMER, PERF_PRECISION_100NS_TIMER:
+		elem.Field(counter.FieldIndexValue).
+			SetFloat(float64(firstValue) * TicksToSecondScaleFactor)
+	default:
+		if counter.FieldIndexSecondValue != -1 {
+			elem.Field(counter.FieldIndexSecondValue).
+				SetFloat(float64(secondValue))
+		}
+
+		if counter.FieldIndexValue != -1 {
+			elem.Field(counter.FieldIndexValue).
+				SetFloat(float64(firstValue))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package perfdata provides a common interface over the PDH and the registry (perflib)
+// performance counter APIs, so collectors can choose the API at runtime.
+package perfdata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/pdh/registry"
+)
+
+// Backend is the API used to read performance counters.
+type Backend string
+
+const (
+	// BackendPDH reads performance counters via the Performance Data Helper (PDH) API.
+	BackendPDH Backend = "pdh"
+	// BackendRegistry reads performance counters from the HKEY_PERFORMANCE_DATA registry key.
+	// It has a lower overhead than PDH, but always reads all instances of the object,
+	// which are filtered afterwards, and only supports raw counter values.
+	BackendRegistry Backend = "registry"
+)
+
+// Backends lists the names of all supported backends.
+//
+//nolint:gochecknoglobals
+var Backends = []string{string(BackendPDH), string(BackendRegistry)}
+
+var ErrUnknownBackend = errors.New("unknown performance counter backend")
+
+// source is implemented by [pdh.Collector] and [registry.Collector].
+type source interface {
+	Collect(dst any) error
+	Describe() map[string]string
+	Close()
+}
+
+// Collector reads the counters of a performance object into a slice of structs,
+// where the counters are mapped to the struct fields via the perfdata tag.
+type Collector struct {
+	backend Backend
+	source  source
+}
+
+// NewCollector creates a collector for the given object with the given backend.
+// An empty backend selects [BackendPDH]. Both backends select the instances the same way:
+// instances may contain * wildcards, and _Total instances are only collected if requested.
+//
+// The backends differ in the handling of PERF_ELAPSED_TIME counters.
+// PDH returns the elapsed seconds, while the registry returns the start time as Unix timestamp.
+func NewCollector[T any](backend Backend, resultType pdh.CounterType, object string, instances []string) (*Collector, error) {
+	var (
+		src source
+		err error
+	)
+
+	switch backend {
+	case BackendPDH, "":
+		backend = BackendPDH
+		src, err = pdh.NewCollector[T](resultType, object, instances)
+	case BackendRegistry:
+		if resultType != pdh.CounterTypeRaw {
+			return nil, fmt.Errorf("backend %s supports only raw counters", backend)
+		}
+
+		src, err = registry.NewCollector[T](object, instances)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, backend)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Collector{
+		backend: backend,
+		source:  src,
+	}, nil
+}
+
+// Backend returns the backend of the collector.
+func (c *Collector) Backend() Backend {
+	return c.backend
+}
+
+// Collect reads the current counter values into dst, which must be a pointer to a slice of structs.
+func (c *Collector) Collect(dst any) error {
+	if c == nil {
+		return pdh.ErrPerformanceCounterNotInitialized
+	}
+
+	return c.source.Collect(dst)
+}
+
+// Describe returns the explain texts of the counters, if provided by the backend.
+func (c *Collector) Describe() map[string]string {
+	if c == nil {
+		return map[string]string{}
+	}
+
+	return c.source.Describe()
+}
+
+func (c *Collector) Close() {
+	if c == nil {
+		return
+	}
+
+	c.source.Close()
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package perfdata_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/perfdata"
+	"github.com/stretchr/testify/require"
+)
+
+type systemCounterValues struct {
+	Name string
+
+	Processes float64 `perfdata:"Processes"`
+	Threads   float64 `perfdata:"Threads"`
+}
+
+func TestNewCollector(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []perfdata.Backend{"", perfdata.BackendPDH, perfdata.BackendRegistry} {
+		collector, err := perfdata.NewCollector[systemCounterValues](backend, pdh.CounterTypeRaw, "System", nil)
+		require.NoError(t, err, backend)
+
+		var values []systemCounterValues
+
+		require.NoError(t, collector.Collect(&values), backend)
+		require.Len(t, values, 1, backend)
+		require.Equal(t, pdh.InstanceEmpty, values[0].Name, backend)
+		require.Positive(t, values[0].Threads, backend)
+
+		collector.Close()
+	}
+}
+
+type processorCounterValues struct {
+	Name string
+
+	ProcessorTime float64 `perfdata:"% Processor Time"`
+}
+
+func TestBackendsSelectSameInstances(t *testing.T) {
+	t.Parallel()
+
+	for _, instances := range [][]string{pdh.InstancesAll, pdh.InstancesTotal} {
+		names := map[perfdata.Backend][]string{}
+
+		for _, backend := range []perfdata.Backend{perfdata.BackendPDH, perfdata.BackendRegistry} {
+			collector, err := perfdata.NewCollector[processorCounterValues](backend, pdh.CounterTypeRaw, "Processor Information", instances)
+			require.NoError(t, err, backend)
+
+			var values []processorCounterValues
+
+			require.NoError(t, collector.Collect(&values), backend)
+
+			for _, value := range values {
+				names[backend] = append(names[backend], value.Name)
+			}
+
+			collector.Close()
+		}
+
+		require.NotEmpty(t, names[perfdata.BackendPDH], instances)
+		require.ElementsMatch(t, names[perfdata.BackendPDH], names[perfdata.BackendRegistry], instances)
+	}
+}
+
+func TestNewCollectorUnknownObject(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []perfdata.Backend{perfdata.BackendPDH, perfdata.BackendRegistry} {
+		_, err := perfdata.NewCollector[systemCounterValues](backend, pdh.CounterTypeRaw, "windows_exporter_missing", nil)
+		require.ErrorIs(t, err, pdh.NewPdhError(pdh.CstatusNoObject), backend)
+	}
+}
+
+func TestNewCollectorInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := perfdata.NewCollector[systemCounterValues]("wmi", pdh.CounterTypeRaw, "System", nil)
+	require.ErrorIs(t, err, perfdata.ErrUnknownBackend)
+
+	_, err = perfdata.NewCollector[systemCounterValues](perfdata.BackendRegistry, pdh.CounterTypeFormatted, "System", nil)
+	require.ErrorContains(t, err, "supports only raw counters")
+}
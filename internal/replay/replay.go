@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replay records the raw data returned by PDH and MI and feeds it back to collectors,
+// so the conversion logic of collectors can be regression-tested against real captures.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// ErrNotRecorded is returned by [Replayer] if the recording does not contain the requested object or query.
+var ErrNotRecorded = errors.New("not recorded")
+
+// Recording is the on-disk format of a capture.
+//
+// PDH recordings hold the raw counter values read by a single pdh.Collector each,
+// in the order the collectors were created. MI samples are keyed by namespace and WQL query.
+// Each sample holds the instances a single query returned, keyed by the mi tag of the field.
+type Recording struct {
+	PDH []PDHRecording                   `json:"pdh,omitempty"`
+	MI  map[string]map[string][]MISample `json:"mi,omitempty"`
+}
+
+// PDHRecording holds the samples of the counters of a performance counter object, as read by one collector.
+type PDHRecording struct {
+	Object   string                `json:"object"`
+	Counters map[string]PDHCounter `json:"counters"`
+	Samples  []PDHSample           `json:"samples"`
+}
+
+// PDHCounter holds the type and the time base of a counter, as returned by PdhGetCounterInfo and PdhGetCounterTimeBase.
+// Both are zero if the counter was not added, e.g. because no instance of the object existed.
+type PDHCounter struct {
+	Type      uint32 `json:"type"`
+	Frequency int64  `json:"frequency,omitempty"`
+}
+
+// PDHSample holds the raw values of all counter instances of a single collection, keyed by counter name.
+type PDHSample map[string][]PDHValue
+
+// PDHValue is the raw value of a counter instance, as returned by PdhGetRawCounterArray.
+// Timestamp is the FILETIME the value was sampled at.
+type PDHValue struct {
+	Instance    string `json:"instance"`
+	Timestamp   int64  `json:"timestamp"`
+	FirstValue  int64  `json:"first_value"`
+	SecondValue int64  `json:"second_value,omitempty"`
+	MultiCount  uint32 `json:"multi_count,omitempty"`
+}
+
+type MISample []map[string]json.RawMessage
+
+// ReadFile reads a recording from disk.
+func ReadFile(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	recording := &Recording{}
+	if err = json.Unmarshal(data, recording); err != nil {
+		return nil, fmt.Errorf("failed to parse recording %s: %w", path, err)
+	}
+
+	return recording, nil
+}
+
+// WriteFile writes the recording to disk.
+func (r *Recording) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+
+	return nil
+}
+
+// Recorder captures the data returned by PDH and MI. It is safe for concurrent use.
+//
+// Only the first maxSamples samples of each PDH collector and MI query are kept, later ones are dropped.
+// This bounds the memory used by the recording, no matter how long the exporter runs.
+type Recorder struct {
+	mu         sync.Mutex
+	recording  Recording
+	maxSamples int
+}
+
+func NewRecorder(maxSamples int) *Recorder {
+	return &Recorder{
+		recording: Recording{
+			MI: make(map[string]map[string][]MISample),
+		},
+		maxSamples: maxSamples,
+	}
+}
+
+// PDHRecorder records the samples of a single PDH collector.
+type PDHRecorder struct {
+	recorder *Recorder
+	index    int
+}
+
+// NewPDHRecorder adds a recording for a collector of object.
+func (r *Recorder) NewPDHRecorder(object string) *PDHRecorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recording.PDH = append(r.recording.PDH, PDHRecording{
+		Object:   object,
+		Counters: make(map[string]PDHCounter),
+	})
+
+	return &PDHRecorder{
+		recorder: r,
+		index:    len(r.recording.PDH) - 1,
+	}
+}
+
+// Record appends sample to the recording. counters replaces the counters of the recording,
+// since counters of instances appearing after the creation of the collector are added later.
+func (p *PDHRecorder) Record(counters map[string]PDHCounter, sample PDHSample) {
+	p.recorder.mu.Lock()
+	defer p.recorder.mu.Unlock()
+
+	recording := &p.recorder.recording.PDH[p.index]
+	recording.Counters = counters
+
+	if len(recording.Samples) < p.recorder.maxSamples {
+		recording.Samples = append(recording.Samples, sample)
+	}
+}
+
+// RecordMI appends the instances of src to the samples of the query.
+// src must be a pointer to a slice of structs, as passed to mi.Session.Query.
+func (r *Recorder) RecordMI(namespace, query string, src any) error {
+	rows, err := sliceValue(src)
+	if err != nil {
+		return err
+	}
+
+	sample := make(MISample, 0, rows.Len())
+
+	for i := range rows.Len() {
+		row := rows.Index(i)
+		instance := make(map[string]json.RawMessage)
+
+		for _, f := range reflect.VisibleFields(row.Type()) {
+			tag := f.Tag.Get("mi")
+			if tag == "" {
+				continue
+			}
+
+			value, err := json.Marshal(row.FieldByIndex(f.Index).Interface())
+			if err != nil {
+				return fmt.Errorf("failed to encode property %s: %w", tag, err)
+			}
+
+			instance[tag] = value
+		}
+
+		sample = append(sample, instance)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.recording.MI[namespace]; !ok {
+		r.recording.MI[namespace] = make(map[string][]MISample)
+	}
+
+	if len(r.recording.MI[namespace][query]) < r.maxSamples {
+		r.recording.MI[namespace][query] = append(r.recording.MI[namespace][query], sample)
+	}
+
+	return nil
+}
+
+// WriteFile writes everything recorded so far to disk.
+func (r *Recorder) WriteFile(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.recording.WriteFile(path)
+}
+
+// Replayer feeds a recording back to PDH and MI consumers. It is safe for concurrent use.
+//
+// Every query consumes the next sample of the query. Once all samples are consumed, the last sample
+// is returned again, so counters stay monotonic no matter how often a collector is scraped.
+// PDH collectors replay the recording of a single collector each, see [Replayer.PDH].
+type Replayer struct {
+	mu        sync.Mutex
+	recording *Recording
+	pdhUsed   []bool
+	miIndex   map[string]int
+}
+
+func NewReplayer(recording *Recording) *Replayer {
+	return &Replayer{
+		recording: recording,
+		pdhUsed:   make([]bool, len(recording.PDH)),
+		miIndex:   make(map[string]int),
+	}
+}
+
+// PDH returns a cursor over the next unused recording of a collector of object with the given counters.
+// Recordings are handed out in the order the collectors were created while recording,
+// so collectors reading the same object don't consume the samples of each other.
+func (r *Replayer) PDH(object string, counters []string) (*PDHCursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, recording := range r.recording.PDH {
+		if r.pdhUsed[i] || recording.Object != object || !recording.hasCounters(counters) {
+			continue
+		}
+
+		r.pdhUsed[i] = true
+
+		return &PDHCursor{recording: &r.recording.PDH[i]}, nil
+	}
+
+	return nil, fmt.Errorf("performance counter object %s with counters %v: %w", object, counters, ErrNotRecorded)
+}
+
+// hasCounters reports whether the recording holds exactly the given counters.
+func (r PDHRecording) hasCounters(counters []string) bool {
+	if len(r.Counters) != len(counters) {
+		return false
+	}
+
+	for _, counter := range counters {
+		if _, ok := r.Counters[counter]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PDHCursor iterates over the samples of a PDH recording. It is not safe for concurrent use.
+type PDHCursor struct {
+	recording *PDHRecording
+	index     int
+}
+
+// Counters returns the counters of the recording.
+func (c *PDHCursor) Counters() map[string]PDHCounter {
+	return c.recording.Counters
+}
+
+// Next returns the next sample. Once all samples are consumed, the last sample is returned again.
+// It returns false if the recording has no samples.
+func (c *PDHCursor) Next() (PDHSample, bool) {
+	samples := c.recording.Samples
+	if len(samples) == 0 {
+		return nil, false
+	}
+
+	sample := samples[min(c.index, len(samples)-1)]
+	c.index++
+
+	return sample, true
+}
+
+// QueryMI fills dst with the next sample of the query.
+// dst must be a pointer to a slice of structs, as passed to mi.Session.Query.
+func (r *Replayer) QueryMI(namespace, query string, dst any) error {
+	rows, err := sliceValue(dst)
+	if err != nil {
+		return err
+	}
+
+	key := namespace + ":" + query
+
+	r.mu.Lock()
+	samples := r.recording.MI[namespace][query]
+	i := min(r.miIndex[key], len(samples)-1)
+	r.miIndex[key] = i + 1
+	r.mu.Unlock()
+
+	if len(samples) == 0 {
+		return fmt.Errorf("query %q in namespace %s: %w", query, namespace, ErrNotRecorded)
+	}
+
+	sample := samples[i]
+
+	rows.Set(reflect.MakeSlice(rows.Type(), len(sample), len(sample)))
+
+	for i, instance := range sample {
+		row := rows.Index(i)
+
+		for _, f := range reflect.VisibleFields(row.Type()) {
+			value, ok := instance[f.Tag.Get("mi")]
+			if !ok {
+				continue
+			}
+
+			if err := json.Unmarshal(value, row.FieldByIndex(f.Index).Addr().Interface()); err != nil {
+				return fmt.Errorf("failed to decode property %s: %w", f.Tag.Get("mi"), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func sliceValue(v any) (reflect.Value, error) {
+	dv := reflect.ValueOf(v)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return reflect.Value{}, fmt.Errorf("expected a pointer, got %s", dv.Kind())
+	}
+
+	dv = dv.Elem()
+
+	if dv.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("expected a pointer to a slice, got %s", dv.Kind())
+	}
+
+	if dv.Type().Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a pointer to a slice of structs, got a slice of %s", dv.Type().Elem().Kind())
+	}
+
+	return dv, nil
+}
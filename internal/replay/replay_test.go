@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/replay"
+	"github.com/stretchr/testify/require"
+)
+
+type miValues struct {
+	Name      string    `mi:"Name"`
+	Size      uint64    `mi:"Size"`
+	Enabled   bool      `mi:"Enabled"`
+	Installed time.Time `mi:"InstallDate"`
+	Ignored   string
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	recorder := replay.NewRecorder(2)
+
+	counters := map[string]replay.PDHCounter{
+		"% Idle Time": {Type: 0x20510500},
+	}
+
+	// Two collectors of the same object record their samples independently.
+	first := recorder.NewPDHRecorder("Processor Information")
+	second := recorder.NewPDHRecorder("Processor Information")
+
+	for idle := range int64(3) {
+		first.Record(counters, replay.PDHSample{
+			"% Idle Time": {{Instance: "0,0", Timestamp: idle, FirstValue: idle}},
+		})
+	}
+
+	second.Record(counters, replay.PDHSample{
+		"% Idle Time": {{Instance: "0,0", FirstValue: 100}},
+	})
+
+	installed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for range 3 {
+		require.NoError(t, recorder.RecordMI("root/CIMv2", "SELECT * FROM Win32_DiskDrive", &[]miValues{
+			{Name: "disk0", Size: 1 << 40, Enabled: true, Installed: installed, Ignored: "ignored"},
+		}))
+	}
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+	require.NoError(t, recorder.WriteFile(path))
+
+	recording, err := replay.ReadFile(path)
+	require.NoError(t, err)
+
+	// Samples beyond the maximum are dropped.
+	require.Len(t, recording.PDH, 2)
+	require.Len(t, recording.PDH[0].Samples, 2)
+	require.Len(t, recording.MI["root/CIMv2"]["SELECT * FROM Win32_DiskDrive"], 2)
+
+	replayer := replay.NewReplayer(recording)
+
+	firstCursor, err := replayer.PDH("Processor Information", []string{"% Idle Time"})
+	require.NoError(t, err)
+	require.Equal(t, counters, firstCursor.Counters())
+
+	secondCursor, err := replayer.PDH("Processor Information", []string{"% Idle Time"})
+	require.NoError(t, err)
+
+	_, err = replayer.PDH("Processor Information", []string{"% Idle Time"})
+	require.ErrorIs(t, err, replay.ErrNotRecorded)
+
+	_, err = replayer.PDH("Processor Information", []string{"% Idle Time", "Interrupts/sec"})
+	require.ErrorIs(t, err, replay.ErrNotRecorded)
+
+	sample, ok := firstCursor.Next()
+	require.True(t, ok)
+	require.Equal(t, replay.PDHSample{
+		"% Idle Time": {{Instance: "0,0", Timestamp: 0, FirstValue: 0}},
+	}, sample)
+
+	// The last sample is repeated once all samples are consumed.
+	for range 2 {
+		sample, ok = firstCursor.Next()
+		require.True(t, ok)
+		require.Equal(t, int64(1), sample["% Idle Time"][0].FirstValue)
+	}
+
+	sample, ok = secondCursor.Next()
+	require.True(t, ok)
+	require.Equal(t, int64(100), sample["% Idle Time"][0].FirstValue)
+
+	var miData []miValues
+
+	require.NoError(t, replayer.QueryMI("root/CIMv2", "SELECT * FROM Win32_DiskDrive", &miData))
+	require.Equal(t, []miValues{
+		{Name: "disk0", Size: 1 << 40, Enabled: true, Installed: installed},
+	}, miData)
+
+	require.ErrorIs(t, replayer.QueryMI("root/CIMv2", "SELECT * FROM Win32_Service", &miData), replay.ErrNotRecorded)
+	require.Error(t, replayer.QueryMI("root/CIMv2", "SELECT * FROM Win32_DiskDrive", miData))
+}
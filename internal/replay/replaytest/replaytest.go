@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replaytest converts PDH recordings with the conversion logic of collectors in tests.
+// Unlike testutils, it doesn't depend on Windows APIs or the collector registry,
+// so it can be used by tests within the collector packages on any platform.
+package replaytest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/replay"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPDH replays the recording of a PDH collector of all instances of object, created with --debug.record-file,
+// and converts the values of each scrape with collect, like the Collect method of the collector does.
+// The metrics of the last scrape are compared against expected, in the text exposition format, limited to metricNames.
+func TestPDH[T any](t *testing.T, recordingFile string, resultType pdh.CounterType, object string, scrapes int,
+	collect func(ch chan<- prometheus.Metric, data []T), expected string, metricNames ...string,
+) {
+	t.Helper()
+
+	recording, err := replay.ReadFile(recordingFile)
+	require.NoError(t, err)
+
+	collector, err := pdh.NewReplayCollector[T](replay.NewReplayer(recording), resultType, object, pdh.InstancesAll)
+	require.NoError(t, err)
+
+	var (
+		data    []T
+		metrics replayedMetrics
+	)
+
+	for range scrapes {
+		require.NoError(t, collector.Collect(&data))
+
+		ch := make(chan prometheus.Metric, 10000)
+
+		collect(ch, data)
+		close(ch)
+
+		metrics = metrics[:0]
+		for metric := range ch {
+			metrics = append(metrics, metric)
+		}
+	}
+
+	require.NoError(t, testutil.CollectAndCompare(metrics, strings.NewReader(expected), metricNames...))
+}
+
+// replayedMetrics is an unchecked [prometheus.Collector] returning the metrics of a replayed scrape.
+type replayedMetrics []prometheus.Metric
+
+func (m replayedMetrics) Describe(chan<- *prometheus.Desc) {}
+
+func (m replayedMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, metric := range m {
+		ch <- metric
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"maps"
+	"runtime"
+	"slices"
+	"sync"
+	"weak"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Desc is a metric descriptor together with its name, help, labels and the value type of the metrics created from it.
+// Name, Help, VariableLabels and ConstLabels are only set for descriptors created by [NewDesc].
+type Desc struct {
+	*prometheus.Desc
+
+	Name           string
+	Help           string
+	VariableLabels []string
+	ConstLabels    prometheus.Labels
+	ValueType      prometheus.ValueType
+}
+
+// descInfo holds the arguments a descriptor was created with by [NewDesc].
+type descInfo struct {
+	name           string
+	help           string
+	variableLabels []string
+	constLabels    prometheus.Labels
+}
+
+// descInfos maps the descriptors created by [NewDesc] to their descInfo.
+// The descriptors are referenced weakly, so the entries are removed once a descriptor is garbage collected.
+//
+//nolint:gochecknoglobals
+var descInfos sync.Map
+
+// NewDesc creates a descriptor like [prometheus.NewDesc] and keeps its name, help and labels,
+// since [prometheus.Desc] does not expose them. [Describe] adds them to the [Desc] sent for the descriptor.
+func NewDesc(fqName, help string, variableLabels []string, constLabels prometheus.Labels) *prometheus.Desc {
+	desc := prometheus.NewDesc(fqName, help, variableLabels, constLabels)
+	key := weak.Make(desc)
+
+	descInfos.Store(key, descInfo{
+		name:           fqName,
+		help:           help,
+		variableLabels: slices.Clone(variableLabels),
+		constLabels:    maps.Clone(constLabels),
+	})
+
+	runtime.AddCleanup(desc, func(key weak.Pointer[prometheus.Desc]) {
+		descInfos.Delete(key)
+	}, key)
+
+	return desc
+}
+
+// Describe sends all descriptors with the given value type to ch.
+// Nil descriptors, e.g. of disabled sub-collectors, are skipped.
+func Describe(ch chan<- Desc, valueType prometheus.ValueType, descs ...*prometheus.Desc) {
+	for _, desc := range descs {
+		if desc == nil {
+			continue
+		}
+
+		described := Desc{Desc: desc, ValueType: valueType}
+
+		if value, ok := descInfos.Load(weak.Make(desc)); ok {
+			info := value.(descInfo) //nolint:forcetypeassert
+
+			described.Name = info.name
+			described.Help = info.help
+			described.VariableLabels = info.variableLabels
+			described.ConstLabels = info.constLabels
+		}
+
+		ch <- described
+	}
+}
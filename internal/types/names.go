@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SanitizeName converts a name given by the user, e.g. a WMI property, into a metric or label name.
+// The name is lowercased, and characters other than letters and digits are replaced by an underscore.
+func SanitizeName(name string) string {
+	return strings.Trim(regExpNonAlphaNum.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+// LabelValue formats a property value, e.g. of a WMI instance, as label value. Arrays are joined with a comma.
+func LabelValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
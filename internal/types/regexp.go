@@ -13,8 +13,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build windows
-
 package types
 
 import "regexp"
@@ -22,4 +20,10 @@ import "regexp"
 var (
 	RegExpAny   = regexp.MustCompile("^.+$")
 	RegExpEmpty = regexp.MustCompile("^$")
+
+	// RegExpLabelName and RegExpMetricName match valid Prometheus label and metric names.
+	RegExpLabelName  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	RegExpMetricName = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+	regExpNonAlphaNum = regexp.MustCompile(`[^a-zA-Z0-9]`)
 )
@@ -21,6 +21,14 @@ func MilliSecToSec(t float64) float64 {
 	return t / 1000
 }
 
+func MicroSecToSec(t float64) float64 {
+	return t / 1000 / 1000
+}
+
+func KBToBytes(kb float64) float64 {
+	return kb * 1024
+}
+
 func MBToBytes(mb float64) float64 {
 	return mb * 1024 * 1024
 }
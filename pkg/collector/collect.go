@@ -22,20 +22,26 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"runtime"
 	"runtime/debug"
+	"runtime/metrics"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
 )
 
 type collectorStatus struct {
-	name       string
-	statusCode collectorStatusCode
+	name                string
+	statusCode          collectorStatusCode
+	seriesLimitExceeded bool
 }
 
 type collectorStatusCode int
@@ -49,6 +55,13 @@ const (
 func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger, maxScrapeDuration time.Duration) {
 	collectorStartTime := time.Now()
 
+	// Sample the counters of all collectors sharing a PDH query at once, before the collectors read them.
+	if err := pdh.CollectSharedQuery(); err != nil {
+		logger.LogAttrs(context.Background(), slog.LevelWarn, "failed to collect shared PDH query",
+			slog.Any("err", err),
+		)
+	}
+
 	// WaitGroup to wait for all collectors to finish
 	wg := sync.WaitGroup{}
 	wg.Add(len(c.collectors))
@@ -63,10 +76,7 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 		go func(name string, metricsCollector Collector) {
 			defer wg.Done()
 
-			collectorStatusCh <- collectorStatus{
-				name:       name,
-				statusCode: c.collectCollector(ch, logger, name, metricsCollector, maxScrapeDuration),
-			}
+			collectorStatusCh <- c.collectCollector(ch, logger, name, metricsCollector, maxScrapeDuration)
 		}(name, metricsCollector)
 	}
 
@@ -86,6 +96,17 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 			successValue = 1.0
 		}
 
+		if status.statusCode == failed {
+			c.collectorErrors[status.name].Add(1)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorErrorsDesc,
+			prometheus.CounterValue,
+			float64(c.collectorErrors[status.name].Load()),
+			status.name,
+		)
+
 		ch <- prometheus.MustNewConstMetric(
 			c.collectorScrapeSuccessDesc,
 			prometheus.GaugeValue,
@@ -99,8 +120,31 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 			timeoutValue,
 			status.name,
 		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorSeriesLimitDesc,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(status.seriesLimitExceeded),
+			status.name,
+		)
 	}
 
+	for change, count := range pdh.InstanceChanges() {
+		ch <- prometheus.MustNewConstMetric(
+			c.pdhInstanceChangesDesc,
+			prometheus.CounterValue,
+			float64(count),
+			change.Object,
+			change.Change,
+		)
+	}
+
+	if c.miSession != nil {
+		c.collectMIQueryStats(ch)
+	}
+
+	c.collectMIOperationStats(ch)
+
 	ch <- prometheus.MustNewConstMetric(
 		c.scrapeDurationDesc,
 		prometheus.GaugeValue,
@@ -108,14 +152,70 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 	)
 }
 
-func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.Logger, name string, collector Collector, maxScrapeDuration time.Duration) collectorStatusCode {
+// collectMIQueryStats sends the counters of the MI query cache shared by all collectors.
+func (c *Collection) collectMIQueryStats(ch chan<- prometheus.Metric) {
+	stats := c.miSession.QueryStats()
+
+	for result, count := range map[string]uint64{
+		"hit":       stats.CacheHits,
+		"miss":      stats.CacheMisses,
+		"coalesced": stats.CacheCoalesced,
+	} {
+		ch <- prometheus.MustNewConstMetric(
+			c.miQueryCacheRequestsDesc,
+			prometheus.CounterValue,
+			float64(count),
+			result,
+		)
+	}
+}
+
+// collectMIOperationStats sends the duration histograms, instance counts and errors of the MI query operations per class.
+func (c *Collection) collectMIOperationStats(ch chan<- prometheus.Metric) {
+	for class, stats := range mi.OperationStats() {
+		ch <- prometheus.MustNewConstHistogram(
+			c.miOperationDurationDesc,
+			stats.Operations,
+			stats.Duration.Seconds(),
+			stats.Buckets,
+			class.Namespace,
+			class.Class,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.miOperationInstancesDesc,
+			prometheus.CounterValue,
+			float64(stats.Instances),
+			class.Namespace,
+			class.Class,
+		)
+
+		for result, count := range stats.Errors {
+			ch <- prometheus.MustNewConstMetric(
+				c.miOperationErrorsDesc,
+				prometheus.CounterValue,
+				float64(count),
+				class.Namespace,
+				class.Class,
+				result,
+			)
+		}
+	}
+}
+
+func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.Logger, name string, collector Collector, maxScrapeDuration time.Duration) collectorStatus {
 	var (
-		err        error
-		numMetrics int
-		duration   time.Duration
-		timeout    atomic.Bool
+		err            error
+		numMetrics     int
+		droppedMetrics int
+		duration       time.Duration
+		cpuTime        time.Duration
+		heapAlloc      uint64
+		timeout        atomic.Bool
 	)
 
+	maxSeries := c.maxSeriesFor(name)
+
 	// bufCh is a buffer channel to store the metrics
 	// This is needed because once timeout is reached, the prometheus registry channel is closed.
 	bufCh := make(chan prometheus.Metric, 1000)
@@ -136,7 +236,18 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			close(bufCh)
 		}()
 
-		errCh <- collector.Collect(bufCh)
+		// Pin the goroutine to its OS thread, so the thread CPU time can be attributed to the collector.
+		// CPU time of goroutines started by the collector is not included.
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		cpuTimeStart := threadCPUTime()
+		heapAllocStart := heapAllocated()
+		collectErr := collector.Collect(bufCh)
+		heapAlloc = heapAllocated() - heapAllocStart
+		cpuTime = threadCPUTime() - cpuTimeStart
+
+		errCh <- collectErr
 	}()
 
 	wg := sync.WaitGroup{}
@@ -162,11 +273,19 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 					return
 				}
 
-				if !timeout.Load() {
-					ch <- m
+				if timeout.Load() {
+					continue
+				}
 
-					numMetrics++
+				if maxSeries > 0 && numMetrics >= maxSeries {
+					droppedMetrics++
+
+					continue
 				}
+
+				ch <- m
+
+				numMetrics++
 			}
 		}
 	}()
@@ -185,6 +304,24 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			duration.Seconds(),
 			name,
 		)
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorMetricsEmittedDesc,
+			prometheus.GaugeValue,
+			float64(numMetrics),
+			name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorCPUTimeDesc,
+			prometheus.GaugeValue,
+			cpuTime.Seconds(),
+			name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorHeapAllocDesc,
+			prometheus.GaugeValue,
+			float64(heapAlloc),
+			name,
+		)
 	case <-ctx.Done():
 		timeout.Store(true)
 
@@ -205,10 +342,19 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			}
 		}()
 
-		return pending
+		return collectorStatus{name: name, statusCode: pending}
 	}
 
-	if err != nil && !errors.Is(err, pdh.ErrNoData) && !errors.Is(err, types.ErrNoData) {
+	if errors.Is(err, pdh.ErrNoData) || errors.Is(err, types.ErrNoData) {
+		err = nil
+	}
+
+	// Checked after ErrNoData, so dropped series fail the collection even if the collector had no data otherwise.
+	if droppedMetrics > 0 {
+		err = errors.Join(err, fmt.Errorf("collector %s exceeded the series limit of %d, dropped %d series", name, maxSeries, droppedMetrics))
+	}
+
+	if err != nil {
 		if errors.Is(err, pdh.ErrPerformanceCounterNotInitialized) || errors.Is(err, mi.MI_RESULT_INVALID_NAMESPACE) {
 			err = fmt.Errorf("%w. Check application logs from initialization pharse for more information", err)
 		}
@@ -218,10 +364,40 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			slog.Any("err", err),
 		)
 
-		return failed
+		return collectorStatus{name: name, statusCode: failed, seriesLimitExceeded: droppedMetrics > 0}
 	}
 
 	logger.LogAttrs(ctx, slog.LevelDebug, fmt.Sprintf("collector %s succeeded after %s, resulting in %d metrics", name, duration, numMetrics))
 
-	return success
+	return collectorStatus{name: name, statusCode: success}
+}
+
+// threadCPUTime returns the user and kernel time consumed by the current OS thread.
+func threadCPUTime() time.Duration {
+	var creationTime, exitTime, kernelTime, userTime windows.Filetime
+
+	if err := kernel32.GetThreadTimes(windows.CurrentThread(), &creationTime, &exitTime, &kernelTime, &userTime); err != nil {
+		return 0
+	}
+
+	return filetimeDuration(kernelTime) + filetimeDuration(userTime)
+}
+
+// heapAllocated returns the cumulative number of bytes allocated on the heap by the whole process.
+// The Go runtime does not track allocations per goroutine, so deltas include the allocations of
+// all goroutines running at the same time, e.g. other collectors.
+func heapAllocated() uint64 {
+	sample := []metrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+	metrics.Read(sample)
+
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+
+	return sample[0].Value.Uint64()
+}
+
+// filetimeDuration converts a FILETIME holding a time span in 100-nanosecond intervals.
+func filetimeDuration(ft windows.Filetime) time.Duration {
+	return time.Duration(uint64(ft.HighDateTime)<<32|uint64(ft.LowDateTime)) * 100
 }
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector_test
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type staticCollector struct {
+	desc    *prometheus.Desc
+	metrics int
+	err     error
+}
+
+func (c *staticCollector) GetName() string { return "static" }
+
+func (c *staticCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *staticCollector) Collect(ch chan<- prometheus.Metric) error {
+	for i := range c.metrics {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(i), strings.Repeat("x", i+1))
+	}
+
+	return c.err
+}
+
+func (c *staticCollector) Close() error { return nil }
+
+func TestCollectorSelfMetrics(t *testing.T) {
+	t.Parallel()
+
+	desc := prometheus.NewDesc("windows_static_value", "Static value.", []string{"id"}, nil)
+
+	collection := collector.New(collector.Map{
+		"ok":     &staticCollector{desc: desc, metrics: 3},
+		"broken": &staticCollector{desc: desc, err: errors.New("broken")},
+	})
+
+	handler, err := collection.NewHandler(time.Minute, slog.New(slog.DiscardHandler), nil)
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(handler))
+
+	_, err = registry.Gather()
+	require.NoError(t, err)
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP windows_exporter_collector_errors_total windows_exporter: Total number of failed collections.
+# TYPE windows_exporter_collector_errors_total counter
+windows_exporter_collector_errors_total{collector="broken"} 2
+windows_exporter_collector_errors_total{collector="ok"} 0
+# HELP windows_exporter_collector_metrics_emitted windows_exporter: Number of metrics emitted by the collector during the last collection.
+# TYPE windows_exporter_collector_metrics_emitted gauge
+windows_exporter_collector_metrics_emitted{collector="broken"} 0
+windows_exporter_collector_metrics_emitted{collector="ok"} 3
+`), "windows_exporter_collector_errors_total", "windows_exporter_collector_metrics_emitted"))
+}
+
+func TestCollectorSeriesLimit(t *testing.T) {
+	t.Parallel()
+
+	desc := prometheus.NewDesc("windows_static_value", "Static value.", []string{"id"}, nil)
+
+	collection := collector.New(collector.Map{
+		"small": &staticCollector{desc: desc, metrics: 2},
+		"large": &staticCollector{desc: desc, metrics: 10},
+	})
+	collection.SetMaxSeries(5, map[string]int{"small": 1})
+
+	handler, err := collection.NewHandler(time.Minute, slog.New(slog.DiscardHandler), nil)
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(handler))
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP windows_exporter_collector_metrics_emitted windows_exporter: Number of metrics emitted by the collector during the last collection.
+# TYPE windows_exporter_collector_metrics_emitted gauge
+windows_exporter_collector_metrics_emitted{collector="large"} 5
+windows_exporter_collector_metrics_emitted{collector="small"} 1
+# HELP windows_exporter_collector_series_limit_exceeded windows_exporter: Whether the collector exceeded its series limit during the last collection.
+# TYPE windows_exporter_collector_series_limit_exceeded gauge
+windows_exporter_collector_series_limit_exceeded{collector="large"} 1
+windows_exporter_collector_series_limit_exceeded{collector="small"} 1
+# HELP windows_exporter_collector_success windows_exporter: Whether the collector was successful.
+# TYPE windows_exporter_collector_success gauge
+windows_exporter_collector_success{collector="large"} 0
+windows_exporter_collector_success{collector="small"} 0
+`), "windows_exporter_collector_metrics_emitted", "windows_exporter_collector_series_limit_exceeded", "windows_exporter_collector_success"))
+
+	collection.SetMaxSeries(0, nil)
+
+	require.Equal(t, 12, testutil.CollectAndCount(handler, "windows_static_value"))
+}
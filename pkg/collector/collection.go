@@ -25,6 +25,7 @@ import (
 	"maps"
 	"slices"
 	"sync"
+	"sync/atomic"
 	gotime "time"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -77,6 +78,8 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi_event"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -88,6 +91,9 @@ import (
 func NewWithFlags(app *kingpin.Application) *Collection {
 	collectors := map[string]Collector{}
 
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
 	for name, builder := range BuildersWithFlags {
 		collectors[name] = builder(app)
 	}
@@ -149,15 +155,31 @@ func NewWithConfig(config Config) *Collection {
 	collectors[udp.Name] = udp.New(&config.UDP)
 	collectors[update.Name] = update.New(&config.Update)
 	collectors[vmware.Name] = vmware.New(&config.Vmware)
+	collectors[wmi.Name] = wmi.New(&config.WMI)
+	collectors[wmi_event.Name] = wmi_event.New(&config.WMIEvent)
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for name, r := range registered {
+		collectors[name] = newRegisteredWithConfig(name, r.builder, config.Registered[name])
+	}
 
 	return New(collectors)
 }
 
 // New To be called by the external libraries for collector initialization.
 func New(collectors Map) *Collection {
+	collectorErrors := make(map[string]*atomic.Uint64, len(collectors))
+	for name := range collectors {
+		collectorErrors[name] = &atomic.Uint64{}
+	}
+
 	return &Collection{
-		collectors:    collectors,
-		concurrencyCh: make(chan struct{}, 1),
+		collectors:      collectors,
+		collectorErrors: collectorErrors,
+		descCache:       &descCache{descs: make(map[string][]Desc)},
+		concurrencyCh:   make(chan struct{}, 1),
 		scrapeDurationDesc: prometheus.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "scrape_duration_seconds"),
 			"windows_exporter: Total scrape duration.",
@@ -182,9 +204,85 @@ func New(collectors Map) *Collection {
 			[]string{"collector"},
 			nil,
 		),
+		collectorMetricsEmittedDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_metrics_emitted"),
+			"windows_exporter: Number of metrics emitted by the collector during the last collection.",
+			[]string{"collector"},
+			nil,
+		),
+		collectorErrorsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_errors_total"),
+			"windows_exporter: Total number of failed collections.",
+			[]string{"collector"},
+			nil,
+		),
+		collectorSeriesLimitDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_series_limit_exceeded"),
+			"windows_exporter: Whether the collector exceeded its series limit during the last collection.",
+			[]string{"collector"},
+			nil,
+		),
+		collectorCPUTimeDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_cpu_time_seconds"),
+			"windows_exporter: CPU time (user and kernel) spent on the collector's own goroutine during the last collection.",
+			[]string{"collector"},
+			nil,
+		),
+		collectorHeapAllocDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_heap_allocated_bytes"),
+			"windows_exporter: Approximate heap memory allocated by the exporter during the last collection of the collector, including allocations of collectors running at the same time.",
+			[]string{"collector"},
+			nil,
+		),
+		pdhInstanceChangesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "pdh_instance_changes_total"),
+			"windows_exporter: Total number of PDH instances added or removed after the collector was created.",
+			[]string{"object", "change"},
+			nil,
+		),
+		miQueryCacheRequestsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "mi_query_cache_requests_total"),
+			"windows_exporter: Total number of MI queries by cache result.",
+			[]string{"result"},
+			nil,
+		),
+		miOperationDurationDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "mi_operation_duration_seconds"),
+			"windows_exporter: Duration of the MI query operations by namespace and class.",
+			[]string{"namespace", "class"},
+			nil,
+		),
+		miOperationInstancesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "mi_operation_instances_total"),
+			"windows_exporter: Total number of instances returned by the MI query operations by namespace and class.",
+			[]string{"namespace", "class"},
+			nil,
+		),
+		miOperationErrorsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "mi_operation_errors_total"),
+			"windows_exporter: Total number of failed MI query operations by namespace, class and MI result.",
+			[]string{"namespace", "class", "result"},
+			nil,
+		),
 	}
 }
 
+// SetMaxSeries limits the number of series each collector may emit per scrape.
+// limits overrides the default limit for single collectors. A limit of 0 disables the limit.
+// Series exceeding the limit are dropped and the collection is marked as failed.
+func (c *Collection) SetMaxSeries(limit int, limits map[string]int) {
+	c.maxSeries = limit
+	c.maxSeriesPerCollector = maps.Clone(limits)
+}
+
+func (c *Collection) maxSeriesFor(name string) int {
+	if limit, ok := c.maxSeriesPerCollector[name]; ok {
+		return limit
+	}
+
+	return c.maxSeries
+}
+
 // Enable removes all collectors that not enabledCollectors.
 func (c *Collection) Enable(enabledCollectors []string) error {
 	for _, name := range enabledCollectors {
@@ -315,6 +413,20 @@ func (c *Collection) WithCollectors(collectors []string) (*Collection, error) {
 		collectorScrapeDurationDesc: c.collectorScrapeDurationDesc,
 		collectorScrapeSuccessDesc:  c.collectorScrapeSuccessDesc,
 		collectorScrapeTimeoutDesc:  c.collectorScrapeTimeoutDesc,
+		collectorMetricsEmittedDesc: c.collectorMetricsEmittedDesc,
+		collectorErrorsDesc:         c.collectorErrorsDesc,
+		collectorCPUTimeDesc:        c.collectorCPUTimeDesc,
+		collectorHeapAllocDesc:      c.collectorHeapAllocDesc,
+		collectorSeriesLimitDesc:    c.collectorSeriesLimitDesc,
+		pdhInstanceChangesDesc:      c.pdhInstanceChangesDesc,
+		miQueryCacheRequestsDesc:    c.miQueryCacheRequestsDesc,
+		miOperationDurationDesc:     c.miOperationDurationDesc,
+		miOperationInstancesDesc:    c.miOperationInstancesDesc,
+		miOperationErrorsDesc:       c.miOperationErrorsDesc,
+		collectorErrors:             c.collectorErrors,
+		descCache:                   c.descCache,
+		maxSeries:                   c.maxSeries,
+		maxSeriesPerCollector:       c.maxSeriesPerCollector,
 		collectors:                  maps.Clone(c.collectors),
 	}
 
@@ -67,6 +67,8 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi_event"
 )
 
 type Config struct {
@@ -119,6 +121,11 @@ type Config struct {
 	UDP                udp.Config                `yaml:"udp"`
 	Update             update.Config             `yaml:"update"`
 	Vmware             vmware.Config             `yaml:"vmware"`
+	WMI                wmi.Config                `yaml:"wmi"`
+	WMIEvent           wmi_event.Config          `yaml:"wmi_event"`
+
+	// Registered holds the config sections of collectors added via [Register], keyed by collector name.
+	Registered map[string]any `yaml:",inline"`
 }
 
 // ConfigDefaults Is an interface to be used by the external libraries. It holds all ConfigDefaults form all collectors
@@ -175,4 +182,6 @@ var ConfigDefaults = Config{
 	UDP:                udp.ConfigDefaults,
 	Update:             update.ConfigDefaults,
 	Vmware:             vmware.ConfigDefaults,
+	WMI:                wmi.ConfigDefaults,
+	WMIEvent:           wmi_event.ConfigDefaults,
 }
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CatalogueMetric describes a metric a collector may emit.
+type CatalogueMetric struct {
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Help        string            `json:"help"`
+	Labels      []string          `json:"labels"`
+	ConstLabels map[string]string `json:"const_labels,omitempty"`
+}
+
+// CatalogueCollector lists the metrics of a single collector.
+// Dynamic is set for collectors which only know their metrics at collection time.
+type CatalogueCollector struct {
+	Name    string            `json:"name"`
+	Dynamic bool              `json:"dynamic"`
+	Metrics []CatalogueMetric `json:"metrics"`
+}
+
+// NewDesc creates a descriptor like [prometheus.NewDesc]. Collectors implementing [Describer] must create
+// their descriptors with NewDesc, since the name, help and labels of a [prometheus.Desc] are not accessible otherwise.
+func NewDesc(fqName, help string, variableLabels []string, constLabels prometheus.Labels) *prometheus.Desc {
+	return types.NewDesc(fqName, help, variableLabels, constLabels)
+}
+
+// Describe sends all descriptors with the given value type to ch. Nil descriptors are skipped.
+// It's meant to be used by the Describe method of collectors implementing [Describer].
+func Describe(ch chan<- Desc, valueType prometheus.ValueType, descs ...*prometheus.Desc) {
+	types.Describe(ch, valueType, descs...)
+}
+
+// descCache holds the descriptors of each collector. Collectors create their descriptors in Build,
+// so they are described once and shared with all collections derived via [Collection.WithCollectors].
+type descCache struct {
+	mu    sync.Mutex
+	descs map[string][]Desc
+}
+
+// describeCollector returns the descriptors of the named collector. Descriptors not created by [NewDesc] are omitted.
+func (c *Collection) describeCollector(name string) []Desc {
+	c.descCache.mu.Lock()
+	defer c.descCache.mu.Unlock()
+
+	if descs, ok := c.descCache.descs[name]; ok {
+		return descs
+	}
+
+	descs := describeCollector(c.collectors[name])
+	c.descCache.descs[name] = descs
+
+	return descs
+}
+
+// describe sends the descriptors of the collection itself and of all collectors implementing [Describer].
+// Only the first descriptor of each metric name is sent, since the prometheus registry
+// rejects descriptors with the same name but different labels or help.
+func (c *Collection) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.scrapeDurationDesc
+	ch <- c.collectorScrapeDurationDesc
+	ch <- c.collectorScrapeSuccessDesc
+	ch <- c.collectorScrapeTimeoutDesc
+	ch <- c.collectorMetricsEmittedDesc
+	ch <- c.collectorErrorsDesc
+	ch <- c.collectorCPUTimeDesc
+	ch <- c.collectorHeapAllocDesc
+	ch <- c.collectorSeriesLimitDesc
+	ch <- c.pdhInstanceChangesDesc
+	ch <- c.miQueryCacheRequestsDesc
+	ch <- c.miOperationDurationDesc
+	ch <- c.miOperationInstancesDesc
+	ch <- c.miOperationErrorsDesc
+
+	seen := make(map[string]struct{})
+
+	for _, name := range slices.Sorted(maps.Keys(c.collectors)) {
+		for _, desc := range c.describeCollector(name) {
+			if _, ok := seen[desc.Name]; ok {
+				continue
+			}
+
+			seen[desc.Name] = struct{}{}
+			ch <- desc.Desc
+		}
+	}
+}
+
+// Catalogue returns the metrics of all collectors in the collection, sorted by collector and metric name.
+func (c *Collection) Catalogue() []CatalogueCollector {
+	catalogue := make([]CatalogueCollector, 0, len(c.collectors))
+
+	for _, name := range slices.Sorted(maps.Keys(c.collectors)) {
+		entry := CatalogueCollector{
+			Name:    name,
+			Metrics: []CatalogueMetric{},
+		}
+
+		if _, ok := c.collectors[name].(Describer); !ok {
+			entry.Dynamic = true
+		}
+
+		for _, desc := range c.describeCollector(name) {
+			entry.Metrics = append(entry.Metrics, newCatalogueMetric(desc))
+		}
+
+		slices.SortFunc(entry.Metrics, func(a, b CatalogueMetric) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
+		entry.Metrics = slices.CompactFunc(entry.Metrics, func(a, b CatalogueMetric) bool {
+			return a.Name == b.Name
+		})
+
+		catalogue = append(catalogue, entry)
+	}
+
+	return catalogue
+}
+
+func describeCollector(collector Collector) []Desc {
+	describer, ok := collector.(Describer)
+	if !ok {
+		return nil
+	}
+
+	ch := make(chan Desc)
+	descs := make([]Desc, 0)
+
+	go func() {
+		describer.Describe(ch)
+		close(ch)
+	}()
+
+	for desc := range ch {
+		if desc.Desc != nil && desc.Name != "" {
+			descs = append(descs, desc)
+		}
+	}
+
+	return descs
+}
+
+// newCatalogueMetric returns the metric name, type, help and labels of a descriptor.
+func newCatalogueMetric(desc Desc) CatalogueMetric {
+	metric := CatalogueMetric{
+		Name:   desc.Name,
+		Type:   strings.ToLower(desc.ValueType.ToDTO().String()),
+		Help:   desc.Help,
+		Labels: []string{},
+	}
+
+	if len(desc.VariableLabels) > 0 {
+		metric.Labels = slices.Clone(desc.VariableLabels)
+	}
+
+	if len(desc.ConstLabels) > 0 {
+		metric.ConstLabels = maps.Clone(desc.ConstLabels)
+	}
+
+	return metric
+}
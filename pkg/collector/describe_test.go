@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type describedCollector struct {
+	info  *prometheus.Desc
+	total *prometheus.Desc
+}
+
+func (c *describedCollector) GetName() string { return "described" }
+
+func (c *describedCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *describedCollector) Collect(_ chan<- prometheus.Metric) error { return nil }
+
+func (c *describedCollector) Close() error { return nil }
+
+func (c *describedCollector) Describe(ch chan<- collector.Desc) {
+	collector.Describe(ch, prometheus.CounterValue, c.total)
+	collector.Describe(ch, prometheus.GaugeValue, c.info)
+}
+
+type dynamicCollector struct{}
+
+func (c *dynamicCollector) GetName() string { return "dynamic" }
+
+func (c *dynamicCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *dynamicCollector) Collect(_ chan<- prometheus.Metric) error { return nil }
+
+func (c *dynamicCollector) Close() error { return nil }
+
+func TestCatalogue(t *testing.T) {
+	t.Parallel()
+
+	collection := collector.New(collector.Map{
+		"described": &describedCollector{
+			info: collector.NewDesc("windows_described_info", "Information about \"described\".",
+				[]string{"version"}, prometheus.Labels{"source": "test"}),
+			total: collector.NewDesc("windows_described_requests_total", "Total requests.",
+				[]string{"method", "code"}, nil),
+		},
+		"dynamic": &dynamicCollector{},
+	})
+
+	require.Equal(t, []collector.CatalogueCollector{
+		{
+			Name: "described",
+			Metrics: []collector.CatalogueMetric{
+				{
+					Name:        "windows_described_info",
+					Type:        "gauge",
+					Help:        "Information about \"described\".",
+					Labels:      []string{"version"},
+					ConstLabels: map[string]string{"source": "test"},
+				},
+				{
+					Name:   "windows_described_requests_total",
+					Type:   "counter",
+					Help:   "Total requests.",
+					Labels: []string{"method", "code"},
+				},
+			},
+		},
+		{
+			Name:    "dynamic",
+			Dynamic: true,
+			Metrics: []collector.CatalogueMetric{},
+		},
+	}, collection.Catalogue())
+}
+
+func TestHandlerDescribe(t *testing.T) {
+	t.Parallel()
+
+	collection := collector.New(collector.Map{
+		"described": &describedCollector{
+			info:  collector.NewDesc("windows_described_info", "Info.", []string{"version"}, nil),
+			total: collector.NewDesc("windows_described_info", "Duplicate.", nil, nil),
+		},
+	})
+
+	handler, err := collection.NewHandler(0, slog.New(slog.DiscardHandler), nil)
+	require.NoError(t, err)
+
+	// Descriptors with the same name are only described once, otherwise the registry would reject the handler.
+	require.NoError(t, prometheus.NewRegistry().Register(handler))
+}
@@ -55,7 +55,11 @@ func (c *Collection) NewHandler(maxScrapeDuration time.Duration, logger *slog.Lo
 	}, nil
 }
 
-func (p *Handler) Describe(_ chan<- *prometheus.Desc) {}
+// Describe sends the descriptors of all collectors implementing [Describer].
+// Metrics of other collectors remain unchecked by the prometheus registry.
+func (p *Handler) Describe(ch chan<- *prometheus.Desc) {
+	p.collection.describe(ch)
+}
 
 // Collect sends the collected metrics from each of the Collection to
 // prometheus.
@@ -71,6 +71,8 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi"
+	"github.com/prometheus-community/windows_exporter/internal/collector/wmi_event"
 )
 
 func NewBuilderWithFlags[C Collector](fn BuilderWithFlags[C]) BuilderWithFlags[Collector] {
@@ -130,11 +132,16 @@ var BuildersWithFlags = map[string]BuilderWithFlags[Collector]{
 	udp.Name:                NewBuilderWithFlags(udp.NewWithFlags),
 	update.Name:             NewBuilderWithFlags(update.NewWithFlags),
 	vmware.Name:             NewBuilderWithFlags(vmware.NewWithFlags),
+	wmi.Name:                NewBuilderWithFlags(wmi.NewWithFlags),
+	wmi_event.Name:          NewBuilderWithFlags(wmi_event.NewWithFlags),
 }
 
 // Available returns a sorted list of available collectors.
 //
 //goland:noinspection GoUnusedExportedFunction
 func Available() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
 	return slices.Sorted(maps.Keys(BuildersWithFlags))
 }
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type registration struct {
+	builder          BuilderWithFlags[Collector]
+	enabledByDefault bool
+}
+
+//nolint:gochecknoglobals
+var (
+	registryMu sync.RWMutex
+	registered = map[string]registration{}
+)
+
+// Register adds a third-party collector to the set of available collectors.
+// Registered collectors can be enabled via --collectors.enabled and collect[] and
+// are configured through their own flags, which may also be set from the
+// collector.<name> section of the configuration file.
+//
+// Register must be called before [NewWithFlags] or [NewWithConfig], typically from an init function.
+func Register[C Collector](name string, builder BuilderWithFlags[C]) error {
+	return register(name, NewBuilderWithFlags(builder), false)
+}
+
+// RegisterDefault is like [Register], but the collector is also enabled by default
+// and becomes part of the [defaults] placeholder.
+func RegisterDefault[C Collector](name string, builder BuilderWithFlags[C]) error {
+	return register(name, NewBuilderWithFlags(builder), true)
+}
+
+func register(name string, builder BuilderWithFlags[Collector], enabledByDefault bool) error {
+	if name == "" {
+		return errors.New("collector name is required")
+	}
+
+	if strings.ContainsAny(name, ",[] ") {
+		return fmt.Errorf("collector name %q contains invalid characters", name)
+	}
+
+	if builder == nil {
+		return fmt.Errorf("collector %s: builder is required", name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := BuildersWithFlags[name]; ok {
+		return fmt.Errorf("collector %s is already registered", name)
+	}
+
+	BuildersWithFlags[name] = builder
+	registered[name] = registration{
+		builder:          builder,
+		enabledByDefault: enabledByDefault,
+	}
+
+	return nil
+}
+
+// unregister removes a collector added via [Register] or [RegisterDefault].
+// Built-in collectors are left untouched.
+func unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registered[name]; !ok {
+		return
+	}
+
+	delete(BuildersWithFlags, name)
+	delete(registered, name)
+}
+
+// Defaults returns the comma-separated list of collectors enabled by default.
+// It extends [DefaultCollectors] with all collectors added via [RegisterDefault].
+func Defaults() string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	defaults := strings.Split(DefaultCollectors, ",")
+
+	for _, name := range slices.Sorted(maps.Keys(registered)) {
+		if registered[name].enabledByDefault {
+			defaults = append(defaults, name)
+		}
+	}
+
+	return strings.Join(defaults, ",")
+}
+
+// newRegisteredWithConfig builds a registered collector outside of kingpin.Parse.
+// The values of the collector.<name> config section are applied as flag defaults,
+// the same way the configuration file is applied to the exporter flags.
+//
+//nolint:ireturn
+func newRegisteredWithConfig(name string, builder BuilderWithFlags[Collector], section any) Collector {
+	app := kingpin.New(name, "")
+	c := builder(app)
+
+	values, ok := section.(map[string]any)
+	if section != nil && !ok {
+		return &misconfiguredCollector{
+			name: name,
+			err:  fmt.Errorf("collector %s: config section must be a map, got %T", name, section),
+		}
+	}
+
+	setFlagDefaults(app, "collector."+name, values)
+
+	if _, err := app.Parse([]string{}); err != nil {
+		return &misconfiguredCollector{
+			name: name,
+			err:  fmt.Errorf("collector %s: failed to apply config: %w", name, err),
+		}
+	}
+
+	return c
+}
+
+func setFlagDefaults(app *kingpin.Application, prefix string, values map[string]any) {
+	for key, value := range values {
+		switch typed := value.(type) {
+		case map[string]any:
+			setFlagDefaults(app, prefix+"."+key, typed)
+		case []any:
+			items := make([]string, 0, len(typed))
+			for _, item := range typed {
+				items = append(items, fmt.Sprint(item))
+			}
+
+			if f := app.GetFlag(prefix + "." + key); f != nil {
+				f.Default(strings.Join(items, ","))
+			}
+		default:
+			if f := app.GetFlag(prefix + "." + key); f != nil {
+				f.Default(fmt.Sprint(typed))
+			}
+		}
+	}
+}
+
+// misconfiguredCollector takes the place of a registered collector whose config could not be applied.
+// The error is reported once the collector is built, so it only surfaces if the collector is enabled.
+type misconfiguredCollector struct {
+	name string
+	err  error
+}
+
+func (c *misconfiguredCollector) GetName() string {
+	return c.name
+}
+
+func (c *misconfiguredCollector) Build(_ *slog.Logger, _ *mi.Session) error {
+	return c.err
+}
+
+func (c *misconfiguredCollector) Collect(_ chan<- prometheus.Metric) error {
+	return c.err
+}
+
+func (c *misconfiguredCollector) Close() error {
+	return nil
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector_test
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type thirdPartyCollector struct {
+	name   string
+	target *string
+}
+
+func newThirdPartyWithFlags(name string) collector.BuilderWithFlags[*thirdPartyCollector] {
+	return func(app *kingpin.Application) *thirdPartyCollector {
+		return &thirdPartyCollector{
+			name:   name,
+			target: app.Flag("collector."+name+".target", "Target to monitor.").Default("localhost").String(),
+		}
+	}
+}
+
+func (c *thirdPartyCollector) GetName() string { return c.name }
+
+func (c *thirdPartyCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *thirdPartyCollector) Collect(_ chan<- prometheus.Metric) error { return nil }
+
+func (c *thirdPartyCollector) Close() error { return nil }
+
+//nolint:paralleltest // Register changes the global set of collectors.
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() {
+		collector.Unregister("third_party")
+		collector.Unregister("third_party_default")
+	})
+
+	var built *thirdPartyCollector
+
+	require.NoError(t, collector.Register("third_party", func(app *kingpin.Application) *thirdPartyCollector {
+		built = newThirdPartyWithFlags("third_party")(app)
+
+		return built
+	}))
+	require.NoError(t, collector.RegisterDefault("third_party_default", newThirdPartyWithFlags("third_party_default")))
+
+	require.ErrorContains(t, collector.Register("third_party", newThirdPartyWithFlags("third_party")), "already registered")
+	require.ErrorContains(t, collector.Register("cpu", newThirdPartyWithFlags("cpu")), "already registered")
+	require.ErrorContains(t, collector.Register("a,b", newThirdPartyWithFlags("a,b")), "invalid characters")
+	require.Error(t, collector.Register("", newThirdPartyWithFlags("")))
+
+	require.Contains(t, collector.Available(), "third_party")
+	require.Contains(t, strings.Split(collector.Defaults(), ","), "third_party_default")
+	require.NotContains(t, strings.Split(collector.Defaults(), ","), "third_party")
+
+	config := collector.ConfigDefaults
+	config.Registered = map[string]any{
+		"third_party": map[string]any{"target": "example.com"},
+	}
+
+	collection := collector.NewWithConfig(config)
+	require.NoError(t, collection.Enable([]string{"third_party", "third_party_default"}))
+	require.Equal(t, "example.com", *built.target)
+}
@@ -19,10 +19,12 @@ package collector
 
 import (
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -38,6 +40,27 @@ type Collection struct {
 	collectorScrapeDurationDesc *prometheus.Desc
 	collectorScrapeSuccessDesc  *prometheus.Desc
 	collectorScrapeTimeoutDesc  *prometheus.Desc
+	collectorMetricsEmittedDesc *prometheus.Desc
+	collectorErrorsDesc         *prometheus.Desc
+	collectorCPUTimeDesc        *prometheus.Desc
+	collectorHeapAllocDesc      *prometheus.Desc
+	collectorSeriesLimitDesc    *prometheus.Desc
+	pdhInstanceChangesDesc      *prometheus.Desc
+	miQueryCacheRequestsDesc    *prometheus.Desc
+	miOperationDurationDesc     *prometheus.Desc
+	miOperationInstancesDesc    *prometheus.Desc
+	miOperationErrorsDesc       *prometheus.Desc
+
+	// collectorErrors counts the failed collections per collector.
+	// The map is populated once in [New] and shared with all collections derived via [Collection.WithCollectors].
+	collectorErrors map[string]*atomic.Uint64
+
+	// descCache holds the described descriptors per collector, shared like collectorErrors.
+	descCache *descCache
+
+	// maxSeries is the default series limit per collector, maxSeriesPerCollector holds overrides. 0 means no limit.
+	maxSeries             int
+	maxSeriesPerCollector map[string]int
 }
 
 type (
@@ -45,6 +68,9 @@ type (
 	Map                           map[string]Collector
 )
 
+// Desc is a metric descriptor together with its name, help, labels and the value type of the metrics created from it.
+type Desc = types.Desc
+
 // Collector interface that a collector has to implement.
 type Collector interface {
 	// GetName get the name of the collector
@@ -56,3 +82,20 @@ type Collector interface {
 	// Close closes the collector
 	Close() error
 }
+
+// Describer is an optional interface for collectors which create all metric descriptors in Build.
+// Collectors creating descriptors at collection time, e.g. from user input, should not implement it.
+type Describer interface {
+	// Describe sends the descriptors of all metrics the collector may emit. The descriptors must be created by [NewDesc].
+	// Describe is called once after Build, even if Build failed with a tolerated error, e.g. a missing perf counter
+	// object. Descriptors, which were not created in that case, are nil and must be skipped.
+	Describe(ch chan<- Desc)
+}
+
+// DescBuilder is an optional interface for collectors implementing [Describer].
+// It is used by tools/docgen to generate the metric tables of the collector documentation.
+type DescBuilder interface {
+	// BuildDescs creates the descriptors of all metrics without touching the OS, regardless of the enabled
+	// sub-collectors. Label values read from the OS, e.g. const labels of info metrics, are empty.
+	BuildDescs()
+}
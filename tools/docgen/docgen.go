@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+)
+
+const (
+	beginMarker = "<!-- BEGIN GENERATED METRICS. DO NOT EDIT. Run `go generate ./tools/docgen` to update. -->"
+	endMarker   = "<!-- END GENERATED METRICS -->"
+)
+
+// metric is a metric as described by a collector.
+type metric struct {
+	Name   string
+	Help   string
+	Type   string
+	Labels []string
+}
+
+// collectorInfo holds all metrics of a single collector.
+type collectorInfo struct {
+	Name    string
+	Metrics []metric
+}
+
+// describeCollector instantiates the collector with its default configuration and returns its metrics.
+// The descriptors are created by [collector.DescBuilder], so the OS is not touched.
+// It returns nil if the collector doesn't implement [collector.DescBuilder] and [collector.Describer].
+func describeCollector(name string, builder collector.BuilderWithFlags[collector.Collector]) (*collectorInfo, error) {
+	c := builder(kingpin.New(name, ""))
+
+	descBuilder, ok := c.(collector.DescBuilder)
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	describer, ok := c.(collector.Describer)
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	descBuilder.BuildDescs()
+
+	ch := make(chan collector.Desc)
+
+	go func() {
+		describer.Describe(ch)
+		close(ch)
+	}()
+
+	info := &collectorInfo{Name: name}
+
+	var errs []error
+
+	for desc := range ch {
+		if desc.Name == "" {
+			errs = append(errs, fmt.Errorf("collector %s: descriptor %s was not created by types.NewDesc", name, desc.String()))
+
+			continue
+		}
+
+		// Const labels are sorted by prometheus, so they are listed after the variable labels.
+		labels := slices.Clone(desc.VariableLabels)
+		labels = append(labels, slices.Sorted(maps.Keys(desc.ConstLabels))...)
+
+		info.Metrics = append(info.Metrics, metric{
+			Name:   desc.Name,
+			Help:   desc.Help,
+			Type:   strings.ToLower(desc.ValueType.ToDTO().String()),
+			Labels: labels,
+		})
+	}
+
+	slices.SortStableFunc(info.Metrics, func(a, b metric) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	info.Metrics = slices.CompactFunc(info.Metrics, func(a, b metric) bool {
+		return a.Name == b.Name
+	})
+
+	return info, errors.Join(errs...)
+}
+
+// renderTable renders the metrics as markdown table, enclosed by the generated markers.
+func renderTable(info *collectorInfo) string {
+	var buf strings.Builder
+
+	buf.WriteString(beginMarker + "\n")
+	buf.WriteString("| Name | Description | Type | Labels |\n")
+	buf.WriteString("|------|-------------|------|--------|\n")
+
+	for _, m := range info.Metrics {
+		labels := "None"
+
+		if len(m.Labels) > 0 {
+			quoted := make([]string, 0, len(m.Labels))
+			for _, label := range m.Labels {
+				quoted = append(quoted, "`"+label+"`")
+			}
+
+			labels = strings.Join(quoted, ", ")
+		}
+
+		help := strings.ReplaceAll(strings.TrimSpace(m.Help), "|", `\|`)
+		help = strings.Join(strings.Fields(help), " ")
+
+		fmt.Fprintf(&buf, "| `%s` | %s | %s | %s |\n", m.Name, help, m.Type, labels)
+	}
+
+	buf.WriteString(endMarker)
+
+	return buf.String()
+}
+
+// updateDoc replaces the generated section of doc with the rendered metrics table.
+func updateDoc(doc []byte, info *collectorInfo) ([]byte, error) {
+	begin := bytes.Index(doc, []byte(beginMarker))
+	end := bytes.Index(doc, []byte(endMarker))
+
+	if begin == -1 || end == -1 || end < begin {
+		return nil, fmt.Errorf("collector %s: generated metrics markers not found", info.Name)
+	}
+
+	updated := make([]byte, 0, len(doc))
+	updated = append(updated, doc[:begin]...)
+	updated = append(updated, renderTable(info)...)
+	updated = append(updated, doc[end+len(endMarker):]...)
+
+	return updated, nil
+}
+
+// run describes all collectors and updates the corresponding docs within docsDir.
+// If check is set, no files are written and an error is returned for each outdated doc.
+func run(docsDir string, check bool) error {
+	var errs []error
+
+	for _, name := range slices.Sorted(maps.Keys(collector.BuildersWithFlags)) {
+		info, err := describeCollector(name, collector.BuildersWithFlags[name])
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if info == nil {
+			continue
+		}
+
+		docPath := filepath.Join(docsDir, "collector."+info.Name+".md")
+
+		doc, err := os.ReadFile(docPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("collector %s: failed to read docs: %w", info.Name, err))
+
+			continue
+		}
+
+		updated, err := updateDoc(doc, info)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if bytes.Equal(doc, updated) {
+			continue
+		}
+
+		if check {
+			errs = append(errs, fmt.Errorf("%s is out of date", docPath))
+
+			continue
+		}
+
+		if err = os.WriteFile(docPath, updated, 0o644); err != nil { //nolint:gosec
+			errs = append(errs, fmt.Errorf("failed to write %s: %w", docPath, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestDocsUpToDate fails if the metric tables within the collector docs differ from the collector descriptors.
+// Run `go generate ./tools/docgen` to update the docs.
+func TestDocsUpToDate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, run("../../docs", true))
+}
+
+func TestUpdateDoc(t *testing.T) {
+	t.Parallel()
+
+	info := &collectorInfo{
+		Name: "example",
+		Metrics: []metric{
+			{Name: "windows_example_info", Help: "Example | information.", Type: "gauge", Labels: []string{"version"}},
+			{Name: "windows_example_requests_total", Help: "Total requests.", Type: "counter"},
+		},
+	}
+
+	doc := "# example collector\n\n## Metrics\n\n" + beginMarker + "\noutdated\n" + endMarker + "\n\n## Useful queries\n"
+
+	updated, err := updateDoc([]byte(doc), info)
+	require.NoError(t, err)
+	require.Equal(t, "# example collector\n\n## Metrics\n\n"+beginMarker+`
+| Name | Description | Type | Labels |
+|------|-------------|------|--------|
+| `+"`windows_example_info` | Example \\| information. | gauge | `version`"+` |
+| `+"`windows_example_requests_total`"+` | Total requests. | counter | None |
+`+endMarker+"\n\n## Useful queries\n", string(updated))
+
+	_, err = updateDoc([]byte("# example collector\n"), info)
+	require.Error(t, err)
+}
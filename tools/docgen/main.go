@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// docgen renders the metric tables of the collector documentation from the
+// descriptors of the collectors.
+//
+// Each collector is instantiated with its default configuration and creates its
+// descriptors with BuildDescs, which doesn't touch the OS. All collectors with
+// a BuildDescs and Describe method are covered. Their docs must contain the
+// generated metrics markers, everything outside the markers is left untouched.
+package main
+
+//go:generate go run . -docs ../../docs
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	docsDir := flag.String("docs", "docs", "Directory containing the collector documentation.")
+	check := flag.Bool("check", false, "Only check whether the documentation is up to date.")
+
+	flag.Parse()
+
+	if err := run(*docsDir, *check); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+}